@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0esentinel.proto\x12\x08sentinel\"\xdf\x01\n\x0e\x41nalyzeRequest\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\x14\n\x0c\x66rom_address\x18\x02 \x01(\t\x12\x12\n\nto_address\x18\x03 \x01(\t\x12\r\n\x05value\x18\x04 \x01(\t\x12\x0b\n\x03gas\x18\x05 \x01(\x04\x12\x11\n\tgas_price\x18\x06 \x01(\t\x12\x12\n\ninput_data\x18\x07 \x01(\x0c\x12\r\n\x05nonce\x18\x08 \x01(\x04\x12\x10\n\x08\x63hain_id\x18\t \x01(\x04\x12.\n\nsimulation\x18\n \x01(\x0b\x32\x1a.sentinel.SimulationResult\"\x9f\x01\n\x10SimulationResult\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x10\n\x08gas_used\x18\x02 \x01(\x04\x12\x30\n\x0fstorage_changes\x18\x03 \x03(\x0b\x32\x17.sentinel.StorageChange\x12(\n\x0b\x63\x61ll_traces\x18\x04 \x03(\x0b\x32\x13.sentinel.CallTrace\x12\x0c\n\x04logs\x18\x05 \x03(\t\"U\n\rStorageChange\x12\x10\n\x08\x63ontract\x18\x01 \x01(\t\x12\x0c\n\x04slot\x18\x02 \x01(\t\x12\x11\n\told_value\x18\x03 \x01(\t\x12\x11\n\tnew_value\x18\x04 \x01(\t\"\xc7\x01\n\tCallTrace\x12\x11\n\tcall_type\x18\x01 \x01(\t\x12\x0c\n\x04\x66rom\x18\x02 \x01(\t\x12\n\n\x02to\x18\x03 \x01(\t\x12\r\n\x05value\x18\x04 \x01(\t\x12\r\n\x05input\x18\x05 \x01(\x0c\x12\x0e\n\x06output\x18\x06 \x01(\x0c\x12\x0b\n\x03gas\x18\x07 \x01(\x04\x12\x10\n\x08gas_used\x18\x08 \x01(\x04\x12\r\n\x05\x64\x65pth\x18\t \x01(\r\x12\r\n\x05\x65rror\x18\n \x01(\x08\x12\"\n\x05\x63\x61lls\x18\x0b \x03(\x0b\x32\x13.sentinel.CallTrace\"\xe4\x02\n\x0f\x41nalyzeResponse\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\x15\n\ris_suspicious\x18\x02 \x01(\x08\x12\x15\n\ranomaly_score\x18\x03 \x01(\x01\x12\x12\n\nconfidence\x18\x04 \x01(\x01\x12\'\n\nrisk_level\x18\x05 \x01(\x0e\x32\x13.sentinel.RiskLevel\x12\x17\n\x0frisk_indicators\x18\x06 \x03(\t\x12\x30\n\x0erecommendation\x18\x07 \x01(\x0e\x32\x18.sentinel.Recommendation\x12\x12\n\nlatency_ms\x18\x08 \x01(\x01\x12\x33\n\x10protocol_context\x18\t \x01(\x0b\x32\x19.sentinel.ProtocolContext\x12,\n\x08\x66\x65\x61tures\x18\n \x01(\x0b\x32\x1a.sentinel.FeatureBreakdown\x12\x13\n\x0b\x65xplanation\x18\x0b \x01(\t\"\xb7\x01\n\x0fProtocolContext\x12\x10\n\x08protocol\x18\x01 \x01(\t\x12\x11\n\toperation\x18\x02 \x01(\t\x12\x19\n\x11is_known_protocol\x18\x03 \x01(\x08\x12\x1a\n\x12is_known_operation\x18\x04 \x01(\x08\x12\x15\n\rwithin_bounds\x18\x05 \x01(\x08\x12\x18\n\x10\x62ound_violations\x18\x06 \x03(\t\x12\x17\n\x0frisk_adjustment\x18\x07 \x01(\x01\"\xd4\x01\n\x10\x46\x65\x61tureBreakdown\x12/\n\nflash_loan\x18\x01 \x01(\x0b\x32\x1b.sentinel.FlashLoanFeatures\x12\x37\n\x0estate_variance\x18\x02 \x01(\x0b\x32\x1f.sentinel.StateVarianceFeatures\x12,\n\x08\x62ytecode\x18\x03 \x01(\x0b\x32\x1a.sentinel.BytecodeFeatures\x12(\n\x06opcode\x18\x04 \x01(\x0b\x32\x18.sentinel.OpcodeFeatures\"\xbe\x01\n\x11\x46lashLoanFeatures\x12\x16\n\x0ehas_flash_loan\x18\x01 \x01(\x08\x12\x18\n\x10\x66lash_loan_count\x18\x02 \x01(\x05\x12\x11\n\tproviders\x18\x03 \x03(\t\x12\x16\n\x0etotal_borrowed\x18\x04 \x01(\t\x12\x14\n\x0chas_callback\x18\x05 \x01(\x08\x12\x1a\n\x12nested_flash_loans\x18\x06 \x01(\x08\x12\x1a\n\x12repayment_detected\x18\x07 \x01(\x08\"\xe4\x01\n\x15StateVarianceFeatures\x12\x1d\n\x15total_storage_changes\x18\x01 \x01(\x05\x12!\n\x19unique_contracts_modified\x18\x02 \x01(\x05\x12\x1d\n\x15unique_slots_modified\x18\x03 \x01(\x05\x12\x1c\n\x14\x62\x61lance_slot_changes\x18\x04 \x01(\x05\x12\x1b\n\x13large_value_changes\x18\x05 \x01(\x05\x12\x17\n\x0fmax_value_delta\x18\x06 \x01(\t\x12\x16\n\x0evariance_ratio\x18\x07 \x01(\x01\"\xd0\x02\n\x10\x42ytecodeFeatures\x12\x17\n\x0f\x62ytecode_length\x18\x01 \x01(\x05\x12\x13\n\x0bis_contract\x18\x02 \x01(\x08\x12\x10\n\x08is_proxy\x18\x03 \x01(\x08\x12\x12\n\nproxy_type\x18\x04 \x01(\t\x12\x1b\n\x13\x63ontract_age_blocks\x18\x05 \x01(\x05\x12\x13\n\x0bis_verified\x18\x06 \x01(\x08\x12\x1d\n\x15matches_known_exploit\x18\x07 \x01(\x08\x12\x1a\n\x12matched_exploit_id\x18\x08 \x01(\t\x12\x1a\n\x12jaccard_similarity\x18\t \x01(\x01\x12\x18\n\x10has_selfdestruct\x18\n \x01(\x08\x12\x18\n\x10has_delegatecall\x18\x0b \x01(\x08\x12\x13\n\x0bhas_create2\x18\x0c \x01(\x08\x12\x16\n\x0eunique_opcodes\x18\r \x01(\x05\"\x9b\x02\n\x0eOpcodeFeatures\x12\x13\n\x0btotal_calls\x18\x01 \x01(\x05\x12\x12\n\ncall_depth\x18\x02 \x01(\x05\x12\x1a\n\x12\x64\x65legatecall_count\x18\x03 \x01(\x05\x12\x18\n\x10staticcall_count\x18\x04 \x01(\x05\x12\x14\n\x0c\x63reate_count\x18\x05 \x01(\x05\x12\x15\n\rcreate2_count\x18\x06 \x01(\x05\x12\x1a\n\x12selfdestruct_count\x18\x07 \x01(\x05\x12\x16\n\x0einternal_calls\x18\x08 \x01(\x05\x12\x16\n\x0e\x65xternal_calls\x18\t \x01(\x05\x12\x1b\n\x13gas_forwarded_ratio\x18\n \x01(\x01\x12\x14\n\x0crevert_count\x18\x0b \x01(\x05\"E\n\x13\x41nalyzeBatchRequest\x12.\n\x0ctransactions\x18\x01 \x03(\x0b\x32\x18.sentinel.AnalyzeRequest\"\\\n\x14\x41nalyzeBatchResponse\x12*\n\x07results\x18\x01 \x03(\x0b\x32\x19.sentinel.AnalyzeResponse\x12\x18\n\x10total_latency_ms\x18\x02 \x01(\x01\"\x0f\n\rHealthRequest\"x\n\x0eHealthResponse\x12\x0f\n\x07healthy\x18\x01 \x01(\x08\x12\x15\n\rmodel_version\x18\x02 \x01(\t\x12\x17\n\x0fmodel_loaded_at\x18\x03 \x01(\t\x12\x15\n\rrpc_connected\x18\x04 \x01(\x08\x12\x0e\n\x06uptime\x18\x05 \x01(\t\"\x0e\n\x0cStatsRequest\"\xcd\x03\n\rStatsResponse\x12\x1d\n\x15transactions_analyzed\x18\x01 \x01(\x04\x12\x1b\n\x13suspicious_detected\x18\x02 \x01(\x04\x12\x1b\n\x13\x62locked_recommended\x18\x03 \x01(\x04\x12\x1a\n\x12\x61verage_latency_ms\x18\x04 \x01(\x01\x12\x16\n\x0emodel_accuracy\x18\x05 \x01(\x01\x12\x1b\n\x13\x66\x61lse_positive_rate\x18\x06 \x01(\x01\x12?\n\rby_risk_level\x18\x07 \x03(\x0b\x32(.sentinel.StatsResponse.ByRiskLevelEntry\x12<\n\x0b\x62y_protocol\x18\x08 \x03(\x0b\x32\'.sentinel.StatsResponse.ByProtocolEntry\x12,\n\rrecent_alerts\x18\t \x03(\x0b\x32\x15.sentinel.RecentAlert\x1a\x32\n\x10\x42yRiskLevelEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x04:\x02\x38\x01\x1a\x31\n\x0f\x42yProtocolEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x04:\x02\x38\x01\"\x85\x01\n\x0bRecentAlert\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\'\n\nrisk_level\x18\x02 \x01(\x0e\x32\x13.sentinel.RiskLevel\x12\x11\n\ttimestamp\x18\x03 \x01(\t\x12\x10\n\x08protocol\x18\x04 \x01(\t\x12\x17\n\x0frisk_indicators\x18\x05 \x03(\t*m\n\tRiskLevel\x12\x10\n\x0cRISK_UNKNOWN\x10\x00\x12\r\n\tRISK_SAFE\x10\x01\x12\x0c\n\x08RISK_LOW\x10\x02\x12\x0f\n\x0bRISK_MEDIUM\x10\x03\x12\r\n\tRISK_HIGH\x10\x04\x12\x11\n\rRISK_CRITICAL\x10\x05*\x94\x01\n\x0eRecommendation\x12\x1a\n\x16RECOMMENDATION_UNKNOWN\x10\x00\x12\x18\n\x14RECOMMENDATION_ALLOW\x10\x01\x12\x17\n\x13RECOMMENDATION_FLAG\x10\x02\x12\x19\n\x15RECOMMENDATION_REVIEW\x10\x03\x12\x18\n\x14RECOMMENDATION_BLOCK\x10\x04\x32\x9c\x02\n\x11SentinelInference\x12>\n\x07\x41nalyze\x12\x18.sentinel.AnalyzeRequest\x1a\x19.sentinel.AnalyzeResponse\x12M\n\x0c\x41nalyzeBatch\x12\x1d.sentinel.AnalyzeBatchRequest\x1a\x1e.sentinel.AnalyzeBatchResponse\x12;\n\x06Health\x12\x17.sentinel.HealthRequest\x1a\x18.sentinel.HealthResponse\x12;\n\x08GetStats\x12\x16.sentinel.StatsRequest\x1a\x17.sentinel.StatsResponseB6Z4github.com/sentinel-protocol/sentinel-node/pkg/protob\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0esentinel.proto\x12\x08sentinel\"\x8c\x02\n\x0e\x41nalyzeRequest\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\x14\n\x0c\x66rom_address\x18\x02 \x01(\t\x12\x12\n\nto_address\x18\x03 \x01(\t\x12\r\n\x05value\x18\x04 \x01(\t\x12\x0b\n\x03gas\x18\x05 \x01(\x04\x12\x11\n\tgas_price\x18\x06 \x01(\t\x12\x12\n\ninput_data\x18\x07 \x01(\x0c\x12\r\n\x05nonce\x18\x08 \x01(\x04\x12\x10\n\x08\x63hain_id\x18\t \x01(\x04\x12.\n\nsimulation\x18\n \x01(\x0b\x32\x1a.sentinel.SimulationResult\x12+\n\x0c\x64\x65\x63oded_call\x18\x0b \x01(\x0b\x32\x15.sentinel.DecodedCall\"]\n\x0b\x44\x65\x63odedCall\x12\x10\n\x08selector\x18\x01 \x01(\t\x12\x0e\n\x06method\x18\x02 \x01(\t\x12,\n\targuments\x18\x03 \x03(\x0b\x32\x19.sentinel.DecodedArgument\"<\n\x0f\x44\x65\x63odedArgument\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\r\n\x05value\x18\x03 \x01(\t\"\x9f\x01\n\x10SimulationResult\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x10\n\x08gas_used\x18\x02 \x01(\x04\x12\x30\n\x0fstorage_changes\x18\x03 \x03(\x0b\x32\x17.sentinel.StorageChange\x12(\n\x0b\x63\x61ll_traces\x18\x04 \x03(\x0b\x32\x13.sentinel.CallTrace\x12\x0c\n\x04logs\x18\x05 \x03(\t\"U\n\rStorageChange\x12\x10\n\x08\x63ontract\x18\x01 \x01(\t\x12\x0c\n\x04slot\x18\x02 \x01(\t\x12\x11\n\told_value\x18\x03 \x01(\t\x12\x11\n\tnew_value\x18\x04 \x01(\t\"\xc7\x01\n\tCallTrace\x12\x11\n\tcall_type\x18\x01 \x01(\t\x12\x0c\n\x04\x66rom\x18\x02 \x01(\t\x12\n\n\x02to\x18\x03 \x01(\t\x12\r\n\x05value\x18\x04 \x01(\t\x12\r\n\x05input\x18\x05 \x01(\x0c\x12\x0e\n\x06output\x18\x06 \x01(\x0c\x12\x0b\n\x03gas\x18\x07 \x01(\x04\x12\x10\n\x08gas_used\x18\x08 \x01(\x04\x12\r\n\x05\x64\x65pth\x18\t \x01(\r\x12\r\n\x05\x65rror\x18\n \x01(\x08\x12\"\n\x05\x63\x61lls\x18\x0b \x03(\x0b\x32\x13.sentinel.CallTrace\"\xe4\x02\n\x0f\x41nalyzeResponse\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\x15\n\ris_suspicious\x18\x02 \x01(\x08\x12\x15\n\ranomaly_score\x18\x03 \x01(\x01\x12\x12\n\nconfidence\x18\x04 \x01(\x01\x12\'\n\nrisk_level\x18\x05 \x01(\x0e\x32\x13.sentinel.RiskLevel\x12\x17\n\x0frisk_indicators\x18\x06 \x03(\t\x12\x30\n\x0erecommendation\x18\x07 \x01(\x0e\x32\x18.sentinel.Recommendation\x12\x12\n\nlatency_ms\x18\x08 \x01(\x01\x12\x33\n\x10protocol_context\x18\t \x01(\x0b\x32\x19.sentinel.ProtocolContext\x12,\n\x08\x66\x65\x61tures\x18\n \x01(\x0b\x32\x1a.sentinel.FeatureBreakdown\x12\x13\n\x0b\x65xplanation\x18\x0b \x01(\t\"\xb7\x01\n\x0fProtocolContext\x12\x10\n\x08protocol\x18\x01 \x01(\t\x12\x11\n\toperation\x18\x02 \x01(\t\x12\x19\n\x11is_known_protocol\x18\x03 \x01(\x08\x12\x1a\n\x12is_known_operation\x18\x04 \x01(\x08\x12\x15\n\rwithin_bounds\x18\x05 \x01(\x08\x12\x18\n\x10\x62ound_violations\x18\x06 \x03(\t\x12\x17\n\x0frisk_adjustment\x18\x07 \x01(\x01\"\xd4\x01\n\x10\x46\x65\x61tureBreakdown\x12/\n\nflash_loan\x18\x01 \x01(\x0b\x32\x1b.sentinel.FlashLoanFeatures\x12\x37\n\x0estate_variance\x18\x02 \x01(\x0b\x32\x1f.sentinel.StateVarianceFeatures\x12,\n\x08\x62ytecode\x18\x03 \x01(\x0b\x32\x1a.sentinel.BytecodeFeatures\x12(\n\x06opcode\x18\x04 \x01(\x0b\x32\x18.sentinel.OpcodeFeatures\"\xbe\x01\n\x11\x46lashLoanFeatures\x12\x16\n\x0ehas_flash_loan\x18\x01 \x01(\x08\x12\x18\n\x10\x66lash_loan_count\x18\x02 \x01(\x05\x12\x11\n\tproviders\x18\x03 \x03(\t\x12\x16\n\x0etotal_borrowed\x18\x04 \x01(\t\x12\x14\n\x0chas_callback\x18\x05 \x01(\x08\x12\x1a\n\x12nested_flash_loans\x18\x06 \x01(\x08\x12\x1a\n\x12repayment_detected\x18\x07 \x01(\x08\"\xe4\x01\n\x15StateVarianceFeatures\x12\x1d\n\x15total_storage_changes\x18\x01 \x01(\x05\x12!\n\x19unique_contracts_modified\x18\x02 \x01(\x05\x12\x1d\n\x15unique_slots_modified\x18\x03 \x01(\x05\x12\x1c\n\x14\x62\x61lance_slot_changes\x18\x04 \x01(\x05\x12\x1b\n\x13large_value_changes\x18\x05 \x01(\x05\x12\x17\n\x0fmax_value_delta\x18\x06 \x01(\t\x12\x16\n\x0evariance_ratio\x18\x07 \x01(\x01\"\xd0\x02\n\x10\x42ytecodeFeatures\x12\x17\n\x0f\x62ytecode_length\x18\x01 \x01(\x05\x12\x13\n\x0bis_contract\x18\x02 \x01(\x08\x12\x10\n\x08is_proxy\x18\x03 \x01(\x08\x12\x12\n\nproxy_type\x18\x04 \x01(\t\x12\x1b\n\x13\x63ontract_age_blocks\x18\x05 \x01(\x05\x12\x13\n\x0bis_verified\x18\x06 \x01(\x08\x12\x1d\n\x15matches_known_exploit\x18\x07 \x01(\x08\x12\x1a\n\x12matched_exploit_id\x18\x08 \x01(\t\x12\x1a\n\x12jaccard_similarity\x18\t \x01(\x01\x12\x18\n\x10has_selfdestruct\x18\n \x01(\x08\x12\x18\n\x10has_delegatecall\x18\x0b \x01(\x08\x12\x13\n\x0bhas_create2\x18\x0c \x01(\x08\x12\x16\n\x0eunique_opcodes\x18\r \x01(\x05\"\x9b\x02\n\x0eOpcodeFeatures\x12\x13\n\x0btotal_calls\x18\x01 \x01(\x05\x12\x12\n\ncall_depth\x18\x02 \x01(\x05\x12\x1a\n\x12\x64\x65legatecall_count\x18\x03 \x01(\x05\x12\x18\n\x10staticcall_count\x18\x04 \x01(\x05\x12\x14\n\x0c\x63reate_count\x18\x05 \x01(\x05\x12\x15\n\rcreate2_count\x18\x06 \x01(\x05\x12\x1a\n\x12selfdestruct_count\x18\x07 \x01(\x05\x12\x16\n\x0einternal_calls\x18\x08 \x01(\x05\x12\x16\n\x0e\x65xternal_calls\x18\t \x01(\x05\x12\x1b\n\x13gas_forwarded_ratio\x18\n \x01(\x01\x12\x14\n\x0crevert_count\x18\x0b \x01(\x05\"E\n\x13\x41nalyzeBatchRequest\x12.\n\x0ctransactions\x18\x01 \x03(\x0b\x32\x18.sentinel.AnalyzeRequest\"\\\n\x14\x41nalyzeBatchResponse\x12*\n\x07results\x18\x01 \x03(\x0b\x32\x19.sentinel.AnalyzeResponse\x12\x18\n\x10total_latency_ms\x18\x02 \x01(\x01\"\x0f\n\rHealthRequest\"x\n\x0eHealthResponse\x12\x0f\n\x07healthy\x18\x01 \x01(\x08\x12\x15\n\rmodel_version\x18\x02 \x01(\t\x12\x17\n\x0fmodel_loaded_at\x18\x03 \x01(\t\x12\x15\n\rrpc_connected\x18\x04 \x01(\x08\x12\x0e\n\x06uptime\x18\x05 \x01(\t\"\x0e\n\x0cStatsRequest\"\xcd\x03\n\rStatsResponse\x12\x1d\n\x15transactions_analyzed\x18\x01 \x01(\x04\x12\x1b\n\x13suspicious_detected\x18\x02 \x01(\x04\x12\x1b\n\x13\x62locked_recommended\x18\x03 \x01(\x04\x12\x1a\n\x12\x61verage_latency_ms\x18\x04 \x01(\x01\x12\x16\n\x0emodel_accuracy\x18\x05 \x01(\x01\x12\x1b\n\x13\x66\x61lse_positive_rate\x18\x06 \x01(\x01\x12?\n\rby_risk_level\x18\x07 \x03(\x0b\x32(.sentinel.StatsResponse.ByRiskLevelEntry\x12<\n\x0b\x62y_protocol\x18\x08 \x03(\x0b\x32\'.sentinel.StatsResponse.ByProtocolEntry\x12,\n\rrecent_alerts\x18\t \x03(\x0b\x32\x15.sentinel.RecentAlert\x1a\x32\n\x10\x42yRiskLevelEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x04:\x02\x38\x01\x1a\x31\n\x0f\x42yProtocolEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x04:\x02\x38\x01\"\x85\x01\n\x0bRecentAlert\x12\x0f\n\x07tx_hash\x18\x01 \x01(\t\x12\'\n\nrisk_level\x18\x02 \x01(\x0e\x32\x13.sentinel.RiskLevel\x12\x11\n\ttimestamp\x18\x03 \x01(\t\x12\x10\n\x08protocol\x18\x04 \x01(\t\x12\x17\n\x0frisk_indicators\x18\x05 \x03(\t*m\n\tRiskLevel\x12\x10\n\x0cRISK_UNKNOWN\x10\x00\x12\r\n\tRISK_SAFE\x10\x01\x12\x0c\n\x08RISK_LOW\x10\x02\x12\x0f\n\x0bRISK_MEDIUM\x10\x03\x12\r\n\tRISK_HIGH\x10\x04\x12\x11\n\rRISK_CRITICAL\x10\x05*\x94\x01\n\x0eRecommendation\x12\x1a\n\x16RECOMMENDATION_UNKNOWN\x10\x00\x12\x18\n\x14RECOMMENDATION_ALLOW\x10\x01\x12\x17\n\x13RECOMMENDATION_FLAG\x10\x02\x12\x19\n\x15RECOMMENDATION_REVIEW\x10\x03\x12\x18\n\x14RECOMMENDATION_BLOCK\x10\x04\x32\x9c\x02\n\x11SentinelInference\x12>\n\x07\x41nalyze\x12\x18.sentinel.AnalyzeRequest\x1a\x19.sentinel.AnalyzeResponse\x12M\n\x0c\x41nalyzeBatch\x12\x1d.sentinel.AnalyzeBatchRequest\x1a\x1e.sentinel.AnalyzeBatchResponse\x12;\n\x06Health\x12\x17.sentinel.HealthRequest\x1a\x18.sentinel.HealthResponse\x12;\n\x08GetStats\x12\x16.sentinel.StatsRequest\x1a\x17.sentinel.StatsResponseB6Z4github.com/sentinel-protocol/sentinel-node/pkg/protob\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -36,50 +36,54 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_STATSRESPONSE_BYRISKLEVELENTRY']._serialized_options = b'8\001'
   _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._loaded_options = None
   _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._serialized_options = b'8\001'
-  _globals['_RISKLEVEL']._serialized_start=3434
-  _globals['_RISKLEVEL']._serialized_end=3543
-  _globals['_RECOMMENDATION']._serialized_start=3546
-  _globals['_RECOMMENDATION']._serialized_end=3694
+  _globals['_RISKLEVEL']._serialized_start=3636
+  _globals['_RISKLEVEL']._serialized_end=3745
+  _globals['_RECOMMENDATION']._serialized_start=3748
+  _globals['_RECOMMENDATION']._serialized_end=3896
   _globals['_ANALYZEREQUEST']._serialized_start=29
-  _globals['_ANALYZEREQUEST']._serialized_end=252
-  _globals['_SIMULATIONRESULT']._serialized_start=255
-  _globals['_SIMULATIONRESULT']._serialized_end=414
-  _globals['_STORAGECHANGE']._serialized_start=416
-  _globals['_STORAGECHANGE']._serialized_end=501
-  _globals['_CALLTRACE']._serialized_start=504
-  _globals['_CALLTRACE']._serialized_end=703
-  _globals['_ANALYZERESPONSE']._serialized_start=706
-  _globals['_ANALYZERESPONSE']._serialized_end=1062
-  _globals['_PROTOCOLCONTEXT']._serialized_start=1065
-  _globals['_PROTOCOLCONTEXT']._serialized_end=1248
-  _globals['_FEATUREBREAKDOWN']._serialized_start=1251
-  _globals['_FEATUREBREAKDOWN']._serialized_end=1463
-  _globals['_FLASHLOANFEATURES']._serialized_start=1466
-  _globals['_FLASHLOANFEATURES']._serialized_end=1656
-  _globals['_STATEVARIANCEFEATURES']._serialized_start=1659
-  _globals['_STATEVARIANCEFEATURES']._serialized_end=1887
-  _globals['_BYTECODEFEATURES']._serialized_start=1890
-  _globals['_BYTECODEFEATURES']._serialized_end=2226
-  _globals['_OPCODEFEATURES']._serialized_start=2229
-  _globals['_OPCODEFEATURES']._serialized_end=2512
-  _globals['_ANALYZEBATCHREQUEST']._serialized_start=2514
-  _globals['_ANALYZEBATCHREQUEST']._serialized_end=2583
-  _globals['_ANALYZEBATCHRESPONSE']._serialized_start=2585
-  _globals['_ANALYZEBATCHRESPONSE']._serialized_end=2677
-  _globals['_HEALTHREQUEST']._serialized_start=2679
-  _globals['_HEALTHREQUEST']._serialized_end=2694
-  _globals['_HEALTHRESPONSE']._serialized_start=2696
-  _globals['_HEALTHRESPONSE']._serialized_end=2816
-  _globals['_STATSREQUEST']._serialized_start=2818
-  _globals['_STATSREQUEST']._serialized_end=2832
-  _globals['_STATSRESPONSE']._serialized_start=2835
-  _globals['_STATSRESPONSE']._serialized_end=3296
-  _globals['_STATSRESPONSE_BYRISKLEVELENTRY']._serialized_start=3195
-  _globals['_STATSRESPONSE_BYRISKLEVELENTRY']._serialized_end=3245
-  _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._serialized_start=3247
-  _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._serialized_end=3296
-  _globals['_RECENTALERT']._serialized_start=3299
-  _globals['_RECENTALERT']._serialized_end=3432
-  _globals['_SENTINELINFERENCE']._serialized_start=3697
-  _globals['_SENTINELINFERENCE']._serialized_end=3981
+  _globals['_ANALYZEREQUEST']._serialized_end=297
+  _globals['_DECODEDCALL']._serialized_start=299
+  _globals['_DECODEDCALL']._serialized_end=392
+  _globals['_DECODEDARGUMENT']._serialized_start=394
+  _globals['_DECODEDARGUMENT']._serialized_end=454
+  _globals['_SIMULATIONRESULT']._serialized_start=457
+  _globals['_SIMULATIONRESULT']._serialized_end=616
+  _globals['_STORAGECHANGE']._serialized_start=618
+  _globals['_STORAGECHANGE']._serialized_end=703
+  _globals['_CALLTRACE']._serialized_start=706
+  _globals['_CALLTRACE']._serialized_end=905
+  _globals['_ANALYZERESPONSE']._serialized_start=908
+  _globals['_ANALYZERESPONSE']._serialized_end=1264
+  _globals['_PROTOCOLCONTEXT']._serialized_start=1267
+  _globals['_PROTOCOLCONTEXT']._serialized_end=1450
+  _globals['_FEATUREBREAKDOWN']._serialized_start=1453
+  _globals['_FEATUREBREAKDOWN']._serialized_end=1665
+  _globals['_FLASHLOANFEATURES']._serialized_start=1668
+  _globals['_FLASHLOANFEATURES']._serialized_end=1858
+  _globals['_STATEVARIANCEFEATURES']._serialized_start=1861
+  _globals['_STATEVARIANCEFEATURES']._serialized_end=2089
+  _globals['_BYTECODEFEATURES']._serialized_start=2092
+  _globals['_BYTECODEFEATURES']._serialized_end=2428
+  _globals['_OPCODEFEATURES']._serialized_start=2431
+  _globals['_OPCODEFEATURES']._serialized_end=2714
+  _globals['_ANALYZEBATCHREQUEST']._serialized_start=2716
+  _globals['_ANALYZEBATCHREQUEST']._serialized_end=2785
+  _globals['_ANALYZEBATCHRESPONSE']._serialized_start=2787
+  _globals['_ANALYZEBATCHRESPONSE']._serialized_end=2879
+  _globals['_HEALTHREQUEST']._serialized_start=2881
+  _globals['_HEALTHREQUEST']._serialized_end=2896
+  _globals['_HEALTHRESPONSE']._serialized_start=2898
+  _globals['_HEALTHRESPONSE']._serialized_end=3018
+  _globals['_STATSREQUEST']._serialized_start=3020
+  _globals['_STATSREQUEST']._serialized_end=3034
+  _globals['_STATSRESPONSE']._serialized_start=3037
+  _globals['_STATSRESPONSE']._serialized_end=3498
+  _globals['_STATSRESPONSE_BYRISKLEVELENTRY']._serialized_start=3397
+  _globals['_STATSRESPONSE_BYRISKLEVELENTRY']._serialized_end=3447
+  _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._serialized_start=3449
+  _globals['_STATSRESPONSE_BYPROTOCOLENTRY']._serialized_end=3498
+  _globals['_RECENTALERT']._serialized_start=3501
+  _globals['_RECENTALERT']._serialized_end=3634
+  _globals['_SENTINELINFERENCE']._serialized_start=3899
+  _globals['_SENTINELINFERENCE']._serialized_end=4183
 # @@protoc_insertion_point(module_scope)
@@ -1,9 +1,13 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.24;
 
+// G2 points are encoded as (x.im, x.re, y.im, y.re), the order the pairing
+// precompile takes and gnark-crypto marshals them in
 contract BLSVerifier {
-    uint256 constant N = 21888242871839275222246405745257275088696311157297823662689037894645226208583;
-    uint256 constant P = 21888242871839275222246405745257275088548364400416034343698204186575808495617;
+    // Group order
+    uint256 constant N = 21888242871839275222246405745257275088548364400416034343698204186575808495617;
+    // Base field modulus
+    uint256 constant P = 21888242871839275222246405745257275088696311157297823662689037894645226208583;
 
     uint256 constant G1_X = 1;
     uint256 constant G1_Y = 2;
@@ -114,17 +118,17 @@ contract BLSVerifier {
 
         input[0] = a1.x;
         input[1] = a1.y;
-        input[2] = a2.x[1];
-        input[3] = a2.x[0];
-        input[4] = a2.y[1];
-        input[5] = a2.y[0];
+        input[2] = a2.x[0];
+        input[3] = a2.x[1];
+        input[4] = a2.y[0];
+        input[5] = a2.y[1];
 
         input[6] = b1.x;
         input[7] = b1.y;
-        input[8] = b2.x[1];
-        input[9] = b2.x[0];
-        input[10] = b2.y[1];
-        input[11] = b2.y[0];
+        input[8] = b2.x[0];
+        input[9] = b2.x[1];
+        input[10] = b2.y[0];
+        input[11] = b2.y[1];
 
         uint256[1] memory result;
         bool success;
@@ -148,10 +152,10 @@ contract BLSVerifier {
         input[1] = aggSig.y;
 
         G2Point memory g2Gen = getG2Generator();
-        input[2] = g2Gen.x[1];
-        input[3] = g2Gen.x[0];
-        input[4] = g2Gen.y[1];
-        input[5] = g2Gen.y[0];
+        input[2] = g2Gen.x[0];
+        input[3] = g2Gen.x[1];
+        input[4] = g2Gen.y[0];
+        input[5] = g2Gen.y[1];
 
         for (uint256 i = 0; i < n; i++) {
             G1Point memory negHash = negate(msgHashes[i]);
@@ -159,10 +163,10 @@ contract BLSVerifier {
 
             input[offset] = negHash.x;
             input[offset + 1] = negHash.y;
-            input[offset + 2] = pubKeys[i].x[1];
-            input[offset + 3] = pubKeys[i].x[0];
-            input[offset + 4] = pubKeys[i].y[1];
-            input[offset + 5] = pubKeys[i].y[0];
+            input[offset + 2] = pubKeys[i].x[0];
+            input[offset + 3] = pubKeys[i].x[1];
+            input[offset + 4] = pubKeys[i].y[0];
+            input[offset + 5] = pubKeys[i].y[1];
         }
 
         uint256[1] memory result;
@@ -255,10 +259,10 @@ contract BLSVerifier {
         uint256[6] memory input;
         input[0] = G1_X;
         input[1] = G1_Y;
-        input[2] = p.x[1];
-        input[3] = p.x[0];
-        input[4] = p.y[1];
-        input[5] = p.y[0];
+        input[2] = p.x[0];
+        input[3] = p.x[1];
+        input[4] = p.y[0];
+        input[5] = p.y[1];
 
         uint256[1] memory result;
         bool success;
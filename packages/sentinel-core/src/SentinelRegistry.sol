@@ -15,6 +15,10 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
     uint256 public constant SLASH_DOWNTIME = 500;
     uint256 public constant SLASH_MALICIOUS = 10000;
     uint256 public constant BASIS_POINTS = 10000;
+    // Uncompressed G2 point, the form BLSVerifier decodes
+    uint256 public constant BLS_PUBLIC_KEY_LENGTH = 128;
+    // Uncompressed G1 point: the key's signature over itself
+    uint256 public constant BLS_PROOF_LENGTH = 64;
 
     uint256 public constant TVL_TIER_1 = 1_000_000 * 1e18;
     uint256 public constant TVL_TIER_2 = 10_000_000 * 1e18;
@@ -46,6 +50,8 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
 
     mapping(address => NodeInfo) public nodes;
     mapping(address => ProtocolInfo) public protocols;
+    mapping(address => bytes) internal nodeBLSKeys;
+    mapping(address => bytes) internal nodeBLSKeyProofs;
 
     address[] public activeNodes;
     address[] public activeProtocols;
@@ -65,6 +71,8 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
     error Unauthorized();
     error InvalidBLSKey();
     error AlreadyRegistered();
+    error BLSKeyMismatch();
+    error InvalidProofOfPossession();
     error ZeroAmount();
 
     event NodeRegistered(address indexed node, uint256 stake, bytes32 blsPublicKey);
@@ -73,6 +81,7 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
     event NodeUnstakeCompleted(address indexed node, uint256 amount);
     event NodeSlashed(address indexed node, uint256 amount, string reason);
     event NodeDeactivated(address indexed node);
+    event NodeBLSKeyPublished(address indexed node, bytes blsPublicKey, bytes proofOfPossession);
 
     event ProtocolRegistered(address indexed protocol, uint256 stake, address pauseTarget);
     event ProtocolStakeIncreased(address indexed protocol, uint256 amount);
@@ -120,6 +129,21 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
         emit NodeRegistered(msg.sender, stakeAmount, blsPublicKey);
     }
 
+    // The proof of possession is hashed to the curve in a way the EVM has no
+    // precompile for, so it is stored rather than checked here; nodes verify
+    // it before aggregating the key
+    function publishBLSKey(bytes calldata blsPublicKey, bytes calldata proofOfPossession) external {
+        if (!nodes[msg.sender].isActive) revert NodeNotActive();
+        if (blsPublicKey.length != BLS_PUBLIC_KEY_LENGTH) revert InvalidBLSKey();
+        if (proofOfPossession.length != BLS_PROOF_LENGTH) revert InvalidProofOfPossession();
+        if (keccak256(blsPublicKey) != nodes[msg.sender].blsPublicKey) revert BLSKeyMismatch();
+
+        nodeBLSKeys[msg.sender] = blsPublicKey;
+        nodeBLSKeyProofs[msg.sender] = proofOfPossession;
+
+        emit NodeBLSKeyPublished(msg.sender, blsPublicKey, proofOfPossession);
+    }
+
     function increaseNodeStake(uint256 amount) external nonReentrant {
         if (!nodes[msg.sender].isActive) revert NodeNotActive();
         if (amount == 0) revert ZeroAmount();
@@ -308,6 +332,14 @@ contract SentinelRegistry is ReentrancyGuard, Owned {
         return nodes[node].stake;
     }
 
+    function getNodeBLSKey(address node) external view returns (bytes memory) {
+        return nodeBLSKeys[node];
+    }
+
+    function getNodeBLSKeyProof(address node) external view returns (bytes memory) {
+        return nodeBLSKeyProofs[node];
+    }
+
     function getProtocolStake(address protocol) external view returns (uint256) {
         return protocols[protocol].stake;
     }
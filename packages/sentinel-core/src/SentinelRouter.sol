@@ -45,6 +45,7 @@ contract SentinelRouter is ReentrancyGuard, Owned {
     error AlreadySigned();
     error RequestAlreadyExecuted();
     error NodeNotRegistered();
+    error BLSKeyNotPublished();
     error ThresholdNotMet();
     error RateLimitExceeded();
 
@@ -138,6 +139,7 @@ contract SentinelRouter is ReentrancyGuard, Owned {
 
         if (signers.length < requiredSigners) revert InsufficientSignatures();
 
+        // Nodes sign exactly these bytes, hashed with BLSVerifier.hashToG1
         bytes memory message = abi.encodePacked(targetProtocol, evidenceHash, block.chainid);
 
         bytes[] memory messages = new bytes[](signers.length);
@@ -148,8 +150,10 @@ contract SentinelRouter is ReentrancyGuard, Owned {
 
             messages[i] = message;
 
-            (,,,,,, bytes32 blsKey) = registry.nodes(signers[i]);
-            publicKeys[i] = abi.encodePacked(blsKey);
+            // The registry only commits to the key's hash; the key itself
+            // must have been published
+            publicKeys[i] = registry.getNodeBLSKey(signers[i]);
+            if (publicKeys[i].length == 0) revert BLSKeyNotPublished();
         }
 
         bool valid = blsVerifier.verifyAggregatedSignature(aggregatedSignature, messages, publicKeys);
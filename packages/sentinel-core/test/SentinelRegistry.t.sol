@@ -19,6 +19,8 @@ contract SentinelRegistryTest is Test {
 
     bytes32 public blsKey1 = keccak256("bls_key_1");
     bytes32 public blsKey2 = keccak256("bls_key_2");
+    // The registry stores a proof of possession without checking it
+    bytes public blsProof = abi.encodePacked(keccak256("pop_x"), keccak256("pop_y"));
 
     uint256 public constant MIN_NODE_STAKE = 10_000 * 1e18;
     uint256 public constant UNSTAKE_COOLDOWN = 21 days;
@@ -94,6 +96,66 @@ contract SentinelRegistryTest is Test {
         vm.stopPrank();
     }
 
+    // ============ BLS Key Publication Tests ============
+
+    function test_PublishBLSKey() public {
+        bytes memory fullKey = abi.encodePacked(keccak256("g2_x0"), keccak256("g2_x1"), keccak256("g2_y0"), keccak256("g2_y1"));
+
+        vm.startPrank(node1);
+        token.approve(address(registry), MIN_NODE_STAKE);
+        registry.registerNode(MIN_NODE_STAKE, keccak256(fullKey));
+
+        vm.expectEmit(true, false, false, true);
+        emit SentinelRegistry.NodeBLSKeyPublished(node1, fullKey, blsProof);
+        registry.publishBLSKey(fullKey, blsProof);
+        vm.stopPrank();
+
+        assertEq(registry.getNodeBLSKey(node1), fullKey);
+        assertEq(registry.getNodeBLSKeyProof(node1), blsProof);
+        assertEq(registry.getNodeBLSKey(node2).length, 0);
+        assertEq(registry.getNodeBLSKeyProof(node2).length, 0);
+    }
+
+    function test_PublishBLSKey_RevertsIfNotRegisteredKey() public {
+        vm.startPrank(node1);
+        token.approve(address(registry), MIN_NODE_STAKE);
+        registry.registerNode(MIN_NODE_STAKE, blsKey1);
+
+        vm.expectRevert(SentinelRegistry.BLSKeyMismatch.selector);
+        registry.publishBLSKey(abi.encodePacked(keccak256("x0"), keccak256("x1"), keccak256("y0"), keccak256("y1")), blsProof);
+        vm.stopPrank();
+    }
+
+    function test_PublishBLSKey_RevertsIfNotUncompressed() public {
+        bytes memory compressedKey = abi.encodePacked(keccak256("g2_x0"), keccak256("g2_x1"));
+
+        vm.startPrank(node1);
+        token.approve(address(registry), MIN_NODE_STAKE);
+        registry.registerNode(MIN_NODE_STAKE, keccak256(compressedKey));
+
+        vm.expectRevert(SentinelRegistry.InvalidBLSKey.selector);
+        registry.publishBLSKey(compressedKey, blsProof);
+        vm.stopPrank();
+    }
+
+    function test_PublishBLSKey_RevertsWithoutProofOfPossession() public {
+        bytes memory fullKey = abi.encodePacked(keccak256("g2_x0"), keccak256("g2_x1"), keccak256("g2_y0"), keccak256("g2_y1"));
+
+        vm.startPrank(node1);
+        token.approve(address(registry), MIN_NODE_STAKE);
+        registry.registerNode(MIN_NODE_STAKE, keccak256(fullKey));
+
+        vm.expectRevert(SentinelRegistry.InvalidProofOfPossession.selector);
+        registry.publishBLSKey(fullKey, "");
+        vm.stopPrank();
+    }
+
+    function test_PublishBLSKey_RevertsIfNotActive() public {
+        vm.prank(node1);
+        vm.expectRevert(SentinelRegistry.NodeNotActive.selector);
+        registry.publishBLSKey(abi.encodePacked(blsKey1), blsProof);
+    }
+
     // ============ Node Stake Increase Tests ============
 
     function test_IncreaseNodeStake() public {
@@ -349,6 +349,74 @@ contract SentinelRouterTest is Test {
         assertEq(successful, 0);
     }
 
+    // ============ Aggregated Signature Tests ============
+
+    // Keys, their proofs of possession and aggregate signature produced by
+    // the node's BLS code: five signers' shares over
+    // abi.encodePacked(protocol1, evidenceHash, 31337), hashed with
+    // BLSVerifier.hashToG1
+    bytes constant AGG_KEY_1 = hex"15228e73d86f0b7d81c3b0081d6c510c117eab810a22730aef216b6946067b3c0d96123d8fac20d7bb273f48b5d44254f00eee4d62488d78ae08b93cd68b72091d51917e8d10ecd1802616095c6d4fb93cface274bda44a7934adfbe530503661f989917d88c1ab30c82347618cff24090e6d96a280e2e1838412d8c3c674dc1";
+    bytes constant AGG_KEY_2 = hex"0c923ce2c050c4c58ff61e86fc93f440d206ff42b4f385065bab93b2cc3117202655cf264fe0eb4a20225ca49f1bb2b2f40254671403c219750bd60f94813bf0230ba6fb388a5c29b1c74d2634bc2c46b3a91906e251c061a641e63bf16351b22f005d763b9371ecf9b8a40b1689eddd1a8771e5366cfbcce77d7c5bcbb797d8";
+    bytes constant AGG_KEY_3 = hex"142aec3d4838b596ea942ff46627d06eef659cc7d633db00a340bece74a5054a0df2c12fb5efc76f4b6770d9bd3c0303de5817edffc8d0db751f836564e5e1bc06f87ce3cdd525b82abebc195a0b06d23935e7569062fbfb38dac25ef0210a76233ea0617b729d86a282b367e02cd074ecde0bd757efd00d167285ea63dda04b";
+    bytes constant AGG_KEY_4 = hex"1a657ebf3b20ac72dd756b45fa46b00d881392029afa6404baf581aa234ea7c0247f083b50c6ad7b4b4721440fca7f7e2d96265a1c3f86bfce2a3cfad32934ed14c1b9299c4313468f6e806f8db94e08dd90ea7740af91f848cdfdf8b255f96612e11f7dac34c440044b7bf2c9e45ca694515bf26b635daa35b901f7312836c8";
+    bytes constant AGG_KEY_5 = hex"256601556a130b59bd8022f8fee7a7553d50dc3d0fe20fb61afdd55bdb68fcf6235e8abd6794aa7eb488d242712d844e85266d8d774318dddc113116c297c8f721f765e4a1b52338aaaf38eb3096571ec9cd2a734ac732c044684d70f4c2670604f6d8b00e5c9e70e552b96b917e87566c72321ec96b2416dcfd41015d2a6249";
+    bytes constant AGG_PROOF_1 = hex"2efc7b94fe82146af1f675a317891cae7f835cc23df154f5dad9722630ada03f23bf9e38cb9fb38c35f6a4039768e146184a3a3dcadb3a87128cd1908888a6f2";
+    bytes constant AGG_PROOF_2 = hex"07bb0aedb54b7bf3bc5f377a94ce9fb315dc6370c052d8adacd32245bc72f01e2389cbf524f5055cce2b7bd9d339c937e88ac742c92792826f4d06c4f5bfa368";
+    bytes constant AGG_PROOF_3 = hex"28efa88ce14c416c389c1bf0da53ff6799173bbd19bfef96b92bd4e3f63a7ab50e977375a61b31d15897137f22acc4f9f4201330c0c4793c23c3bd90d9b88e27";
+    bytes constant AGG_PROOF_4 = hex"1c095b43db3a7ebf6216719bef220b69cb3118d1f36aeaef7931658af0a0a7b60107127f4ccaee617fdaa22ff27f231f76a910d5bf170d989cc9fb2e21aee2b4";
+    bytes constant AGG_PROOF_5 = hex"2c49e2c074bfb7526a56d0e6be5df404ac4cf8884dcf3e2005b816c6c9828c65298c799dbdf36feeddf083a32d07ed61d9cee9e37eea0753acaecdcb5f694d88";
+    bytes constant AGG_SIGNATURE = hex"1d4326d900f60b93031a2639d24e169b7302eca7022960106188a009d43e3ed915ee04cd8109a1d2c122a92359cd4c2af759a27e0c6d7deb32b0cb946d8abc3e";
+
+    function _registerNodeWithKey(address node, bytes memory blsKey, bytes memory proof) internal {
+        vm.startPrank(node);
+        token.approve(address(registry), MIN_NODE_STAKE);
+        registry.registerNode(MIN_NODE_STAKE, keccak256(blsKey));
+        registry.publishBLSKey(blsKey, proof);
+        vm.stopPrank();
+    }
+
+    function _registerAggregateSigners() internal returns (address[] memory signers) {
+        _registerNodeWithKey(node1, AGG_KEY_1, AGG_PROOF_1);
+        _registerNodeWithKey(node2, AGG_KEY_2, AGG_PROOF_2);
+        _registerNodeWithKey(node3, AGG_KEY_3, AGG_PROOF_3);
+        _registerNodeWithKey(node4, AGG_KEY_4, AGG_PROOF_4);
+        _registerNodeWithKey(node5, AGG_KEY_5, AGG_PROOF_5);
+
+        signers = new address[](5);
+        signers[0] = node1;
+        signers[1] = node2;
+        signers[2] = node3;
+        signers[3] = node4;
+        signers[4] = node5;
+    }
+
+    function test_ExecutePauseWithAggregatedSignature() public {
+        vm.chainId(31337);
+        address[] memory signers = _registerAggregateSigners();
+
+        router.executePauseWithAggregatedSignature(protocol1, evidenceHash, AGG_SIGNATURE, signers);
+
+        assertTrue(mockProtocol.paused());
+    }
+
+    function test_ExecutePauseWithAggregatedSignature_RevertsOnOtherChain() public {
+        vm.chainId(1);
+        address[] memory signers = _registerAggregateSigners();
+
+        vm.expectRevert(SentinelRouter.InvalidSignature.selector);
+        router.executePauseWithAggregatedSignature(protocol1, evidenceHash, AGG_SIGNATURE, signers);
+    }
+
+    function test_ExecutePauseWithAggregatedSignature_RevertsIfKeyNotPublished() public {
+        vm.chainId(31337);
+        address[] memory signers = _registerAggregateSigners();
+        _registerNode(node6, blsKey6);
+        signers[4] = node6;
+
+        vm.expectRevert(SentinelRouter.BLSKeyNotPublished.selector);
+        router.executePauseWithAggregatedSignature(protocol1, evidenceHash, AGG_SIGNATURE, signers);
+    }
+
     // ============ View Functions Tests ============
 
     function test_GetRequiredSignatures_MinimumNodes() public {
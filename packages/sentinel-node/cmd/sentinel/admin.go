@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	// pauseModeDrop discards transactions that arrive while analysis is paused
+	pauseModeDrop = "drop"
+	// pauseModeDrain holds them in a bounded backlog analyzed on resume
+	pauseModeDrain = "drain"
+
+	defaultPauseBacklogSize = 1000
+)
+
+// analysisPause is the operator-controlled switch that suspends transaction
+// analysis without touching gossip or the mempool subscription
+type analysisPause struct {
+	mu          sync.Mutex
+	paused      bool
+	mode        string
+	backlog     []*types.PendingTransaction
+	backlogSize int
+	dropped     uint64
+	draining    sync.WaitGroup
+}
+
+// PauseAnalysis suspends transaction analysis. Gossip, peer connections and
+// the mempool subscription keep running.
+func (n *SentinelNode) PauseAnalysis() {
+	n.pause.mu.Lock()
+	defer n.pause.mu.Unlock()
+
+	if n.pause.paused {
+		return
+	}
+	n.pause.paused = true
+
+	n.logger.Warn().Str("mode", n.pauseMode()).Msg("Transaction analysis paused by operator")
+}
+
+// ResumeAnalysis restarts transaction analysis. In drain mode the backlog
+// collected while paused is analyzed in the background; the number of
+// backlogged transactions is returned.
+func (n *SentinelNode) ResumeAnalysis() int {
+	n.pause.mu.Lock()
+	if !n.pause.paused {
+		n.pause.mu.Unlock()
+		return 0
+	}
+	n.pause.paused = false
+	backlog := n.pause.backlog
+	n.pause.backlog = nil
+	dropped := n.pause.dropped
+	n.pause.dropped = 0
+	n.pause.mu.Unlock()
+
+	n.logger.Info().
+		Int("backlog", len(backlog)).
+		Uint64("dropped", dropped).
+		Msg("Transaction analysis resumed by operator")
+
+	if len(backlog) > 0 {
+		n.pause.draining.Add(1)
+		go func() {
+			defer n.pause.draining.Done()
+			for _, tx := range backlog {
+				n.handleTransaction(tx)
+			}
+		}()
+	}
+
+	return len(backlog)
+}
+
+// IsAnalysisPaused reports whether analysis is currently paused
+func (n *SentinelNode) IsAnalysisPaused() bool {
+	n.pause.mu.Lock()
+	defer n.pause.mu.Unlock()
+	return n.pause.paused
+}
+
+// holdIfPaused takes a transaction out of the analysis path while paused,
+// backlogging or dropping it per the configured mode. It returns false when
+// analysis should proceed.
+func (n *SentinelNode) holdIfPaused(tx *types.PendingTransaction) bool {
+	n.pause.mu.Lock()
+	defer n.pause.mu.Unlock()
+
+	if !n.pause.paused {
+		return false
+	}
+
+	backlogSize := n.pause.backlogSize
+	if backlogSize <= 0 {
+		backlogSize = defaultPauseBacklogSize
+	}
+
+	if n.pauseMode() == pauseModeDrain && len(n.pause.backlog) < backlogSize {
+		n.pause.backlog = append(n.pause.backlog, tx)
+	} else {
+		n.pause.dropped++
+	}
+	return true
+}
+
+func (n *SentinelNode) pauseMode() string {
+	if n.pause.mode == pauseModeDrain {
+		return pauseModeDrain
+	}
+	return pauseModeDrop
+}
+
+func (a *apiServer) handleAdminPause(w http.ResponseWriter, r *http.Request) {
+	a.node.PauseAnalysis()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"paused": true,
+		"mode":   a.node.pauseMode(),
+	})
+}
+
+func (a *apiServer) handleAdminResume(w http.ResponseWriter, r *http.Request) {
+	backlog := a.node.ResumeAnalysis()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"paused":  false,
+		"backlog": backlog,
+	})
+}
+
+// handleAdminAlertAck records that the operator acted on an alert and
+// propagates the ack to peers. The optional body names the action taken.
+func (a *apiServer) handleAdminAlertAck(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := a.node.alerts.Get(id); !ok {
+		writeError(w, http.StatusNotFound, "unknown alert")
+		return
+	}
+
+	body := struct {
+		Action string `json:"action"`
+	}{Action: "manual"}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	handled, err := a.node.AcknowledgeAlert(id, body.Action)
+	if err != nil {
+		a.logger.Warn().Err(err).Str("id", id).Msg("Failed to broadcast alert ack")
+	}
+	if !handled {
+		writeError(w, http.StatusConflict, "alert already handled or resolved")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":        id,
+		"handled":   true,
+		"broadcast": err == nil,
+	})
+}
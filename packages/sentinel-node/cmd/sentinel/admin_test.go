@@ -0,0 +1,145 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestSentinelNode_PauseSkipsAnalysis(t *testing.T) {
+	node := newTestNode(t)
+
+	node.PauseAnalysis()
+	if !node.IsAnalysisPaused() {
+		t.Fatal("Node should report paused")
+	}
+
+	node.handleTransaction(testTransaction(1))
+	if node.stats.analyzed.Load() != 0 {
+		t.Errorf("Expected no analysis while paused, got %d", node.stats.analyzed.Load())
+	}
+
+	if backlog := node.ResumeAnalysis(); backlog != 0 {
+		t.Errorf("Drop mode should not backlog, got %d", backlog)
+	}
+
+	node.handleTransaction(testTransaction(2))
+	if node.stats.analyzed.Load() != 1 {
+		t.Errorf("Expected analysis after resume, got %d", node.stats.analyzed.Load())
+	}
+}
+
+func TestSentinelNode_PauseDrainMode(t *testing.T) {
+	node := newTestNode(t)
+	node.pause.mode = pauseModeDrain
+	node.pause.backlogSize = 2
+
+	node.PauseAnalysis()
+	for i := int64(0); i < 3; i++ {
+		node.handleTransaction(testTransaction(i))
+	}
+
+	if node.stats.analyzed.Load() != 0 {
+		t.Fatalf("Expected no analysis while paused, got %d", node.stats.analyzed.Load())
+	}
+
+	// Backlog is bounded: the third transaction is dropped
+	if backlog := node.ResumeAnalysis(); backlog != 2 {
+		t.Fatalf("Expected backlog of 2, got %d", backlog)
+	}
+
+	node.pause.draining.Wait()
+	if node.stats.analyzed.Load() != 2 {
+		t.Errorf("Expected backlog to be analyzed on resume, got %d", node.stats.analyzed.Load())
+	}
+}
+
+func TestAPIServer_AdminPauseResume(t *testing.T) {
+	node := newTestNode(t)
+	api := newAPIServer(node, 0, "secret")
+	handler := api.routes()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/pause", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !node.IsAnalysisPaused() {
+		t.Error("Node should be paused after /admin/pause")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/resume", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if node.IsAnalysisPaused() {
+		t.Error("Node should be running after /admin/resume")
+	}
+}
+
+func TestAPIServer_AdminRequiresToken(t *testing.T) {
+	node := newTestNode(t)
+	handler := newAPIServer(node, 0, "secret").routes()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/pause", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", rec.Code)
+	}
+	if node.IsAnalysisPaused() {
+		t.Error("Unauthorized request should not pause the node")
+	}
+
+	// Without a configured token the admin API is disabled
+	handler = newAPIServer(node, 0, "").routes()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/pause", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 with admin API disabled, got %d", rec.Code)
+	}
+}
+
+func TestAPIServer_AdminAlertAck(t *testing.T) {
+	node := newTestNode(t)
+	// Without a joined topic the broadcast fails but the local mark stands
+	node.pauses = &consensus.GossipNode{}
+	handler := newAPIServer(node, 0, "secret").routes()
+
+	node.alerts.Add(&types.Alert{ID: "a1", Level: types.AlertLevelCritical})
+
+	ack := func(id string) int {
+		req := httptest.NewRequest(http.MethodPost, "/admin/alerts/"+id+"/ack", strings.NewReader(`{"action":"pause"}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := ack("a1"); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if record, _ := node.alerts.Get("a1"); record.Status != alerts.StatusHandled {
+		t.Errorf("Expected alert handled, got %s", record.Status)
+	}
+	if code := ack("a1"); code != http.StatusConflict {
+		t.Errorf("Expected 409 for a repeated ack, got %d", code)
+	}
+	if code := ack("unknown"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown alert, got %d", code)
+	}
+}
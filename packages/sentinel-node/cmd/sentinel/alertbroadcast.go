@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/config"
+	"github.com/sentinel-protocol/sentinel-node/internal/events"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultAlertRetryBackoff = time.Second
+	maxAlertRetryBackoff     = 30 * time.Second
+	// An escalation gets this long to reach the notifier
+	defaultNotifyTimeout = 10 * time.Second
+)
+
+// alertBroadcaster is the subset of the gossip node locally raised alerts
+// go out through
+type alertBroadcaster interface {
+	BroadcastAlert(alert *types.Alert) error
+}
+
+// alertRetryPolicy sets how many times a failed alert broadcast is retried
+// by level, with the delay doubling from backoff between attempts. Levels
+// without retries are given up on after the first failure.
+type alertRetryPolicy struct {
+	retries map[types.AlertLevel]int
+	backoff time.Duration
+}
+
+func newAlertRetryPolicy(cfg config.AlertsConfig) alertRetryPolicy {
+	retries := make(map[types.AlertLevel]int, len(cfg.BroadcastRetries))
+	for level, n := range cfg.BroadcastRetries {
+		retries[types.AlertLevel(level)] = n
+	}
+	return alertRetryPolicy{retries: retries, backoff: cfg.BroadcastRetryBackoff}
+}
+
+// broadcastAlert publishes a locally raised alert. A failure is retried in
+// the background for levels with retries, and escalated to the notifier
+// once they run out, so a critical alert can't vanish over a transient
+// gossip problem without anyone hearing of it.
+func (n *SentinelNode) broadcastAlert(alert *types.Alert) {
+	err := n.alertGossip.BroadcastAlert(alert)
+	if err == nil {
+		return
+	}
+
+	retries := n.alertRetry.retries[alert.Level]
+	if retries <= 0 {
+		n.logger.Error().Err(err).Str("id", alert.ID).Msg("Failed to broadcast alert")
+		return
+	}
+
+	n.logger.Warn().
+		Err(err).
+		Str("id", alert.ID).
+		Str("level", string(alert.Level)).
+		Int("retries", retries).
+		Msg("Failed to broadcast alert, retrying")
+	go n.retryAlertBroadcast(alert, retries)
+}
+
+func (n *SentinelNode) retryAlertBroadcast(alert *types.Alert, retries int) {
+	ctx := n.rootContext()
+	delay := n.alertRetry.backoff
+	if delay <= 0 {
+		delay = defaultAlertRetryBackoff
+	}
+
+	var err error
+	for attempt := 1; attempt <= retries; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if err = n.alertGossip.BroadcastAlert(alert); err == nil {
+			n.logger.Info().Str("id", alert.ID).Int("attempt", attempt).Msg("Alert broadcast after retrying")
+			return
+		}
+		delay = min(delay*2, maxAlertRetryBackoff)
+	}
+
+	n.escalateAlert(alert, fmt.Errorf("broadcast failed after %d retries: %w", retries, err))
+}
+
+// escalateAlert reports an alert peers never received, for the notifier
+// to bring to the operator
+func (n *SentinelNode) escalateAlert(alert *types.Alert, cause error) {
+	n.logger.Error().
+		Err(cause).
+		Str("id", alert.ID).
+		Str("level", string(alert.Level)).
+		Str("tx", alert.TxHash.Hex()).
+		Msg("Alert never reached the gossip mesh, escalating")
+
+	n.events.Publish(events.AlertUndelivered{Alert: alert, Err: cause})
+}
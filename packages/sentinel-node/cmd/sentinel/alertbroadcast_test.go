@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// flakyGossip fails the first failures broadcasts and counts every attempt
+type flakyGossip struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (g *flakyGossip) BroadcastAlert(alert *types.Alert) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.attempts++
+	if g.attempts <= g.failures {
+		return errors.New("gossip topic not joined")
+	}
+	return nil
+}
+
+func (g *flakyGossip) Attempts() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.attempts
+}
+
+type escalation struct {
+	alert  *types.Alert
+	reason string
+}
+
+type fakeNotifier chan escalation
+
+func (f fakeNotifier) Notify(ctx context.Context, alert *types.Alert, reason string) error {
+	f <- escalation{alert: alert, reason: reason}
+	return nil
+}
+
+func newRetryTestNode(t *testing.T, gossip *flakyGossip) (*SentinelNode, fakeNotifier) {
+	t.Helper()
+	node := newTestNode(t)
+	node.alertGossip = gossip
+	node.alertRetry = alertRetryPolicy{
+		retries: map[types.AlertLevel]int{types.AlertLevelCritical: 3},
+		backoff: time.Millisecond,
+	}
+	notifier := make(fakeNotifier, 1)
+	node.notifier = notifier
+
+	var cancel context.CancelFunc
+	node.ctx, cancel = context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return node, notifier
+}
+
+func TestSentinelNode_CriticalAlertRetriesThenEscalates(t *testing.T) {
+	gossip := &flakyGossip{failures: 100}
+	node, notifier := newRetryTestNode(t, gossip)
+
+	tx := testTransaction(1)
+	node.handleSuspiciousTransaction(tx, &types.InferenceResult{IsSuspicious: true, AnomalyScore: 0.95, RiskLevel: "critical"})
+
+	select {
+	case got := <-notifier:
+		if got.alert.TxHash != tx.Hash || got.alert.Level != types.AlertLevelCritical {
+			t.Errorf("Escalated the wrong alert: %+v", got.alert)
+		}
+		if got.reason == "" {
+			t.Error("Expected the escalation to say why")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the undelivered alert escalated to the notifier")
+	}
+
+	if attempts := gossip.Attempts(); attempts != 4 {
+		t.Errorf("Expected 1 attempt and 3 retries, got %d attempts", attempts)
+	}
+}
+
+func TestSentinelNode_AlertRetrySucceedsWithoutEscalating(t *testing.T) {
+	gossip := &flakyGossip{failures: 2}
+	node, notifier := newRetryTestNode(t, gossip)
+
+	node.handleSuspiciousTransaction(testTransaction(1), &types.InferenceResult{IsSuspicious: true, RiskLevel: "critical"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for gossip.Attempts() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if attempts := gossip.Attempts(); attempts != 3 {
+		t.Fatalf("Expected the broadcast to succeed on the third attempt, got %d attempts", attempts)
+	}
+
+	select {
+	case got := <-notifier:
+		t.Errorf("Delivered alert escalated anyway: %+v", got.alert)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSentinelNode_AlertWithoutRetriesGivesUp(t *testing.T) {
+	gossip := &flakyGossip{failures: 100}
+	node, notifier := newRetryTestNode(t, gossip)
+
+	node.handleSuspiciousTransaction(testTransaction(1), &types.InferenceResult{IsSuspicious: true, RiskLevel: "low"})
+
+	select {
+	case got := <-notifier:
+		t.Errorf("Low alert escalated: %+v", got.alert)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if attempts := gossip.Attempts(); attempts != 1 {
+		t.Errorf("Expected a single attempt for a level without retries, got %d", attempts)
+	}
+}
+
+func TestWebhookNotifier_PostsAlert(t *testing.T) {
+	received := make(chan webhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode webhook body: %v", err)
+		}
+		received <- payload
+	}))
+	defer server.Close()
+
+	alert := &types.Alert{ID: "0xabc", Level: types.AlertLevelCritical}
+	if err := newWebhookNotifier(server.URL).Notify(context.Background(), alert, "broadcast failed"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	got := <-received
+	if got.Alert == nil || got.Alert.ID != "0xabc" || got.Reason != "broadcast failed" {
+		t.Errorf("Unexpected webhook payload %+v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	if err := newWebhookNotifier(failing.URL).Notify(context.Background(), alert, "broadcast failed"); err == nil {
+		t.Error("Expected an error for a non-2xx response")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// apiServer exposes the node's HTTP operational interface on node.apiPort
+type apiServer struct {
+	node       *SentinelNode
+	server     *http.Server
+	adminToken string
+	logger     zerolog.Logger
+}
+
+func newAPIServer(node *SentinelNode, port int, adminToken string) *apiServer {
+	a := &apiServer{
+		node:       node,
+		adminToken: adminToken,
+		logger:     node.logger.With().Str("module", "api").Logger(),
+	}
+
+	a.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           a.routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return a
+}
+
+func (a *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/pause", a.requireAdmin(a.handleAdminPause))
+	mux.HandleFunc("POST /admin/resume", a.requireAdmin(a.handleAdminResume))
+	mux.HandleFunc("POST /admin/alerts/{id}/ack", a.requireAdmin(a.handleAdminAlertAck))
+	mux.HandleFunc("POST /pause", a.requireAdmin(a.handlePause))
+	mux.HandleFunc("GET /health", a.handleHealth)
+	mux.HandleFunc("GET /stats", a.handleStats)
+	mux.HandleFunc("GET /peers", a.handlePeers)
+	mux.HandleFunc("GET /circuit", a.handleCircuit)
+	mux.HandleFunc("GET /recent", a.handleRecent)
+	mux.HandleFunc("GET /alerts/stream", a.handleAlertStream)
+	return mux
+}
+
+// Start begins serving in the background
+func (a *apiServer) Start() error {
+	listener, err := net.Listen("tcp", a.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := a.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Error().Err(err).Msg("API server stopped unexpectedly")
+		}
+	}()
+
+	a.logger.Info().Str("addr", listener.Addr().String()).Msg("API server started")
+	return nil
+}
+
+// Stop shuts the server down, honoring the shutdown context
+func (a *apiServer) Stop(ctx context.Context) error {
+	return a.server.Shutdown(ctx)
+}
+
+// requireAdmin rejects requests without the configured bearer token.
+// Admin endpoints are disabled entirely when no token is configured.
+func (a *apiServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.adminToken == "" {
+			writeError(w, http.StatusForbidden, "admin API disabled: no admin token configured")
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) != 1 {
+			a.logger.Warn().Str("remote", r.RemoteAddr).Str("path", r.URL.Path).Msg("Rejected unauthorized admin request")
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
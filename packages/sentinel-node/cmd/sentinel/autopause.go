@@ -0,0 +1,117 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultAutoPauseCooldown = 30 * time.Minute
+
+// autoPauser rate-limits the pause requests critical alerts create. An
+// exploit usually shows up as a burst of related transactions against the
+// same protocol, and one request per protocol per cooldown is enough for
+// peers to co-sign; the rest would only split their signatures.
+type autoPauser struct {
+	cooldown time.Duration
+
+	mu sync.Mutex
+	// requested holds when a pause request last went out, by target
+	requested map[common.Address]time.Time
+
+	now func() time.Time
+}
+
+func newAutoPauser(cooldown time.Duration) *autoPauser {
+	if cooldown <= 0 {
+		cooldown = defaultAutoPauseCooldown
+	}
+	return &autoPauser{
+		cooldown:  cooldown,
+		requested: make(map[common.Address]time.Time),
+		now:       time.Now,
+	}
+}
+
+// reserve claims target for a pause request, unless one went out for it
+// within the cooldown
+func (p *autoPauser) reserve(target common.Address) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for requested, at := range p.requested {
+		if now.Sub(at) >= p.cooldown {
+			delete(p.requested, requested)
+		}
+	}
+	if _, ok := p.requested[target]; ok {
+		return false
+	}
+	p.requested[target] = now
+	return true
+}
+
+// release gives up a claim whose pause request never went out
+func (p *autoPauser) release(target common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.requested, target)
+}
+
+// warrantsPause reports whether an alert is severe enough to pause its
+// target: it is critical, or analysis recommends blocking the transaction
+func warrantsPause(alert *types.Alert) bool {
+	if alert.Level == types.AlertLevelCritical {
+		return true
+	}
+	return alert.Result != nil && alert.Result.Recommendation == "block"
+}
+
+// autoPause broadcasts a pause request against the protocol a severe alert
+// targets, with the alert's ID as the evidence peers look it up by
+func (n *SentinelNode) autoPause(alert *types.Alert) {
+	if !warrantsPause(alert) || alert.TargetProtocol == (common.Address{}) {
+		return
+	}
+	target := alert.TargetProtocol
+	if !n.autoPauser.reserve(target) {
+		n.logger.Debug().
+			Str("protocol", target.Hex()).
+			Str("alert", alert.ID).
+			Msg("Pause request for protocol already sent, not sending another")
+		return
+	}
+
+	hash, evidence := evidenceHash(alert.ID)
+	if evidence != nil {
+		n.pauses.StoreEvidence(evidence)
+	}
+
+	signed, err := n.broadcastPauseRequest(target, hash)
+	if err != nil {
+		n.autoPauser.release(target)
+		n.logger.Warn().
+			Err(err).
+			Str("protocol", target.Hex()).
+			Str("alert", alert.ID).
+			Msg("Failed to create pause request for critical alert")
+		return
+	}
+
+	n.logger.Warn().
+		Str("protocol", target.Hex()).
+		Str("alert", alert.ID).
+		Str("level", string(alert.Level)).
+		Str("signer", signed.Signer.Hex()).
+		Msg("AUTOMATIC pause request broadcast")
+
+	// Peers flagging the same transaction record the alert handled and
+	// co-sign this request instead of sending their own
+	if _, err := n.AcknowledgeAlert(alert.ID, "pause"); err != nil {
+		n.logger.Warn().Err(err).Str("alert", alert.ID).Msg("Failed to acknowledge auto-paused alert")
+	}
+}
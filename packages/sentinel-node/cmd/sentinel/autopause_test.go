@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/events"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func criticalAlert(id, target string) *types.Alert {
+	return &types.Alert{
+		ID:             common.HexToHash(id).Hex(),
+		Level:          types.AlertLevelCritical,
+		TargetProtocol: common.HexToAddress(target),
+		Result:         &types.InferenceResult{RiskLevel: "critical", Recommendation: "block"},
+	}
+}
+
+func TestAutoPause_CriticalAlertBroadcastsRequest(t *testing.T) {
+	node, pauses := newPauseTestNode(t)
+	node.autoPauser = newAutoPauser(time.Minute)
+
+	alert := criticalAlert("0x1", "0xdead")
+	node.autoPause(alert)
+
+	if len(pauses.sent) != 1 {
+		t.Fatalf("Expected 1 pause request broadcast, got %d", len(pauses.sent))
+	}
+	sent := pauses.sent[0]
+	if sent.Request.TargetProtocol != alert.TargetProtocol {
+		t.Errorf("Expected the alert's target paused, got %s", sent.Request.TargetProtocol.Hex())
+	}
+	if sent.Request.EvidenceHash != common.HexToHash(alert.ID) {
+		t.Errorf("Expected the alert ID as evidence, got %s", sent.Request.EvidenceHash.Hex())
+	}
+	if !node.verifier.VerifyPauseRequest(sent) {
+		t.Error("Expected the request to carry a valid signature")
+	}
+	if node.stats.pausesCreated.Load() != 1 {
+		t.Errorf("Expected PauseRequestsCreated 1, got %d", node.stats.pausesCreated.Load())
+	}
+}
+
+func TestAutoPause_SkipsAlertsThatDontWarrantIt(t *testing.T) {
+	node, pauses := newPauseTestNode(t)
+	node.autoPauser = newAutoPauser(time.Minute)
+
+	medium := criticalAlert("0x1", "0xdead")
+	medium.Level = types.AlertLevelMedium
+	medium.Result = &types.InferenceResult{RiskLevel: "medium", Recommendation: "review"}
+	node.autoPause(medium)
+
+	// Contract creations have no protocol to pause
+	node.autoPause(criticalAlert("0x2", "0x0"))
+
+	if len(pauses.sent) != 0 {
+		t.Errorf("Expected no pause request, got %d", len(pauses.sent))
+	}
+}
+
+func TestAutoPause_Deduplicates(t *testing.T) {
+	node, pauses := newPauseTestNode(t)
+	node.autoPauser = newAutoPauser(time.Minute)
+	now := time.Now()
+	node.autoPauser.now = func() time.Time { return now }
+
+	// A burst against one protocol creates one request
+	for i := 1; i <= 5; i++ {
+		node.autoPause(criticalAlert(fmt.Sprintf("0x%d", i), "0xdead"))
+	}
+	node.autoPause(criticalAlert("0x9", "0xf00d"))
+	if len(pauses.sent) != 2 {
+		t.Fatalf("Expected 1 request per protocol, got %d", len(pauses.sent))
+	}
+
+	// Until the cooldown passes
+	now = now.Add(time.Minute)
+	node.autoPause(criticalAlert("0xa", "0xdead"))
+	if len(pauses.sent) != 3 {
+		t.Errorf("Expected a new request after the cooldown, got %d", len(pauses.sent))
+	}
+
+	// A request that couldn't go out doesn't hold the protocol
+	pauses.peers = 0
+	node.autoPause(criticalAlert("0xb", "0xbeef"))
+	pauses.peers = 2
+	node.autoPause(criticalAlert("0xc", "0xbeef"))
+	if len(pauses.sent) != 4 {
+		t.Errorf("Expected the request retried once peers are back, got %d", len(pauses.sent))
+	}
+}
+
+// newAutoPauseTestNode is a pause test node whose raised alerts reach
+// autoPause through the event bus, as in a running node
+func newAutoPauseTestNode(t *testing.T) (*SentinelNode, *fakePauseBroadcaster) {
+	t.Helper()
+
+	node, pauses := newPauseTestNode(t)
+	node.autoPauser = newAutoPauser(time.Minute)
+	node.alertGossip = &consensus.GossipNode{}
+	node.events = events.NewBus(events.Config{Logger: zerolog.Nop()})
+	node.subscribeAlertConsumers()
+	t.Cleanup(node.events.Close)
+	return node, pauses
+}
+
+func TestAutoPause_AckSuppressesPeersPause(t *testing.T) {
+	tx := testTransaction(1)
+	result := &types.InferenceResult{IsSuspicious: true, AnomalyScore: 0.95, RiskLevel: "critical", Recommendation: "block"}
+	alertID := types.ComputeAlertID(tx, result)
+
+	// Node A flags the transaction first and pauses its target
+	nodeA, pausesA := newAutoPauseTestNode(t)
+	nodeA.handleSuspiciousTransaction(tx, result)
+	nodeA.events.Close()
+
+	if len(pausesA.sent) != 1 {
+		t.Fatalf("Expected node A to broadcast 1 pause request, got %d", len(pausesA.sent))
+	}
+	if len(pausesA.acks) != 1 || pausesA.acks[0] != alertID {
+		t.Fatalf("Expected node A to ack %s, got %v", alertID, pausesA.acks)
+	}
+
+	// Node B hears A's ack before flagging the same transaction
+	nodeB, pausesB := newAutoPauseTestNode(t)
+	nodeB.handleAlertAck(&types.AlertAck{AlertID: pausesA.acks[0], Action: "pause", HandledBy: "0xaa"})
+	nodeB.handleSuspiciousTransaction(tx, result)
+	nodeB.events.Close()
+
+	if len(pausesB.sent) != 0 {
+		t.Errorf("Expected node B to leave the pause to node A, got %d requests", len(pausesB.sent))
+	}
+	if len(pausesB.acks) != 0 {
+		t.Errorf("Expected node B not to ack, got %v", pausesB.acks)
+	}
+}
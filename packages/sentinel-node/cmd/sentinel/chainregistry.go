@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// registryABI covers the SentinelRegistry views and membership events the
+// node reads
+const registryABI = `[
+	{"name":"getActiveNodes","type":"function","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"address[]"}]},
+	{"name":"nodes","type":"function","stateMutability":"view","inputs":[{"name":"","type":"address"}],
+		"outputs":[{"name":"stake","type":"uint256"},{"name":"unstakeRequestTime","type":"uint256"},
+			{"name":"unstakeAmount","type":"uint256"},{"name":"lastRewardClaim","type":"uint256"},
+			{"name":"totalRewardsClaimed","type":"uint256"},{"name":"isActive","type":"bool"},
+			{"name":"blsPublicKey","type":"bytes32"}]},
+	{"name":"getNodeBLSKey","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"address"}],
+		"outputs":[{"name":"","type":"bytes"}]},
+	{"name":"getNodeBLSKeyProof","type":"function","stateMutability":"view","inputs":[{"name":"node","type":"address"}],
+		"outputs":[{"name":"","type":"bytes"}]},
+	{"name":"NodeRegistered","type":"event","inputs":[{"name":"node","type":"address","indexed":true},
+		{"name":"stake","type":"uint256"},{"name":"blsPublicKey","type":"bytes32"}]},
+	{"name":"NodeStakeIncreased","type":"event","inputs":[{"name":"node","type":"address","indexed":true},
+		{"name":"amount","type":"uint256"},{"name":"newTotal","type":"uint256"}]},
+	{"name":"NodeUnstakeCompleted","type":"event","inputs":[{"name":"node","type":"address","indexed":true},
+		{"name":"amount","type":"uint256"}]},
+	{"name":"NodeSlashed","type":"event","inputs":[{"name":"node","type":"address","indexed":true},
+		{"name":"amount","type":"uint256"},{"name":"reason","type":"string"}]},
+	{"name":"NodeDeactivated","type":"event","inputs":[{"name":"node","type":"address","indexed":true}]},
+	{"name":"NodeBLSKeyPublished","type":"event","inputs":[{"name":"node","type":"address","indexed":true},
+		{"name":"blsPublicKey","type":"bytes"},{"name":"proofOfPossession","type":"bytes"}]}
+]`
+
+var parsedRegistryABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(registryABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// registryBackend is the part of an ethclient the registry is read through
+type registryBackend interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error)
+	Close()
+}
+
+// chainRegistry reads the active node set from the SentinelRegistry
+// contract. It is a registrySource, so lookups are served from
+// cachedRegistry's snapshot rather than an RPC per message.
+//
+// A node registers a bytes32 commitment to its BLS key and publishes the
+// full key separately, with a proof of possession the contract stores
+// unchecked; nodes are returned with the published key once it matches the
+// commitment and its proof verifies, and left out until then. Without the
+// proof a node could publish a rogue key that cancels out honest keys in
+// a same-message aggregate. The contract doesn't record libp2p peer IDs, so nodes
+// come back without one and are resolved by the operator address their
+// gossip envelopes carry.
+//
+// The RPC connection is made on first use and remade after a failed call,
+// so an unreachable endpoint only fails refreshes.
+type chainRegistry struct {
+	address common.Address
+	url     string
+	dial    func(ctx context.Context, url string) (registryBackend, error)
+	logger  zerolog.Logger
+
+	mu      sync.Mutex
+	backend registryBackend
+}
+
+func newChainRegistry(address common.Address, url string, logger zerolog.Logger) *chainRegistry {
+	return &chainRegistry{
+		address: address,
+		url:     url,
+		logger:  logger,
+		dial: func(ctx context.Context, url string) (registryBackend, error) {
+			return ethclient.DialContext(ctx, url)
+		},
+	}
+}
+
+// client returns the connection to the registry, dialing it if needed
+func (r *chainRegistry) client(ctx context.Context) (registryBackend, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.backend == nil {
+		backend, err := r.dial(ctx, r.url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to registry: %w", err)
+		}
+		r.backend = backend
+	}
+	return r.backend, nil
+}
+
+// reset drops a connection a call failed on, unless it was already replaced
+func (r *chainRegistry) reset(backend registryBackend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.backend == backend {
+		r.backend.Close()
+		r.backend = nil
+	}
+}
+
+// Close releases the RPC connection
+func (r *chainRegistry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.backend != nil {
+		r.backend.Close()
+		r.backend = nil
+	}
+}
+
+// CheckBLSKeys reports an error unless the registry contract serves full BLS
+// keys and their proofs of possession. Without them every peer's signatures
+// fail verification, so the node refuses to start rather than reject all
+// gossip.
+func (r *chainRegistry) CheckBLSKeys(ctx context.Context) error {
+	for _, method := range []string{"getNodeBLSKey", "getNodeBLSKeyProof"} {
+		if _, err := r.call(ctx, method, common.Address{}); err != nil {
+			return fmt.Errorf("registry %s does not serve full BLS keys: %w", r.address.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// ActiveNodes returns every node the registry lists as active and whose
+// published BLS key matches its registration and proves possession
+func (r *chainRegistry) ActiveNodes(ctx context.Context) ([]types.NodeInfo, error) {
+	out, err := r.call(ctx, "getActiveNodes")
+	if err != nil {
+		return nil, err
+	}
+	addresses, ok := out[0].([]common.Address)
+	if !ok {
+		return nil, fmt.Errorf("getActiveNodes: unexpected result %T", out[0])
+	}
+
+	nodes := make([]types.NodeInfo, 0, len(addresses))
+	for _, address := range addresses {
+		out, err := r.call(ctx, "nodes", address)
+		if err != nil {
+			return nil, err
+		}
+		stake, _ := out[0].(*big.Int)
+		isActive, _ := out[5].(bool)
+		commitment, _ := out[6].([32]byte)
+		if !isActive {
+			continue
+		}
+
+		out, err = r.call(ctx, "getNodeBLSKey", address)
+		if err != nil {
+			return nil, err
+		}
+		key, _ := out[0].([]byte)
+		if len(key) == 0 || crypto.Keccak256Hash(key) != common.Hash(commitment) || !consensus.ValidPublicKey(key) {
+			r.logger.Warn().
+				Str("node", address.Hex()).
+				Int("keyBytes", len(key)).
+				Msg("Skipping registered node without a valid published BLS key")
+			continue
+		}
+
+		out, err = r.call(ctx, "getNodeBLSKeyProof", address)
+		if err != nil {
+			return nil, err
+		}
+		proof, _ := out[0].([]byte)
+		if valid, err := consensus.VerifyProofOfPossession(key, proof); !valid {
+			r.logger.Warn().
+				Err(err).
+				Str("node", address.Hex()).
+				Msg("Skipping registered node whose BLS key proof of possession doesn't verify")
+			continue
+		}
+
+		nodes = append(nodes, types.NodeInfo{
+			Address:      address,
+			BLSPublicKey: key,
+			Stake:        stake,
+			IsActive:     true,
+		})
+	}
+	return nodes, nil
+}
+
+// WatchMembership signals on every event that changes a node's membership,
+// stake or key. The channel is closed when the subscription drops, which sends
+// cachedRegistry back to periodic refreshes.
+func (r *chainRegistry) WatchMembership(ctx context.Context) (<-chan struct{}, error) {
+	var topics []common.Hash
+	for _, name := range []string{"NodeRegistered", "NodeStakeIncreased", "NodeUnstakeCompleted", "NodeSlashed", "NodeDeactivated", "NodeBLSKeyPublished"} {
+		topics = append(topics, parsedRegistryABI.Events[name].ID)
+	}
+
+	backend, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logs := make(chan gethtypes.Log, 16)
+	sub, err := backend.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{r.address},
+		Topics:    [][]common.Hash{topics},
+	}, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		defer close(changed)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.Err():
+				return
+			case <-logs:
+				// A refresh already pending covers this event too
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changed, nil
+}
+
+func (r *chainRegistry) call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	input, err := parsedRegistryABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{To: &r.address, Data: input}, nil)
+	if err != nil {
+		r.reset(backend)
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	out, err := parsedRegistryABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+)
+
+var testRegistryAddress = common.HexToAddress("0x4e9")
+
+// registeredNode is a SentinelRegistry nodes() entry and the BLS key and
+// proof of possession it published
+type registeredNode struct {
+	stake     int64
+	isActive  bool
+	key       [32]byte
+	published []byte
+	proof     []byte
+}
+
+// testRegisteredNode registers a fresh BLS key the way an operator would:
+// committing to its hash and publishing the key itself with its proof of
+// possession
+func testRegisteredNode(t *testing.T, stake int64) registeredNode {
+	t.Helper()
+	signer, err := consensus.NewBLSSigner("")
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed: %v", err)
+	}
+	proof, err := signer.GenerateProofOfPossession()
+	if err != nil {
+		t.Fatalf("GenerateProofOfPossession failed: %v", err)
+	}
+	key := signer.PublicKey()
+	return registeredNode{stake: stake, isActive: true, key: crypto.Keccak256Hash(key), published: key, proof: proof}
+}
+
+// mockRegistryClient answers SentinelRegistry calls from its nodes
+type mockRegistryClient struct {
+	mu     sync.Mutex
+	active []common.Address
+	nodes  map[common.Address]registeredNode
+	err    error
+	logs   chan<- gethtypes.Log
+	closed bool
+	// noKeys answers getNodeBLSKey and getNodeBLSKeyProof like a contract
+	// deployed without them
+	noKeys bool
+}
+
+func (m *mockRegistryClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	if call.To == nil || *call.To != testRegistryAddress {
+		return nil, errors.New("call to the wrong contract")
+	}
+	method, err := parsedRegistryABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case "getActiveNodes":
+		return method.Outputs.Pack(m.active)
+	case "nodes":
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		node := m.nodes[args[0].(common.Address)]
+		zero := new(big.Int)
+		return method.Outputs.Pack(big.NewInt(node.stake), zero, zero, zero, zero, node.isActive, node.key)
+	case "getNodeBLSKey", "getNodeBLSKeyProof":
+		if m.noKeys {
+			return nil, errors.New("execution reverted")
+		}
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		node := m.nodes[args[0].(common.Address)]
+		if method.Name == "getNodeBLSKeyProof" {
+			return method.Outputs.Pack(node.proof)
+		}
+		return method.Outputs.Pack(node.published)
+	}
+	return nil, errors.New("unexpected method " + method.Name)
+}
+
+func (m *mockRegistryClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = ch
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+func (m *mockRegistryClient) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+}
+
+func newTestChainRegistry(client *mockRegistryClient) (*chainRegistry, *int) {
+	dials := 0
+	registry := newChainRegistry(testRegistryAddress, "ws://registry", zerolog.Nop())
+	registry.dial = func(ctx context.Context, url string) (registryBackend, error) {
+		dials++
+		return client, nil
+	}
+	return registry, &dials
+}
+
+func TestChainRegistry_ActiveNodes(t *testing.T) {
+	alice, bob, carol := common.HexToAddress("0xa1"), common.HexToAddress("0xb0b"), common.HexToAddress("0xca")
+	client := &mockRegistryClient{
+		active: []common.Address{alice, bob, carol},
+		nodes: map[common.Address]registeredNode{
+			alice: testRegisteredNode(t, 10000),
+			bob:   testRegisteredNode(t, 20000),
+			// Slashed below the minimum but not yet removed from the list
+			carol: {stake: 100, isActive: false},
+		},
+	}
+	source, _ := newTestChainRegistry(client)
+
+	nodes, err := source.ActiveNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveNodes failed: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("Expected the 2 active nodes, got %+v", nodes)
+	}
+	if nodes[1].Address != bob || nodes[1].Stake.Int64() != 20000 || !bytes.Equal(nodes[1].BLSPublicKey, client.nodes[bob].published) {
+		t.Errorf("Expected bob's registration read back, got %+v", nodes[1])
+	}
+
+	// Served to the verifier through the cached view
+	registry := newCachedRegistry(source, time.Minute, zerolog.Nop())
+	registry.refresh(context.Background())
+	for address, want := range map[common.Address]bool{alice: true, bob: true, carol: false} {
+		if active, err := registry.IsNodeActive(address.Hex()); err != nil || active != want {
+			t.Errorf("Expected %s active %v, got %v, %v", address.Hex(), want, active, err)
+		}
+	}
+	if key, _ := registry.BLSPublicKey(carol); key != nil {
+		t.Errorf("Expected no key for an inactive node, got %x", key)
+	}
+}
+
+func TestChainRegistry_SkipsNodesWithoutUsableKey(t *testing.T) {
+	unpublished, mismatched, invalid := common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	compressed, good := common.HexToAddress("0x05"), common.HexToAddress("0x04")
+
+	mismatch := testRegisteredNode(t, 10000)
+	mismatch.published = testRegisteredNode(t, 10000).published
+	// A bytes32 is too short for any G2 encoding
+	junk := bytes.Repeat([]byte{0x01}, 32)
+	// A valid key, but in a form the router can't decode
+	keyPair, err := consensus.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair failed: %v", err)
+	}
+	compressedKey := keyPair.PublicKey.Bytes()
+
+	client := &mockRegistryClient{
+		active: []common.Address{unpublished, mismatched, invalid, compressed, good},
+		nodes: map[common.Address]registeredNode{
+			// Registered before publishing: only the bytes32 commitment is known
+			unpublished: {stake: 10000, isActive: true, key: [32]byte{0xa1}},
+			mismatched:  mismatch,
+			invalid:     {stake: 10000, isActive: true, key: crypto.Keccak256Hash(junk), published: junk},
+			compressed:  {stake: 10000, isActive: true, key: crypto.Keccak256Hash(compressedKey[:]), published: compressedKey[:]},
+			good:        testRegisteredNode(t, 10000),
+		},
+	}
+	source, _ := newTestChainRegistry(client)
+
+	nodes, err := source.ActiveNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Address != good {
+		t.Errorf("Expected only the node with a valid published key, got %+v", nodes)
+	}
+}
+
+func TestChainRegistry_SkipsNodesWithoutProofOfPossession(t *testing.T) {
+	unproven, borrowed, good := common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")
+
+	honest := testRegisteredNode(t, 10000)
+	// A rogue key can't be signed for, so it comes with someone else's proof
+	rogue := testRegisteredNode(t, 10000)
+	rogue.proof = honest.proof
+	missing := testRegisteredNode(t, 10000)
+	missing.proof = nil
+
+	client := &mockRegistryClient{
+		active: []common.Address{unproven, borrowed, good},
+		nodes: map[common.Address]registeredNode{
+			unproven: missing,
+			borrowed: rogue,
+			good:     honest,
+		},
+	}
+	source, _ := newTestChainRegistry(client)
+
+	nodes, err := source.ActiveNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveNodes failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Address != good {
+		t.Errorf("Expected only the node proving possession of its key, got %+v", nodes)
+	}
+}
+
+func TestChainRegistry_CheckBLSKeys(t *testing.T) {
+	client := &mockRegistryClient{}
+	source, _ := newTestChainRegistry(client)
+	if err := source.CheckBLSKeys(context.Background()); err != nil {
+		t.Errorf("Expected a registry serving keys accepted, got %v", err)
+	}
+
+	client.noKeys = true
+	if err := source.CheckBLSKeys(context.Background()); err == nil {
+		t.Error("Expected a registry without full keys refused")
+	}
+}
+
+func TestChainRegistry_RedialsAfterFailure(t *testing.T) {
+	client := &mockRegistryClient{err: errors.New("connection reset")}
+	source, dials := newTestChainRegistry(client)
+
+	if _, err := source.ActiveNodes(context.Background()); err == nil {
+		t.Fatal("Expected the failed call reported")
+	}
+	if !client.closed {
+		t.Error("Expected the failed connection closed")
+	}
+
+	client.err = nil
+	if _, err := source.ActiveNodes(context.Background()); err != nil {
+		t.Fatalf("ActiveNodes failed: %v", err)
+	}
+	if *dials != 2 {
+		t.Errorf("Expected a fresh connection after the failure, got %d dials", *dials)
+	}
+}
+
+func TestChainRegistry_WatchMembership(t *testing.T) {
+	client := &mockRegistryClient{}
+	source, _ := newTestChainRegistry(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changed, err := source.WatchMembership(ctx)
+	if err != nil {
+		t.Fatalf("WatchMembership failed: %v", err)
+	}
+
+	client.logs <- gethtypes.Log{Address: testRegistryAddress}
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a registry event to signal a refresh")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-changed:
+		if ok {
+			t.Error("Expected the channel closed once the watch ends")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the watch to end with its context")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultConfirmationInterval = 3 * time.Second
+	// Alerted transactions still unmined after this long were most likely
+	// dropped or replaced
+	defaultConfirmationMaxAge = time.Hour
+)
+
+// receiptSource finds the block a transaction was mined in; the mempool
+// listener implements it
+type receiptSource interface {
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+// awaitingBlock is an alerted transaction not yet seen mined
+type awaitingBlock struct {
+	alertID    string
+	detectedAt time.Time
+}
+
+// confirmationTracker watches locally alerted transactions until they are
+// mined, recording the mined time on the alert and keeping detection
+// latency and lead time metrics. Mined times come from block timestamps,
+// so lead times are accurate to about a second.
+type confirmationTracker struct {
+	source   receiptSource
+	alerts   *alerts.Store
+	interval time.Duration
+	maxAge   time.Duration
+	logger   zerolog.Logger
+
+	mu      sync.Mutex
+	pending map[common.Hash]awaitingBlock
+
+	detections     uint64
+	detectionTotal time.Duration
+	mined          uint64
+	leadTotal      time.Duration
+
+	now func() time.Time
+}
+
+func newConfirmationTracker(source receiptSource, store *alerts.Store, logger zerolog.Logger) *confirmationTracker {
+	return &confirmationTracker{
+		source:   source,
+		alerts:   store,
+		interval: defaultConfirmationInterval,
+		maxAge:   defaultConfirmationMaxAge,
+		logger:   logger,
+		pending:  make(map[common.Hash]awaitingBlock),
+		now:      time.Now,
+	}
+}
+
+// track records an alert's detection latency and watches its transaction
+// for inclusion. Alerts without detection timing are ignored.
+func (c *confirmationTracker) track(alert *types.Alert) {
+	if c == nil || alert.Timing == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.pending[alert.TxHash]; ok {
+		return
+	}
+	c.pending[alert.TxHash] = awaitingBlock{alertID: alert.ID, detectedAt: alert.Timing.DetectedAt}
+	c.detections++
+	c.detectionTotal += alert.Timing.DetectionLatency()
+}
+
+// Run polls for mined transactions until ctx is canceled
+func (c *confirmationTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+// poll checks every pending transaction once
+func (c *confirmationTracker) poll(ctx context.Context) {
+	c.mu.Lock()
+	pending := make(map[common.Hash]awaitingBlock, len(c.pending))
+	for hash, awaiting := range c.pending {
+		pending[hash] = awaiting
+	}
+	c.mu.Unlock()
+
+	for hash, awaiting := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+
+		minedAt, err := c.minedAt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			if c.now().Sub(awaiting.detectedAt) > c.maxAge {
+				c.forget(hash)
+			}
+			continue
+		}
+		if err != nil {
+			c.logger.Debug().Err(err).Str("tx", hash.Hex()).Msg("Failed to check alerted transaction inclusion")
+			continue
+		}
+
+		c.confirm(hash, awaiting, minedAt)
+	}
+}
+
+// minedAt returns the timestamp of the block that included hash
+func (c *confirmationTracker) minedAt(ctx context.Context, hash common.Hash) (time.Time, error) {
+	receipt, err := c.source.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return time.Time{}, err
+	}
+	header, err := c.source.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+func (c *confirmationTracker) confirm(hash common.Hash, awaiting awaitingBlock, minedAt time.Time) {
+	c.mu.Lock()
+	if _, ok := c.pending[hash]; !ok {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, hash)
+	lead := minedAt.Sub(awaiting.detectedAt)
+	c.mined++
+	c.leadTotal += lead
+	c.mu.Unlock()
+
+	c.alerts.SetMinedAt(awaiting.alertID, minedAt)
+	c.logger.Info().
+		Str("id", awaiting.alertID).
+		Str("tx", hash.Hex()).
+		Dur("leadTime", lead).
+		Msg("Alerted transaction mined")
+}
+
+func (c *confirmationTracker) forget(hash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, hash)
+}
+
+// applyStats fills in the detection timing metrics
+func (c *confirmationTracker) applyStats(stats *types.NodeStats) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.detections > 0 {
+		stats.AverageDetectionLatencyMs = float64(c.detectionTotal.Milliseconds()) / float64(c.detections)
+	}
+	stats.AlertsMined = c.mined
+	if c.mined > 0 {
+		stats.AverageLeadTimeMs = float64(c.leadTotal.Milliseconds()) / float64(c.mined)
+	}
+}
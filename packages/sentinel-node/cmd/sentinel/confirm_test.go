@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// fakeReceiptSource serves receipts for mined transactions and headers
+// with fixed timestamps
+type fakeReceiptSource struct {
+	blocks map[common.Hash]int64
+	times  map[int64]uint64
+}
+
+func (s *fakeReceiptSource) TransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	number, ok := s.blocks[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return &ethtypes.Receipt{TxHash: hash, BlockNumber: big.NewInt(number)}, nil
+}
+
+func (s *fakeReceiptSource) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{Number: number, Time: s.times[number.Int64()]}, nil
+}
+
+func TestConfirmationTracker_LeadTimeMetrics(t *testing.T) {
+	received := time.Unix(1700000000, 0)
+	mined, dropped := testTransaction(1), testTransaction(2)
+	mined.ReceivedAt, dropped.ReceivedAt = received, received
+
+	source := &fakeReceiptSource{
+		blocks: map[common.Hash]int64{mined.Hash: 100},
+		times:  map[int64]uint64{100: uint64(received.Add(12 * time.Second).Unix())},
+	}
+
+	node := newTestNode(t)
+	node.confirmations = newConfirmationTracker(source, node.alerts, zerolog.Nop())
+	now := received.Add(time.Minute)
+	node.confirmations.now = func() time.Time { return now }
+
+	// Alerts are flagged 200ms and 400ms after the transactions arrived
+	result := &types.InferenceResult{IsSuspicious: true, RiskLevel: "high"}
+	for tx, delay := range map[*types.PendingTransaction]time.Duration{mined: 200 * time.Millisecond, dropped: 400 * time.Millisecond} {
+		alert := &types.Alert{
+			ID:     types.ComputeAlertID(tx, result),
+			Level:  types.AlertLevelHigh,
+			TxHash: tx.Hash,
+			Timing: &types.DetectionTiming{ReceivedAt: tx.ReceivedAt, DetectedAt: tx.ReceivedAt.Add(delay)},
+		}
+		node.alerts.Add(alert)
+		node.confirmations.track(alert)
+	}
+
+	node.confirmations.poll(context.Background())
+
+	var stats types.NodeStats
+	node.confirmations.applyStats(&stats)
+	if stats.AverageDetectionLatencyMs != 300 {
+		t.Errorf("Expected 300ms average detection latency, got %v", stats.AverageDetectionLatencyMs)
+	}
+	if stats.AlertsMined != 1 || stats.AverageLeadTimeMs != 11800 {
+		t.Errorf("Expected 1 mined alert with 11800ms lead time, got %d with %v", stats.AlertsMined, stats.AverageLeadTimeMs)
+	}
+
+	record, _ := node.alerts.Get(types.ComputeAlertID(mined, result))
+	if lead, ok := record.Alert.Timing.LeadTime(); !ok || lead != 11800*time.Millisecond {
+		t.Errorf("Expected alert metadata to carry an 11.8s lead time, got %v (%v)", lead, ok)
+	}
+
+	// The unmined transaction is kept until it is presumed dropped
+	if len(node.confirmations.pending) != 1 {
+		t.Fatalf("Expected 1 transaction still pending, got %d", len(node.confirmations.pending))
+	}
+	now = received.Add(2 * time.Hour)
+	node.confirmations.poll(context.Background())
+	if len(node.confirmations.pending) != 0 {
+		t.Error("Expected the unmined transaction to be forgotten after maxAge")
+	}
+}
+
+func TestSentinelNode_AlertCarriesDetectionTiming(t *testing.T) {
+	node := newTestNode(t)
+	node.confirmations = newConfirmationTracker(&fakeReceiptSource{}, node.alerts, zerolog.Nop())
+
+	tx := testTransaction(1)
+	result := &types.InferenceResult{IsSuspicious: true, RiskLevel: "critical"}
+	// Acked by a peer so the alert isn't broadcast without a gossip node
+	node.alerts.MarkHandled(types.ComputeAlertID(tx, result), "node-a")
+	node.handleSuspiciousTransaction(tx, result)
+
+	record, ok := node.alerts.Get(types.ComputeAlertID(tx, result))
+	if !ok || record.Alert.Timing == nil {
+		t.Fatal("Expected the alert to carry detection timing")
+	}
+	if !record.Alert.Timing.ReceivedAt.Equal(tx.ReceivedAt) || record.Alert.Timing.DetectionLatency() < 0 {
+		t.Errorf("Unexpected timing %+v", record.Alert.Timing)
+	}
+	if _, tracked := node.confirmations.pending[tx.Hash]; !tracked {
+		t.Error("Expected the alerted transaction to be watched for inclusion")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+	"github.com/sentinel-protocol/sentinel-node/internal/events"
+)
+
+// subscribeAlertConsumers wires up what happens to a raised alert. The
+// store, archive, co-signer and detection metrics see it before detection
+// moves on, so the API, acks and peers' pause requests find it straight
+// away; broadcasting it, pausing its target and notifying about undelivered
+// alerts can block on the network and run off the detection path.
+func (n *SentinelNode) subscribeAlertConsumers() {
+	n.events.SubscribeSync(events.TypeAlertCreated, "metrics", func(e events.Event) error {
+		n.confirmations.track(e.(events.AlertCreated).Alert)
+		return nil
+	})
+
+	n.events.SubscribeSync(events.TypeAlertCreated, "store", func(e events.Event) error {
+		n.alerts.Add(e.(events.AlertCreated).Alert)
+		return nil
+	})
+
+	if n.archive != nil {
+		n.events.SubscribeSync(events.TypeAlertCreated, "archive", func(e events.Event) error {
+			return n.archive.Append(e.(events.AlertCreated).Alert, alerts.SourceLocal)
+		})
+	}
+
+	if n.coSigner != nil {
+		n.events.SubscribeSync(events.TypeAlertCreated, "cosign", func(e events.Event) error {
+			n.coSigner.observe(e.(events.AlertCreated).Alert)
+			return nil
+		})
+	}
+
+	n.events.Subscribe(events.TypeAlertCreated, "gossip", func(e events.Event) error {
+		created := e.(events.AlertCreated)
+		if !created.HandledByPeer {
+			n.broadcastAlert(created.Alert)
+		}
+		return nil
+	})
+
+	if n.autoPauser != nil {
+		n.events.Subscribe(events.TypeAlertCreated, "autopause", func(e events.Event) error {
+			created := e.(events.AlertCreated)
+			if !created.HandledByPeer {
+				n.autoPause(created.Alert)
+			}
+			return nil
+		})
+	}
+
+	n.events.Subscribe(events.TypeAlertUndelivered, "notifier", func(e events.Event) error {
+		if n.notifier == nil {
+			return nil
+		}
+		undelivered := e.(events.AlertUndelivered)
+		ctx, cancel := context.WithTimeout(n.rootContext(), defaultNotifyTimeout)
+		defer cancel()
+		return n.notifier.Notify(ctx, undelivered.Alert, undelivered.Err.Error())
+	})
+}
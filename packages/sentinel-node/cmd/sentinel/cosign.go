@@ -0,0 +1,136 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultCoSignMinScore = 0.8
+	defaultCoSignWindow   = 10 * time.Minute
+	// Requests stamped further ahead than this are from a skewed clock
+	maxPauseRequestSkew = time.Minute
+)
+
+// detection is this node's strongest alert against a protocol
+type detection struct {
+	score float64
+	at    time.Time
+}
+
+// pauseCoSigner decides which peers' pause requests this node co-signs.
+// A request is only backed when this node raised an alert of its own
+// against the same protocol, scoring at least minScore within window, so a
+// pause needs independent detections rather than one node's word. Each
+// request is signed at most once and only while its originator is still
+// collecting shares.
+type pauseCoSigner struct {
+	minScore float64
+	window   time.Duration
+	maxAge   time.Duration
+
+	mu         sync.Mutex
+	detections map[common.Address]detection
+	// signed holds when each co-signed request was signed, by request ID
+	signed map[string]time.Time
+
+	now func() time.Time
+}
+
+func newPauseCoSigner(minScore float64, window, maxAge time.Duration) *pauseCoSigner {
+	if minScore <= 0 {
+		minScore = defaultCoSignMinScore
+	}
+	if window <= 0 {
+		window = defaultCoSignWindow
+	}
+	if maxAge <= 0 {
+		maxAge = 5 * time.Minute
+	}
+	return &pauseCoSigner{
+		minScore:   minScore,
+		window:     window,
+		maxAge:     maxAge,
+		detections: make(map[common.Address]detection),
+		signed:     make(map[string]time.Time),
+		now:        time.Now,
+	}
+}
+
+// observe records a locally raised alert as a detection against its target
+func (c *pauseCoSigner) observe(alert *types.Alert) {
+	if alert.Result == nil || alert.TargetProtocol == (common.Address{}) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+
+	existing, ok := c.detections[alert.TargetProtocol]
+	if ok && now.Sub(existing.at) <= c.window && existing.score > alert.Result.AnomalyScore {
+		return
+	}
+	c.detections[alert.TargetProtocol] = detection{score: alert.Result.AnomalyScore, at: now}
+}
+
+// decide reports whether to co-sign request and, if not, why. Agreeing
+// reserves the request so it isn't signed twice.
+func (c *pauseCoSigner) decide(request types.PauseRequest) (bool, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+
+	switch age := now.Sub(request.Timestamp); {
+	case age > c.maxAge:
+		return false, "stale request"
+	case age < -maxPauseRequestSkew:
+		return false, "request timestamp in the future"
+	}
+
+	id := request.ID()
+	if _, ok := c.signed[id]; ok {
+		return false, "already signed"
+	}
+
+	seen, ok := c.detections[request.TargetProtocol]
+	if !ok || now.Sub(seen.at) > c.window {
+		return false, "no local detection for target"
+	}
+	if seen.score < c.minScore {
+		return false, "local detection below co-sign score"
+	}
+
+	c.signed[id] = now
+	return true, ""
+}
+
+// release forgets a reservation whose signature never went out, so a
+// redelivered request can be signed
+func (c *pauseCoSigner) release(request types.PauseRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.signed, request.ID())
+}
+
+func (c *pauseCoSigner) pruneLocked(now time.Time) {
+	for target, seen := range c.detections {
+		if now.Sub(seen.at) > c.window {
+			delete(c.detections, target)
+		}
+	}
+	// A request can't be signed again once it is too old anyway
+	for id, at := range c.signed {
+		if now.Sub(at) > c.maxAge+maxPauseRequestSkew {
+			delete(c.signed, id)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// newCoSignTestNode returns a pause test node that has flagged 0xdead with
+// a score of 0.9
+func newCoSignTestNode(t *testing.T) (*SentinelNode, *fakePauseBroadcaster) {
+	t.Helper()
+
+	node, pauses := newPauseTestNode(t)
+	node.coSigner = newPauseCoSigner(0.8, 10*time.Minute, 5*time.Minute)
+	node.coSigner.observe(&types.Alert{
+		TargetProtocol: common.HexToAddress("0xdead"),
+		Result:         &types.InferenceResult{AnomalyScore: 0.9},
+	})
+	return node, pauses
+}
+
+func peerPauseRequest(target string) *types.SignedPauseRequest {
+	return &types.SignedPauseRequest{
+		Request: types.PauseRequest{
+			TargetProtocol: common.HexToAddress(target),
+			EvidenceHash:   common.HexToHash("0xbeef"),
+			Timestamp:      time.Now(),
+			Signers:        []common.Address{common.HexToAddress("0xbb")},
+		},
+		Signer: common.HexToAddress("0xbb"),
+	}
+}
+
+func TestHandlePauseRequest_CoSignsIndependentDetection(t *testing.T) {
+	node, pauses := newCoSignTestNode(t)
+	request := peerPauseRequest("0xdead")
+
+	node.handlePauseRequest(request)
+
+	share, ok := pauses.shares[request.Request.ID()]
+	if !ok {
+		t.Fatalf("Expected a signature share for %s, got %v", request.Request.ID(), pauses.shares)
+	}
+	valid, err := consensus.VerifySignatureForRouter(share, onchain.PauseMessage(request.Request, testChainID), node.bls.PublicKey())
+	if err != nil || !valid {
+		t.Errorf("Expected the share to verify against this node's key, got %v, %v", valid, err)
+	}
+	if signer := pauses.signers[request.Request.ID()]; signer != common.HexToAddress(node.config.Node.OperatorAddress) {
+		t.Errorf("Expected the share attributed to the operator, got %s", signer.Hex())
+	}
+	if node.stats.pausesSigned.Load() != 1 {
+		t.Errorf("Expected PauseRequestsSigned 1, got %d", node.stats.pausesSigned.Load())
+	}
+
+	// Redelivery of the same request isn't signed twice
+	pauses.shares = nil
+	node.handlePauseRequest(request)
+	if len(pauses.shares) != 0 || node.stats.pausesSigned.Load() != 1 {
+		t.Errorf("Expected a duplicate request declined, got %d shares", len(pauses.shares))
+	}
+}
+
+func TestHandlePauseRequest_Declines(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func() *types.SignedPauseRequest
+	}{
+		{"no local detection", func() *types.SignedPauseRequest {
+			return peerPauseRequest("0xf00d")
+		}},
+		{"stale", func() *types.SignedPauseRequest {
+			request := peerPauseRequest("0xdead")
+			request.Request.Timestamp = time.Now().Add(-6 * time.Minute)
+			return request
+		}},
+		{"from the future", func() *types.SignedPauseRequest {
+			request := peerPauseRequest("0xdead")
+			request.Request.Timestamp = time.Now().Add(time.Hour)
+			return request
+		}},
+		{"own request", func() *types.SignedPauseRequest {
+			request := peerPauseRequest("0xdead")
+			request.Signer = common.HexToAddress("0xaa")
+			return request
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, pauses := newCoSignTestNode(t)
+			node.handlePauseRequest(tt.request())
+			if len(pauses.shares) != 0 || node.stats.pausesSigned.Load() != 0 {
+				t.Errorf("Expected the request declined, got %d shares", len(pauses.shares))
+			}
+		})
+	}
+}
+
+func TestPauseCoSigner_Detections(t *testing.T) {
+	signer := newPauseCoSigner(0.8, 10*time.Minute, 5*time.Minute)
+	now := time.Now()
+	signer.now = func() time.Time { return now }
+
+	target := common.HexToAddress("0xdead")
+	request := types.PauseRequest{TargetProtocol: target, Timestamp: now}
+	alert := func(score float64) *types.Alert {
+		return &types.Alert{TargetProtocol: target, Result: &types.InferenceResult{AnomalyScore: score}}
+	}
+
+	signer.observe(alert(0.5))
+	if agree, reason := signer.decide(request); agree || reason != "local detection below co-sign score" {
+		t.Fatalf("Expected a low score declined, got %v %q", agree, reason)
+	}
+
+	// A weaker alert later doesn't mask a stronger one
+	signer.observe(alert(0.95))
+	signer.observe(alert(0.6))
+	if agree, reason := signer.decide(request); !agree {
+		t.Fatalf("Expected the strong detection to co-sign, got %q", reason)
+	}
+
+	// Detections expire after the window
+	signer.release(request)
+	now = now.Add(11 * time.Minute)
+	request.Timestamp = now
+	if agree, reason := signer.decide(request); agree || reason != "no local detection for target" {
+		t.Errorf("Expected an expired detection declined, got %v %q", agree, reason)
+	}
+}
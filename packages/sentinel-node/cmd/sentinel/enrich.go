@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultEnricherTimeout = 500 * time.Millisecond
+
+// Enricher adds external context to a flagged transaction before it is
+// alerted on, such as address labels from an analytics API or matches
+// against a known-incident feed. Enrich may append RiskIndicators, change
+// RiskLevel, add Metadata or clear IsSuspicious for a known-benign sender.
+// It works on a copy of the result, so changes made after it returns an
+// error or overruns its timeout are discarded.
+type Enricher interface {
+	Name() string
+	Enrich(ctx context.Context, tx *types.PendingTransaction, result *types.InferenceResult) error
+}
+
+// RegisterEnricher adds e to the enrichers run, in registration order, on
+// every flagged transaction. It must be called before Start.
+func (n *SentinelNode) RegisterEnricher(e Enricher) {
+	n.enrichers = append(n.enrichers, e)
+}
+
+// enrich runs each enricher in turn under its own timeout and returns the
+// enriched result. An enricher that fails or times out is skipped and the
+// pipeline continues with the result as it was before it ran.
+func (n *SentinelNode) enrich(tx *types.PendingTransaction, result *types.InferenceResult) *types.InferenceResult {
+	timeout := n.config.Inference.EnricherTimeout
+	if timeout <= 0 {
+		timeout = defaultEnricherTimeout
+	}
+
+	for _, e := range n.enrichers {
+		ctx, cancel := context.WithTimeout(n.rootContext(), timeout)
+		candidate := cloneResult(result)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- e.Enrich(ctx, tx, candidate)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				n.logger.Warn().Err(err).Str("enricher", e.Name()).Str("tx", tx.Hash.Hex()).Msg("Enricher failed, skipping")
+			} else {
+				result = candidate
+			}
+		case <-ctx.Done():
+			n.logger.Warn().Str("enricher", e.Name()).Str("tx", tx.Hash.Hex()).Dur("timeout", timeout).Msg("Enricher timed out, skipping")
+		}
+		cancel()
+	}
+
+	return result
+}
+
+// cloneResult copies a result deeply enough that an enricher still running
+// after its timeout can't modify the one the pipeline continues with
+func cloneResult(result *types.InferenceResult) *types.InferenceResult {
+	clone := *result
+	clone.RiskIndicators = append([]string(nil), result.RiskIndicators...)
+	if result.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(result.Metadata))
+		for k, v := range result.Metadata {
+			clone.Metadata[k] = v
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// labelEnricher tags every result with a known-incident indicator
+type labelEnricher struct{}
+
+func (labelEnricher) Name() string { return "labels" }
+
+func (labelEnricher) Enrich(ctx context.Context, tx *types.PendingTransaction, result *types.InferenceResult) error {
+	result.RiskIndicators = append(result.RiskIndicators, "known_exploiter")
+	result.RiskLevel = "critical"
+	result.Metadata = map[string]string{"label": "Exploiter 1"}
+	return nil
+}
+
+// stallingEnricher ignores its deadline and then scribbles on the result
+type stallingEnricher struct {
+	release chan struct{}
+	done    chan struct{}
+}
+
+func (stallingEnricher) Name() string { return "stalling" }
+
+func (e stallingEnricher) Enrich(ctx context.Context, tx *types.PendingTransaction, result *types.InferenceResult) error {
+	defer close(e.done)
+	<-e.release
+	result.RiskIndicators = append(result.RiskIndicators, "late")
+	result.IsSuspicious = false
+	return nil
+}
+
+func TestSentinelNode_EnrichAddsContext(t *testing.T) {
+	node := newTestNode(t)
+	node.RegisterEnricher(labelEnricher{})
+
+	result := &types.InferenceResult{IsSuspicious: true, RiskLevel: "high", RiskIndicators: []string{"flash_loan"}}
+	enriched := node.enrich(testTransaction(1), result)
+
+	if len(enriched.RiskIndicators) != 2 || enriched.RiskIndicators[1] != "known_exploiter" {
+		t.Errorf("Expected the enricher's indicator to be added, got %v", enriched.RiskIndicators)
+	}
+	if enriched.RiskLevel != "critical" || enriched.Metadata["label"] != "Exploiter 1" {
+		t.Errorf("Expected level and metadata from the enricher, got %s %v", enriched.RiskLevel, enriched.Metadata)
+	}
+	if len(result.RiskIndicators) != 1 {
+		t.Error("The original result should not be modified")
+	}
+}
+
+func TestSentinelNode_EnrichSkipsSlowEnricher(t *testing.T) {
+	node := newTestNode(t)
+	node.config.Inference.EnricherTimeout = 20 * time.Millisecond
+
+	stalling := stallingEnricher{release: make(chan struct{}), done: make(chan struct{})}
+	node.RegisterEnricher(stalling)
+	node.RegisterEnricher(labelEnricher{})
+
+	start := time.Now()
+	result := &types.InferenceResult{IsSuspicious: true, RiskLevel: "high"}
+	enriched := node.enrich(testTransaction(1), result)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("A slow enricher should not block the pipeline, took %v", elapsed)
+	}
+
+	// The stalled enricher finishing late changes nothing
+	close(stalling.release)
+	<-stalling.done
+
+	if !enriched.IsSuspicious {
+		t.Error("Changes from the timed-out enricher should be discarded")
+	}
+	if len(enriched.RiskIndicators) != 1 || enriched.RiskIndicators[0] != "known_exploiter" {
+		t.Errorf("Expected only the next enricher's indicator, got %v", enriched.RiskIndicators)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+)
+
+// runKeygen implements `sentinel keygen`: it writes a new BLS key file and
+// prints what the registry needs, without starting the node. The passphrase
+// is read from the environment so it never appears in the process list.
+func runKeygen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	out := fs.String("out", "./keys/bls.key", "Path to write the BLS key file")
+	passphraseEnv := fs.String("passphrase-env", "SENTINEL_BLS_KEY_PASSPHRASE", "Environment variable holding the key file passphrase (unset = unencrypted)")
+	force := fs.Bool("force", false, "Overwrite an existing key file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	signer, err := consensus.GenerateKeyFile(*out, os.Getenv(*passphraseEnv), *force)
+	if errors.Is(err, consensus.ErrKeyFileExists) {
+		return fmt.Errorf("%s already exists; pass --force to replace it", *out)
+	}
+	if err != nil {
+		return fmt.Errorf("generating key: %w", err)
+	}
+
+	pop, err := signer.GenerateProofOfPossession()
+	if err != nil {
+		return fmt.Errorf("generating proof of possession: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Key file:            %s\n", *out)
+	fmt.Fprintf(stdout, "Public key:          0x%s\n", signer.PublicKeyHex())
+	fmt.Fprintf(stdout, "Public key (compr.): 0x%s\n", hex.EncodeToString(signer.PublicKeyCompressed()))
+	fmt.Fprintf(stdout, "Registry key hash:   %s\n", crypto.Keccak256Hash(signer.PublicKey()).Hex())
+	fmt.Fprintf(stdout, "Proof of possession: 0x%s\n", hex.EncodeToString(pop))
+	return nil
+}
+
+// runKey implements the `sentinel key` maintenance subcommands
+func runKey(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: sentinel key rotate-passphrase [flags]")
+	}
+
+	switch args[0] {
+	case "rotate-passphrase":
+		return runRotatePassphrase(args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown key command %q", args[0])
+	}
+}
+
+// runRotatePassphrase re-encrypts an existing key file under a new
+// passphrase. Both passphrases come from the environment, like keygen's.
+func runRotatePassphrase(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("rotate-passphrase", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	keyPath := fs.String("key", "./keys/bls.key", "Path to the BLS key file")
+	oldEnv := fs.String("old-passphrase-env", "SENTINEL_BLS_KEY_PASSPHRASE", "Environment variable holding the current passphrase (unset = unencrypted)")
+	newEnv := fs.String("new-passphrase-env", "SENTINEL_BLS_KEY_NEW_PASSPHRASE", "Environment variable holding the new passphrase")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	signer, err := consensus.RotateKeyPassphrase(*keyPath, os.Getenv(*oldEnv), os.Getenv(*newEnv))
+	if errors.Is(err, consensus.ErrEmptyNewPassphrase) {
+		return fmt.Errorf("set %s to the new passphrase", *newEnv)
+	}
+	if err != nil {
+		return fmt.Errorf("rotating passphrase: %w", err)
+	}
+	defer signer.Zeroize()
+
+	fmt.Fprintf(stdout, "Key file:   %s\n", *keyPath)
+	fmt.Fprintf(stdout, "Public key: 0x%s\n", signer.PublicKeyHex())
+	fmt.Fprintf(stdout, "Update %s to the new passphrase before restarting the node.\n", *oldEnv)
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+)
+
+func TestRunKeygen(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "keys", "bls.key")
+
+	var out bytes.Buffer
+	if err := runKeygen([]string{"--out", keyPath}, &out); err != nil {
+		t.Fatalf("keygen failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Proof of possession: 0x") {
+		t.Errorf("Expected a proof of possession in the output, got:\n%s", out.String())
+	}
+
+	signer, err := consensus.NewBLSSigner(keyPath)
+	if err != nil {
+		t.Fatalf("Generated key file does not load: %v", err)
+	}
+	if !strings.Contains(out.String(), signer.PublicKeyHex()) {
+		t.Error("Printed public key does not match the key file")
+	}
+	if !strings.Contains(out.String(), crypto.Keccak256Hash(signer.PublicKey()).Hex()) {
+		t.Error("Printed registry key hash does not match the key file")
+	}
+
+	original, _ := os.ReadFile(keyPath)
+
+	// An existing key must survive a plain rerun
+	if err := runKeygen([]string{"--out", keyPath}, &bytes.Buffer{}); err == nil {
+		t.Fatal("keygen should refuse to overwrite an existing key")
+	}
+	if current, _ := os.ReadFile(keyPath); !bytes.Equal(current, original) {
+		t.Fatal("Refused keygen modified the existing key file")
+	}
+
+	if err := runKeygen([]string{"--out", keyPath, "--force"}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("keygen --force failed: %v", err)
+	}
+	if current, _ := os.ReadFile(keyPath); bytes.Equal(current, original) {
+		t.Error("keygen --force should replace the key")
+	}
+}
+
+func TestRunKeygen_Encrypted(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "bls.key")
+	t.Setenv("TEST_KEYGEN_PASSPHRASE", "correct horse")
+
+	if err := runKeygen([]string{"--out", keyPath, "--passphrase-env", "TEST_KEYGEN_PASSPHRASE"}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("keygen failed: %v", err)
+	}
+
+	if _, err := consensus.NewBLSSigner(keyPath); err == nil {
+		t.Error("Encrypted key should not load without its passphrase")
+	}
+	if _, err := consensus.NewBLSSignerWithPassphrase(keyPath, "correct horse"); err != nil {
+		t.Errorf("Encrypted key should load with its passphrase: %v", err)
+	}
+}
+
+func TestRunKeyRotatePassphrase(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "bls.key")
+	original, err := consensus.GenerateKeyFile(keyPath, "old secret", false)
+	if err != nil {
+		t.Fatalf("GenerateKeyFile failed: %v", err)
+	}
+
+	t.Setenv("TEST_OLD_PASSPHRASE", "old secret")
+	t.Setenv("TEST_NEW_PASSPHRASE", "new secret")
+	args := []string{"rotate-passphrase", "--key", keyPath,
+		"--old-passphrase-env", "TEST_OLD_PASSPHRASE", "--new-passphrase-env", "TEST_NEW_PASSPHRASE"}
+
+	var out bytes.Buffer
+	if err := runKey(args, &out); err != nil {
+		t.Fatalf("rotate-passphrase failed: %v", err)
+	}
+
+	if _, err := consensus.NewBLSSignerWithPassphrase(keyPath, "old secret"); err == nil {
+		t.Error("Rotated key should no longer load with the old passphrase")
+	}
+	rotated, err := consensus.NewBLSSignerWithPassphrase(keyPath, "new secret")
+	if err != nil {
+		t.Fatalf("Rotated key should load with the new passphrase: %v", err)
+	}
+	if rotated.PublicKeyHex() != original.PublicKeyHex() {
+		t.Error("Rotation changed the key pair")
+	}
+	if !strings.Contains(out.String(), original.PublicKeyHex()) {
+		t.Errorf("Expected the public key in the output, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(keyPath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Temporary file left behind after rotation")
+	}
+
+	// A wrong current passphrase leaves the file untouched
+	before, _ := os.ReadFile(keyPath)
+	if err := runKey(args, &bytes.Buffer{}); err == nil {
+		t.Error("Rotation with the wrong current passphrase should fail")
+	}
+	if after, _ := os.ReadFile(keyPath); !bytes.Equal(before, after) {
+		t.Error("Failed rotation modified the key file")
+	}
+}
+
+func TestRunKeyRotatePassphrase_RequiresNewPassphrase(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "bls.key")
+	if _, err := consensus.GenerateKeyFile(keyPath, "", false); err != nil {
+		t.Fatalf("GenerateKeyFile failed: %v", err)
+	}
+
+	err := runKey([]string{"rotate-passphrase", "--key", keyPath, "--new-passphrase-env", "TEST_UNSET_PASSPHRASE"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "TEST_UNSET_PASSPHRASE") {
+		t.Errorf("Expected an error naming the unset variable, got %v", err)
+	}
+}
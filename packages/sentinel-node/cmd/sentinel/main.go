@@ -3,18 +3,30 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
 	"github.com/sentinel-protocol/sentinel-node/internal/config"
 	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/events"
 	"github.com/sentinel-protocol/sentinel-node/internal/inference"
 	"github.com/sentinel-protocol/sentinel-node/internal/mempool"
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/internal/sink"
 	"github.com/sentinel-protocol/sentinel-node/pkg/types"
 )
 
@@ -28,53 +40,103 @@ type SentinelNode struct {
 	mempool   *mempool.Listener
 	gossip    *consensus.GossipNode
 	bls       *consensus.BLSSigner
-	bridge    *inference.Bridge
+	bridge    analyzer
 	verifier  *nodeVerifier
+	sink      *sink.ResultSink
+	alerts    *alerts.Store
+	archive   *alerts.Archive
+	escalator *inference.ValueEscalator
+	api       *apiServer
+	metrics   *metricsServer
+	pause     analysisPause
+	recent    recentBuffer
 	logger    zerolog.Logger
-	stats     *types.NodeStats
 	startTime time.Time
-}
-
-// FIX: nodeVerifier implements consensus.SignatureVerifier for gossip message validation
-type nodeVerifier struct {
-	bls    *consensus.BLSSigner
-	logger zerolog.Logger
-	// In production, this would query the SentinelRegistry contract
-	// For now, accept all registered nodes (will be connected to registry)
-}
 
-func (v *nodeVerifier) VerifyPauseRequest(request *types.SignedPauseRequest) bool {
-	// Verify the BLS signature on the pause request
-	if request == nil || len(request.Signature) == 0 {
-		return false
+	// stats are bumped from the analysis workers and read by the API and
+	// metrics scrapes; GetStats snapshots them
+	stats struct {
+		analyzed      atomic.Uint64
+		suspicious    atomic.Uint64
+		pausesCreated atomic.Uint64
+		pausesSigned  atomic.Uint64
 	}
 
-	// Create message hash from pause request data
-	// In production, this should match the on-chain hashing scheme
-	message := append(request.Request.TargetProtocol.Bytes(), request.Request.EvidenceHash.Bytes()...)
+	// chainRegistry backs the verifier's registry view; nil in development
+	// mode
+	chainRegistry *chainRegistry
+	// submitter executes this node's pause requests on chain once they
+	// reach quorum; nil without a router address and transaction key
+	submitter *pauseSubmitter
 
-	// Get public key from signer (in production, this would be looked up from registry)
-	// For now, we verify against the embedded public key in the BLS signer
-	signerPubKey := v.bls.PublicKey()
+	// confirmations watches alerted transactions until they are mined
+	confirmations *confirmationTracker
 
-	// Use package-level VerifySignature function
-	valid, err := consensus.VerifySignature(request.Signature, message, signerPubKey)
-	if err != nil {
-		v.logger.Debug().Err(err).Msg("BLS signature verification error")
-		return false
-	}
-	return valid
+	// pauses publishes operator-initiated pause requests, co-signatures of
+	// peers' ones and alert acks; it is the gossip node outside tests
+	pauses pauseBroadcaster
+	// coSigner decides which peers' pause requests this node backs
+	coSigner *pauseCoSigner
+	// autoPauser limits the pause requests critical alerts create; nil
+	// unless node.autoPause is on
+	autoPauser *autoPauser
+
+	// peers lists the mesh for the API; it is the gossip node outside tests
+	peers peerLister
+
+	// events carries raised alerts to the consumers that store, broadcast
+	// and escalate them
+	events *events.Bus
+
+	// alertGossip publishes locally raised alerts; it is the gossip node
+	// outside tests. Broadcasts that keep failing go to notifier, if set.
+	alertGossip alertBroadcaster
+	alertRetry  alertRetryPolicy
+	notifier    Notifier
+
+	// enrichers add external context to flagged transactions before alerting
+	enrichers []Enricher
+
+	// selectorRules are the operator's selector scores for the heuristics,
+	// reloaded on SIGHUP
+	selectorRules *inference.SelectorRules
+
+	// ctx is the node's lifecycle context, created in Start and canceled
+	// in Stop so in-flight analyses are abandoned on shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func (v *nodeVerifier) IsRegisteredNode(address string) bool {
-	// TODO: In production, query SentinelRegistry.isNodeActive(address)
-	// For now, allow all nodes during development
-	// This should be connected to an Ethereum client to check on-chain
-	v.logger.Debug().Str("address", address).Msg("Node registration check (development mode: allowing all)")
-	return true
+// analyzer is the subset of the inference bridge the node depends on
+type analyzer interface {
+	Analyze(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error)
+	QuickFilter(tx *types.PendingTransaction) bool
+	Observe(tx *types.PendingTransaction)
+	Close() error
+}
+
+// contextAnalyzer is implemented by bridges that can weigh a transaction
+// against other recent mempool activity
+type contextAnalyzer interface {
+	AnalyzeWithContext(ctx context.Context, tx *types.PendingTransaction, recent []*types.PendingTransaction) (*types.InferenceResult, error)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "keygen" {
+		if err := runKeygen(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "key" {
+		if err := runKey(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.Parse()
 
 	level, err := zerolog.ParseLevel(*logLevel)
@@ -100,6 +162,14 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for range hupChan {
+			node.reloadSelectorRules()
+		}
+	}()
+
 	if err := node.Start(ctx); err != nil {
 		log.Fatal().Err(err).Msg("Failed to start sentinel node")
 	}
@@ -122,80 +192,548 @@ func main() {
 func NewSentinelNode(cfg *config.Config) (*SentinelNode, error) {
 	logger := log.With().Str("component", "sentinel-node").Logger()
 
+	var maxGasPrice *big.Int
+	if cfg.Ethereum.MaxGasPrice > 0 {
+		maxGasPrice = big.NewInt(cfg.Ethereum.MaxGasPrice)
+	}
+
+	minTxValue, err := parseWei("minimum transaction value", cfg.Ethereum.MinTxValue)
+	if err != nil {
+		return nil, err
+	}
+
 	mempoolListener, err := mempool.NewListener(mempool.ListenerConfig{
-		RPCURL:     cfg.Ethereum.RPCURL,
-		WSURL:      cfg.Ethereum.WSURL,
-		BufferSize: 10000,
-		Logger:     logger.With().Str("module", "mempool").Logger(),
+		RPCURL:             cfg.Ethereum.RPCURL,
+		WSURL:              cfg.Ethereum.WSURL,
+		RPCURLs:            cfg.Ethereum.RPCURLs,
+		WSURLs:             cfg.Ethereum.WSURLs,
+		BufferSize:         10000,
+		FetchWorkers:       cfg.Ethereum.FetchWorkers,
+		SubscriptionMethod: mempool.SubscriptionMethod(cfg.Ethereum.SubscriptionMethod),
+		SeenCacheSize:      cfg.Ethereum.SeenCacheSize,
+		SeenCacheTTL:       cfg.Ethereum.SeenCacheTTL,
+		Logger:             logger.With().Str("module", "mempool").Logger(),
+		MaxGasPrice:        maxGasPrice,
+		Filter: mempool.Filter{
+			Allow:    parseAddressList(logger, "watched", cfg.Ethereum.WatchAddresses),
+			Deny:     parseAddressList(logger, "ignored", cfg.Ethereum.IgnoreAddresses),
+			MinValue: minTxValue,
+			MinGas:   cfg.Ethereum.MinTxGas,
+		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// FIX: Create BLS signer first (needed for verifier)
-	blsSigner, err := consensus.NewBLSSigner(cfg.Node.BLSKeyPath)
+	blsSigner, err := consensus.NewBLSSignerWithPassphrase(cfg.Node.BLSKeyPath, cfg.Node.BLSKeyPassphrase)
 	if err != nil {
 		mempoolListener.Stop()
 		return nil, err
 	}
 
+	registrationPolicy, err := consensus.ParseFailurePolicy(cfg.Verifier.RegistrationFailurePolicy)
+	if err != nil {
+		mempoolListener.Stop()
+		return nil, err
+	}
+	pauseRequestPolicy, err := consensus.ParseFailurePolicy(cfg.Verifier.PauseRequestFailurePolicy)
+	if err != nil {
+		mempoolListener.Stop()
+		return nil, err
+	}
+
+	minPeerStake, err := parseStake(cfg.Verifier.MinPeerStake)
+	if err != nil {
+		mempoolListener.Stop()
+		return nil, err
+	}
+
+	// Without a registry address the verifier runs in development mode.
+	// Membership events need a subscription, so the websocket is preferred.
+	var registry nodeRegistry
+	var registrySource *chainRegistry
+	var pauseStakes func() map[common.Address]*big.Int
+	if cfg.Contracts.RegistryAddress != (common.Address{}) {
+		url := cfg.Ethereum.WSURL
+		if url == "" {
+			url = cfg.Ethereum.RPCURL
+		}
+		if !common.IsHexAddress(cfg.Node.OperatorAddress) {
+			mempoolListener.Stop()
+			return nil, fmt.Errorf("node.operatorAddress is required with contracts.registryAddress: peers verify envelopes against the operator's registered key")
+		}
+		registrySource = newChainRegistry(cfg.Contracts.RegistryAddress, url, logger.With().Str("module", "registry").Logger())
+		checkCtx, cancel := context.WithTimeout(context.Background(), registryCheckTimeout)
+		err := registrySource.CheckBLSKeys(checkCtx)
+		cancel()
+		if err != nil {
+			registrySource.Close()
+			mempoolListener.Stop()
+			return nil, err
+		}
+		cached := newCachedRegistry(registrySource, defaultRegistryRefreshInterval, logger.With().Str("module", "registry").Logger())
+		if cfg.Node.PauseStakeFraction > 0 {
+			pauseStakes = cached.Stakes
+		}
+		registry = cached
+	} else if cfg.Node.PauseStakeFraction > 0 {
+		logger.Warn().Msg("node.pauseStakeFraction needs contracts.registryAddress; pause requests complete at node.pauseQuorum signers")
+	}
+
 	// FIX: Create verifier for gossip message validation (required for security)
 	verifier := &nodeVerifier{
-		bls:    blsSigner,
-		logger: logger.With().Str("module", "verifier").Logger(),
+		bls:                blsSigner,
+		logger:             logger.With().Str("module", "verifier").Logger(),
+		registry:           registry,
+		registrationPolicy: registrationPolicy,
+		pauseRequestPolicy: pauseRequestPolicy,
+		minStake:           minPeerStake,
+		chainID:            uint64(cfg.Ethereum.ChainID),
 	}
 
 	// FIX: Pass verifier to gossip config (now required)
 	gossipNode, err := consensus.NewGossipNode(consensus.GossipConfig{
-		ListenAddresses: cfg.P2P.ListenAddresses,
-		BootstrapPeers:  cfg.P2P.BootstrapPeers,
-		TopicName:       cfg.P2P.TopicName,
-		Logger:          logger.With().Str("module", "gossip").Logger(),
-		Verifier:        verifier,
+		ListenAddresses:        cfg.P2P.ListenAddresses,
+		BootstrapPeers:         cfg.P2P.BootstrapPeers,
+		TopicName:              cfg.P2P.TopicName,
+		Topics:                 cfg.P2P.Topics,
+		EvidenceTopicName:      cfg.P2P.EvidenceTopic,
+		Logger:                 logger.With().Str("module", "gossip").Logger(),
+		Verifier:               verifier,
+		Signer:                 chainSigner{bls: blsSigner, chainID: uint64(cfg.Ethereum.ChainID)},
+		Operator:               common.HexToAddress(cfg.Node.OperatorAddress),
+		DataDir:                cfg.Node.DataDir,
+		ReputationHalfLife:     cfg.P2P.ReputationHalfLife,
+		BlockThreshold:         cfg.P2P.ReputationBlockThreshold,
+		MaxOutboundBytesPerSec: cfg.P2P.MaxOutboundBytesPerSec,
+		SeenCacheSize:          cfg.P2P.SeenCacheSize,
+		SeenCacheTTL:           cfg.P2P.SeenCacheTTL,
+		TopicPolicies:          topicPolicies(cfg.P2P.TopicPolicies),
+		MaxMessageAge:          cfg.P2P.MaxMessageAge,
+		MaxClockSkew:           cfg.P2P.MaxClockSkew,
+		MeasureClockSkew:       cfg.P2P.MeasureClockSkew,
+		PeerMessagesPerSec:     cfg.P2P.PeerMessagesPerSec,
+		PeerMessageBurst:       cfg.P2P.PeerMessageBurst,
+		WireFormat:             consensus.WireFormat(cfg.P2P.WireFormat),
+		Compression:            consensus.Compression(cfg.P2P.Compression),
+		MaxMessageBytes:        cfg.P2P.MaxMessageBytes,
+		MaxPeers:               cfg.P2P.MaxPeers,
+		BanThreshold:           cfg.P2P.BanThreshold,
+		BanDuration:            cfg.P2P.BanDuration,
+		EvictionWindow:         cfg.P2P.EvictionWindow,
+		EvictionMinViolations:  cfg.P2P.EvictionMinViolations,
+		EvictionGracePeriod:    cfg.P2P.EvictionGracePeriod,
+		EvictionCooldown:       cfg.P2P.EvictionCooldown,
+		PersistPeers:           cfg.P2P.PersistPeers,
+		MaxPersistedPeers:      cfg.P2P.MaxPersistedPeers,
+		MaxPersistedPeerAge:    cfg.P2P.MaxPersistedPeerAge,
+		EnableMDNS:             cfg.P2P.EnableMDNS,
+		EnableDHT:              cfg.P2P.EnableDHT,
+		HandlerQueueDepth:      cfg.P2P.HandlerQueueDepth,
+		MinHealthyPeers:        cfg.P2P.MinHealthyPeers,
+		PeerCountHysteresis:    cfg.P2P.PeerCountHysteresis,
+		PauseQuorum:            cfg.Node.PauseQuorum,
+		PauseRequestTimeout:    cfg.Node.PauseRequestTimeout,
+		PauseStakes:            pauseStakes,
+		PauseStakeFraction:     cfg.Node.PauseStakeFraction,
 	})
 	if err != nil {
 		mempoolListener.Stop()
 		return nil, err
 	}
 
-	inferenceBridge, err := inference.NewBridge(inference.BridgeConfig{
-		Address:          cfg.Inference.GRPCAddress,
-		Timeout:          cfg.Inference.Timeout,
-		AnomalyThreshold: cfg.Inference.AnomalyThreshold,
-		Logger:           logger.With().Str("module", "inference").Logger(),
-	})
+	// Only hand the listener over as a tracer when enabled; tracing needs the
+	// provider's debug namespace
+	var tracer inference.Tracer
+	if cfg.Inference.EnableTracing {
+		tracer = mempoolListener
+	}
+
+	selectorRules, err := inference.NewSelectorRules(
+		dataPath(cfg.Node.DataDir, cfg.Inference.SelectorRulesFile),
+		cfg.Inference.SelectorRulesPollInterval,
+		logger.With().Str("module", "inference").Logger(),
+	)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to connect to inference server, using fallback analysis")
-		inferenceBridge = nil
+		mempoolListener.Stop()
+		gossipNode.Stop()
+		return nil, err
+	}
+
+	bridgeCfg := inference.BridgeConfig{
+		Address:             cfg.Inference.GRPCAddress,
+		Timeout:             cfg.Inference.Timeout,
+		AnomalyThreshold:    cfg.Inference.AnomalyThreshold,
+		ThinLiquidityTokens: parseAddressList(logger, "thin-liquidity token", cfg.Inference.ThinLiquidityTokens),
+		DecodeCalldata:      cfg.Inference.DecodeCalldata,
+		ABIDir:              dataPath(cfg.Node.DataDir, cfg.Inference.ABIDir),
+		// Unrecognised calls score at least this, so novel exploits aren't
+		// treated like plain transfers
+		UnclassifiedCallScore: cfg.Inference.UnclassifiedCallScore,
+		AllowInsecure:         cfg.Inference.AllowInsecure,
+		Streaming:             cfg.Inference.Streaming,
+		TLS: inference.TLSConfig{
+			Enabled:    cfg.Inference.TLS.Enabled,
+			CAFile:     cfg.Inference.TLS.CAFile,
+			CertFile:   cfg.Inference.TLS.CertFile,
+			KeyFile:    cfg.Inference.TLS.KeyFile,
+			ServerName: cfg.Inference.TLS.ServerName,
+		},
+		Cluster: inference.ClusterConfig{
+			Window:     cfg.Inference.ClusterWindow,
+			MinMembers: cfg.Inference.ClusterMinMembers,
+		},
+		Approvals: inference.ApprovalConfig{
+			TrustedSpenders: parseAddressList(logger, "trusted spender", cfg.Inference.TrustedSpenders),
+			FlaggedSpenders: parseAddressList(logger, "flagged spender", cfg.Inference.FlaggedSpenders),
+			CodeReader:      mempoolListener,
+		},
+		Trace: inference.TraceConfig{
+			Tracer:             tracer,
+			DeepCallDepth:      cfg.Inference.TraceDeepCallDepth,
+			SensitiveContracts: parseAddressList(logger, "sensitive contract", cfg.Inference.SensitiveContracts),
+		},
+		SafeSelectors: inference.SafeSelectorConfig{
+			Enabled:   cfg.Inference.EnableSafeSelectors,
+			Selectors: cfg.Inference.SafeSelectors,
+		},
+		SelectorRules: selectorRules,
+		Weights: inference.HeuristicWeights{
+			FlashLoan:        cfg.Inference.HeuristicWeights.FlashLoan,
+			HighSlippageSwap: cfg.Inference.HeuristicWeights.HighSlippageSwap,
+			HighGasLimit:     cfg.Inference.HeuristicWeights.HighGasLimit,
+			LargeValue:       cfg.Inference.HeuristicWeights.LargeValue,
+			ContractCreation: cfg.Inference.HeuristicWeights.ContractCreation,
+			LargeCalldata:    cfg.Inference.HeuristicWeights.LargeCalldata,
+			Reentrancy:       cfg.Inference.HeuristicWeights.Reentrancy,
+			MaxScore:         cfg.Inference.HeuristicWeights.MaxScore,
+			Thresholds: inference.RiskThresholds{
+				Critical: cfg.Inference.HeuristicWeights.CriticalThreshold,
+				High:     cfg.Inference.HeuristicWeights.HighThreshold,
+				Medium:   cfg.Inference.HeuristicWeights.MediumThreshold,
+			},
+		},
+		Sandwich: inference.SandwichConfig{
+			Window: cfg.Inference.SandwichWindow,
+		},
+		Reentrancy: inference.ReentrancyConfig{
+			Repeats:         cfg.Inference.ReentrancyRepeats,
+			WithdrawRepeats: cfg.Inference.ReentrancyWithdrawRepeats,
+		},
+		SenderHistory: inference.SenderHistoryConfig{
+			Enabled:    cfg.Inference.EnableSenderHistory,
+			Alpha:      cfg.Inference.SenderHistoryAlpha,
+			HalfLife:   cfg.Inference.SenderHistoryHalfLife,
+			MaxSenders: cfg.Inference.SenderHistoryMaxSenders,
+		},
+		Cache: inference.ResultCacheConfig{
+			Size:        cfg.Inference.ResultCacheSize,
+			TTL:         cfg.Inference.ResultCacheTTL,
+			FallbackTTL: cfg.Inference.ResultCacheFallbackTTL,
+		},
+		Logger: logger.With().Str("module", "inference").Logger(),
 	}
 
-	return &SentinelNode{
-		config:    cfg,
-		mempool:   mempoolListener,
-		gossip:    gossipNode,
-		bls:       blsSigner,
-		bridge:    inferenceBridge,
-		verifier:  verifier,
+	// Assign only on success so a failed bridge leaves the interface nil
+	var bridge analyzer
+	if len(cfg.Inference.GRPCAddresses) > 0 {
+		multiBridge, err := inference.NewMultiBridge(inference.MultiBridgeConfig{
+			Bridge:    bridgeCfg,
+			Addresses: cfg.Inference.GRPCAddresses,
+			Quorum:    cfg.Inference.Quorum,
+			// Spread load across the servers rather than asking each
+			LoadBalance: cfg.Inference.LoadBalance,
+		})
+		if err != nil {
+			mempoolListener.Stop()
+			gossipNode.Stop()
+			return nil, err
+		}
+		bridge = multiBridge
+	} else if inferenceBridge, err := inference.NewBridge(bridgeCfg); err != nil {
+		// Only a misconfigured connection fails here; an unreachable server
+		// is retried in the background
+		mempoolListener.Stop()
+		gossipNode.Stop()
+		return nil, err
+	} else {
+		bridge = inferenceBridge
+	}
+
+	var resultSink *sink.ResultSink
+	if cfg.ResultSink.Enabled {
+		resultSink, err = sink.New(sink.Config{
+			Type:       cfg.ResultSink.Type,
+			Path:       cfg.ResultSink.Path,
+			SampleRate: cfg.ResultSink.SampleRate,
+			BufferSize: cfg.ResultSink.BufferSize,
+			Logger:     logger.With().Str("module", "sink").Logger(),
+		})
+		if err != nil {
+			mempoolListener.Stop()
+			gossipNode.Stop()
+			if bridge != nil {
+				bridge.Close()
+			}
+			return nil, err
+		}
+	}
+
+	var archive *alerts.Archive
+	if cfg.Alerts.ArchiveFile != "" {
+		archive, err = alerts.OpenArchive(alerts.ArchiveConfig{
+			Path:     dataPath(cfg.Node.DataDir, cfg.Alerts.ArchiveFile),
+			MaxAge:   cfg.Alerts.ArchiveMaxAge,
+			MaxBytes: cfg.Alerts.ArchiveMaxBytes,
+		})
+		if err != nil {
+			mempoolListener.Stop()
+			gossipNode.Stop()
+			if bridge != nil {
+				bridge.Close()
+			}
+			if resultSink != nil {
+				resultSink.Close()
+			}
+			return nil, fmt.Errorf("failed to open alert archive: %w", err)
+		}
+	}
+
+	submitter, err := newPauseSubmitter(cfg, logger.With().Str("module", "onchain").Logger())
+	if err != nil {
+		mempoolListener.Stop()
+		gossipNode.Stop()
+		if bridge != nil {
+			bridge.Close()
+		}
+		if resultSink != nil {
+			resultSink.Close()
+		}
+		if archive != nil {
+			archive.Close()
+		}
+		return nil, err
+	}
+
+	node := &SentinelNode{
+		config:   cfg,
+		mempool:  mempoolListener,
+		gossip:   gossipNode,
+		bls:      blsSigner,
+		bridge:   bridge,
+		verifier: verifier,
+		sink:     resultSink,
+		archive:  archive,
+		alerts: alerts.NewStore(alerts.Config{
+			TTLs: map[types.AlertLevel]time.Duration{
+				types.AlertLevelLow:      cfg.Alerts.LowTTL,
+				types.AlertLevelMedium:   cfg.Alerts.MediumTTL,
+				types.AlertLevelHigh:     cfg.Alerts.HighTTL,
+				types.AlertLevelCritical: cfg.Alerts.CriticalTTL,
+			},
+		}),
+		escalator: newValueEscalator(logger, cfg.Alerts),
 		logger:    logger,
-		stats:     &types.NodeStats{},
 		startTime: time.Now(),
-	}, nil
+		pause: analysisPause{
+			mode:        cfg.Node.PauseMode,
+			backlogSize: cfg.Node.PauseBacklogSize,
+		},
+		recent: recentBuffer{size: cfg.Node.RecentBufferSize},
+	}
+
+	node.selectorRules = selectorRules
+	node.chainRegistry = registrySource
+	node.submitter = submitter
+	node.pauses = gossipNode
+	node.coSigner = newPauseCoSigner(cfg.Node.CoSignMinScore, cfg.Node.CoSignWindow, cfg.Node.PauseRequestTimeout)
+	node.peers = gossipNode
+	node.events = events.NewBus(events.Config{Logger: logger.With().Str("module", "events").Logger()})
+	node.alertGossip = gossipNode
+	node.alertRetry = newAlertRetryPolicy(cfg.Alerts)
+	if cfg.Alerts.NotifyWebhook != "" {
+		node.notifier = newWebhookNotifier(cfg.Alerts.NotifyWebhook)
+	}
+	if cfg.Node.AutoPause {
+		if common.IsHexAddress(cfg.Node.OperatorAddress) {
+			node.autoPauser = newAutoPauser(cfg.Node.AutoPauseCooldown)
+		} else {
+			logger.Warn().Msg("node.autoPause is on but node.operatorAddress is unset; critical alerts won't create pause requests")
+		}
+	}
+	node.subscribeAlertConsumers()
+	node.confirmations = newConfirmationTracker(mempoolListener, node.alerts, logger.With().Str("module", "confirmations").Logger())
+
+	if cfg.Node.APIPort > 0 {
+		node.api = newAPIServer(node, cfg.Node.APIPort, cfg.Node.AdminToken)
+	}
+
+	if cfg.Node.MetricsPort > 0 {
+		collectors := []prometheus.Collector{
+			newNodeCollector(node, mempoolListener),
+			gossipNode.Metrics(),
+			node.events.Metrics(),
+		}
+		if instrumented, ok := bridge.(interface{ Metrics() prometheus.Collector }); ok {
+			collectors = append(collectors, instrumented.Metrics())
+		}
+		node.metrics = newMetricsServer(cfg.Node.MetricsPort, logger.With().Str("module", "metrics").Logger(), collectors...)
+	}
+
+	return node, nil
+}
+
+// dataPath resolves a file name from the config, taking relative names to
+// be under dataDir
+func dataPath(dataDir, name string) string {
+	if name == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(dataDir, name)
+}
+
+// parseStake parses a wei amount; an empty value disables the stake check
+func parseStake(value string) (*big.Int, error) {
+	return parseWei("minimum peer stake", value)
+}
+
+// parseWei parses a decimal wei amount, returning nil for an empty value
+func parseWei(kind, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %q", kind, value)
+	}
+	return amount, nil
+}
+
+// parseAddressList converts configured hex addresses, skipping invalid entries
+func parseAddressList(logger zerolog.Logger, kind string, values []string) []common.Address {
+	addrs := make([]common.Address, 0, len(values))
+	for _, value := range values {
+		if !common.IsHexAddress(value) {
+			logger.Warn().Str("address", value).Msgf("Ignoring invalid %s address", kind)
+			continue
+		}
+		addrs = append(addrs, common.HexToAddress(value))
+	}
+	return addrs
+}
+
+// topicPolicies converts configured message type names; unknown names are
+// rejected when the gossip node validates the policy
+func topicPolicies(cfg map[string]config.TopicPolicyConfig) map[string]consensus.TopicPolicy {
+	messageTypes := func(names []string) []consensus.MessageType {
+		msgTypes := make([]consensus.MessageType, len(names))
+		for i, name := range names {
+			msgTypes[i] = consensus.MessageType(name)
+		}
+		return msgTypes
+	}
+
+	policies := make(map[string]consensus.TopicPolicy, len(cfg))
+	for topic, p := range cfg {
+		policies[topic] = consensus.TopicPolicy{
+			Inbound:  consensus.MessageTypeFilter{Allow: messageTypes(p.InboundAllow), Deny: messageTypes(p.InboundDeny)},
+			Outbound: consensus.MessageTypeFilter{Allow: messageTypes(p.OutboundAllow), Deny: messageTypes(p.OutboundDeny)},
+		}
+	}
+	return policies
+}
+
+// newValueEscalator builds alert escalation from config, skipping tokens
+// whose price key is not an address
+func newValueEscalator(logger zerolog.Logger, cfg config.AlertsConfig) *inference.ValueEscalator {
+	tiers := make([]inference.EscalationTier, 0, len(cfg.Escalation))
+	for _, tier := range cfg.Escalation {
+		tiers = append(tiers, inference.EscalationTier{MinUSD: tier.MinUSD, Levels: tier.Levels})
+	}
+
+	prices := make(inference.StaticPrices, len(cfg.TokenPrices))
+	for key, price := range cfg.TokenPrices {
+		token := inference.NativeToken
+		if !strings.EqualFold(key, "eth") {
+			if !common.IsHexAddress(key) {
+				logger.Warn().Str("token", key).Msg("Ignoring price for invalid token address")
+				continue
+			}
+			token = common.HexToAddress(key)
+		}
+		prices[token] = inference.TokenPrice{USD: price.USD, Decimals: price.Decimals}
+	}
+
+	return inference.NewValueEscalator(tiers, prices)
 }
 
 func (n *SentinelNode) Start(ctx context.Context) error {
+	n.ctx, n.cancel = context.WithCancel(ctx)
+
 	n.mempool.AddHandler(n.handleTransaction)
 
-	if err := n.mempool.Start(ctx); err != nil {
+	if err := n.mempool.Start(n.ctx); err != nil {
+		n.cancel()
 		return err
 	}
 
-	if err := n.gossip.Start(ctx); err != nil {
+	if err := n.gossip.Start(n.ctx); err != nil {
 		n.mempool.Stop()
+		n.cancel()
 		return err
 	}
 
+	check := selfCheck{rpc: n.mempool, ws: n.mempool, inference: n.bridge, gossip: n.gossip}
+	logDependencySummary(n.logger, check.run(n.ctx))
+
+	// Keep the cached registry view reconciled with the chain
+	if registry, ok := n.verifier.registry.(*cachedRegistry); ok {
+		go registry.Run(n.ctx)
+	}
+
+	if n.confirmations != nil {
+		go n.confirmations.Run(n.ctx)
+	}
+
+	if n.selectorRules != nil {
+		go n.selectorRules.Watch(n.ctx)
+	}
+
+	if n.archive != nil {
+		go n.pruneArchive(n.ctx)
+	}
+
 	n.gossip.OnPauseRequest(n.handlePauseRequest)
+	if n.submitter != nil {
+		// Quorum is reached on the gossip handler path; submitting waits on RPCs
+		n.gossip.OnQuorumReached(func(requestID string, agg *types.AggregatedPauseRequest) {
+			go n.submitter.submit(n.rootContext(), requestID, agg)
+		})
+	}
 	n.gossip.OnAlert(n.handleAlert)
+	n.gossip.OnAlertAck(n.handleAlertAck)
+
+	if n.api != nil {
+		if err := n.api.Start(); err != nil {
+			n.gossip.Stop()
+			n.mempool.Stop()
+			n.cancel()
+			return err
+		}
+	}
+
+	if n.metrics != nil {
+		if err := n.metrics.Start(); err != nil {
+			if n.api != nil {
+				n.api.Stop(context.Background())
+			}
+			n.gossip.Stop()
+			n.mempool.Stop()
+			n.cancel()
+			return err
+		}
+	}
 
 	n.logger.Info().
 		Str("peerID", n.gossip.PeerID()).
@@ -205,39 +743,125 @@ func (n *SentinelNode) Start(ctx context.Context) error {
 	return nil
 }
 
+// reloadSelectorRules re-reads the selector rules file, keeping the current
+// rules if it is invalid
+func (n *SentinelNode) reloadSelectorRules() {
+	if n.selectorRules == nil {
+		return
+	}
+	if err := n.selectorRules.Reload(); err != nil {
+		n.logger.Warn().Err(err).Msg("Keeping previous selector rules")
+	}
+}
+
+// pruneArchive drops alerts past the archive's retention every hour
+func (n *SentinelNode) pruneArchive(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := n.archive.Prune()
+			if err != nil {
+				n.logger.Warn().Err(err).Msg("Failed to prune alert archive")
+			} else if pruned > 0 {
+				n.logger.Debug().Int("pruned", pruned).Msg("Pruned alert archive")
+			}
+		}
+	}
+}
+
 func (n *SentinelNode) Stop(ctx context.Context) error {
+	// Abort in-flight analyses before tearing down their dependencies
+	if n.cancel != nil {
+		n.cancel()
+	}
+
+	if n.api != nil {
+		if err := n.api.Stop(ctx); err != nil {
+			n.logger.Warn().Err(err).Msg("Failed to shut down API server")
+		}
+	}
+
+	if n.metrics != nil {
+		if err := n.metrics.Stop(ctx); err != nil {
+			n.logger.Warn().Err(err).Msg("Failed to shut down metrics server")
+		}
+	}
+
 	n.mempool.Stop()
+	// Let queued alerts go out before gossip stops
+	n.events.Close()
 	n.gossip.Stop()
 
+	if n.chainRegistry != nil {
+		n.chainRegistry.Close()
+	}
+	if n.submitter != nil {
+		n.submitter.Close()
+	}
+
 	if n.bridge != nil {
 		n.bridge.Close()
 	}
 
-	n.stats.Uptime = time.Since(n.startTime)
+	// Nothing signs once gossip has stopped
+	if n.bls != nil {
+		n.bls.Zeroize()
+	}
+
+	if n.sink != nil {
+		if err := n.sink.Close(); err != nil {
+			n.logger.Warn().Err(err).Msg("Failed to close result sink")
+		}
+	}
+
+	// After the event bus, so alerts raised during shutdown are archived
+	if n.archive != nil {
+		if err := n.archive.Close(); err != nil {
+			n.logger.Warn().Err(err).Msg("Failed to close alert archive")
+		}
+	}
 
 	n.logger.Info().
-		Uint64("analyzed", n.stats.TransactionsAnalyzed).
-		Uint64("suspicious", n.stats.SuspiciousDetected).
-		Dur("uptime", n.stats.Uptime).
+		Uint64("analyzed", n.stats.analyzed.Load()).
+		Uint64("suspicious", n.stats.suspicious.Load()).
+		Dur("uptime", time.Since(n.startTime)).
 		Msg("Final statistics")
 
 	return nil
 }
 
 func (n *SentinelNode) handleTransaction(tx *types.PendingTransaction) {
-	n.stats.TransactionsAnalyzed++
-
-	if n.bridge != nil && !n.bridge.QuickFilter(tx) {
+	if n.holdIfPaused(tx) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), n.config.Inference.Timeout)
+	n.stats.analyzed.Add(1)
+
+	// Not filtered out as obviously safe: a plain transfer bidding far
+	// above market is still worth an alert
+	excessiveGas := n.exceedsMaxGasPrice(tx)
+
+	if n.bridge != nil {
+		n.bridge.Observe(tx)
+		if !excessiveGas && !n.bridge.QuickFilter(tx) {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(n.rootContext(), n.config.Inference.Timeout)
 	defer cancel()
 
 	var result *types.InferenceResult
 	var err error
 
-	if n.bridge != nil {
+	if contextual, ok := n.bridge.(contextAnalyzer); ok {
+		result, err = contextual.AnalyzeWithContext(ctx, tx, n.recent.since(n.config.Inference.SandwichWindow))
+	} else if n.bridge != nil {
 		result, err = n.bridge.Analyze(ctx, tx)
 	} else {
 		result = n.localAnalysis(tx)
@@ -248,12 +872,42 @@ func (n *SentinelNode) handleTransaction(tx *types.PendingTransaction) {
 		return
 	}
 
+	// The bridge falls back to heuristics when canceled; don't act on
+	// results produced while the node is shutting down
+	if n.rootContext().Err() != nil {
+		n.logger.Debug().Str("tx", tx.Hash.Hex()).Msg("Analysis abandoned on shutdown")
+		return
+	}
+
+	if excessiveGas {
+		n.flagExcessiveGasPrice(result)
+	}
+
+	if result.IsSuspicious && len(n.enrichers) > 0 {
+		result = n.enrich(tx, result)
+	}
+
+	n.recent.add(tx, result)
+
+	if n.sink != nil {
+		n.sink.Record(tx, result)
+	}
+
 	if result.IsSuspicious {
-		n.stats.SuspiciousDetected++
+		n.stats.suspicious.Add(1)
 		n.handleSuspiciousTransaction(tx, result)
 	}
 }
 
+// rootContext returns the node's lifecycle context, or a background context
+// if the node was never started
+func (n *SentinelNode) rootContext() context.Context {
+	if n.ctx == nil {
+		return context.Background()
+	}
+	return n.ctx
+}
+
 func (n *SentinelNode) localAnalysis(tx *types.PendingTransaction) *types.InferenceResult {
 	if tx.IsSimpleTransfer() {
 		return &types.InferenceResult{
@@ -265,13 +919,43 @@ func (n *SentinelNode) localAnalysis(tx *types.PendingTransaction) *types.Infere
 		}
 	}
 
-	return &types.InferenceResult{
+	result := &types.InferenceResult{
 		TxHash:         tx.Hash,
 		IsSuspicious:   false,
 		AnomalyScore:   0.1,
 		RiskLevel:      "low",
 		Recommendation: "allow",
 	}
+
+	// Calls the node can't classify are scored at least at the baseline
+	baseline := n.config.Inference.UnclassifiedCallScore
+	if baseline > 0 && tx.IsContractInteraction() && !inference.IsKnownSelector(tx.Input) {
+		result.RiskIndicators = append(result.RiskIndicators, "unclassified_contract_call")
+		result.AnomalyScore = math.Max(result.AnomalyScore, baseline)
+		result.IsSuspicious = result.AnomalyScore >= n.config.Inference.AnomalyThreshold
+		result.RiskLevel, result.Recommendation = inference.ClassifyScore(result.AnomalyScore)
+	}
+
+	return result
+}
+
+// exceedsMaxGasPrice reports whether tx bids more than ethereum.maxGasPrice,
+// a sign of desperate front-running or a misconfigured sender
+func (n *SentinelNode) exceedsMaxGasPrice(tx *types.PendingTransaction) bool {
+	limit := n.config.Ethereum.MaxGasPrice
+	price := tx.EffectiveGasPrice()
+	return limit > 0 && price != nil && price.Cmp(big.NewInt(limit)) > 0
+}
+
+// flagExcessiveGasPrice marks a result suspicious for an excessive gas bid,
+// raising its score to the anomaly threshold if it was below
+func (n *SentinelNode) flagExcessiveGasPrice(result *types.InferenceResult) {
+	result.RiskIndicators = append(result.RiskIndicators, "excessive_gas_price")
+	result.IsSuspicious = true
+	if threshold := n.config.Inference.AnomalyThreshold; result.AnomalyScore < threshold {
+		result.AnomalyScore = threshold
+		result.RiskLevel, result.Recommendation = inference.ClassifyScore(threshold)
+	}
 }
 
 func (n *SentinelNode) handleSuspiciousTransaction(tx *types.PendingTransaction, result *types.InferenceResult) {
@@ -283,27 +967,68 @@ func (n *SentinelNode) handleSuspiciousTransaction(tx *types.PendingTransaction,
 		Msg("Suspicious transaction detected")
 
 	alert := &types.Alert{
-		ID:        tx.Hash.Hex(),
-		Level:     types.AlertLevel(result.RiskLevel),
+		ID:        types.ComputeAlertID(tx, result),
+		Level:     n.escalator.Escalate(tx, types.AlertLevel(result.RiskLevel)),
 		TxHash:    tx.Hash,
 		Message:   "Suspicious transaction detected",
 		Timestamp: time.Now(),
 		Result:    result,
 	}
+	if target, ok := inference.TargetProtocol(tx); ok {
+		alert.TargetProtocol = target
+	}
+	if !tx.ReceivedAt.IsZero() {
+		alert.Timing = &types.DetectionTiming{ReceivedAt: tx.ReceivedAt, DetectedAt: alert.Timestamp}
+	}
 
-	if err := n.gossip.BroadcastAlert(alert); err != nil {
-		n.logger.Error().Err(err).Msg("Failed to broadcast alert")
+	// A peer already acted on this alert; record it but don't escalate again
+	handled := n.alerts.IsHandled(alert.ID)
+	if handled {
+		n.logger.Info().Str("id", alert.ID).Msg("Alert already handled by a peer, suppressing escalation")
 	}
+	n.events.Publish(events.AlertCreated{Alert: alert, HandledByPeer: handled})
 }
 
+// handlePauseRequest co-signs a peer's pause request when this node flagged
+// the same protocol itself. Gossip has already checked the originator's
+// signature; the share goes back under the request's ID and the operator's
+// address for the originator to aggregate.
 func (n *SentinelNode) handlePauseRequest(request *types.SignedPauseRequest) {
-	n.logger.Info().
+	logger := n.logger.With().
 		Str("protocol", request.Request.TargetProtocol.Hex()).
 		Str("signer", request.Signer.Hex()).
-		Msg("Received pause request")
+		Str("requestId", request.Request.ID()).
+		Logger()
+	logger.Info().Msg("Received pause request")
 
-	// TODO: Validate and co-sign if appropriate
-	n.stats.PauseRequestsSigned++
+	// Shares are attributed to the operator, so without one there is
+	// nothing to sign as
+	if n.coSigner == nil || n.verifier == nil || !common.IsHexAddress(n.config.Node.OperatorAddress) {
+		return
+	}
+	operator := common.HexToAddress(n.config.Node.OperatorAddress)
+	if operator == request.Signer {
+		return
+	}
+
+	agree, reason := n.coSigner.decide(request.Request)
+	if !agree {
+		logger.Info().Str("reason", reason).Msg("Declined to co-sign pause request")
+		return
+	}
+
+	signature, err := n.bls.SignForRouter(onchain.PauseMessage(request.Request, n.verifier.chainID))
+	if err == nil {
+		err = n.pauses.BroadcastSignature(request.Request.ID(), operator, signature)
+	}
+	if err != nil {
+		n.coSigner.release(request.Request)
+		logger.Error().Err(err).Msg("Failed to co-sign pause request")
+		return
+	}
+	n.stats.pausesSigned.Add(1)
+
+	logger.Warn().Msg("Co-signed pause request")
 }
 
 func (n *SentinelNode) handleAlert(alert *types.Alert) {
@@ -312,17 +1037,58 @@ func (n *SentinelNode) handleAlert(alert *types.Alert) {
 		Str("level", string(alert.Level)).
 		Str("message", alert.Message).
 		Msg("Received alert from peer")
+
+	n.alerts.Add(alert)
+	if n.archive != nil {
+		if err := n.archive.Append(alert, alerts.SourcePeer); err != nil {
+			n.logger.Warn().Err(err).Str("id", alert.ID).Msg("Failed to archive alert")
+		}
+	}
+}
+
+func (n *SentinelNode) handleAlertAck(ack *types.AlertAck) {
+	if n.alerts.MarkHandled(ack.AlertID, ack.HandledBy) {
+		n.logger.Info().
+			Str("id", ack.AlertID).
+			Str("action", ack.Action).
+			Str("handledBy", ack.HandledBy).
+			Msg("Alert handled by peer")
+	}
+}
+
+// AcknowledgeAlert marks an alert handled by this node and tells peers, so
+// they suppress their own escalation of it. It returns false if the alert
+// was already handled; a failed broadcast leaves the local mark in place.
+func (n *SentinelNode) AcknowledgeAlert(alertID, action string) (bool, error) {
+	if !n.alerts.MarkHandled(alertID, "") {
+		return false, nil
+	}
+	return true, n.pauses.BroadcastAlertAck(alertID, action)
 }
 
 func (n *SentinelNode) GetStats() *types.NodeStats {
-	stats := *n.stats
-	stats.Uptime = time.Since(n.startTime)
+	stats := types.NodeStats{
+		TransactionsAnalyzed: n.stats.analyzed.Load(),
+		SuspiciousDetected:   n.stats.suspicious.Load(),
+		PauseRequestsCreated: n.stats.pausesCreated.Load(),
+		PauseRequestsSigned:  n.stats.pausesSigned.Load(),
+		Uptime:               time.Since(n.startTime),
+	}
+
+	if n.mempool != nil {
+		if _, processed, _ := n.mempool.GetStats(); processed > 0 {
+			stats.AverageLatencyMs = float64(n.config.Inference.Timeout.Milliseconds()) / 2
+		}
+	}
 
-	received, processed, _ := n.mempool.GetStats()
-	if processed > 0 {
-		stats.AverageLatencyMs = float64(n.config.Inference.Timeout.Milliseconds()) / 2
+	if n.gossip != nil {
+		bandwidth := n.gossip.BandwidthStats()
+		stats.GossipBytesSent = bandwidth.BytesSent
+		stats.GossipMessagesShed = bandwidth.MessagesShed
+		stats.GossipConnections = n.gossip.ConnectionCount()
 	}
 
-	_ = received
+	n.confirmations.applyStats(&stats)
+
 	return &stats
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+	"github.com/sentinel-protocol/sentinel-node/internal/config"
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/events"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// newTestNode builds a SentinelNode with no network dependencies; analysis
+// falls back to localAnalysis since no bridge is configured
+func newTestNode(t *testing.T) *SentinelNode {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Inference.Timeout = 300 * time.Millisecond
+
+	node := &SentinelNode{
+		config:    cfg,
+		alerts:    alerts.NewStore(alerts.Config{}),
+		events:    events.NewBus(events.Config{Logger: zerolog.Nop()}),
+		logger:    zerolog.Nop(),
+		startTime: time.Now(),
+	}
+	node.subscribeAlertConsumers()
+	t.Cleanup(node.events.Close)
+	return node
+}
+
+func testTransaction(seed int64) *types.PendingTransaction {
+	to := common.HexToAddress("0x2")
+	return &types.PendingTransaction{
+		Hash:       common.BigToHash(big.NewInt(seed)),
+		From:       common.HexToAddress("0x1"),
+		To:         &to,
+		Value:      big.NewInt(0),
+		Gas:        500000,
+		Input:      []byte{0x5c, 0xff, 0xe9, 0xde},
+		ReceivedAt: time.Now(),
+	}
+}
+
+// slowBridge blocks every analysis until its context is canceled, then
+// returns a suspicious result the way the real bridge's fallback would
+type slowBridge struct {
+	started chan struct{}
+}
+
+func (b *slowBridge) Analyze(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
+	close(b.started)
+	<-ctx.Done()
+	return &types.InferenceResult{TxHash: tx.Hash, IsSuspicious: true, RiskLevel: "high"}, nil
+}
+
+func (b *slowBridge) QuickFilter(tx *types.PendingTransaction) bool { return true }
+func (b *slowBridge) Observe(tx *types.PendingTransaction)          {}
+func (b *slowBridge) Close() error                                  { return nil }
+
+func TestSentinelNode_CancelAbortsInFlightAnalysis(t *testing.T) {
+	node := newTestNode(t)
+	node.config.Inference.Timeout = time.Minute
+	bridge := &slowBridge{started: make(chan struct{})}
+	node.bridge = bridge
+	node.ctx, node.cancel = context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		node.handleTransaction(testTransaction(1))
+		close(done)
+	}()
+
+	<-bridge.started
+	node.cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Analysis did not abort after the node context was canceled")
+	}
+
+	if node.stats.suspicious.Load() != 0 {
+		t.Error("Results produced during shutdown should be discarded")
+	}
+	if active := node.alerts.Active(); len(active) != 0 {
+		t.Errorf("Expected no alerts raised during shutdown, got %d", len(active))
+	}
+}
+
+func TestSentinelNode_PeerAckSuppressesEscalation(t *testing.T) {
+	tx := testTransaction(1)
+	result := &types.InferenceResult{IsSuspicious: true, AnomalyScore: 0.9, RiskLevel: "critical"}
+	alertID := types.ComputeAlertID(tx, result)
+
+	// Node A detects the transaction first and acts on it
+	nodeA := newTestNode(t)
+	nodeA.pauses = &consensus.GossipNode{}
+	nodeA.alertGossip = &consensus.GossipNode{}
+	nodeA.handleSuspiciousTransaction(tx, result)
+	if handled, _ := nodeA.AcknowledgeAlert(alertID, "pause"); !handled {
+		t.Fatal("Node A should mark its own alert handled")
+	}
+
+	// Node B receives A's ack before detecting the same transaction. Its
+	// gossip is nil, so escalating by broadcasting the alert would panic.
+	nodeB := newTestNode(t)
+	nodeB.handleAlertAck(&types.AlertAck{AlertID: alertID, Action: "pause", HandledBy: "node-a"})
+	nodeB.handleSuspiciousTransaction(tx, result)
+
+	record, ok := nodeB.alerts.Get(alertID)
+	if !ok {
+		t.Fatal("Node B should still record the alert")
+	}
+	if record.Status != alerts.StatusHandled || record.HandledBy != "node-a" {
+		t.Errorf("Expected alert handled by node-a, got %s by %q", record.Status, record.HandledBy)
+	}
+}
+
+func TestSentinelNode_FlagsExcessiveGasPrice(t *testing.T) {
+	node := newTestNode(t)
+	node.alertGossip = &consensus.GossipNode{}
+	node.config.Ethereum.MaxGasPrice = 100_000_000_000 // 100 gwei
+	node.config.Inference.AnomalyThreshold = 0.7
+
+	// A plain transfer, which would otherwise be scored low
+	tx := testTransaction(1)
+	tx.Input = nil
+	tx.GasPrice = big.NewInt(500_000_000_000)
+	node.handleTransaction(tx)
+
+	if node.stats.suspicious.Load() != 1 {
+		t.Fatalf("Expected the transaction flagged, got %d suspicious", node.stats.suspicious.Load())
+	}
+	active := node.alerts.Active()
+	if len(active) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(active))
+	}
+	result := active[0].Alert.Result
+	if len(result.RiskIndicators) != 1 || result.RiskIndicators[0] != "excessive_gas_price" {
+		t.Errorf("Expected the excessive_gas_price indicator, got %v", result.RiskIndicators)
+	}
+	if result.AnomalyScore < node.config.Inference.AnomalyThreshold {
+		t.Errorf("Expected score raised to the threshold, got %v", result.AnomalyScore)
+	}
+}
+
+func TestSentinelNode_AllowsGasPriceBelowMax(t *testing.T) {
+	node := newTestNode(t)
+	node.config.Ethereum.MaxGasPrice = 100_000_000_000
+	node.config.Inference.AnomalyThreshold = 0.7
+
+	tx := testTransaction(1)
+	tx.Input = nil
+	tx.GasPrice = big.NewInt(30_000_000_000)
+	node.handleTransaction(tx)
+
+	if node.stats.suspicious.Load() != 0 {
+		t.Errorf("Expected no flag below maxGasPrice, got %d suspicious", node.stats.suspicious.Load())
+	}
+
+	// The fee cap is what's compared for dynamic-fee transactions
+	tx.GasPrice = nil
+	tx.MaxFeePerGas = big.NewInt(200_000_000_000)
+	if !node.exceedsMaxGasPrice(tx) {
+		t.Error("Expected a fee cap above maxGasPrice to exceed it")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/mempool"
+)
+
+// metricsServer exposes Prometheus metrics on node.metricsPort
+type metricsServer struct {
+	server *http.Server
+	logger zerolog.Logger
+}
+
+// newMetricsServer serves the given collectors at /metrics
+func newMetricsServer(port int, logger zerolog.Logger, collectors ...prometheus.Collector) *metricsServer {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors...)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &metricsServer{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Start begins serving in the background
+func (m *metricsServer) Start() error {
+	listener, err := net.Listen("tcp", m.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := m.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.logger.Error().Err(err).Msg("Metrics server stopped unexpectedly")
+		}
+	}()
+
+	m.logger.Info().Str("addr", listener.Addr().String()).Msg("Metrics server started")
+	return nil
+}
+
+// Stop shuts the server down, honoring the shutdown context
+func (m *metricsServer) Stop(ctx context.Context) error {
+	return m.server.Shutdown(ctx)
+}
+
+// mempoolStats reports the listener's transaction counts; the mempool
+// listener implements it
+type mempoolStats interface {
+	Stats() mempool.ListenerStats
+}
+
+var (
+	txsAnalyzedDesc = prometheus.NewDesc(
+		"sentinel_txs_analyzed_total", "Total transactions analyzed", nil, nil)
+	txsSuspiciousDesc = prometheus.NewDesc(
+		"sentinel_txs_suspicious_total", "Suspicious transactions detected", nil, nil)
+	pauseRequestsDesc = prometheus.NewDesc(
+		"sentinel_pause_requests_total", "Pause requests created by this node or signed for peers", []string{"action"}, nil)
+	peersConnectedDesc = prometheus.NewDesc(
+		"sentinel_peers_connected", "Connected P2P peers", nil, nil)
+	uptimeDesc = prometheus.NewDesc(
+		"sentinel_uptime_seconds", "Time since the node started", nil, nil)
+	mempoolReceivedDesc = prometheus.NewDesc(
+		"sentinel_mempool_txs_received_total", "Pending transaction hashes received from the subscription", nil, nil)
+	mempoolProcessedDesc = prometheus.NewDesc(
+		"sentinel_mempool_txs_processed_total", "Pending transactions handed to analysis", nil, nil)
+	mempoolDroppedDesc = prometheus.NewDesc(
+		"sentinel_mempool_txs_dropped_total", "Pending transactions dropped because analysis fell behind", nil, nil)
+	mempoolHashesDroppedDesc = prometheus.NewDesc(
+		"sentinel_mempool_hashes_dropped_total", "Pending transaction hashes dropped unfetched because the fetch workers fell behind", nil, nil)
+	mempoolReconnectsDesc = prometheus.NewDesc(
+		"sentinel_mempool_reconnects_total", "Times the pending transaction subscription was redialed after failing", nil, nil)
+	mempoolFilteredDesc = prometheus.NewDesc(
+		"sentinel_mempool_txs_filtered_total", "Pending transactions dropped by the address, value and gas filter", nil, nil)
+	mempoolDuplicatesDesc = prometheus.NewDesc(
+		"sentinel_mempool_hashes_duplicate_total", "Pending transaction hashes dropped because they were already received", nil, nil)
+)
+
+// nodeCollector exports the node's running statistics. They are read at
+// scrape time from the same counters GetStats reports, so the two agree.
+type nodeCollector struct {
+	node    *SentinelNode
+	mempool mempoolStats
+}
+
+func newNodeCollector(node *SentinelNode, mempool mempoolStats) *nodeCollector {
+	return &nodeCollector{node: node, mempool: mempool}
+}
+
+func (c *nodeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- txsAnalyzedDesc
+	ch <- txsSuspiciousDesc
+	ch <- pauseRequestsDesc
+	ch <- peersConnectedDesc
+	ch <- uptimeDesc
+	ch <- mempoolReceivedDesc
+	ch <- mempoolProcessedDesc
+	ch <- mempoolDroppedDesc
+	ch <- mempoolHashesDroppedDesc
+	ch <- mempoolReconnectsDesc
+	ch <- mempoolFilteredDesc
+	ch <- mempoolDuplicatesDesc
+}
+
+func (c *nodeCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.node.GetStats()
+	ch <- prometheus.MustNewConstMetric(txsAnalyzedDesc, prometheus.CounterValue, float64(stats.TransactionsAnalyzed))
+	ch <- prometheus.MustNewConstMetric(txsSuspiciousDesc, prometheus.CounterValue, float64(stats.SuspiciousDetected))
+	ch <- prometheus.MustNewConstMetric(pauseRequestsDesc, prometheus.CounterValue, float64(stats.PauseRequestsCreated), "created")
+	ch <- prometheus.MustNewConstMetric(pauseRequestsDesc, prometheus.CounterValue, float64(stats.PauseRequestsSigned), "signed")
+	ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, stats.Uptime.Seconds())
+
+	if c.node.peers != nil {
+		ch <- prometheus.MustNewConstMetric(peersConnectedDesc, prometheus.GaugeValue, float64(len(c.node.peers.ConnectedPeers())))
+	}
+
+	if c.mempool != nil {
+		mempoolStats := c.mempool.Stats()
+		ch <- prometheus.MustNewConstMetric(mempoolReceivedDesc, prometheus.CounterValue, float64(mempoolStats.Received))
+		ch <- prometheus.MustNewConstMetric(mempoolProcessedDesc, prometheus.CounterValue, float64(mempoolStats.Processed))
+		ch <- prometheus.MustNewConstMetric(mempoolDroppedDesc, prometheus.CounterValue, float64(mempoolStats.Dropped))
+		ch <- prometheus.MustNewConstMetric(mempoolHashesDroppedDesc, prometheus.CounterValue, float64(mempoolStats.DroppedHashes))
+		ch <- prometheus.MustNewConstMetric(mempoolReconnectsDesc, prometheus.CounterValue, float64(mempoolStats.Reconnects))
+		ch <- prometheus.MustNewConstMetric(mempoolFilteredDesc, prometheus.CounterValue, float64(mempoolStats.Filtered))
+		ch <- prometheus.MustNewConstMetric(mempoolDuplicatesDesc, prometheus.CounterValue, float64(mempoolStats.Duplicates))
+	}
+}
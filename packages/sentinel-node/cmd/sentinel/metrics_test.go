@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/mempool"
+)
+
+type fakeMempoolStats struct{}
+
+func (fakeMempoolStats) Stats() mempool.ListenerStats {
+	return mempool.ListenerStats{Received: 10, Processed: 8, Dropped: 2, DroppedHashes: 5, Reconnects: 3, Filtered: 4, Duplicates: 6}
+}
+
+func TestMetricsServer_ExportsNodeStats(t *testing.T) {
+	node := newTestNode(t)
+	node.peers = &fakePeerLister{connected: []string{"12D3KooWa"}}
+	node.stats.analyzed.Store(8)
+	node.stats.suspicious.Store(1)
+
+	metrics := newMetricsServer(0, zerolog.Nop(), newNodeCollector(node, fakeMempoolStats{}))
+	rec := httptest.NewRecorder()
+	metrics.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"sentinel_txs_analyzed_total 8",
+		"sentinel_txs_suspicious_total 1",
+		`sentinel_pause_requests_total{action="signed"} 0`,
+		"sentinel_peers_connected 1",
+		"sentinel_uptime_seconds",
+		"sentinel_mempool_txs_received_total 10",
+		"sentinel_mempool_txs_processed_total 8",
+		"sentinel_mempool_txs_dropped_total 2",
+		"sentinel_mempool_hashes_dropped_total 5",
+		"sentinel_mempool_reconnects_total 3",
+		"sentinel_mempool_txs_filtered_total 4",
+		"sentinel_mempool_hashes_duplicate_total 6",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in the metrics, got:\n%s", want, body)
+		}
+	}
+}
+
+// Run with -race: scrapes read the counters the analysis workers bump
+func TestMetricsServer_ScrapeDuringAnalysis(t *testing.T) {
+	node := newTestNode(t)
+	metrics := newMetricsServer(0, zerolog.Nop(), newNodeCollector(node, nil))
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		metrics.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	const workers, perWorker = 4, 25
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				node.handleTransaction(testTransaction(int64(w*perWorker + i)))
+				node.stats.pausesSigned.Add(1)
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for scraping := true; scraping; {
+		select {
+		case <-done:
+			scraping = false
+		default:
+			scrape()
+		}
+	}
+
+	body := scrape()
+	for _, want := range []string{
+		fmt.Sprintf("sentinel_txs_analyzed_total %d", workers*perWorker),
+		fmt.Sprintf(`sentinel_pause_requests_total{action="signed"} %d`, workers*perWorker),
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q once the workers finished, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// Notifier brings an alert to a human's attention outside the gossip mesh,
+// used when the mesh couldn't carry it
+type Notifier interface {
+	Notify(ctx context.Context, alert *types.Alert, reason string) error
+}
+
+// webhookNotifier POSTs each escalated alert as JSON, in a shape chat and
+// paging integrations can template from
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Alert  *types.Alert `json:"alert"`
+	Reason string       `json:"reason"`
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{url: url, client: &http.Client{}}
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, alert *types.Alert, reason string) error {
+	body, err := json.Marshal(webhookPayload{Alert: alert, Reason: reason})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var (
+	errNoOperatorAddress     = errors.New("no valid node.operatorAddress configured")
+	errInsufficientPeers     = errors.New("not enough active peers")
+	errOperatorNotRegistered = errors.New("operator address is not a registered node")
+)
+
+// pauseBroadcaster is the subset of the gossip node pause requests, their
+// co-signatures and the acks of the alerts they act on go through
+type pauseBroadcaster interface {
+	ActivePeerCount() int
+	BroadcastPauseRequest(request *types.SignedPauseRequest) error
+	TrackPauseRequest(requestID string, signed *types.SignedPauseRequest)
+	BroadcastSignature(requestID string, signer common.Address, signature []byte) error
+	BroadcastAlertAck(alertID, action string) error
+	StoreEvidence(evidence []byte) common.Hash
+}
+
+// RequestPause builds, signs and broadcasts a pause request for target on
+// the operator's behalf
+func (n *SentinelNode) RequestPause(target common.Address, evidence common.Hash) (*types.SignedPauseRequest, error) {
+	signed, err := n.broadcastPauseRequest(target, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	n.logger.Warn().
+		Str("protocol", target.Hex()).
+		Str("evidence", evidence.Hex()).
+		Str("signer", signed.Signer.Hex()).
+		Msg("OPERATOR-INITIATED pause request broadcast")
+
+	return signed, nil
+}
+
+// broadcastPauseRequest signs a pause request for target with the
+// operator's key and broadcasts it. It is refused unless the node has
+// enough active peers to carry it and its operator address is registered,
+// since peers would discard it otherwise.
+func (n *SentinelNode) broadcastPauseRequest(target common.Address, evidence common.Hash) (*types.SignedPauseRequest, error) {
+	if !common.IsHexAddress(n.config.Node.OperatorAddress) {
+		return nil, errNoOperatorAddress
+	}
+	operator := common.HexToAddress(n.config.Node.OperatorAddress)
+
+	if required := n.config.Node.MinPausePeers; required > 0 {
+		if active := n.pauses.ActivePeerCount(); active < required {
+			return nil, fmt.Errorf("%w: %d of %d", errInsufficientPeers, active, required)
+		}
+	}
+	if !n.verifier.IsRegisteredNode(operator.Hex()) {
+		return nil, errOperatorNotRegistered
+	}
+
+	request := types.PauseRequest{
+		TargetProtocol: target,
+		EvidenceHash:   evidence,
+		Timestamp:      time.Now(),
+		Signers:        []common.Address{operator},
+	}
+	signature, err := n.bls.SignForRouter(onchain.PauseMessage(request, n.verifier.chainID))
+	if err != nil {
+		return nil, err
+	}
+	signed := &types.SignedPauseRequest{
+		Request:   request,
+		Signature: signature,
+		Signer:    operator,
+	}
+
+	// Track before broadcasting so no co-signature can arrive untracked
+	n.pauses.TrackPauseRequest(request.ID(), signed)
+	if err := n.pauses.BroadcastPauseRequest(signed); err != nil {
+		return nil, err
+	}
+	n.stats.pausesCreated.Add(1)
+
+	return signed, nil
+}
+
+// evidenceHash takes a 32-byte hex evidence reference as is and hashes any
+// other reference (an alert ID, a URL) with keccak256. For the latter it
+// also returns the reference as the evidence peers can fetch.
+func evidenceHash(reference string) (common.Hash, []byte) {
+	if b, err := hexutil.Decode(reference); err == nil && len(b) == common.HashLength {
+		return common.BytesToHash(b), nil
+	}
+	return crypto.Keccak256Hash([]byte(reference)), []byte(reference)
+}
+
+// handlePause triggers a pause request for incident response. The body
+// names the target protocol and an evidence reference.
+func (a *apiServer) handlePause(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TargetProtocol string `json:"targetProtocol"`
+		Evidence       string `json:"evidence"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !common.IsHexAddress(body.TargetProtocol) {
+		writeError(w, http.StatusBadRequest, "targetProtocol must be an address")
+		return
+	}
+	if body.Evidence == "" {
+		writeError(w, http.StatusBadRequest, "evidence is required")
+		return
+	}
+
+	hash, evidence := evidenceHash(body.Evidence)
+	if evidence != nil {
+		a.node.pauses.StoreEvidence(evidence)
+	}
+
+	signed, err := a.node.RequestPause(common.HexToAddress(body.TargetProtocol), hash)
+	switch {
+	case errors.Is(err, errNoOperatorAddress), errors.Is(err, errInsufficientPeers), errors.Is(err, errOperatorNotRegistered):
+		a.logger.Warn().Err(err).Str("protocol", body.TargetProtocol).Msg("Operator pause request refused")
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		a.logger.Error().Err(err).Msg("Failed to broadcast operator pause request")
+		writeError(w, http.StatusServiceUnavailable, "failed to broadcast pause request")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"targetProtocol": signed.Request.TargetProtocol.Hex(),
+		"evidenceHash":   signed.Request.EvidenceHash.Hex(),
+		"signer":         signed.Signer.Hex(),
+		"broadcast":      true,
+	})
+}
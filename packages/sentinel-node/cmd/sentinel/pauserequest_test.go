@@ -0,0 +1,141 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// fakePauseBroadcaster records the pause requests, signature shares and
+// alert acks it is asked to publish
+type fakePauseBroadcaster struct {
+	peers    int
+	sent     []*types.SignedPauseRequest
+	tracked  []string
+	acks     []string
+	shares   map[string][]byte
+	signers  map[string]common.Address
+	evidence map[common.Hash][]byte
+}
+
+func (f *fakePauseBroadcaster) ActivePeerCount() int {
+	return f.peers
+}
+
+func (f *fakePauseBroadcaster) BroadcastPauseRequest(request *types.SignedPauseRequest) error {
+	f.sent = append(f.sent, request)
+	return nil
+}
+
+func (f *fakePauseBroadcaster) TrackPauseRequest(requestID string, signed *types.SignedPauseRequest) {
+	f.tracked = append(f.tracked, requestID)
+}
+
+func (f *fakePauseBroadcaster) BroadcastSignature(requestID string, signer common.Address, signature []byte) error {
+	if f.shares == nil {
+		f.shares = make(map[string][]byte)
+		f.signers = make(map[string]common.Address)
+	}
+	f.shares[requestID] = signature
+	f.signers[requestID] = signer
+	return nil
+}
+
+func (f *fakePauseBroadcaster) BroadcastAlertAck(alertID, action string) error {
+	f.acks = append(f.acks, alertID)
+	return nil
+}
+
+func (f *fakePauseBroadcaster) StoreEvidence(evidence []byte) common.Hash {
+	hash := crypto.Keccak256Hash(evidence)
+	if f.evidence == nil {
+		f.evidence = make(map[common.Hash][]byte)
+	}
+	f.evidence[hash] = evidence
+	return hash
+}
+
+func newPauseTestNode(t *testing.T) (*SentinelNode, *fakePauseBroadcaster) {
+	t.Helper()
+
+	node := newTestNode(t)
+	node.verifier = newTestVerifier(t, nil, consensus.FailClosed)
+	node.bls = node.verifier.bls
+	node.config.Node.OperatorAddress = "0x00000000000000000000000000000000000000aa"
+	node.config.Node.MinPausePeers = 2
+
+	pauses := &fakePauseBroadcaster{peers: 2}
+	node.pauses = pauses
+	return node, pauses
+}
+
+func postPause(handler http.Handler, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pause", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAPIServer_PauseBroadcastsSignedRequest(t *testing.T) {
+	node, pauses := newPauseTestNode(t)
+	handler := newAPIServer(node, 0, "secret").routes()
+
+	rec := postPause(handler, "secret", `{"targetProtocol":"0x000000000000000000000000000000000000dead","evidence":"alert-123"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(pauses.sent) != 1 {
+		t.Fatalf("Expected 1 pause request broadcast, got %d", len(pauses.sent))
+	}
+
+	sent := pauses.sent[0]
+	if sent.Request.TargetProtocol != common.HexToAddress("0xdead") {
+		t.Errorf("Unexpected target %s", sent.Request.TargetProtocol.Hex())
+	}
+	if sent.Request.EvidenceHash != crypto.Keccak256Hash([]byte("alert-123")) {
+		t.Errorf("Expected the evidence reference to be hashed, got %s", sent.Request.EvidenceHash.Hex())
+	}
+	if string(pauses.evidence[sent.Request.EvidenceHash]) != "alert-123" {
+		t.Error("Expected the evidence stored for peers to fetch")
+	}
+	if sent.Signer != common.HexToAddress(node.config.Node.OperatorAddress) {
+		t.Errorf("Expected the operator as signer, got %s", sent.Signer.Hex())
+	}
+	if !node.verifier.VerifyPauseRequest(sent) {
+		t.Error("Expected the broadcast request to carry a valid signature")
+	}
+	if node.stats.pausesCreated.Load() != 1 {
+		t.Errorf("Expected PauseRequestsCreated 1, got %d", node.stats.pausesCreated.Load())
+	}
+	if len(pauses.tracked) != 1 || pauses.tracked[0] != sent.Request.ID() {
+		t.Errorf("Expected the request tracked for co-signatures, got %v", pauses.tracked)
+	}
+}
+
+func TestAPIServer_PauseRejected(t *testing.T) {
+	node, pauses := newPauseTestNode(t)
+	handler := newAPIServer(node, 0, "secret").routes()
+	body := `{"targetProtocol":"0x000000000000000000000000000000000000dead","evidence":"alert-123"}`
+
+	if rec := postPause(handler, "wrong", body); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", rec.Code)
+	}
+
+	// Too few peers to carry the request
+	pauses.peers = 1
+	if rec := postPause(handler, "secret", body); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 with too few peers, got %d", rec.Code)
+	}
+
+	if len(pauses.sent) != 0 {
+		t.Errorf("Expected no pause request broadcast, got %d", len(pauses.sent))
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultRecentBufferSize = 1000
+
+// recentEntry is an analyzed transaction together with its outcome
+type recentEntry struct {
+	Transaction *types.PendingTransaction `json:"transaction"`
+	Result      *types.InferenceResult    `json:"result"`
+	AnalyzedAt  time.Time                 `json:"analyzedAt"`
+}
+
+// recentBuffer is a fixed-size ring of the most recently analyzed
+// transactions, kept for debugging missed detections without persistence.
+// The zero value holds defaultRecentBufferSize entries.
+type recentBuffer struct {
+	mu      sync.Mutex
+	size    int
+	entries []recentEntry
+	next    int
+	full    bool
+}
+
+// add records an analysis outcome, overwriting the oldest entry when full
+func (b *recentBuffer) add(tx *types.PendingTransaction, result *types.InferenceResult) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.entries == nil {
+		if b.size <= 0 {
+			b.size = defaultRecentBufferSize
+		}
+		b.entries = make([]recentEntry, b.size)
+	}
+
+	b.entries[b.next] = recentEntry{Transaction: tx, Result: result, AnalyzedAt: time.Now()}
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// list returns up to limit entries, newest first, optionally restricted to
+// one risk level. A limit of 0 returns every match.
+func (b *recentBuffer) list(level string, limit int) []recentEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.entries)
+	}
+
+	matches := make([]recentEntry, 0)
+	for i := 0; i < count; i++ {
+		idx := (b.next - 1 - i + len(b.entries)) % len(b.entries)
+		entry := b.entries[idx]
+		if level != "" && (entry.Result == nil || entry.Result.RiskLevel != level) {
+			continue
+		}
+		matches = append(matches, entry)
+		if limit > 0 && len(matches) == limit {
+			break
+		}
+	}
+	return matches
+}
+
+// since returns the transactions analyzed within the last window, e.g. as
+// mempool context for spotting attacks spread over several transactions
+func (b *recentBuffer) since(window time.Duration) []*types.PendingTransaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.entries)
+	}
+
+	cutoff := time.Now().Add(-window)
+	txs := make([]*types.PendingTransaction, 0)
+	for i := 0; i < count; i++ {
+		entry := b.entries[(b.next-1-i+len(b.entries))%len(b.entries)]
+		if entry.AnalyzedAt.Before(cutoff) {
+			break
+		}
+		txs = append(txs, entry.Transaction)
+	}
+	return txs
+}
+
+// handleRecent serves GET /recent?level=<risk level>&limit=<n>
+func (a *apiServer) handleRecent(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	writeJSON(w, http.StatusOK, a.node.recent.list(r.URL.Query().Get("level"), limit))
+}
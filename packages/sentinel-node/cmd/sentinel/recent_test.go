@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestRecentBuffer_RetainsLastN(t *testing.T) {
+	buffer := recentBuffer{size: 3}
+
+	for i := int64(1); i <= 5; i++ {
+		buffer.add(testTransaction(i), &types.InferenceResult{RiskLevel: "low"})
+	}
+
+	entries := buffer.list("", 0)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 retained entries, got %d", len(entries))
+	}
+	// Newest first: transactions 5, 4, 3
+	for i, want := range []int64{5, 4, 3} {
+		if got := entries[i].Transaction.Hash; got != testTransaction(want).Hash {
+			t.Errorf("Entry %d: expected tx %d, got %s", i, want, got.Hex())
+		}
+	}
+
+	if limited := buffer.list("", 2); len(limited) != 2 {
+		t.Errorf("Expected limit to cap results at 2, got %d", len(limited))
+	}
+}
+
+func TestRecentBuffer_Since(t *testing.T) {
+	var buffer recentBuffer
+	if len(buffer.since(time.Minute)) != 0 {
+		t.Fatal("Expected nothing from an empty buffer")
+	}
+
+	buffer.add(testTransaction(1), &types.InferenceResult{RiskLevel: "low"})
+	buffer.add(testTransaction(2), &types.InferenceResult{RiskLevel: "low"})
+	buffer.entries[0].AnalyzedAt = time.Now().Add(-time.Hour)
+
+	txs := buffer.since(time.Minute)
+	if len(txs) != 1 || txs[0].Hash != testTransaction(2).Hash {
+		t.Errorf("Expected only the transaction analyzed within the window, got %d", len(txs))
+	}
+}
+
+func TestRecentBuffer_FilterByLevel(t *testing.T) {
+	var buffer recentBuffer
+
+	levels := []string{"low", "high", "medium", "high", "critical"}
+	for i, level := range levels {
+		buffer.add(testTransaction(int64(i)), &types.InferenceResult{RiskLevel: level})
+	}
+
+	high := buffer.list("high", 0)
+	if len(high) != 2 {
+		t.Fatalf("Expected 2 high-risk entries, got %d", len(high))
+	}
+	for _, entry := range high {
+		if entry.Result.RiskLevel != "high" {
+			t.Errorf("Filter returned %s entry", entry.Result.RiskLevel)
+		}
+	}
+	if len(buffer.list("none", 0)) != 0 {
+		t.Error("Unknown level should match nothing")
+	}
+}
+
+func TestSentinelNode_RecordsRecentAnalyses(t *testing.T) {
+	node := newTestNode(t)
+	node.recent = recentBuffer{size: 10}
+
+	node.handleTransaction(testTransaction(1))
+	node.handleTransaction(testTransaction(2))
+
+	handler := newAPIServer(node, 0, "").routes()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/recent?level=low&limit=1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var entries []recentEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Transaction.Hash != testTransaction(2).Hash {
+		t.Errorf("Expected the most recent low-risk transaction, got %+v", entries)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/recent?limit=-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid limit, got %d", rec.Code)
+	}
+}
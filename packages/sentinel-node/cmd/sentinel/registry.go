@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultRegistryRefreshInterval = 5 * time.Minute
+
+// registryCheckTimeout bounds the startup probe of the registry contract
+const registryCheckTimeout = 15 * time.Second
+
+var errRegistryNotLoaded = errors.New("registry view not loaded")
+
+// registrySource reads the active node set from the on-chain registry
+type registrySource interface {
+	ActiveNodes(ctx context.Context) ([]types.NodeInfo, error)
+}
+
+// registryWatcher is implemented by sources that can subscribe to registry
+// events; each value on the channel triggers an immediate refresh
+type registryWatcher interface {
+	WatchMembership(ctx context.Context) (<-chan struct{}, error)
+}
+
+// cachedRegistry serves nodeRegistry lookups from a periodically refreshed
+// snapshot of the registry, so per-message checks don't hit the chain and
+// registrations or slashings are picked up within one refresh interval
+type cachedRegistry struct {
+	source   registrySource
+	interval time.Duration
+	logger   zerolog.Logger
+
+	mu       sync.RWMutex
+	loaded   bool
+	byPeerID map[string]*types.NodeInfo
+	byAddr   map[common.Address]*types.NodeInfo
+	synced   time.Time
+}
+
+func newCachedRegistry(source registrySource, interval time.Duration, logger zerolog.Logger) *cachedRegistry {
+	if interval <= 0 {
+		interval = defaultRegistryRefreshInterval
+	}
+	return &cachedRegistry{
+		source:   source,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run refreshes the snapshot every interval, and on membership events when
+// the source supports them, until ctx is canceled
+func (r *cachedRegistry) Run(ctx context.Context) {
+	r.refresh(ctx)
+
+	var events <-chan struct{}
+	if watcher, ok := r.source.(registryWatcher); ok {
+		ch, err := watcher.WatchMembership(ctx)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Registry event subscription failed, relying on periodic refresh")
+		} else {
+			events = ch
+		}
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresh replaces the snapshot. A failed refresh keeps serving the previous
+// view rather than rejecting every peer.
+func (r *cachedRegistry) refresh(ctx context.Context) {
+	nodes, err := r.source.ActiveNodes(ctx)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("Registry refresh failed")
+		return
+	}
+
+	byPeerID := make(map[string]*types.NodeInfo, len(nodes))
+	byAddr := make(map[common.Address]*types.NodeInfo, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		if !node.IsActive {
+			continue
+		}
+		if node.PeerID != "" {
+			byPeerID[node.PeerID] = node
+		}
+		byAddr[node.Address] = node
+	}
+
+	r.mu.Lock()
+	r.loaded = true
+	r.byPeerID = byPeerID
+	r.byAddr = byAddr
+	r.synced = time.Now()
+	r.mu.Unlock()
+
+	r.logger.Debug().Int("activeNodes", len(byAddr)).Msg("Registry view refreshed")
+}
+
+// lookup resolves a peer ID or hex address against the snapshot
+func (r *cachedRegistry) lookup(id string) (*types.NodeInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.loaded {
+		return nil, errRegistryNotLoaded
+	}
+	return r.lookupLocked(id), nil
+}
+
+func (r *cachedRegistry) lookupLocked(id string) *types.NodeInfo {
+	if node, ok := r.byPeerID[id]; ok {
+		return node
+	}
+	if strings.HasPrefix(id, "0x") && common.IsHexAddress(id) {
+		return r.byAddr[common.HexToAddress(id)]
+	}
+	return nil
+}
+
+func (r *cachedRegistry) IsNodeActive(address string) (bool, error) {
+	node, err := r.lookup(address)
+	if err != nil {
+		return false, err
+	}
+	return node != nil, nil
+}
+
+// AreNodesActive resolves every address against the same snapshot
+func (r *cachedRegistry) AreNodesActive(addresses []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.loaded {
+		return nil, errRegistryNotLoaded
+	}
+	active := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		active[address] = r.lookupLocked(address) != nil
+	}
+	return active, nil
+}
+
+// BLSPublicKey returns nil for unknown signers, which fails verification
+func (r *cachedRegistry) BLSPublicKey(signer common.Address) ([]byte, error) {
+	node, err := r.lookup(signer.Hex())
+	if err != nil || node == nil {
+		return nil, err
+	}
+	return node.BLSPublicKey, nil
+}
+
+// NodeInfo returns nil for unknown nodes, which fails the stake check
+func (r *cachedRegistry) NodeInfo(address string) (*types.NodeInfo, error) {
+	node, err := r.lookup(address)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	info := *node
+	return &info, nil
+}
+
+// Stakes returns the stake of every active node, keyed by address
+func (r *cachedRegistry) Stakes() map[common.Address]*big.Int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stakes := make(map[common.Address]*big.Int, len(r.byAddr))
+	for address, node := range r.byAddr {
+		if node.Stake != nil {
+			stakes[address] = new(big.Int).Set(node.Stake)
+		}
+	}
+	return stakes
+}
+
+// LastSync returns when the snapshot was last refreshed successfully
+func (r *cachedRegistry) LastSync() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.synced
+}
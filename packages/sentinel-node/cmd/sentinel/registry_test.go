@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// mockRegistrySource is an on-chain registry whose membership tests can change
+type mockRegistrySource struct {
+	mu     sync.Mutex
+	nodes  []types.NodeInfo
+	err    error
+	events chan struct{}
+	// calls counts ActiveNodes RPCs
+	calls int
+}
+
+func (m *mockRegistrySource) ActiveNodes(ctx context.Context) ([]types.NodeInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return append([]types.NodeInfo(nil), m.nodes...), nil
+}
+
+func (m *mockRegistrySource) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func (m *mockRegistrySource) set(nodes []types.NodeInfo, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes = nodes
+	m.err = err
+}
+
+// watchingRegistrySource additionally supports registry event subscriptions
+type watchingRegistrySource struct {
+	*mockRegistrySource
+}
+
+func (w watchingRegistrySource) WatchMembership(ctx context.Context) (<-chan struct{}, error) {
+	return w.events, nil
+}
+
+func testNodeInfo(peerID string, addr string, stake int64) types.NodeInfo {
+	return types.NodeInfo{
+		Address:      common.HexToAddress(addr),
+		PeerID:       peerID,
+		BLSPublicKey: []byte(peerID),
+		Stake:        big.NewInt(stake),
+		IsActive:     true,
+	}
+}
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, within time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(within)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestCachedRegistry_NotLoaded(t *testing.T) {
+	registry := newCachedRegistry(&mockRegistrySource{}, time.Minute, zerolog.Nop())
+
+	if _, err := registry.IsNodeActive("peer-a"); !errors.Is(err, errRegistryNotLoaded) {
+		t.Errorf("Expected errRegistryNotLoaded before the first refresh, got %v", err)
+	}
+}
+
+func TestCachedRegistry_ReconcilesWithinInterval(t *testing.T) {
+	source := &mockRegistrySource{}
+	source.set([]types.NodeInfo{testNodeInfo("peer-a", "0xa", 1000)}, nil)
+
+	registry := newCachedRegistry(source, 20*time.Millisecond, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Run(ctx)
+
+	if !waitFor(t, time.Second, func() bool { active, _ := registry.IsNodeActive("peer-a"); return active }) {
+		t.Fatal("Expected initial membership to load")
+	}
+
+	// peer-a is slashed, peer-b registers with a new key
+	source.set([]types.NodeInfo{testNodeInfo("peer-b", "0xb", 5000)}, nil)
+
+	updated := waitFor(t, 200*time.Millisecond, func() bool {
+		a, _ := registry.IsNodeActive("peer-a")
+		b, _ := registry.IsNodeActive("peer-b")
+		return !a && b
+	})
+	if !updated {
+		t.Fatal("Registry view did not pick up membership changes within the interval")
+	}
+
+	key, err := registry.BLSPublicKey(common.HexToAddress("0xb"))
+	if err != nil || string(key) != "peer-b" {
+		t.Errorf("Expected peer-b's key, got %q, %v", key, err)
+	}
+	info, err := registry.NodeInfo(common.HexToAddress("0xb").Hex())
+	if err != nil || info == nil || info.Stake.Int64() != 5000 {
+		t.Errorf("Expected peer-b's stake, got %+v, %v", info, err)
+	}
+	if key, _ := registry.BLSPublicKey(common.HexToAddress("0xa")); key != nil {
+		t.Error("Removed node's key should no longer be served")
+	}
+}
+
+func TestCachedRegistry_RefreshFailureKeepsView(t *testing.T) {
+	source := &mockRegistrySource{}
+	source.set([]types.NodeInfo{testNodeInfo("peer-a", "0xa", 1000)}, nil)
+	registry := newCachedRegistry(source, time.Minute, zerolog.Nop())
+
+	registry.refresh(context.Background())
+	source.set(nil, errors.New("rpc unavailable"))
+	registry.refresh(context.Background())
+
+	if active, err := registry.IsNodeActive("peer-a"); err != nil || !active {
+		t.Errorf("Failed refresh should keep the previous view, got %v, %v", active, err)
+	}
+}
+
+func TestCachedRegistry_RefreshesOnEvent(t *testing.T) {
+	source := &mockRegistrySource{events: make(chan struct{}, 1)}
+	source.set([]types.NodeInfo{testNodeInfo("peer-a", "0xa", 1000)}, nil)
+
+	// The periodic refresh is far away; only the event can trigger an update
+	registry := newCachedRegistry(watchingRegistrySource{source}, time.Hour, zerolog.Nop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Run(ctx)
+
+	if !waitFor(t, time.Second, func() bool { return !registry.LastSync().IsZero() }) {
+		t.Fatal("Expected initial refresh")
+	}
+
+	source.set([]types.NodeInfo{testNodeInfo("peer-c", "0xc", 1000)}, nil)
+	source.events <- struct{}{}
+
+	if !waitFor(t, time.Second, func() bool { active, _ := registry.IsNodeActive("peer-c"); return active }) {
+		t.Error("Expected a membership event to refresh the view")
+	}
+}
+
+func TestCachedRegistry_StakeCheckThroughVerifier(t *testing.T) {
+	source := &mockRegistrySource{}
+	source.set([]types.NodeInfo{
+		testNodeInfo("peer-low", "0x1", 10),
+		testNodeInfo("peer-high", "0x2", 5000),
+	}, nil)
+	registry := newCachedRegistry(source, time.Minute, zerolog.Nop())
+	registry.refresh(context.Background())
+
+	v := &nodeVerifier{logger: zerolog.Nop(), registry: registry, minStake: big.NewInt(1000)}
+	if v.IsRegisteredNode("peer-low") {
+		t.Error("Under-staked node should be rejected")
+	}
+	if !v.IsRegisteredNode("peer-high") {
+		t.Error("Sufficiently staked node should be accepted")
+	}
+	if v.IsRegisteredNode("peer-unknown") {
+		t.Error("Unknown node should be rejected")
+	}
+}
+
+func TestCachedRegistry_Stakes(t *testing.T) {
+	source := &mockRegistrySource{}
+	source.set([]types.NodeInfo{
+		testNodeInfo("peer-a", "0x1", 10),
+		testNodeInfo("peer-b", "0x2", 5000),
+	}, nil)
+	registry := newCachedRegistry(source, time.Minute, zerolog.Nop())
+	registry.refresh(context.Background())
+
+	stakes := registry.Stakes()
+	if len(stakes) != 2 || stakes[common.HexToAddress("0x2")].Int64() != 5000 {
+		t.Fatalf("Expected both nodes' stakes, got %v", stakes)
+	}
+
+	// Handed out as copies, so the pause tracker can't alter the snapshot
+	stakes[common.HexToAddress("0x2")].SetInt64(0)
+	if info, _ := registry.NodeInfo("0x0000000000000000000000000000000000000002"); info.Stake.Int64() != 5000 {
+		t.Errorf("Expected the cached stake untouched, got %v", info.Stake)
+	}
+}
+
+func TestCachedRegistry_VerifierChecksServedFromCache(t *testing.T) {
+	source := &mockRegistrySource{}
+	source.set([]types.NodeInfo{
+		testNodeInfo("peer-a", "0xa", 1000),
+		testNodeInfo("peer-b", "0xb", 1000),
+	}, nil)
+
+	registry := newCachedRegistry(source, 50*time.Millisecond, zerolog.Nop())
+	v := &nodeVerifier{logger: zerolog.Nop(), registry: registry, registrationPolicy: consensus.FailClosed}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Run(ctx)
+
+	if !waitFor(t, time.Second, func() bool { return !registry.LastSync().IsZero() }) {
+		t.Fatal("Expected initial refresh")
+	}
+
+	// A storm of checks across many senders stays in memory
+	before := source.callCount()
+	senders := []string{"peer-a", "peer-b", "peer-x", common.HexToAddress("0xa").Hex()}
+	for i := 0; i < 100; i++ {
+		v.IsRegisteredNode(senders[i%len(senders)])
+	}
+	registered := v.AreRegisteredNodes(senders)
+	if calls := source.callCount(); calls > before+1 {
+		t.Errorf("Registration checks hit the registry: %d RPCs during the burst", calls-before)
+	}
+
+	want := map[string]bool{"peer-a": true, "peer-b": true, "peer-x": false, common.HexToAddress("0xa").Hex(): true}
+	for sender, expected := range want {
+		if registered[sender] != expected {
+			t.Errorf("AreRegisteredNodes[%s] = %v, want %v", sender, registered[sender], expected)
+		}
+	}
+
+	// peer-b is slashed; the next periodic refresh drops it
+	source.set([]types.NodeInfo{testNodeInfo("peer-a", "0xa", 1000)}, nil)
+	refreshed := waitFor(t, time.Second, func() bool {
+		return !v.AreRegisteredNodes([]string{"peer-b"})["peer-b"]
+	})
+	if !refreshed {
+		t.Error("Expected the periodic refresh to pick up the slashing")
+	}
+	if source.callCount() < before+1 {
+		t.Error("Expected the registry to be polled on the interval")
+	}
+}
+
+func TestNodeVerifier_AreRegisteredNodesWithoutBulkRegistry(t *testing.T) {
+	v := newTestVerifier(t, &mockRegistry{err: errors.New("rpc unavailable")}, consensus.FailOpen)
+
+	registered := v.AreRegisteredNodes([]string{"peer-a", "peer-b"})
+	if !registered["peer-a"] || !registered["peer-b"] {
+		t.Errorf("Expected per-address fallback to follow the failure policy, got %v", registered)
+	}
+
+	v = newTestVerifier(t, nil, consensus.FailClosed)
+	if !v.AreRegisteredNodes([]string{"peer-a"})["peer-a"] {
+		t.Error("Development mode should allow all nodes")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+)
+
+// Each probe gets this long, so an unreachable dependency can't hold up
+// startup
+const defaultSelfCheckTimeout = 5 * time.Second
+
+// Dependency states reported by the startup self-check
+const (
+	dependencyHealthy   = "healthy"
+	dependencyUnhealthy = "unhealthy"
+	// dependencyFallback is an inference server the node knowingly runs
+	// without, analyzing with local heuristics instead
+	dependencyFallback = "fallback"
+	// dependencyUnchecked is a dependency with no way to probe it
+	dependencyUnchecked = "unchecked"
+)
+
+// rpcProbe reads the chain head; the mempool listener implements it
+type rpcProbe interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// subscriptionProbe checks the provider serves pending transaction
+// subscriptions; the mempool listener implements it
+type subscriptionProbe interface {
+	CheckSubscription(ctx context.Context) error
+}
+
+// inferenceProbe is implemented by inference bridges with a health endpoint
+type inferenceProbe interface {
+	Health(ctx context.Context) (*pb.HealthResponse, error)
+}
+
+// topicProbe lists the gossip topics joined; the gossip node implements it
+type topicProbe interface {
+	Topics() []string
+}
+
+// dependencyStatus is the outcome of probing one dependency
+type dependencyStatus struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// selfCheck probes the node's connectivity dependencies once at startup.
+// Failures don't stop the node, which runs degraded, but are summarized so
+// an operator sees what is missing. A nil inference bridge is the
+// acknowledged local analysis fallback.
+type selfCheck struct {
+	rpc       rpcProbe
+	ws        subscriptionProbe
+	inference analyzer
+	gossip    topicProbe
+	timeout   time.Duration
+}
+
+func (c selfCheck) run(ctx context.Context) []dependencyStatus {
+	return []dependencyStatus{
+		c.probe(ctx, "rpc", c.checkRPC),
+		c.probe(ctx, "websocket", c.checkSubscription),
+		c.probe(ctx, "inference", c.checkInference),
+		c.probe(ctx, "gossip", c.checkGossip),
+	}
+}
+
+func (c selfCheck) probe(ctx context.Context, name string, check func(context.Context) (string, string)) dependencyStatus {
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = defaultSelfCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, detail := check(ctx)
+	return dependencyStatus{Name: name, Status: status, Detail: detail}
+}
+
+func (c selfCheck) checkRPC(ctx context.Context) (string, string) {
+	block, err := c.rpc.BlockNumber(ctx)
+	if err != nil {
+		return dependencyUnhealthy, err.Error()
+	}
+	return dependencyHealthy, fmt.Sprintf("block %d", block)
+}
+
+func (c selfCheck) checkSubscription(ctx context.Context) (string, string) {
+	if err := c.ws.CheckSubscription(ctx); err != nil {
+		return dependencyUnhealthy, err.Error()
+	}
+	return dependencyHealthy, "pending transaction subscription available"
+}
+
+func (c selfCheck) checkInference(ctx context.Context) (string, string) {
+	if c.inference == nil {
+		return dependencyFallback, "no inference server, using local analysis"
+	}
+	probe, ok := c.inference.(inferenceProbe)
+	if !ok {
+		return dependencyUnchecked, "inference bridge has no health endpoint"
+	}
+
+	resp, err := probe.Health(ctx)
+	if err != nil {
+		return dependencyUnhealthy, err.Error()
+	}
+	if !resp.GetHealthy() {
+		return dependencyUnhealthy, "inference server reports unhealthy"
+	}
+	if version := resp.GetModelVersion(); version != "" {
+		return dependencyHealthy, "model " + version
+	}
+	return dependencyHealthy, ""
+}
+
+func (c selfCheck) checkGossip(ctx context.Context) (string, string) {
+	topics := c.gossip.Topics()
+	if len(topics) == 0 {
+		return dependencyUnhealthy, "no gossip topics joined"
+	}
+	return dependencyHealthy, strings.Join(topics, ",")
+}
+
+// logDependencySummary logs the self-check results as a single event,
+// warning when any dependency is unhealthy
+func logDependencySummary(logger zerolog.Logger, statuses []dependencyStatus) {
+	healthy := true
+	dependencies := zerolog.Dict()
+	for _, s := range statuses {
+		if s.Status == dependencyUnhealthy {
+			healthy = false
+		}
+		entry := zerolog.Dict().Str("status", s.Status)
+		if s.Detail != "" {
+			entry = entry.Str("detail", s.Detail)
+		}
+		dependencies = dependencies.Dict(s.Name, entry)
+	}
+
+	event := logger.Info()
+	if !healthy {
+		event = logger.Warn()
+	}
+	event.Bool("healthy", healthy).Dict("dependencies", dependencies).Msg("Startup dependency check")
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+)
+
+type fakeRPC struct {
+	block uint64
+	err   error
+}
+
+func (f fakeRPC) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.block, f.err
+}
+
+type fakeSubscriptions struct{ err error }
+
+func (f fakeSubscriptions) CheckSubscription(ctx context.Context) error {
+	return f.err
+}
+
+type fakeTopics []string
+
+func (f fakeTopics) Topics() []string {
+	return f
+}
+
+// healthAnalyzer is an inference bridge with a health endpoint
+type healthAnalyzer struct {
+	slowBridge
+	resp *pb.HealthResponse
+	err  error
+}
+
+func (h *healthAnalyzer) Health(ctx context.Context) (*pb.HealthResponse, error) {
+	return h.resp, h.err
+}
+
+func TestSelfCheck_ReportsEachDependency(t *testing.T) {
+	healthyCheck := selfCheck{
+		rpc:       fakeRPC{block: 19000000},
+		ws:        fakeSubscriptions{},
+		inference: &healthAnalyzer{resp: &pb.HealthResponse{Healthy: true, ModelVersion: "v2"}},
+		gossip:    fakeTopics{"sentinel/v1/alerts", "sentinel/v1/heartbeat"},
+	}
+
+	tests := []struct {
+		name  string
+		check func() selfCheck
+		want  map[string]string
+	}{
+		{
+			name:  "all healthy",
+			check: func() selfCheck { return healthyCheck },
+			want: map[string]string{
+				"rpc":       dependencyHealthy,
+				"websocket": dependencyHealthy,
+				"inference": dependencyHealthy,
+				"gossip":    dependencyHealthy,
+			},
+		},
+		{
+			name: "rpc unreachable",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.rpc = fakeRPC{err: errors.New("connection refused")}
+				return c
+			},
+			want: map[string]string{"rpc": dependencyUnhealthy, "websocket": dependencyHealthy},
+		},
+		{
+			name: "subscriptions unsupported",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.ws = fakeSubscriptions{err: errors.New("notifications not supported")}
+				return c
+			},
+			want: map[string]string{"rpc": dependencyHealthy, "websocket": dependencyUnhealthy},
+		},
+		{
+			name: "inference fallback",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.inference = nil
+				return c
+			},
+			want: map[string]string{"inference": dependencyFallback},
+		},
+		{
+			name: "inference unreachable",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.inference = &healthAnalyzer{err: errors.New("gRPC client not initialized")}
+				return c
+			},
+			want: map[string]string{"inference": dependencyUnhealthy},
+		},
+		{
+			name: "inference reports unhealthy",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.inference = &healthAnalyzer{resp: &pb.HealthResponse{}}
+				return c
+			},
+			want: map[string]string{"inference": dependencyUnhealthy},
+		},
+		{
+			name: "inference without health endpoint",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.inference = &slowBridge{}
+				return c
+			},
+			want: map[string]string{"inference": dependencyUnchecked},
+		},
+		{
+			name: "no gossip topics",
+			check: func() selfCheck {
+				c := healthyCheck
+				c.gossip = fakeTopics{}
+				return c
+			},
+			want: map[string]string{"gossip": dependencyUnhealthy},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := tt.check().run(context.Background())
+			if len(statuses) != 4 {
+				t.Fatalf("Expected four dependencies, got %+v", statuses)
+			}
+			got := make(map[string]string, len(statuses))
+			for _, s := range statuses {
+				got[s.Name] = s.Status
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("%s = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLogDependencySummary(t *testing.T) {
+	var buf bytes.Buffer
+	logDependencySummary(zerolog.New(&buf), []dependencyStatus{
+		{Name: "rpc", Status: dependencyHealthy, Detail: "block 1"},
+		{Name: "websocket", Status: dependencyUnhealthy, Detail: "connection refused"},
+		{Name: "inference", Status: dependencyFallback, Detail: "no inference server, using local analysis"},
+	})
+
+	var event struct {
+		Level        string `json:"level"`
+		Healthy      bool   `json:"healthy"`
+		Dependencies map[string]struct {
+			Status string `json:"status"`
+			Detail string `json:"detail"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &event); err != nil {
+		t.Fatalf("Expected a single JSON event, got %q: %v", buf.String(), err)
+	}
+
+	if event.Healthy || event.Level != "warn" {
+		t.Errorf("Expected an unhealthy warning, got healthy=%v level=%s", event.Healthy, event.Level)
+	}
+	if ws := event.Dependencies["websocket"]; ws.Status != dependencyUnhealthy || ws.Detail != "connection refused" {
+		t.Errorf("Unexpected websocket entry %+v", ws)
+	}
+	if got := event.Dependencies["inference"].Status; got != dependencyFallback {
+		t.Errorf("Expected the inference fallback reported, got %q", got)
+	}
+
+	// A fallback alone doesn't make the node unhealthy
+	buf.Reset()
+	logDependencySummary(zerolog.New(&buf), []dependencyStatus{
+		{Name: "rpc", Status: dependencyHealthy},
+		{Name: "inference", Status: dependencyFallback},
+	})
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &event); err != nil {
+		t.Fatal(err)
+	}
+	if !event.Healthy || event.Level != "info" {
+		t.Errorf("Expected a healthy summary, got healthy=%v level=%s", event.Healthy, event.Level)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/inference"
+)
+
+// peerLister reports the gossip mesh; the gossip node implements it
+type peerLister interface {
+	PeerID() string
+	ConnectedPeers() []string
+	ActivePeerCount() int
+}
+
+// Bridges report their inference servers' circuit breakers: a MultiBridge
+// one per endpoint, a Bridge its single server
+type (
+	endpointsReporter interface {
+		GetCircuitBreakerStatus() []inference.EndpointStatus
+	}
+	endpointReporter interface {
+		Status() inference.EndpointStatus
+	}
+)
+
+type healthResponse struct {
+	Status string `json:"status"`
+	// Reasons say why the node is degraded
+	Reasons []string `json:"reasons,omitempty"`
+	Paused  bool     `json:"paused"`
+	Uptime  string   `json:"uptime"`
+}
+
+type peersResponse struct {
+	PeerID    string   `json:"peerId"`
+	Connected []string `json:"connected"`
+	// Active counts peers heard from within the last heartbeat window
+	Active int `json:"active"`
+}
+
+type circuitStatus struct {
+	Address  string `json:"address"`
+	State    string `json:"state"`
+	Failures int    `json:"failures"`
+	// ReopenAt is when an open circuit next lets a probe through
+	ReopenAt    *time.Time `json:"reopenAt,omitempty"`
+	Outstanding int64      `json:"outstanding"`
+}
+
+// handleHealth serves GET /health. A degraded node keeps running, so the
+// response is 200 either way and the body says what is missing.
+func (a *apiServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := healthResponse{
+		Status: "ok",
+		Paused: a.node.IsAnalysisPaused(),
+		Uptime: time.Since(a.node.startTime).Round(time.Second).String(),
+	}
+
+	if health.Paused {
+		health.Reasons = append(health.Reasons, "analysis paused")
+	}
+	if circuits := a.node.circuitStatuses(); len(circuits) > 0 {
+		open := 0
+		for _, circuit := range circuits {
+			if circuit.Circuit == inference.CircuitOpen {
+				open++
+			}
+		}
+		if open == len(circuits) {
+			health.Reasons = append(health.Reasons, "inference unavailable, using local heuristics")
+		}
+	}
+	if a.node.peers != nil && a.node.peers.ActivePeerCount() == 0 {
+		health.Reasons = append(health.Reasons, "no active peers")
+	}
+
+	if len(health.Reasons) > 0 {
+		health.Status = "degraded"
+	}
+	writeJSON(w, http.StatusOK, health)
+}
+
+// handleStats serves GET /stats
+func (a *apiServer) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.node.GetStats())
+}
+
+// handlePeers serves GET /peers
+func (a *apiServer) handlePeers(w http.ResponseWriter, r *http.Request) {
+	peers := peersResponse{Connected: []string{}}
+	if a.node.peers != nil {
+		peers.PeerID = a.node.peers.PeerID()
+		peers.Connected = a.node.peers.ConnectedPeers()
+		peers.Active = a.node.peers.ActivePeerCount()
+	}
+	writeJSON(w, http.StatusOK, peers)
+}
+
+// handleCircuit serves GET /circuit
+func (a *apiServer) handleCircuit(w http.ResponseWriter, r *http.Request) {
+	statuses := a.node.circuitStatuses()
+	circuits := make([]circuitStatus, len(statuses))
+	for i, status := range statuses {
+		circuits[i] = circuitStatus{
+			Address:     status.Address,
+			State:       status.Circuit.String(),
+			Failures:    status.Failures,
+			Outstanding: status.Outstanding,
+		}
+		if status.Circuit != inference.CircuitClosed {
+			reopenAt := status.ReopenAt
+			circuits[i].ReopenAt = &reopenAt
+		}
+	}
+	writeJSON(w, http.StatusOK, circuits)
+}
+
+// circuitStatuses reports the breaker of every inference server the bridge
+// talks to; none when it analyzes locally only
+func (n *SentinelNode) circuitStatuses() []inference.EndpointStatus {
+	switch bridge := n.bridge.(type) {
+	case endpointsReporter:
+		return bridge.GetCircuitBreakerStatus()
+	case endpointReporter:
+		if status := bridge.Status(); status.Address != "" {
+			return []inference.EndpointStatus{status}
+		}
+	}
+	return nil
+}
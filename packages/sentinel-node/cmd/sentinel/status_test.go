@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/inference"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+type fakePeerLister struct {
+	connected []string
+	active    int
+}
+
+func (p *fakePeerLister) PeerID() string           { return "12D3KooWself" }
+func (p *fakePeerLister) ConnectedPeers() []string { return p.connected }
+func (p *fakePeerLister) ActivePeerCount() int     { return p.active }
+
+// getJSON serves a GET request and decodes the JSON response into v
+func getJSON(t *testing.T, handler http.Handler, path string, v interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from %s, got %d: %s", path, rec.Code, rec.Body.String())
+	}
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Fatalf("Failed to decode %s: %v", path, err)
+	}
+}
+
+func TestAPIServer_Health(t *testing.T) {
+	node := newTestNode(t)
+	peers := &fakePeerLister{active: 2}
+	node.peers = peers
+	handler := newAPIServer(node, 0, "").routes()
+
+	var health healthResponse
+	getJSON(t, handler, "/health", &health)
+	if health.Status != "ok" || len(health.Reasons) != 0 {
+		t.Errorf("Expected a healthy node, got %+v", health)
+	}
+
+	peers.active = 0
+	node.PauseAnalysis()
+	getJSON(t, handler, "/health", &health)
+	if health.Status != "degraded" || len(health.Reasons) != 2 || !health.Paused {
+		t.Errorf("Expected degraded for pause and no peers, got %+v", health)
+	}
+}
+
+func TestAPIServer_StatsAndPeers(t *testing.T) {
+	node := newTestNode(t)
+	node.peers = &fakePeerLister{connected: []string{"12D3KooWa", "12D3KooWb"}, active: 1}
+	handler := newAPIServer(node, 0, "").routes()
+
+	node.stats.analyzed.Store(3)
+	node.stats.suspicious.Store(1)
+
+	var stats types.NodeStats
+	getJSON(t, handler, "/stats", &stats)
+	if stats.TransactionsAnalyzed != 3 || stats.SuspiciousDetected != 1 || stats.Uptime <= 0 {
+		t.Errorf("Expected the node's statistics, got %+v", stats)
+	}
+
+	var peers peersResponse
+	getJSON(t, handler, "/peers", &peers)
+	if peers.PeerID != "12D3KooWself" || len(peers.Connected) != 2 || peers.Active != 1 {
+		t.Errorf("Expected the gossip mesh reported, got %+v", peers)
+	}
+}
+
+func TestAPIServer_Circuit(t *testing.T) {
+	node := newTestNode(t)
+	handler := newAPIServer(node, 0, "").routes()
+
+	// Without an inference server there are no circuits
+	var circuits []circuitStatus
+	getJSON(t, handler, "/circuit", &circuits)
+	if len(circuits) != 0 {
+		t.Errorf("Expected no circuits for local analysis, got %+v", circuits)
+	}
+
+	bridge, err := inference.NewBridge(inference.BridgeConfig{
+		Address:       "127.0.0.1:1",
+		AllowInsecure: true,
+		Logger:        zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	t.Cleanup(func() { bridge.Close() })
+	node.bridge = bridge
+
+	getJSON(t, handler, "/circuit", &circuits)
+	if len(circuits) != 1 || circuits[0].Address != "127.0.0.1:1" || circuits[0].State != "closed" || circuits[0].ReopenAt != nil {
+		t.Errorf("Expected one closed circuit, got %+v", circuits)
+	}
+}
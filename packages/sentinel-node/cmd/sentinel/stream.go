@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+)
+
+const (
+	// alertStreamBuffer is how many alerts a client may fall behind before
+	// it is disconnected
+	alertStreamBuffer = 64
+	// alertStreamKeepAlive keeps idle connections open through proxies
+	alertStreamKeepAlive = 15 * time.Second
+)
+
+// handleAlertStream serves GET /alerts/stream as Server-Sent Events. Each
+// event's ID is the alert record's sequence number, so a reconnecting
+// client's Last-Event-ID resumes from the store without gaps.
+func (a *apiServer) handleAlertStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	var after uint64
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		after = parsed
+	}
+
+	backlog, sub := a.node.alerts.Subscribe(after, alertStreamBuffer)
+	defer a.node.alerts.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, record := range backlog {
+		if err := writeAlertEvent(w, record); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(alertStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	shutdown := a.node.rootContext().Done()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-shutdown:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case record, ok := <-sub.C:
+			if !ok {
+				if sub.Dropped() {
+					a.logger.Warn().Str("remote", r.RemoteAddr).Msg("Dropped slow alert stream client")
+				}
+				return
+			}
+			if err := writeAlertEvent(w, record); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeAlertEvent(w http.ResponseWriter, record alerts.Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: alert\ndata: %s\n\n", record.Seq, data)
+	return err
+}
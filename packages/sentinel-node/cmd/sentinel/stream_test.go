@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/alerts"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// sseEvent is one parsed Server-Sent Event
+type sseEvent struct {
+	id   string
+	data string
+}
+
+func openAlertStream(t *testing.T, server *httptest.Server, lastEventID string) (*bufio.Reader, func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/alerts/stream", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("Connecting to stream failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Unexpected response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	return bufio.NewReader(resp.Body), func() {
+		cancel()
+		resp.Body.Close()
+	}
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Reading stream failed: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && event.data != "":
+			return event
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func eventAlertID(t *testing.T, event sseEvent) string {
+	t.Helper()
+
+	var record alerts.Record
+	if err := json.Unmarshal([]byte(event.data), &record); err != nil {
+		t.Fatalf("Decoding event data failed: %v", err)
+	}
+	return record.Alert.ID
+}
+
+func TestAlertStream_DeliversNewAlerts(t *testing.T) {
+	node := newTestNode(t)
+	server := httptest.NewServer(newAPIServer(node, 0, "").routes())
+	defer server.Close()
+
+	node.alerts.Add(&types.Alert{ID: "old", Level: types.AlertLevelHigh})
+
+	reader, closeStream := openAlertStream(t, server, "")
+	defer closeStream()
+
+	added := node.alerts.Add(&types.Alert{ID: "new", Level: types.AlertLevelCritical})
+
+	event := readEvent(t, reader)
+	if id := eventAlertID(t, event); id != "new" {
+		t.Errorf("Expected the newly created alert, got %q", id)
+	}
+	if event.id != strconv.FormatUint(added.Seq, 10) {
+		t.Errorf("Expected event ID %d, got %s", added.Seq, event.id)
+	}
+}
+
+func TestAlertStream_ResumesFromLastEventID(t *testing.T) {
+	node := newTestNode(t)
+	server := httptest.NewServer(newAPIServer(node, 0, "").routes())
+	defer server.Close()
+
+	first := node.alerts.Add(&types.Alert{ID: "a", Level: types.AlertLevelHigh})
+	node.alerts.Add(&types.Alert{ID: "b", Level: types.AlertLevelHigh})
+	node.alerts.Add(&types.Alert{ID: "c", Level: types.AlertLevelHigh})
+
+	reader, closeStream := openAlertStream(t, server, strconv.FormatUint(first.Seq, 10))
+	defer closeStream()
+
+	for _, want := range []string{"b", "c"} {
+		if id := eventAlertID(t, readEvent(t, reader)); id != want {
+			t.Errorf("Expected resumed alert %q, got %q", want, id)
+		}
+	}
+
+	node.alerts.Add(&types.Alert{ID: "d", Level: types.AlertLevelHigh})
+	if id := eventAlertID(t, readEvent(t, reader)); id != "d" {
+		t.Errorf("Expected live alert after the backlog, got %q", id)
+	}
+}
+
+func TestAlertStream_InvalidLastEventID(t *testing.T) {
+	node := newTestNode(t)
+	handler := newAPIServer(node, 0, "").routes()
+
+	req := httptest.NewRequest(http.MethodGet, "/alerts/stream", nil)
+	req.Header.Set("Last-Event-ID", "not-a-number")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid Last-Event-ID, got %d", rec.Code)
+	}
+}
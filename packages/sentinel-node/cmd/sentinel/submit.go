@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/config"
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultPauseTxTimeout = 2 * time.Minute
+
+// pauseSubmitter executes the pause requests this node originated once
+// they reach quorum, through the SentinelRouter
+type pauseSubmitter struct {
+	submitter *onchain.Submitter
+	clients   []*ethclient.Client
+	timeout   time.Duration
+	logger    zerolog.Logger
+}
+
+// newPauseSubmitter returns nil when no router address or transaction key
+// is configured, in which case pauses reaching quorum are only logged
+func newPauseSubmitter(cfg *config.Config, logger zerolog.Logger) (*pauseSubmitter, error) {
+	if cfg.Contracts.RouterAddress == (common.Address{}) || cfg.Node.PrivateKeyPath == "" {
+		return nil, nil
+	}
+
+	key, err := crypto.LoadECDSA(cfg.Node.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction key: %w", err)
+	}
+
+	client, err := ethclient.Dial(cfg.Ethereum.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Ethereum.RPCURL, err)
+	}
+	p := &pauseSubmitter{
+		clients: []*ethclient.Client{client},
+		timeout: cfg.Ethereum.TxTimeout,
+		logger:  logger,
+	}
+	if p.timeout <= 0 {
+		p.timeout = defaultPauseTxTimeout
+	}
+
+	var private onchain.TxSender
+	if cfg.Ethereum.FlashbotsRPCURL != "" {
+		relay, err := onchain.NewFlashbotsRelay(onchain.FlashbotsConfig{
+			URL:   cfg.Ethereum.FlashbotsRPCURL,
+			Key:   key,
+			Chain: client,
+		})
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		private = relay
+	}
+
+	var maxGasPrice *big.Int
+	if cfg.Ethereum.MaxGasPrice > 0 {
+		maxGasPrice = big.NewInt(cfg.Ethereum.MaxGasPrice)
+	}
+	p.submitter, err = onchain.NewSubmitter(onchain.SubmitterConfig{
+		Router:         cfg.Contracts.RouterAddress,
+		Key:            key,
+		ChainID:        big.NewInt(cfg.Ethereum.ChainID),
+		Backend:        client,
+		Private:        private,
+		PublicFallback: !cfg.Ethereum.UseMEVProtection,
+		MaxGasPrice:    maxGasPrice,
+		Logger:         logger,
+	})
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// submit executes a pause request that reached quorum, logging the
+// transaction hash it was sent in
+func (p *pauseSubmitter) submit(ctx context.Context, requestID string, agg *types.AggregatedPauseRequest) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	hash, err := p.submitter.Submit(ctx, agg)
+	if err != nil {
+		p.logger.Error().
+			Err(err).
+			Str("requestId", requestID).
+			Str("protocol", agg.Request.TargetProtocol.Hex()).
+			Msg("Failed to submit pause on chain")
+		return
+	}
+
+	p.logger.Warn().
+		Str("requestId", requestID).
+		Str("protocol", agg.Request.TargetProtocol.Hex()).
+		Str("tx", hash.Hex()).
+		Int("signers", len(agg.Signers)).
+		Msg("PAUSE SUBMITTED on chain")
+}
+
+func (p *pauseSubmitter) Close() {
+	for _, client := range p.clients {
+		client.Close()
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// nodeRegistry is the view of the SentinelRegistry consulted by the verifier.
+// Lookups return an error when the registry could not be reached.
+type nodeRegistry interface {
+	IsNodeActive(address string) (bool, error)
+	BLSPublicKey(signer common.Address) ([]byte, error)
+	NodeInfo(address string) (*types.NodeInfo, error)
+}
+
+// bulkRegistry is implemented by registries that can answer many
+// membership checks in one lookup
+type bulkRegistry interface {
+	AreNodesActive(addresses []string) (map[string]bool, error)
+}
+
+// FIX: nodeVerifier implements consensus.SignatureVerifier for gossip message validation
+type nodeVerifier struct {
+	bls    *consensus.BLSSigner
+	logger zerolog.Logger
+	// registry is nil in development mode, in which case every node is
+	// treated as registered and pause requests are checked against the local key
+	registry nodeRegistry
+
+	// Failure policies applied when a registry lookup errors
+	registrationPolicy consensus.FailurePolicy
+	pauseRequestPolicy consensus.FailurePolicy
+
+	// minStake rejects messages from registered nodes staking less, so
+	// cheap Sybil registrations can't raise alerts or co-sign pauses.
+	// Nil disables the check.
+	minStake *big.Int
+
+	// chainID is the signature domain for envelopes and is part of the
+	// signed pause message, so either fails verification on another chain
+	chainID uint64
+}
+
+func (v *nodeVerifier) VerifyPauseRequest(request *types.SignedPauseRequest) bool {
+	// Verify the BLS signature on the pause request
+	if request == nil || len(request.Signature) == 0 {
+		return false
+	}
+
+	message := onchain.PauseMessage(request.Request, v.chainID)
+
+	// Without a registry, verify against the embedded public key in the BLS signer
+	signerPubKey := v.bls.PublicKey()
+	if v.registry != nil {
+		pubKey, err := v.registry.BLSPublicKey(request.Signer)
+		if err != nil {
+			accept := v.pauseRequestPolicy.Accept()
+			v.logger.Warn().
+				Err(err).
+				Str("signer", request.Signer.Hex()).
+				Str("policy", string(v.pauseRequestPolicy)).
+				Bool("accepted", accept).
+				Msg("Signer key lookup failed")
+			return accept
+		}
+		signerPubKey = pubKey
+	}
+
+	valid, err := consensus.VerifySignatureForRouter(request.Signature, message, signerPubKey)
+	if err != nil {
+		v.logger.Debug().Err(err).Msg("BLS signature verification error")
+		return false
+	}
+	return valid && v.hasMinStake(request.Signer.Hex())
+}
+
+func (v *nodeVerifier) IsRegisteredNode(address string) bool {
+	if v.registry == nil {
+		// Development mode: no registry configured, allow all nodes
+		v.logger.Debug().Str("address", address).Msg("Node registration check (development mode: allowing all)")
+		return true
+	}
+
+	active, err := v.registry.IsNodeActive(address)
+	if err != nil {
+		accept := v.registrationPolicy.Accept()
+		v.logger.Warn().
+			Err(err).
+			Str("address", address).
+			Str("policy", string(v.registrationPolicy)).
+			Bool("accepted", accept).
+			Msg("Node registration lookup failed")
+		return accept
+	}
+	return active && v.hasMinStake(address)
+}
+
+// AreRegisteredNodes answers IsRegisteredNode for each address, resolving
+// membership in a single registry lookup when the registry supports it
+func (v *nodeVerifier) AreRegisteredNodes(addresses []string) map[string]bool {
+	registered := make(map[string]bool, len(addresses))
+
+	bulk, ok := v.registry.(bulkRegistry)
+	if !ok {
+		for _, address := range addresses {
+			registered[address] = v.IsRegisteredNode(address)
+		}
+		return registered
+	}
+
+	active, err := bulk.AreNodesActive(addresses)
+	if err != nil {
+		accept := v.registrationPolicy.Accept()
+		v.logger.Warn().
+			Err(err).
+			Int("addresses", len(addresses)).
+			Str("policy", string(v.registrationPolicy)).
+			Bool("accepted", accept).
+			Msg("Bulk node registration lookup failed")
+		for _, address := range addresses {
+			registered[address] = accept
+		}
+		return registered
+	}
+
+	for _, address := range addresses {
+		registered[address] = active[address] && v.hasMinStake(address)
+	}
+	return registered
+}
+
+// VerifyMessage checks a gossip envelope signature against the registered
+// BLS key of the operator address it was sent as. Lookup errors follow the
+// pause request policy that governs signer key lookups.
+func (v *nodeVerifier) VerifyMessage(operator string, message, signature []byte) bool {
+	if len(signature) == 0 {
+		return false
+	}
+
+	if v.registry == nil {
+		// Development mode: peers' keys are unknown, so envelopes can't be checked
+		v.logger.Debug().Str("operator", operator).Msg("Envelope check (development mode: allowing all)")
+		return true
+	}
+
+	info, err := v.registry.NodeInfo(operator)
+	if err != nil {
+		accept := v.pauseRequestPolicy.Accept()
+		v.logger.Warn().
+			Err(err).
+			Str("operator", operator).
+			Str("policy", string(v.pauseRequestPolicy)).
+			Bool("accepted", accept).
+			Msg("Operator key lookup failed")
+		return accept
+	}
+	if info == nil || len(info.BLSPublicKey) == 0 {
+		return false
+	}
+
+	valid, err := consensus.VerifySignatureForChain(signature, message, info.BLSPublicKey, v.chainID)
+	if err != nil {
+		v.logger.Debug().Err(err).Str("operator", operator).Msg("Envelope signature verification error")
+		return false
+	}
+	return valid
+}
+
+// chainSigner signs gossip envelopes in the chain domain nodeVerifier
+// checks them in
+type chainSigner struct {
+	bls     *consensus.BLSSigner
+	chainID uint64
+}
+
+func (s chainSigner) SignMessage(message []byte) ([]byte, error) {
+	return s.bls.SignForChain(message, s.chainID)
+}
+
+// hasMinStake reports whether a node's on-chain stake meets minStake. Lookup
+// errors follow the registration failure policy.
+func (v *nodeVerifier) hasMinStake(address string) bool {
+	if v.registry == nil || v.minStake == nil || v.minStake.Sign() == 0 {
+		return true
+	}
+
+	info, err := v.registry.NodeInfo(address)
+	if err != nil {
+		accept := v.registrationPolicy.Accept()
+		v.logger.Warn().
+			Err(err).
+			Str("address", address).
+			Str("policy", string(v.registrationPolicy)).
+			Bool("accepted", accept).
+			Msg("Node stake lookup failed")
+		return accept
+	}
+
+	if info == nil || info.Stake == nil || info.Stake.Cmp(v.minStake) < 0 {
+		v.logger.Warn().
+			Str("address", address).
+			Str("minStake", v.minStake.String()).
+			Msg("Rejected message from under-staked node")
+		return false
+	}
+	return true
+}
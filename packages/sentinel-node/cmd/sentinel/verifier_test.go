@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/internal/onchain"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// mockRegistry implements nodeRegistry for testing
+type mockRegistry struct {
+	active   bool
+	pubKey   []byte
+	stake    *big.Int
+	err      error
+	stakeErr error
+}
+
+func (m *mockRegistry) IsNodeActive(address string) (bool, error) {
+	return m.active, m.err
+}
+
+func (m *mockRegistry) BLSPublicKey(signer common.Address) ([]byte, error) {
+	return m.pubKey, m.err
+}
+
+func (m *mockRegistry) NodeInfo(address string) (*types.NodeInfo, error) {
+	if m.stakeErr != nil {
+		return nil, m.stakeErr
+	}
+	return &types.NodeInfo{BLSPublicKey: m.pubKey, Stake: m.stake, IsActive: m.active}, m.err
+}
+
+// testChainID is the chain test verifiers and requests are bound to
+const testChainID = 1
+
+func newTestVerifier(t *testing.T, registry nodeRegistry, policy consensus.FailurePolicy) *nodeVerifier {
+	t.Helper()
+
+	signer, err := consensus.NewBLSSigner("")
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed: %v", err)
+	}
+
+	return &nodeVerifier{
+		bls:                signer,
+		logger:             zerolog.Nop(),
+		registry:           registry,
+		registrationPolicy: policy,
+		pauseRequestPolicy: policy,
+		chainID:            testChainID,
+	}
+}
+
+func signedTestRequest(t *testing.T, signer *consensus.BLSSigner) *types.SignedPauseRequest {
+	t.Helper()
+
+	request := types.PauseRequest{
+		TargetProtocol: common.HexToAddress("0xdead"),
+		EvidenceHash:   common.HexToHash("0xbeef"),
+	}
+	sig, err := signer.SignForRouter(onchain.PauseMessage(request, testChainID))
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	return &types.SignedPauseRequest{
+		Request:   request,
+		Signature: sig,
+		Signer:    common.HexToAddress("0x1"),
+	}
+}
+
+func TestNodeVerifier_DevelopmentModeAllowsAll(t *testing.T) {
+	v := newTestVerifier(t, nil, consensus.FailClosed)
+
+	if !v.IsRegisteredNode("any-peer") {
+		t.Error("Development mode should allow all nodes")
+	}
+}
+
+func TestNodeVerifier_RegistrationLookupError(t *testing.T) {
+	registry := &mockRegistry{err: errors.New("rpc unavailable")}
+
+	tests := []struct {
+		name     string
+		policy   consensus.FailurePolicy
+		expected bool
+	}{
+		{name: "fail closed rejects", policy: consensus.FailClosed, expected: false},
+		{name: "fail open accepts", policy: consensus.FailOpen, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t, registry, tt.policy)
+			if got := v.IsRegisteredNode("peer"); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNodeVerifier_RegistrationLookupAnswer(t *testing.T) {
+	// Policies only apply to lookup errors, not to definitive answers
+	v := newTestVerifier(t, &mockRegistry{active: false}, consensus.FailOpen)
+	if v.IsRegisteredNode("peer") {
+		t.Error("Inactive node should be rejected regardless of policy")
+	}
+
+	v = newTestVerifier(t, &mockRegistry{active: true}, consensus.FailClosed)
+	if !v.IsRegisteredNode("peer") {
+		t.Error("Active node should be accepted")
+	}
+}
+
+func TestNodeVerifier_PauseRequestLookupError(t *testing.T) {
+	registry := &mockRegistry{err: errors.New("rpc unavailable")}
+
+	tests := []struct {
+		name     string
+		policy   consensus.FailurePolicy
+		expected bool
+	}{
+		{name: "fail closed rejects", policy: consensus.FailClosed, expected: false},
+		{name: "fail open accepts", policy: consensus.FailOpen, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t, registry, tt.policy)
+			request := signedTestRequest(t, v.bls)
+			if got := v.VerifyPauseRequest(request); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNodeVerifier_PauseRequestRegistryKey(t *testing.T) {
+	signer, _ := consensus.NewBLSSigner("")
+	v := newTestVerifier(t, &mockRegistry{pubKey: signer.PublicKey()}, consensus.FailClosed)
+
+	if !v.VerifyPauseRequest(signedTestRequest(t, signer)) {
+		t.Error("Request signed by the registered key should verify")
+	}
+
+	// Signed by the local key, but the registry reports a different key
+	if v.VerifyPauseRequest(signedTestRequest(t, v.bls)) {
+		t.Error("Request signed by an unregistered key should be rejected")
+	}
+}
+
+func TestNodeVerifier_PauseRequestOtherChain(t *testing.T) {
+	signer, _ := consensus.NewBLSSigner("")
+	v := newTestVerifier(t, &mockRegistry{pubKey: signer.PublicKey()}, consensus.FailClosed)
+	v.chainID = 5
+
+	// Signed for chain 1, replayed to a node on chain 5
+	if v.VerifyPauseRequest(signedTestRequest(t, signer)) {
+		t.Error("Request signed for another chain should be rejected")
+	}
+}
+
+func TestNodeVerifier_MinPeerStake(t *testing.T) {
+	minStake := big.NewInt(1000)
+
+	tests := []struct {
+		name     string
+		registry *mockRegistry
+		policy   consensus.FailurePolicy
+		expected bool
+	}{
+		{name: "under-staked rejected", registry: &mockRegistry{active: true, stake: big.NewInt(999)}, policy: consensus.FailOpen, expected: false},
+		{name: "no stake rejected", registry: &mockRegistry{active: true}, policy: consensus.FailOpen, expected: false},
+		{name: "sufficient stake accepted", registry: &mockRegistry{active: true, stake: big.NewInt(1000)}, policy: consensus.FailClosed, expected: true},
+		{name: "lookup error fail closed", registry: &mockRegistry{active: true, stakeErr: errors.New("rpc unavailable")}, policy: consensus.FailClosed, expected: false},
+		{name: "lookup error fail open", registry: &mockRegistry{active: true, stakeErr: errors.New("rpc unavailable")}, policy: consensus.FailOpen, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t, tt.registry, tt.policy)
+			v.minStake = minStake
+			if got := v.IsRegisteredNode("peer"); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNodeVerifier_MinPeerStakePauseRequest(t *testing.T) {
+	signer, _ := consensus.NewBLSSigner("")
+	registry := &mockRegistry{active: true, pubKey: signer.PublicKey(), stake: big.NewInt(10)}
+	v := newTestVerifier(t, registry, consensus.FailClosed)
+	v.minStake = big.NewInt(1000)
+
+	if v.VerifyPauseRequest(signedTestRequest(t, signer)) {
+		t.Error("Pause signature from an under-staked node should not count")
+	}
+
+	registry.stake = big.NewInt(5000)
+	if !v.VerifyPauseRequest(signedTestRequest(t, signer)) {
+		t.Error("Pause signature from a sufficiently staked node should verify")
+	}
+}
+
+func TestNodeVerifier_EnvelopeSignature(t *testing.T) {
+	signer, _ := consensus.NewBLSSigner("")
+	v := newTestVerifier(t, &mockRegistry{active: true, pubKey: signer.PublicKey()}, consensus.FailClosed)
+	operator := common.HexToAddress("0xa1")
+
+	msg := consensus.GossipMessage{
+		Type:      consensus.MessageTypeSignature,
+		Sender:    "peer",
+		Timestamp: time.Now(),
+		Payload:   json.RawMessage(`{"requestId":"r1"}`),
+		Operator:  operator,
+	}
+	sig, err := chainSigner{bls: signer, chainID: testChainID}.SignMessage(consensus.EnvelopeMessage(&msg))
+	if err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+
+	if !v.VerifyMessage(operator.Hex(), consensus.EnvelopeMessage(&msg), sig) {
+		t.Fatal("Envelope signed by the registered key should verify")
+	}
+
+	msg.Payload = json.RawMessage(`{"requestId":"r2"}`)
+	if v.VerifyMessage(operator.Hex(), consensus.EnvelopeMessage(&msg), sig) {
+		t.Error("Tampered payload should fail verification")
+	}
+
+	// A different registered key didn't sign it
+	other, _ := consensus.NewBLSSigner("")
+	v.registry = &mockRegistry{active: true, pubKey: other.PublicKey()}
+	msg.Payload = json.RawMessage(`{"requestId":"r1"}`)
+	if v.VerifyMessage(operator.Hex(), consensus.EnvelopeMessage(&msg), sig) {
+		t.Error("Envelope should not verify against another node's key")
+	}
+}
+
+func TestParseStake(t *testing.T) {
+	if stake, err := parseStake(""); err != nil || stake != nil {
+		t.Errorf("Empty stake should disable the check, got %v, %v", stake, err)
+	}
+	if stake, err := parseStake("25000000000000000000000"); err != nil || stake.String() != "25000000000000000000000" {
+		t.Errorf("Unexpected parse result %v, %v", stake, err)
+	}
+	for _, invalid := range []string{"abc", "-1", "1e18"} {
+		if _, err := parseStake(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package alerts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultArchiveMaxAge   = 30 * 24 * time.Hour
+	defaultArchiveMaxBytes = 64 << 20
+)
+
+// levelRank orders alert levels by severity; unknown levels rank lowest
+var levelRank = map[types.AlertLevel]int{
+	types.AlertLevelLow:      1,
+	types.AlertLevelMedium:   2,
+	types.AlertLevelHigh:     3,
+	types.AlertLevelCritical: 4,
+}
+
+// Source says whether an archived alert was raised here or by a peer
+type Source string
+
+const (
+	SourceLocal Source = "local"
+	SourcePeer  Source = "peer"
+)
+
+// ArchivedAlert is an alert as this node saw it
+type ArchivedAlert struct {
+	SeenAt time.Time    `json:"seenAt"`
+	Source Source       `json:"source"`
+	Alert  *types.Alert `json:"alert"`
+}
+
+// ArchiveConfig configures the alert archive
+type ArchiveConfig struct {
+	// Path is the JSONL file alerts are appended to
+	Path string
+	// MaxAge drops alerts seen longer ago than this when pruning (0 = 30 days)
+	MaxAge time.Duration
+	// MaxBytes bounds the file; past it the oldest alerts are dropped until
+	// it is back under three quarters of the limit (0 = 64 MiB)
+	MaxBytes int64
+}
+
+// archiveEntry locates one alert in the archive file
+type archiveEntry struct {
+	seenAt time.Time
+	offset int64
+	length int64
+}
+
+// Archive keeps every alert this node sees on disk, unlike Store which
+// only holds recent ones in memory, so operators can review what the node
+// saw after an incident. Alerts are appended to a JSONL file and indexed in
+// memory by level and the time they were seen.
+type Archive struct {
+	path     string
+	maxAge   time.Duration
+	maxBytes int64
+
+	mu   sync.RWMutex
+	file *os.File
+	size int64
+	// byLevel holds each level's entries, oldest first
+	byLevel map[types.AlertLevel][]archiveEntry
+
+	now func() time.Time
+}
+
+// OpenArchive opens, or creates, the archive at cfg.Path and indexes the
+// alerts already in it
+func OpenArchive(cfg ArchiveConfig) (*Archive, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("alert archive path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0700); err != nil {
+		return nil, err
+	}
+
+	a := &Archive{
+		path:     cfg.Path,
+		maxAge:   cfg.MaxAge,
+		maxBytes: cfg.MaxBytes,
+		now:      time.Now,
+	}
+	if a.maxAge <= 0 {
+		a.maxAge = defaultArchiveMaxAge
+	}
+	if a.maxBytes <= 0 {
+		a.maxBytes = defaultArchiveMaxBytes
+	}
+
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// open (re)opens the file and rebuilds the index from it. A torn last line
+// left by a crash is cut off so later appends start on a line of their own.
+func (a *Archive) open() error {
+	file, err := os.OpenFile(a.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+
+	byLevel := make(map[types.AlertLevel][]archiveEntry)
+	var offset int64
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// Anything before EOF without a newline is a partial write
+			break
+		}
+
+		var archived ArchivedAlert
+		if json.Unmarshal(line, &archived) == nil && archived.Alert != nil {
+			level := archived.Alert.Level
+			byLevel[level] = append(byLevel[level], archiveEntry{
+				seenAt: archived.SeenAt,
+				offset: offset,
+				length: int64(len(line)),
+			})
+		}
+		offset += int64(len(line))
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(offset, 0); err != nil {
+		file.Close()
+		return err
+	}
+
+	a.file = file
+	a.size = offset
+	a.byLevel = byLevel
+	return nil
+}
+
+// Append archives an alert seen now
+func (a *Archive) Append(alert *types.Alert, source Source) error {
+	archived := ArchivedAlert{SeenAt: a.now(), Source: source, Alert: alert}
+	line, err := json.Marshal(archived)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return errors.New("alert archive is closed")
+	}
+	if _, err := a.file.Write(line); err != nil {
+		return fmt.Errorf("failed to archive alert %s: %w", alert.ID, err)
+	}
+	a.byLevel[alert.Level] = append(a.byLevel[alert.Level], archiveEntry{
+		seenAt: archived.SeenAt,
+		offset: a.size,
+		length: int64(len(line)),
+	})
+	a.size += int64(len(line))
+
+	if a.size > a.maxBytes {
+		return a.compactLocked(a.oldestKeptLocked(a.now()))
+	}
+	return nil
+}
+
+// QueryAlerts returns the archived alerts seen since since at minLevel or
+// above, oldest first
+func (a *Archive) QueryAlerts(since time.Time, minLevel types.AlertLevel) ([]ArchivedAlert, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.file == nil {
+		return nil, errors.New("alert archive is closed")
+	}
+
+	if cutoff := a.now().Add(-a.maxAge); since.Before(cutoff) {
+		since = cutoff
+	}
+
+	var matched []archiveEntry
+	for level, entries := range a.byLevel {
+		if levelRank[level] < levelRank[minLevel] {
+			continue
+		}
+		first := sort.Search(len(entries), func(i int) bool {
+			return !entries[i].seenAt.Before(since)
+		})
+		matched = append(matched, entries[first:]...)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].offset < matched[j].offset
+	})
+
+	alerts := make([]ArchivedAlert, 0, len(matched))
+	for _, entry := range matched {
+		archived, err := a.readLocked(entry)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, archived)
+	}
+	return alerts, nil
+}
+
+func (a *Archive) readLocked(entry archiveEntry) (ArchivedAlert, error) {
+	line := make([]byte, entry.length)
+	if _, err := a.file.ReadAt(line, entry.offset); err != nil {
+		return ArchivedAlert{}, err
+	}
+	var archived ArchivedAlert
+	if err := json.Unmarshal(line, &archived); err != nil {
+		return ArchivedAlert{}, err
+	}
+	return archived, nil
+}
+
+// Prune drops alerts older than MaxAge and returns how many it dropped
+func (a *Archive) Prune() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return 0, errors.New("alert archive is closed")
+	}
+
+	cutoff := a.now().Add(-a.maxAge)
+	before := a.countLocked()
+	for _, entries := range a.byLevel {
+		if len(entries) > 0 && entries[0].seenAt.Before(cutoff) {
+			if err := a.compactLocked(a.oldestKeptLocked(cutoff)); err != nil {
+				return 0, err
+			}
+			break
+		}
+	}
+	return before - a.countLocked(), nil
+}
+
+// oldestKeptLocked returns the offset of the first alert to keep: the
+// first seen at or after cutoff, moved on past older ones while the file
+// would still exceed three quarters of MaxBytes
+func (a *Archive) oldestKeptLocked(cutoff time.Time) int64 {
+	entries := make([]archiveEntry, 0, a.countLocked())
+	for _, level := range a.byLevel {
+		entries = append(entries, level...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].offset < entries[j].offset
+	})
+
+	target := a.maxBytes / 4 * 3
+	for _, entry := range entries {
+		if !entry.seenAt.Before(cutoff) && a.size-entry.offset <= target {
+			return entry.offset
+		}
+	}
+	return a.size
+}
+
+// compactLocked rewrites the archive without everything before offset
+func (a *Archive) compactLocked(offset int64) error {
+	tmp := a.path + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Seek(offset, 0); err != nil {
+		out.Close()
+		return err
+	}
+	if _, err := bufio.NewReader(a.file).WriteTo(out); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	a.file.Close()
+	a.file = nil
+	if err := os.Rename(tmp, a.path); err != nil {
+		// Carry on with the uncompacted file
+		return errors.Join(err, a.open())
+	}
+	return a.open()
+}
+
+func (a *Archive) countLocked() int {
+	count := 0
+	for _, entries := range a.byLevel {
+		count += len(entries)
+	}
+	return count
+}
+
+// Close closes the archive file
+func (a *Archive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
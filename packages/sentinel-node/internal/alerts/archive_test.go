@@ -0,0 +1,145 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// newTestArchive returns an archive whose clock is advanced through the
+// returned pointer
+func newTestArchive(t *testing.T, cfg ArchiveConfig) (*Archive, *time.Time) {
+	t.Helper()
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "alerts.jsonl")
+	}
+	archive, err := OpenArchive(cfg)
+	if err != nil {
+		t.Fatalf("OpenArchive failed: %v", err)
+	}
+	t.Cleanup(func() { archive.Close() })
+
+	now := time.Unix(1700000000, 0)
+	archive.now = func() time.Time { return now }
+	return archive, &now
+}
+
+func archivedIDs(alerts []ArchivedAlert) []string {
+	ids := make([]string, len(alerts))
+	for i, archived := range alerts {
+		ids[i] = archived.Alert.ID
+	}
+	return ids
+}
+
+func TestArchive_AppendSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	archive, now := newTestArchive(t, ArchiveConfig{Path: path})
+
+	if err := archive.Append(&types.Alert{ID: "a", Level: types.AlertLevelHigh, Message: "flash loan"}, SourceLocal); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	*now = now.Add(time.Second)
+	archive.Append(&types.Alert{ID: "b", Level: types.AlertLevelLow}, SourcePeer)
+	archive.Close()
+
+	// A crash mid-write leaves a torn line that reopening discards
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	file.WriteString(`{"seenAt":"2023-`)
+	file.Close()
+
+	reopened, _ := newTestArchive(t, ArchiveConfig{Path: path})
+	reopened.Append(&types.Alert{ID: "c", Level: types.AlertLevelCritical}, SourceLocal)
+
+	alerts, err := reopened.QueryAlerts(time.Time{}, types.AlertLevelLow)
+	if err != nil {
+		t.Fatalf("QueryAlerts failed: %v", err)
+	}
+	if ids := fmt.Sprint(archivedIDs(alerts)); ids != "[a b c]" {
+		t.Fatalf("Expected [a b c] after reopening, got %s", ids)
+	}
+	if alerts[0].Source != SourceLocal || alerts[0].Alert.Message != "flash loan" || alerts[1].Source != SourcePeer {
+		t.Errorf("Expected alerts read back intact, got %+v", alerts[:2])
+	}
+}
+
+func TestArchive_QueryByLevelAndTime(t *testing.T) {
+	archive, now := newTestArchive(t, ArchiveConfig{})
+	start := *now
+
+	for i, level := range []types.AlertLevel{
+		types.AlertLevelLow,
+		types.AlertLevelCritical,
+		types.AlertLevelMedium,
+		types.AlertLevelHigh,
+		types.AlertLevelLow,
+	} {
+		archive.Append(&types.Alert{ID: fmt.Sprint(i), Level: level}, SourceLocal)
+		*now = now.Add(time.Minute)
+	}
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		minLevel types.AlertLevel
+		want     string
+	}{
+		{"everything", time.Time{}, types.AlertLevelLow, "[0 1 2 3 4]"},
+		{"high and above", time.Time{}, types.AlertLevelHigh, "[1 3]"},
+		{"medium and above since the second minute", start.Add(2 * time.Minute), types.AlertLevelMedium, "[2 3]"},
+		{"nothing since", now.Add(time.Second), types.AlertLevelLow, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts, err := archive.QueryAlerts(tt.since, tt.minLevel)
+			if err != nil {
+				t.Fatalf("QueryAlerts failed: %v", err)
+			}
+			if ids := fmt.Sprint(archivedIDs(alerts)); ids != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestArchive_Retention(t *testing.T) {
+	archive, now := newTestArchive(t, ArchiveConfig{MaxAge: time.Hour})
+
+	archive.Append(&types.Alert{ID: "old", Level: types.AlertLevelCritical}, SourceLocal)
+	*now = now.Add(50 * time.Minute)
+	archive.Append(&types.Alert{ID: "recent", Level: types.AlertLevelCritical}, SourceLocal)
+	*now = now.Add(20 * time.Minute)
+
+	pruned, err := archive.Prune()
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("Expected 1 alert pruned by age, got %d", pruned)
+	}
+	alerts, _ := archive.QueryAlerts(time.Time{}, types.AlertLevelLow)
+	if ids := fmt.Sprint(archivedIDs(alerts)); ids != "[recent]" {
+		t.Errorf("Expected only the recent alert kept, got %s", ids)
+	}
+
+	// Past MaxBytes the oldest alerts go first
+	sized, _ := newTestArchive(t, ArchiveConfig{MaxBytes: 1000})
+	for i := 0; i < 20; i++ {
+		if err := sized.Append(&types.Alert{ID: fmt.Sprint(i), Level: types.AlertLevelHigh}, SourceLocal); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	info, _ := os.Stat(sized.path)
+	if info.Size() > 1000 {
+		t.Errorf("Expected the archive kept under 1000 bytes, got %d", info.Size())
+	}
+	alerts, _ = sized.QueryAlerts(time.Time{}, types.AlertLevelLow)
+	if len(alerts) == 0 || alerts[len(alerts)-1].Alert.ID != "19" || alerts[0].Alert.ID == "0" {
+		t.Errorf("Expected the newest alerts kept, got %v", archivedIDs(alerts))
+	}
+}
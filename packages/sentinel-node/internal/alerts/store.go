@@ -0,0 +1,353 @@
+package alerts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// Status is the lifecycle state of a stored alert
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusResolved Status = "resolved"
+	// StatusExpired marks an alert resolved automatically when its TTL elapsed
+	StatusExpired Status = "resolved-expired"
+	// StatusHandled marks an alert some node, this one or a peer, acted on
+	StatusHandled Status = "handled"
+)
+
+const defaultMaxAlerts = 10000
+
+// DefaultTTLs keeps low-severity alerts briefly while critical alerts never
+// expire on their own
+var DefaultTTLs = map[types.AlertLevel]time.Duration{
+	types.AlertLevelLow:      15 * time.Minute,
+	types.AlertLevelMedium:   time.Hour,
+	types.AlertLevelHigh:     6 * time.Hour,
+	types.AlertLevelCritical: 0,
+}
+
+// Record is an alert together with its lifecycle state
+type Record struct {
+	// Seq orders records by insertion and identifies them in alert streams
+	Seq        uint64       `json:"seq"`
+	Alert      *types.Alert `json:"alert"`
+	Status     Status       `json:"status"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	ResolvedAt time.Time    `json:"resolvedAt,omitempty"`
+	// HandledBy is the peer that acted on a handled alert; empty when this
+	// node did
+	HandledBy string `json:"handledBy,omitempty"`
+}
+
+// Config configures the alert store
+type Config struct {
+	// TTLs per alert level; a zero or missing TTL means the level never expires
+	TTLs map[types.AlertLevel]time.Duration
+	// MaxAlerts bounds memory by evicting the oldest alerts
+	MaxAlerts int
+}
+
+// Store keeps alerts seen or raised by this node and expires unresolved ones
+// once their level's TTL elapses
+type Store struct {
+	mu        sync.Mutex
+	ttls      map[types.AlertLevel]time.Duration
+	maxAlerts int
+	records   map[string]*Record
+	order     []string
+	seq       uint64
+
+	// Acks for alerts not seen yet, applied when the alert is added. Peers
+	// derive the same alert ID, so an ack often arrives before local detection.
+	acks     map[string]string
+	ackOrder []string
+
+	subscribers map[*Subscription]struct{}
+
+	now func() time.Time
+}
+
+// Subscription delivers records as they are added. Its channel is closed when
+// the subscriber falls a full buffer behind or unsubscribes.
+type Subscription struct {
+	C       <-chan Record
+	ch      chan Record
+	dropped bool
+}
+
+// Dropped reports whether the subscription was closed for falling behind
+func (sub *Subscription) Dropped() bool {
+	return sub.dropped
+}
+
+// NewStore creates an alert store, falling back to DefaultTTLs when none
+// are configured
+func NewStore(cfg Config) *Store {
+	ttls := cfg.TTLs
+	if ttls == nil {
+		ttls = DefaultTTLs
+	}
+
+	maxAlerts := cfg.MaxAlerts
+	if maxAlerts <= 0 {
+		maxAlerts = defaultMaxAlerts
+	}
+
+	return &Store{
+		ttls:      ttls,
+		maxAlerts: maxAlerts,
+		records:   make(map[string]*Record),
+		acks:      make(map[string]string),
+		now:       time.Now,
+
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Add stores an alert as active. Adding an alert whose ID is already stored
+// keeps the existing record.
+func (s *Store) Add(alert *types.Alert) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[alert.ID]; ok {
+		s.expireLocked(record, s.now())
+		return *record
+	}
+
+	s.seq++
+	record := &Record{
+		Seq:       s.seq,
+		Alert:     alert,
+		Status:    StatusActive,
+		CreatedAt: s.now(),
+	}
+	if by, ok := s.acks[alert.ID]; ok {
+		record.Status = StatusHandled
+		record.ResolvedAt = record.CreatedAt
+		record.HandledBy = by
+		delete(s.acks, alert.ID)
+	}
+	s.records[alert.ID] = record
+	s.order = append(s.order, alert.ID)
+
+	for len(s.order) > s.maxAlerts {
+		delete(s.records, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	s.publishLocked(*record)
+	return *record
+}
+
+// Subscribe returns the stored records added after sequence number after,
+// oldest first, and a subscription for every record added from then on.
+// Both are taken under one lock so no record falls between them. A
+// subscriber more than buffer records behind is dropped.
+func (s *Store) Subscribe(after uint64, buffer int) ([]Record, *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backlog := make([]Record, 0)
+	if after > 0 {
+		now := s.now()
+		for _, id := range s.order {
+			if record := s.records[id]; record.Seq > after {
+				s.expireLocked(record, now)
+				backlog = append(backlog, *record)
+			}
+		}
+	}
+
+	if buffer < 1 {
+		buffer = 1
+	}
+	ch := make(chan Record, buffer)
+	sub := &Subscription{C: ch, ch: ch}
+	s.subscribers[sub] = struct{}{}
+
+	return backlog, sub
+}
+
+// Unsubscribe stops delivery and closes the subscription's channel
+func (s *Store) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// publishLocked hands a new record to every subscriber without blocking
+func (s *Store) publishLocked(record Record) {
+	for sub := range s.subscribers {
+		select {
+		case sub.ch <- record:
+		default:
+			sub.dropped = true
+			delete(s.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Get returns the record for an alert ID
+func (s *Store) Get(id string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return Record{}, false
+	}
+	s.expireLocked(record, s.now())
+	return *record, true
+}
+
+// Resolve marks an active alert resolved. It returns false if the alert is
+// unknown or no longer active.
+func (s *Store) Resolve(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return false
+	}
+
+	now := s.now()
+	s.expireLocked(record, now)
+	if record.Status != StatusActive {
+		return false
+	}
+
+	record.Status = StatusResolved
+	record.ResolvedAt = now
+	return true
+}
+
+// MarkHandled records that by acted on an alert; by is empty for this node.
+// An ack for an unknown alert is kept and applied once the alert is added.
+// It returns false if the alert was already handled or no longer active.
+func (s *Store) MarkHandled(id, by string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		if _, pending := s.acks[id]; pending {
+			return false
+		}
+		s.acks[id] = by
+		s.ackOrder = append(s.ackOrder, id)
+		for len(s.ackOrder) > s.maxAlerts {
+			delete(s.acks, s.ackOrder[0])
+			s.ackOrder = s.ackOrder[1:]
+		}
+		return true
+	}
+
+	now := s.now()
+	s.expireLocked(record, now)
+	if record.Status != StatusActive {
+		return false
+	}
+
+	record.Status = StatusHandled
+	record.ResolvedAt = now
+	record.HandledBy = by
+	return true
+}
+
+// SetMinedAt records when an alert's transaction was mined. The alert is
+// replaced rather than modified, since readers may hold the old one. It
+// returns false if the alert is unknown or carries no detection timing.
+func (s *Store) SetMinedAt(id string, minedAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[id]
+	if !ok || record.Alert.Timing == nil {
+		return false
+	}
+
+	timing := *record.Alert.Timing
+	timing.MinedAt = &minedAt
+	alert := *record.Alert
+	alert.Timing = &timing
+	record.Alert = &alert
+	return true
+}
+
+// IsHandled reports whether an alert, stored or not yet seen, was acted on
+func (s *Store) IsHandled(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[id]; ok {
+		return record.Status == StatusHandled
+	}
+	_, ok := s.acks[id]
+	return ok
+}
+
+// Active returns unresolved, unexpired alerts, oldest first
+func (s *Store) Active() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	active := make([]Record, 0)
+	for _, id := range s.order {
+		record := s.records[id]
+		s.expireLocked(record, now)
+		if record.Status == StatusActive {
+			active = append(active, *record)
+		}
+	}
+	return active
+}
+
+// Expire resolves every active alert past its TTL and returns how many
+// expired
+func (s *Store) Expire() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	expired := 0
+	for _, record := range s.records {
+		if s.expireLocked(record, now) {
+			expired++
+		}
+	}
+	return expired
+}
+
+// expireLocked marks an active record expired once its TTL has elapsed and
+// reports whether it did so
+func (s *Store) expireLocked(record *Record, now time.Time) bool {
+	if record.Status != StatusActive {
+		return false
+	}
+
+	ttl := s.ttls[record.Alert.Level]
+	if ttl <= 0 {
+		return false
+	}
+
+	expiresAt := record.CreatedAt.Add(ttl)
+	if now.Before(expiresAt) {
+		return false
+	}
+
+	record.Status = StatusExpired
+	record.ResolvedAt = expiresAt
+	return true
+}
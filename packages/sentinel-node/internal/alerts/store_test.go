@@ -0,0 +1,234 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// newTestStore returns a store whose clock is advanced through the returned pointer
+func newTestStore(cfg Config) (*Store, *time.Time) {
+	store := NewStore(cfg)
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestStore_LowAlertExpiresCriticalPersists(t *testing.T) {
+	store, now := newTestStore(Config{
+		TTLs: map[types.AlertLevel]time.Duration{
+			types.AlertLevelLow:      10 * time.Minute,
+			types.AlertLevelCritical: 0,
+		},
+	})
+
+	store.Add(&types.Alert{ID: "low-1", Level: types.AlertLevelLow})
+	store.Add(&types.Alert{ID: "critical-1", Level: types.AlertLevelCritical})
+
+	*now = now.Add(9 * time.Minute)
+	if active := store.Active(); len(active) != 2 {
+		t.Fatalf("Expected 2 active alerts before TTL, got %d", len(active))
+	}
+
+	*now = now.Add(2 * time.Minute)
+	active := store.Active()
+	if len(active) != 1 || active[0].Alert.ID != "critical-1" {
+		t.Fatalf("Expected only the critical alert to remain active, got %+v", active)
+	}
+
+	record, ok := store.Get("low-1")
+	if !ok {
+		t.Fatal("Expired alert should still be retrievable")
+	}
+	if record.Status != StatusExpired {
+		t.Errorf("Expected status %s, got %s", StatusExpired, record.Status)
+	}
+	if want := time.Unix(1700000000, 0).Add(10 * time.Minute); !record.ResolvedAt.Equal(want) {
+		t.Errorf("Expected ResolvedAt %v, got %v", want, record.ResolvedAt)
+	}
+
+	// Critical alerts never expire on their own
+	*now = now.Add(30 * 24 * time.Hour)
+	if record, _ := store.Get("critical-1"); record.Status != StatusActive {
+		t.Errorf("Expected critical alert to stay active, got %s", record.Status)
+	}
+}
+
+func TestStore_Expire(t *testing.T) {
+	store, now := newTestStore(Config{})
+
+	store.Add(&types.Alert{ID: "low-1", Level: types.AlertLevelLow})
+	store.Add(&types.Alert{ID: "medium-1", Level: types.AlertLevelMedium})
+	store.Add(&types.Alert{ID: "high-1", Level: types.AlertLevelHigh})
+
+	*now = now.Add(DefaultTTLs[types.AlertLevelMedium])
+	if expired := store.Expire(); expired != 2 {
+		t.Errorf("Expected 2 alerts to expire, got %d", expired)
+	}
+	if expired := store.Expire(); expired != 0 {
+		t.Errorf("Expected no further expirations, got %d", expired)
+	}
+}
+
+func TestStore_ResolveBeforeExpiry(t *testing.T) {
+	store, now := newTestStore(Config{})
+	store.Add(&types.Alert{ID: "low-1", Level: types.AlertLevelLow})
+
+	if !store.Resolve("low-1") {
+		t.Fatal("Expected active alert to resolve")
+	}
+
+	*now = now.Add(time.Hour)
+	record, _ := store.Get("low-1")
+	if record.Status != StatusResolved {
+		t.Errorf("Resolved alert should not be re-marked as expired, got %s", record.Status)
+	}
+	if store.Resolve("low-1") {
+		t.Error("Resolving an already resolved alert should report false")
+	}
+	if store.Resolve("missing") {
+		t.Error("Resolving an unknown alert should report false")
+	}
+}
+
+func TestStore_DuplicateAndCapacity(t *testing.T) {
+	store, _ := newTestStore(Config{MaxAlerts: 2})
+
+	first := store.Add(&types.Alert{ID: "a", Level: types.AlertLevelLow, Message: "first"})
+	again := store.Add(&types.Alert{ID: "a", Level: types.AlertLevelLow, Message: "second"})
+	if again.Alert.Message != first.Alert.Message {
+		t.Error("Re-adding an alert ID should keep the original record")
+	}
+
+	store.Add(&types.Alert{ID: "b", Level: types.AlertLevelLow})
+	store.Add(&types.Alert{ID: "c", Level: types.AlertLevelLow})
+
+	if _, ok := store.Get("a"); ok {
+		t.Error("Expected oldest alert to be evicted at capacity")
+	}
+	if len(store.Active()) != 2 {
+		t.Errorf("Expected 2 alerts at capacity, got %d", len(store.Active()))
+	}
+}
+
+func TestStore_SubscribeReceivesNewAlerts(t *testing.T) {
+	store, _ := newTestStore(Config{})
+
+	store.Add(&types.Alert{ID: "before", Level: types.AlertLevelHigh})
+	backlog, sub := store.Subscribe(0, 4)
+	defer store.Unsubscribe(sub)
+
+	if len(backlog) != 0 {
+		t.Errorf("Expected no backlog without a resume point, got %d", len(backlog))
+	}
+
+	added := store.Add(&types.Alert{ID: "after", Level: types.AlertLevelHigh})
+	select {
+	case record := <-sub.C:
+		if record.Alert.ID != "after" || record.Seq != added.Seq {
+			t.Errorf("Unexpected record %s (seq %d)", record.Alert.ID, record.Seq)
+		}
+	default:
+		t.Fatal("Expected the new alert to be delivered")
+	}
+
+	// Duplicates aren't new alerts
+	store.Add(&types.Alert{ID: "after", Level: types.AlertLevelHigh})
+	if len(sub.C) != 0 {
+		t.Error("Re-adding a stored alert should not be delivered again")
+	}
+}
+
+func TestStore_SubscribeResumesAfterSeq(t *testing.T) {
+	store, _ := newTestStore(Config{})
+
+	var seqs []uint64
+	for _, id := range []string{"a", "b", "c"} {
+		seqs = append(seqs, store.Add(&types.Alert{ID: id, Level: types.AlertLevelHigh}).Seq)
+	}
+
+	backlog, sub := store.Subscribe(seqs[0], 4)
+	defer store.Unsubscribe(sub)
+
+	if len(backlog) != 2 || backlog[0].Alert.ID != "b" || backlog[1].Alert.ID != "c" {
+		t.Errorf("Expected alerts b and c after seq %d, got %+v", seqs[0], backlog)
+	}
+}
+
+func TestStore_SlowSubscriberDropped(t *testing.T) {
+	store, _ := newTestStore(Config{})
+
+	_, slow := store.Subscribe(0, 2)
+	for _, id := range []string{"a", "b", "c"} {
+		store.Add(&types.Alert{ID: id, Level: types.AlertLevelHigh})
+	}
+
+	received := 0
+	for range slow.C {
+		received++
+	}
+	if received != 2 || !slow.Dropped() {
+		t.Errorf("Expected subscriber dropped after 2 buffered alerts, got %d (dropped %v)", received, slow.Dropped())
+	}
+
+	// Unsubscribing after a drop is harmless
+	store.Unsubscribe(slow)
+}
+
+func TestStore_MarkHandled(t *testing.T) {
+	store := NewStore(Config{})
+
+	store.Add(&types.Alert{ID: "seen", Level: types.AlertLevelHigh})
+	if !store.MarkHandled("seen", "peer-1") {
+		t.Fatal("Active alert should be marked handled")
+	}
+	if store.MarkHandled("seen", "peer-2") {
+		t.Error("Handled alert should not be marked again")
+	}
+	record, _ := store.Get("seen")
+	if record.Status != StatusHandled || record.HandledBy != "peer-1" {
+		t.Errorf("Expected handled by peer-1, got %s by %q", record.Status, record.HandledBy)
+	}
+	if len(store.Active()) != 0 {
+		t.Error("Handled alert should not be active")
+	}
+
+	// An ack can arrive before this node sees the alert
+	store.MarkHandled("early", "peer-1")
+	if !store.IsHandled("early") {
+		t.Error("Early ack should mark the unseen alert handled")
+	}
+	if added := store.Add(&types.Alert{ID: "early", Level: types.AlertLevelHigh}); added.Status != StatusHandled {
+		t.Errorf("Alert added after its ack should be handled, got %s", added.Status)
+	}
+}
+
+func TestStore_SetMinedAt(t *testing.T) {
+	store := NewStore(Config{})
+
+	detected := time.Unix(1700000000, 0)
+	alert := &types.Alert{
+		ID:     "timed",
+		Level:  types.AlertLevelHigh,
+		Timing: &types.DetectionTiming{ReceivedAt: detected.Add(-time.Second), DetectedAt: detected},
+	}
+	store.Add(alert)
+	store.Add(&types.Alert{ID: "peer", Level: types.AlertLevelHigh})
+
+	mined := detected.Add(12 * time.Second)
+	if !store.SetMinedAt("timed", mined) {
+		t.Fatal("Expected mined time recorded for a timed alert")
+	}
+	record, _ := store.Get("timed")
+	if lead, ok := record.Alert.Timing.LeadTime(); !ok || lead != 12*time.Second {
+		t.Errorf("Expected 12s lead time, got %v (%v)", lead, ok)
+	}
+	if alert.Timing.MinedAt != nil {
+		t.Error("The originally added alert should not be modified")
+	}
+
+	if store.SetMinedAt("peer", mined) || store.SetMinedAt("unknown", mined) {
+		t.Error("Expected alerts without timing, or unknown, to be rejected")
+	}
+}
@@ -8,49 +8,281 @@ import (
 )
 
 type Config struct {
-	Node      NodeConfig      `mapstructure:"node"`
-	Ethereum  EthereumConfig  `mapstructure:"ethereum"`
-	P2P       P2PConfig       `mapstructure:"p2p"`
-	Inference InferenceConfig `mapstructure:"inference"`
-	Contracts ContractConfig  `mapstructure:"contracts"`
-	Logging   LoggingConfig   `mapstructure:"logging"`
+	Node       NodeConfig       `mapstructure:"node"`
+	Ethereum   EthereumConfig   `mapstructure:"ethereum"`
+	P2P        P2PConfig        `mapstructure:"p2p"`
+	Inference  InferenceConfig  `mapstructure:"inference"`
+	Contracts  ContractConfig   `mapstructure:"contracts"`
+	Verifier   VerifierConfig   `mapstructure:"verifier"`
+	ResultSink ResultSinkConfig `mapstructure:"resultSink"`
+	Alerts     AlertsConfig     `mapstructure:"alerts"`
+	Logging    LoggingConfig    `mapstructure:"logging"`
 }
 
 type NodeConfig struct {
-	Name           string        `mapstructure:"name"`
-	DataDir        string        `mapstructure:"dataDir"`
-	PrivateKeyPath string        `mapstructure:"privateKeyPath"`
-	BLSKeyPath     string        `mapstructure:"blsKeyPath"`
-	MetricsPort    int           `mapstructure:"metricsPort"`
-	APIPort        int           `mapstructure:"apiPort"`
+	Name            string        `mapstructure:"name"`
+	DataDir         string        `mapstructure:"dataDir"`
+	PrivateKeyPath  string        `mapstructure:"privateKeyPath"`
+	BLSKeyPath      string        `mapstructure:"blsKeyPath"`
+	MetricsPort     int           `mapstructure:"metricsPort"`
+	APIPort         int           `mapstructure:"apiPort"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdownTimeout"`
+	// AdminToken authorizes admin API calls (Bearer token); empty disables them
+	AdminToken string `mapstructure:"adminToken"`
+	// PauseMode is "drop" or "drain": what happens to transactions while analysis is paused
+	PauseMode        string `mapstructure:"pauseMode"`
+	PauseBacklogSize int    `mapstructure:"pauseBacklogSize"`
+	// RecentBufferSize is how many recently analyzed transactions GET /recent keeps
+	RecentBufferSize int `mapstructure:"recentBufferSize"`
+	// BLSKeyPassphrase encrypts the BLS key file at rest; empty keeps it in plaintext
+	BLSKeyPassphrase string `mapstructure:"blsKeyPassphrase"`
+
+	// OperatorAddress is this node's registered address; it signs
+	// operator-initiated pause requests (POST /pause)
+	OperatorAddress string `mapstructure:"operatorAddress"`
+	// MinPausePeers is how many active peers are needed before a pause
+	// request is broadcast (0 = no check)
+	MinPausePeers int `mapstructure:"minPausePeers"`
+	// PauseQuorum is how many distinct signers complete a broadcast pause
+	// request; one short of it after PauseRequestTimeout is abandoned
+	PauseQuorum         int           `mapstructure:"pauseQuorum"`
+	PauseRequestTimeout time.Duration `mapstructure:"pauseRequestTimeout"`
+	// PauseStakeFraction, when above 0, completes broadcast pause requests
+	// once signers holding this share of registered stake have signed,
+	// instead of at PauseQuorum; it needs contracts.registryAddress
+	PauseStakeFraction float64 `mapstructure:"pauseStakeFraction"`
+
+	// A peer's pause request is co-signed only if this node raised an alert
+	// against the same protocol scoring at least CoSignMinScore within the
+	// last CoSignWindow
+	CoSignMinScore float64       `mapstructure:"coSignMinScore"`
+	CoSignWindow   time.Duration `mapstructure:"coSignWindow"`
+
+	// AutoPause has critical alerts create pause requests against their
+	// target, at most one per target every AutoPauseCooldown
+	AutoPause         bool          `mapstructure:"autoPause"`
+	AutoPauseCooldown time.Duration `mapstructure:"autoPauseCooldown"`
 }
 
 type EthereumConfig struct {
 	RPCURL             string        `mapstructure:"rpcUrl"`
 	WSURL              string        `mapstructure:"wsUrl"`
-	FlashbotsRPCURL    string        `mapstructure:"flashbotsRpcUrl"`  // FIX: MEV protection
+	FlashbotsRPCURL    string        `mapstructure:"flashbotsRpcUrl"` // FIX: MEV protection
 	ChainID            int64         `mapstructure:"chainId"`
 	BlockConfirmations int           `mapstructure:"blockConfirmations"`
 	TxTimeout          time.Duration `mapstructure:"txTimeout"`
 	MaxGasPrice        int64         `mapstructure:"maxGasPrice"`
 	UseMEVProtection   bool          `mapstructure:"useMevProtection"` // FIX: Enable MEV protection
+
+	// FetchWorkers bounds the pending transaction lookups in flight
+	FetchWorkers int `mapstructure:"fetchWorkers"`
+	// SubscriptionMethod is "hashes", or "geth" or "alchemy" for providers
+	// that push full pending transactions
+	SubscriptionMethod string `mapstructure:"subscriptionMethod"`
+
+	// RPCURLs and WSURLs are fallback endpoints the mempool listener fails
+	// over to behind RPCURL and WSURL
+	RPCURLs []string `mapstructure:"rpcUrls"`
+	WSURLs  []string `mapstructure:"wsUrls"`
+
+	// WatchAddresses, if set, restricts analysis to transactions sent to
+	// these addresses; IgnoreAddresses drops transactions sent to them
+	WatchAddresses  []string `mapstructure:"watchAddresses"`
+	IgnoreAddresses []string `mapstructure:"ignoreAddresses"`
+	// MinTxValue (wei) and MinTxGas drop smaller pending transactions
+	MinTxValue string `mapstructure:"minTxValue"`
+	MinTxGas   uint64 `mapstructure:"minTxGas"`
+
+	// SeenCacheSize and SeenCacheTTL bound the pending transaction hashes
+	// remembered so repeats aren't fetched again
+	SeenCacheSize int           `mapstructure:"seenCacheSize"`
+	SeenCacheTTL  time.Duration `mapstructure:"seenCacheTTL"`
 }
 
 type P2PConfig struct {
-	ListenAddresses []string      `mapstructure:"listenAddresses"`
-	BootstrapPeers  []string      `mapstructure:"bootstrapPeers"`
-	MaxPeers        int           `mapstructure:"maxPeers"`
-	TopicName       string        `mapstructure:"topicName"`
+	ListenAddresses   []string      `mapstructure:"listenAddresses"`
+	BootstrapPeers    []string      `mapstructure:"bootstrapPeers"`
+	MaxPeers          int           `mapstructure:"maxPeers"`
+	TopicName         string        `mapstructure:"topicName"`
 	HeartbeatInterval time.Duration `mapstructure:"heartbeatInterval"`
+	// EvidenceTopic carries pause request evidence on demand (empty = disabled)
+	EvidenceTopic string `mapstructure:"evidenceTopic"`
+	// Topics lists the topic classes joined under the TopicName prefix:
+	// "alerts", "pause-consensus" and "heartbeat" (empty = all)
+	Topics []string `mapstructure:"topics"`
+	// Peer reputation decay and the score at which a peer's messages are dropped
+	ReputationHalfLife       time.Duration `mapstructure:"reputationHalfLife"`
+	ReputationBlockThreshold float64       `mapstructure:"reputationBlockThreshold"`
+	// MaxOutboundBytesPerSec caps outbound gossip; alerts and heartbeats are
+	// shed before pause requests (0 = unlimited)
+	MaxOutboundBytesPerSec int64 `mapstructure:"maxOutboundBytesPerSec"`
+	// Replay protection remembers this many handled messages for SeenCacheTTL
+	SeenCacheSize int           `mapstructure:"seenCacheSize"`
+	SeenCacheTTL  time.Duration `mapstructure:"seenCacheTTL"`
+	// TopicPolicies restricts the message types handled and published,
+	// keyed by topic name
+	TopicPolicies map[string]TopicPolicyConfig `mapstructure:"topicPolicies"`
+	// Inbound messages older than MaxMessageAge or further ahead than
+	// MaxClockSkew are dropped; MaxClockSkew also extends MaxMessageAge
+	MaxMessageAge time.Duration `mapstructure:"maxMessageAge"`
+	MaxClockSkew  time.Duration `mapstructure:"maxClockSkew"`
+	// MeasureClockSkew warns when a peer's heartbeats show its clock is
+	// off by more than MaxClockSkew
+	MeasureClockSkew bool `mapstructure:"measureClockSkew"`
+	// Inbound messages per second and burst allowed from each peer
+	// (0 = unlimited)
+	PeerMessagesPerSec float64 `mapstructure:"peerMessagesPerSec"`
+	PeerMessageBurst   int     `mapstructure:"peerMessageBurst"`
+	// WireFormat encodes outbound gossip: "json" or "binary"
+	WireFormat string `mapstructure:"wireFormat"`
+	// Compression for outbound gossip: "none", "gzip" or "zstd"
+	Compression string `mapstructure:"compression"`
+	// MaxMessageBytes caps an encoded gossip message; larger ones are
+	// dropped before decoding
+	MaxMessageBytes int `mapstructure:"maxMessageBytes"`
+	// Peers whose reputation falls to BanThreshold are disconnected and
+	// refused for BanDuration (0 = reputationBlockThreshold)
+	BanThreshold float64       `mapstructure:"banThreshold"`
+	BanDuration  time.Duration `mapstructure:"banDuration"`
+	// A ban also needs EvictionMinViolations within EvictionWindow, the
+	// earliest at least EvictionGracePeriod old, and is skipped within
+	// EvictionCooldown of the peer's previous ban expiring
+	EvictionWindow        time.Duration `mapstructure:"evictionWindow"`
+	EvictionMinViolations int           `mapstructure:"evictionMinViolations"`
+	EvictionGracePeriod   time.Duration `mapstructure:"evictionGracePeriod"`
+	EvictionCooldown      time.Duration `mapstructure:"evictionCooldown"`
+	// PersistPeers remembers recently seen peers under node.dataDir and
+	// redials them on restart
+	PersistPeers        bool          `mapstructure:"persistPeers"`
+	MaxPersistedPeers   int           `mapstructure:"maxPersistedPeers"`
+	MaxPersistedPeerAge time.Duration `mapstructure:"maxPersistedPeerAge"`
+	// EnableMDNS discovers other nodes on the local network; meant for
+	// development clusters
+	EnableMDNS bool `mapstructure:"enableMdns"`
+	// EnableDHT finds other sentinels through a Kademlia DHT seeded from
+	// the bootstrap peers
+	EnableDHT bool `mapstructure:"enableDht"`
+	// HandlerQueueDepth is how many received messages per topic wait for
+	// handlers before further ones are dropped
+	HandlerQueueDepth int `mapstructure:"handlerQueueDepth"`
+	// Fewer than MinHealthyPeers active peers is logged as an unhealthy
+	// mesh; recovering needs PeerCountHysteresis more (0 = not tracked)
+	MinHealthyPeers     int `mapstructure:"minHealthyPeers"`
+	PeerCountHysteresis int `mapstructure:"peerCountHysteresis"`
+}
+
+// TopicPolicyConfig lists message types by name; an empty allow list
+// permits every type that is not denied
+type TopicPolicyConfig struct {
+	InboundAllow  []string `mapstructure:"inboundAllow"`
+	InboundDeny   []string `mapstructure:"inboundDeny"`
+	OutboundAllow []string `mapstructure:"outboundAllow"`
+	OutboundDeny  []string `mapstructure:"outboundDeny"`
 }
 
 type InferenceConfig struct {
-	GRPCAddress     string        `mapstructure:"grpcAddress"`
-	Timeout         time.Duration `mapstructure:"timeout"`
-	BatchSize       int           `mapstructure:"batchSize"`
-	EnableSimulation bool         `mapstructure:"enableSimulation"`
-	AnomalyThreshold float64      `mapstructure:"anomalyThreshold"`
+	GRPCAddress      string        `mapstructure:"grpcAddress"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	BatchSize        int           `mapstructure:"batchSize"`
+	EnableSimulation bool          `mapstructure:"enableSimulation"`
+	AnomalyThreshold float64       `mapstructure:"anomalyThreshold"`
+	// Token addresses with shallow liquidity; swaps through them are flagged
+	ThinLiquidityTokens []string `mapstructure:"thinLiquidityTokens"`
+	// Approval spenders that are never / always flagged on unlimited approvals
+	TrustedSpenders []string `mapstructure:"trustedSpenders"`
+	FlaggedSpenders []string `mapstructure:"flaggedSpenders"`
+	// Window and size for detecting coordinated freshly-funded senders
+	ClusterWindow     time.Duration `mapstructure:"clusterWindow"`
+	ClusterMinMembers int           `mapstructure:"clusterMinMembers"`
+	// Send decoded calldata for known selectors alongside the raw input
+	DecodeCalldata bool `mapstructure:"decodeCalldata"`
+	// ABIDir holds extra ABI files to decode calldata with; relative paths
+	// are under node.dataDir (empty = built-in ABIs only)
+	ABIDir string `mapstructure:"abiDir"`
+	// Trace flagged transactions with debug_traceCall (provider must support it)
+	EnableTracing      bool     `mapstructure:"enableTracing"`
+	TraceDeepCallDepth int      `mapstructure:"traceDeepCallDepth"`
+	SensitiveContracts []string `mapstructure:"sensitiveContracts"`
+	// Redundant inference servers; when set, grpcAddress is ignored and a
+	// transaction is suspicious only if quorum of them agree (0 = majority)
+	GRPCAddresses []string `mapstructure:"grpcAddresses"`
+	Quorum        int      `mapstructure:"quorum"`
+	// LoadBalance sends each transaction to one of grpcAddresses, the least
+	// loaded healthy one, instead of asking a quorum
+	LoadBalance bool `mapstructure:"loadBalance"`
+	// Classify ERC20 transfers, bounded approvals to trusted spenders and
+	// these extra selectors as low risk without calling the server
+	EnableSafeSelectors bool     `mapstructure:"enableSafeSelectors"`
+	SafeSelectors       []string `mapstructure:"safeSelectors"`
+	// Smooth each sender's scores so an escalating run of borderline
+	// transactions is flagged (escalating_sender_pattern)
+	EnableSenderHistory     bool          `mapstructure:"enableSenderHistory"`
+	SenderHistoryAlpha      float64       `mapstructure:"senderHistoryAlpha"`
+	SenderHistoryHalfLife   time.Duration `mapstructure:"senderHistoryHalfLife"`
+	SenderHistoryMaxSenders int           `mapstructure:"senderHistoryMaxSenders"`
+	// Minimum score of contract calls with an unrecognised selector, flagged
+	// unclassified_contract_call (0 = off)
+	UnclassifiedCallScore float64 `mapstructure:"unclassifiedCallScore"`
+	// EnricherTimeout bounds each enricher run on a flagged transaction;
+	// one that overruns is skipped
+	EnricherTimeout time.Duration `mapstructure:"enricherTimeout"`
+	// Reuse results for transactions seen again within the TTL, e.g. echoed
+	// by several providers; fallback results only for ResultCacheFallbackTTL
+	// (size 0 = off)
+	ResultCacheSize        int           `mapstructure:"resultCacheSize"`
+	ResultCacheTTL         time.Duration `mapstructure:"resultCacheTTL"`
+	ResultCacheFallbackTTL time.Duration `mapstructure:"resultCacheFallbackTTL"`
+	// TLS secures the inference server connection; without it the node
+	// only connects in plaintext if AllowInsecure is set
+	TLS           InferenceTLSConfig `mapstructure:"tls"`
+	AllowInsecure bool               `mapstructure:"allowInsecure"`
+	// Streaming sends transactions over one long-lived stream per server
+	// instead of a call each, falling back to calls if the stream fails
+	Streaming bool `mapstructure:"streaming"`
+	// SelectorRulesFile maps selectors to heuristic indicators and scores;
+	// relative paths are under node.dataDir. It is reloaded on SIGHUP and,
+	// every SelectorRulesPollInterval, when it changes (0 = SIGHUP only).
+	SelectorRulesFile         string        `mapstructure:"selectorRulesFile"`
+	SelectorRulesPollInterval time.Duration `mapstructure:"selectorRulesPollInterval"`
+	// HeuristicWeights tune the fallback detector's scores
+	HeuristicWeights HeuristicWeightsConfig `mapstructure:"heuristicWeights"`
+	// SandwichWindow is how far apart an attacker's swaps and the victim's
+	// may arrive to be flagged sandwich_suspected
+	SandwichWindow time.Duration `mapstructure:"sandwichWindow"`
+	// ReentrancyRepeats is how many calls to one function of one contract a
+	// multicall needs to be flagged reentrancy_suspected;
+	// ReentrancyWithdrawRepeats applies to withdraw, redeem and borrow calls
+	ReentrancyRepeats         int `mapstructure:"reentrancyRepeats"`
+	ReentrancyWithdrawRepeats int `mapstructure:"reentrancyWithdrawRepeats"`
+}
+
+// HeuristicWeightsConfig are the score contributions of the fallback
+// heuristics, the cap on their total and the lowest score at each risk level
+type HeuristicWeightsConfig struct {
+	FlashLoan         float64 `mapstructure:"flashLoan"`
+	HighSlippageSwap  float64 `mapstructure:"highSlippageSwap"`
+	HighGasLimit      float64 `mapstructure:"highGasLimit"`
+	LargeValue        float64 `mapstructure:"largeValue"`
+	ContractCreation  float64 `mapstructure:"contractCreation"`
+	LargeCalldata     float64 `mapstructure:"largeCalldata"`
+	Reentrancy        float64 `mapstructure:"reentrancy"`
+	MaxScore          float64 `mapstructure:"maxScore"`
+	CriticalThreshold float64 `mapstructure:"criticalThreshold"`
+	HighThreshold     float64 `mapstructure:"highThreshold"`
+	MediumThreshold   float64 `mapstructure:"mediumThreshold"`
+}
+
+// InferenceTLSConfig configures TLS, and optionally mutual TLS, to the
+// inference server
+type InferenceTLSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CAFile verifies the server certificate (empty = system roots)
+	CAFile string `mapstructure:"caFile"`
+	// Client certificate presented for mutual TLS
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
+	// ServerName overrides the name checked against the server certificate
+	ServerName string `mapstructure:"serverName"`
 }
 
 type ContractConfig struct {
@@ -60,6 +292,65 @@ type ContractConfig struct {
 	RouterAddress   common.Address `mapstructure:"routerAddress"`
 }
 
+// VerifierConfig selects how peer-message checks behave when their lookups
+// fail: "closed" rejects the message, "open" accepts it
+type VerifierConfig struct {
+	RegistrationFailurePolicy string `mapstructure:"registrationFailurePolicy"`
+	PauseRequestFailurePolicy string `mapstructure:"pauseRequestFailurePolicy"`
+	// MinPeerStake is the minimum on-chain stake in wei a registered peer
+	// needs for its alerts and pause signatures to count (empty or 0 = off)
+	MinPeerStake string `mapstructure:"minPeerStake"`
+}
+
+// ResultSinkConfig exports analyzed transactions and their results for
+// offline model training
+type ResultSinkConfig struct {
+	Enabled    bool    `mapstructure:"enabled"`
+	Type       string  `mapstructure:"type"`
+	Path       string  `mapstructure:"path"`
+	SampleRate float64 `mapstructure:"sampleRate"`
+	BufferSize int     `mapstructure:"bufferSize"`
+}
+
+// AlertsConfig sets how long unresolved alerts of each level stay active
+// before auto-expiring (zero means the level never expires) and how the
+// value a transaction moves escalates its alert level.
+type AlertsConfig struct {
+	LowTTL      time.Duration `mapstructure:"lowTTL"`
+	MediumTTL   time.Duration `mapstructure:"mediumTTL"`
+	HighTTL     time.Duration `mapstructure:"highTTL"`
+	CriticalTTL time.Duration `mapstructure:"criticalTTL"`
+	// Escalation raises an alert's level by the USD value its transaction
+	// moves, priced from TokenPrices (key "eth" for the native value)
+	Escalation  []EscalationTierConfig      `mapstructure:"escalation"`
+	TokenPrices map[string]TokenPriceConfig `mapstructure:"tokenPrices"`
+	// BroadcastRetries is how many times a failed alert broadcast is
+	// retried, keyed by level, backing off from BroadcastRetryBackoff.
+	// Alerts still undelivered are posted to NotifyWebhook (empty = log only).
+	BroadcastRetries      map[string]int `mapstructure:"broadcastRetries"`
+	BroadcastRetryBackoff time.Duration  `mapstructure:"broadcastRetryBackoff"`
+	NotifyWebhook         string         `mapstructure:"notifyWebhook"`
+	// ArchiveFile keeps every alert raised or received for post-incident
+	// review; relative paths are under node.dataDir (empty = disabled).
+	// Alerts older than ArchiveMaxAge are pruned hourly, and the oldest are
+	// dropped once the file outgrows ArchiveMaxBytes.
+	ArchiveFile     string        `mapstructure:"archiveFile"`
+	ArchiveMaxAge   time.Duration `mapstructure:"archiveMaxAge"`
+	ArchiveMaxBytes int64         `mapstructure:"archiveMaxBytes"`
+}
+
+// EscalationTierConfig raises alerts by Levels from MinUSD moved
+type EscalationTierConfig struct {
+	MinUSD float64 `mapstructure:"minUsd"`
+	Levels int     `mapstructure:"levels"`
+}
+
+// TokenPriceConfig values one whole token in USD
+type TokenPriceConfig struct {
+	USD      float64 `mapstructure:"usd"`
+	Decimals uint8   `mapstructure:"decimals"`
+}
+
 type LoggingConfig struct {
 	Level      string `mapstructure:"level"`
 	Format     string `mapstructure:"format"`
@@ -75,24 +366,121 @@ func Load(configPath string) (*Config, error) {
 	viper.SetDefault("node.metricsPort", 9090)
 	viper.SetDefault("node.apiPort", 8080)
 	viper.SetDefault("node.shutdownTimeout", 30*time.Second)
+	viper.SetDefault("node.pauseMode", "drop")
+	viper.SetDefault("node.pauseBacklogSize", 1000)
+	viper.SetDefault("node.recentBufferSize", 1000)
+	viper.SetDefault("node.minPausePeers", 1)
+	viper.SetDefault("node.pauseQuorum", 3)
+	viper.SetDefault("node.pauseRequestTimeout", 5*time.Minute)
+	viper.SetDefault("node.coSignMinScore", 0.8)
+	viper.SetDefault("node.coSignWindow", 10*time.Minute)
+	viper.SetDefault("node.autoPause", true)
+	viper.SetDefault("node.autoPauseCooldown", 30*time.Minute)
 
 	viper.SetDefault("ethereum.chainId", 1)
 	viper.SetDefault("ethereum.blockConfirmations", 1)
 	viper.SetDefault("ethereum.txTimeout", 5*time.Minute)
 	viper.SetDefault("ethereum.maxGasPrice", 500_000_000_000)
 	viper.SetDefault("ethereum.flashbotsRpcUrl", "https://relay.flashbots.net")
-	viper.SetDefault("ethereum.useMevProtection", true)  // FIX: Enable MEV protection by default
+	viper.SetDefault("ethereum.useMevProtection", true) // FIX: Enable MEV protection by default
+	viper.SetDefault("ethereum.fetchWorkers", 32)
+	viper.SetDefault("ethereum.subscriptionMethod", "hashes")
+	viper.SetDefault("ethereum.seenCacheSize", 100000)
+	viper.SetDefault("ethereum.seenCacheTTL", 10*time.Minute)
 
 	viper.SetDefault("p2p.listenAddresses", []string{"/ip4/0.0.0.0/tcp/9000"})
 	viper.SetDefault("p2p.maxPeers", 50)
-	viper.SetDefault("p2p.topicName", "sentinel/v1/alerts")
+	viper.SetDefault("p2p.topicName", "sentinel/v1")
+	viper.SetDefault("p2p.evidenceTopic", "sentinel/v1/evidence")
 	viper.SetDefault("p2p.heartbeatInterval", 10*time.Second)
+	viper.SetDefault("p2p.reputationHalfLife", time.Hour)
+	viper.SetDefault("p2p.reputationBlockThreshold", -50.0)
+	viper.SetDefault("p2p.maxOutboundBytesPerSec", 0)
+	viper.SetDefault("p2p.seenCacheSize", 10000)
+	viper.SetDefault("p2p.seenCacheTTL", 30*time.Minute)
+	viper.SetDefault("p2p.maxMessageAge", 10*time.Minute)
+	viper.SetDefault("p2p.maxClockSkew", time.Minute)
+	viper.SetDefault("p2p.measureClockSkew", true)
+	viper.SetDefault("p2p.peerMessagesPerSec", 20.0)
+	viper.SetDefault("p2p.peerMessageBurst", 50)
+	viper.SetDefault("p2p.wireFormat", "json")
+	viper.SetDefault("p2p.compression", "none")
+	viper.SetDefault("p2p.maxMessageBytes", 256<<10)
+	viper.SetDefault("p2p.banThreshold", 0.0)
+	viper.SetDefault("p2p.banDuration", time.Hour)
+	viper.SetDefault("p2p.evictionWindow", 10*time.Minute)
+	viper.SetDefault("p2p.evictionMinViolations", 3)
+	viper.SetDefault("p2p.evictionGracePeriod", 30*time.Second)
+	viper.SetDefault("p2p.evictionCooldown", 30*time.Minute)
+	viper.SetDefault("p2p.persistPeers", false)
+	viper.SetDefault("p2p.maxPersistedPeers", 100)
+	viper.SetDefault("p2p.maxPersistedPeerAge", 24*time.Hour)
+	viper.SetDefault("p2p.enableMdns", false)
+	viper.SetDefault("p2p.enableDht", false)
+	viper.SetDefault("p2p.handlerQueueDepth", 256)
+	viper.SetDefault("p2p.minHealthyPeers", 3)
+	viper.SetDefault("p2p.peerCountHysteresis", 1)
 
 	viper.SetDefault("inference.grpcAddress", "localhost:50051")
 	viper.SetDefault("inference.timeout", 300*time.Millisecond)
 	viper.SetDefault("inference.batchSize", 10)
 	viper.SetDefault("inference.enableSimulation", true)
 	viper.SetDefault("inference.anomalyThreshold", 0.65)
+	viper.SetDefault("inference.decodeCalldata", true)
+	viper.SetDefault("inference.abiDir", "")
+	viper.SetDefault("inference.loadBalance", false)
+	viper.SetDefault("inference.clusterWindow", 10*time.Minute)
+	viper.SetDefault("inference.clusterMinMembers", 3)
+	viper.SetDefault("inference.enableTracing", false)
+	viper.SetDefault("inference.enableSafeSelectors", false)
+	viper.SetDefault("inference.enricherTimeout", 500*time.Millisecond)
+	viper.SetDefault("inference.enableSenderHistory", false)
+	viper.SetDefault("inference.senderHistoryAlpha", 0.4)
+	viper.SetDefault("inference.senderHistoryHalfLife", 30*time.Minute)
+	viper.SetDefault("inference.senderHistoryMaxSenders", 100000)
+	viper.SetDefault("inference.traceDeepCallDepth", 8)
+	viper.SetDefault("inference.unclassifiedCallScore", 0.2)
+	viper.SetDefault("inference.resultCacheSize", 10000)
+	viper.SetDefault("inference.resultCacheTTL", 2*time.Minute)
+	viper.SetDefault("inference.resultCacheFallbackTTL", 5*time.Second)
+	viper.SetDefault("inference.tls.enabled", false)
+	viper.SetDefault("inference.allowInsecure", true)
+	viper.SetDefault("inference.streaming", false)
+	viper.SetDefault("inference.selectorRulesFile", "selector_rules.yaml")
+	viper.SetDefault("inference.selectorRulesPollInterval", 10*time.Second)
+	viper.SetDefault("inference.heuristicWeights.flashLoan", 0.4)
+	viper.SetDefault("inference.heuristicWeights.highSlippageSwap", 0.2)
+	viper.SetDefault("inference.heuristicWeights.highGasLimit", 0.1)
+	viper.SetDefault("inference.heuristicWeights.largeValue", 0.1)
+	viper.SetDefault("inference.heuristicWeights.contractCreation", 0.2)
+	viper.SetDefault("inference.heuristicWeights.largeCalldata", 0.1)
+	viper.SetDefault("inference.heuristicWeights.reentrancy", 0.3)
+	viper.SetDefault("inference.heuristicWeights.maxScore", 1.0)
+	viper.SetDefault("inference.heuristicWeights.criticalThreshold", 0.8)
+	viper.SetDefault("inference.heuristicWeights.highThreshold", 0.65)
+	viper.SetDefault("inference.heuristicWeights.mediumThreshold", 0.4)
+	viper.SetDefault("inference.sandwichWindow", 12*time.Second)
+	viper.SetDefault("inference.reentrancyRepeats", 3)
+	viper.SetDefault("inference.reentrancyWithdrawRepeats", 2)
+
+	viper.SetDefault("verifier.registrationFailurePolicy", "closed")
+	viper.SetDefault("verifier.pauseRequestFailurePolicy", "closed")
+
+	viper.SetDefault("resultSink.enabled", false)
+	viper.SetDefault("resultSink.type", "file")
+	viper.SetDefault("resultSink.path", "./data/analysis.jsonl")
+	viper.SetDefault("resultSink.sampleRate", 1.0)
+	viper.SetDefault("resultSink.bufferSize", 1024)
+
+	viper.SetDefault("alerts.lowTTL", 15*time.Minute)
+	viper.SetDefault("alerts.mediumTTL", time.Hour)
+	viper.SetDefault("alerts.highTTL", 6*time.Hour)
+	viper.SetDefault("alerts.criticalTTL", 0)
+	viper.SetDefault("alerts.broadcastRetries", map[string]int{"high": 2, "critical": 5})
+	viper.SetDefault("alerts.broadcastRetryBackoff", time.Second)
+	viper.SetDefault("alerts.archiveFile", "alerts.jsonl")
+	viper.SetDefault("alerts.archiveMaxAge", 30*24*time.Hour)
+	viper.SetDefault("alerts.archiveMaxBytes", 64<<20)
 
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "json")
@@ -116,13 +504,31 @@ func LoadFromEnv() (*Config, error) {
 
 	config := &Config{
 		Node: NodeConfig{
-			Name:            viper.GetString("NODE_NAME"),
-			DataDir:         viper.GetString("DATA_DIR"),
-			PrivateKeyPath:  viper.GetString("PRIVATE_KEY_PATH"),
-			BLSKeyPath:      viper.GetString("BLS_KEY_PATH"),
-			MetricsPort:     viper.GetInt("METRICS_PORT"),
-			APIPort:         viper.GetInt("API_PORT"),
-			ShutdownTimeout: viper.GetDuration("SHUTDOWN_TIMEOUT"),
+			Name:             viper.GetString("NODE_NAME"),
+			DataDir:          viper.GetString("DATA_DIR"),
+			PrivateKeyPath:   viper.GetString("PRIVATE_KEY_PATH"),
+			BLSKeyPath:       viper.GetString("BLS_KEY_PATH"),
+			MetricsPort:      viper.GetInt("METRICS_PORT"),
+			APIPort:          viper.GetInt("API_PORT"),
+			ShutdownTimeout:  viper.GetDuration("SHUTDOWN_TIMEOUT"),
+			AdminToken:       viper.GetString("ADMIN_TOKEN"),
+			PauseMode:        viper.GetString("PAUSE_MODE"),
+			PauseBacklogSize: viper.GetInt("PAUSE_BACKLOG_SIZE"),
+			RecentBufferSize: viper.GetInt("RECENT_BUFFER_SIZE"),
+			BLSKeyPassphrase: viper.GetString("BLS_KEY_PASSPHRASE"),
+
+			OperatorAddress: viper.GetString("OPERATOR_ADDRESS"),
+			MinPausePeers:   viper.GetInt("MIN_PAUSE_PEERS"),
+
+			PauseQuorum:         viper.GetInt("PAUSE_QUORUM"),
+			PauseRequestTimeout: viper.GetDuration("PAUSE_REQUEST_TIMEOUT"),
+			PauseStakeFraction:  viper.GetFloat64("PAUSE_STAKE_FRACTION"),
+
+			CoSignMinScore: viper.GetFloat64("COSIGN_MIN_SCORE"),
+			CoSignWindow:   viper.GetDuration("COSIGN_WINDOW"),
+
+			AutoPause:         viper.GetBool("AUTO_PAUSE"),
+			AutoPauseCooldown: viper.GetDuration("AUTO_PAUSE_COOLDOWN"),
 		},
 		Ethereum: EthereumConfig{
 			RPCURL:             viper.GetString("ETH_RPC_URL"),
@@ -131,20 +537,158 @@ func LoadFromEnv() (*Config, error) {
 			BlockConfirmations: viper.GetInt("BLOCK_CONFIRMATIONS"),
 			TxTimeout:          viper.GetDuration("TX_TIMEOUT"),
 			MaxGasPrice:        viper.GetInt64("MAX_GAS_PRICE"),
+
+			FetchWorkers:       viper.GetInt("ETH_FETCH_WORKERS"),
+			SubscriptionMethod: viper.GetString("ETH_SUBSCRIPTION_METHOD"),
+
+			RPCURLs: viper.GetStringSlice("ETH_RPC_URLS"),
+			WSURLs:  viper.GetStringSlice("ETH_WS_URLS"),
+
+			WatchAddresses:  viper.GetStringSlice("ETH_WATCH_ADDRESSES"),
+			IgnoreAddresses: viper.GetStringSlice("ETH_IGNORE_ADDRESSES"),
+			MinTxValue:      viper.GetString("ETH_MIN_TX_VALUE"),
+			MinTxGas:        viper.GetUint64("ETH_MIN_TX_GAS"),
+
+			SeenCacheSize: viper.GetInt("ETH_SEEN_CACHE_SIZE"),
+			SeenCacheTTL:  viper.GetDuration("ETH_SEEN_CACHE_TTL"),
 		},
 		P2P: P2PConfig{
-			ListenAddresses:   viper.GetStringSlice("P2P_LISTEN"),
-			BootstrapPeers:    viper.GetStringSlice("P2P_BOOTSTRAP"),
-			MaxPeers:          viper.GetInt("P2P_MAX_PEERS"),
-			TopicName:         viper.GetString("P2P_TOPIC"),
-			HeartbeatInterval: viper.GetDuration("P2P_HEARTBEAT"),
+			ListenAddresses:          viper.GetStringSlice("P2P_LISTEN"),
+			BootstrapPeers:           viper.GetStringSlice("P2P_BOOTSTRAP"),
+			MaxPeers:                 viper.GetInt("P2P_MAX_PEERS"),
+			TopicName:                viper.GetString("P2P_TOPIC"),
+			EvidenceTopic:            viper.GetString("P2P_EVIDENCE_TOPIC"),
+			Topics:                   viper.GetStringSlice("P2P_TOPICS"),
+			HeartbeatInterval:        viper.GetDuration("P2P_HEARTBEAT"),
+			ReputationHalfLife:       viper.GetDuration("P2P_REPUTATION_HALF_LIFE"),
+			ReputationBlockThreshold: viper.GetFloat64("P2P_REPUTATION_BLOCK_THRESHOLD"),
+			MaxOutboundBytesPerSec:   viper.GetInt64("P2P_MAX_OUTBOUND_BPS"),
+
+			SeenCacheSize: viper.GetInt("P2P_SEEN_CACHE_SIZE"),
+			SeenCacheTTL:  viper.GetDuration("P2P_SEEN_CACHE_TTL"),
+			MaxMessageAge: viper.GetDuration("P2P_MAX_MESSAGE_AGE"),
+			MaxClockSkew:  viper.GetDuration("P2P_MAX_CLOCK_SKEW"),
+
+			MeasureClockSkew: viper.GetBool("P2P_MEASURE_CLOCK_SKEW"),
+
+			PeerMessagesPerSec: viper.GetFloat64("P2P_PEER_MESSAGES_PER_SEC"),
+			PeerMessageBurst:   viper.GetInt("P2P_PEER_MESSAGE_BURST"),
+			WireFormat:         viper.GetString("P2P_WIRE_FORMAT"),
+			Compression:        viper.GetString("P2P_COMPRESSION"),
+			MaxMessageBytes:    viper.GetInt("P2P_MAX_MESSAGE_BYTES"),
+
+			BanThreshold: viper.GetFloat64("P2P_BAN_THRESHOLD"),
+			BanDuration:  viper.GetDuration("P2P_BAN_DURATION"),
+
+			EvictionWindow:        viper.GetDuration("P2P_EVICTION_WINDOW"),
+			EvictionMinViolations: viper.GetInt("P2P_EVICTION_MIN_VIOLATIONS"),
+			EvictionGracePeriod:   viper.GetDuration("P2P_EVICTION_GRACE_PERIOD"),
+			EvictionCooldown:      viper.GetDuration("P2P_EVICTION_COOLDOWN"),
+
+			PersistPeers:        viper.GetBool("P2P_PERSIST_PEERS"),
+			MaxPersistedPeers:   viper.GetInt("P2P_MAX_PERSISTED_PEERS"),
+			MaxPersistedPeerAge: viper.GetDuration("P2P_MAX_PERSISTED_PEER_AGE"),
+
+			EnableMDNS: viper.GetBool("P2P_ENABLE_MDNS"),
+			EnableDHT:  viper.GetBool("P2P_ENABLE_DHT"),
+
+			HandlerQueueDepth: viper.GetInt("P2P_HANDLER_QUEUE_DEPTH"),
+
+			MinHealthyPeers:     viper.GetInt("P2P_MIN_HEALTHY_PEERS"),
+			PeerCountHysteresis: viper.GetInt("P2P_PEER_COUNT_HYSTERESIS"),
 		},
 		Inference: InferenceConfig{
-			GRPCAddress:      viper.GetString("INFERENCE_GRPC"),
-			Timeout:          viper.GetDuration("INFERENCE_TIMEOUT"),
-			BatchSize:        viper.GetInt("INFERENCE_BATCH_SIZE"),
-			EnableSimulation: viper.GetBool("ENABLE_SIMULATION"),
-			AnomalyThreshold: viper.GetFloat64("ANOMALY_THRESHOLD"),
+			GRPCAddress:         viper.GetString("INFERENCE_GRPC"),
+			Timeout:             viper.GetDuration("INFERENCE_TIMEOUT"),
+			BatchSize:           viper.GetInt("INFERENCE_BATCH_SIZE"),
+			EnableSimulation:    viper.GetBool("ENABLE_SIMULATION"),
+			AnomalyThreshold:    viper.GetFloat64("ANOMALY_THRESHOLD"),
+			ThinLiquidityTokens: viper.GetStringSlice("THIN_LIQUIDITY_TOKENS"),
+			DecodeCalldata:      viper.GetBool("DECODE_CALLDATA"),
+			TrustedSpenders:     viper.GetStringSlice("TRUSTED_SPENDERS"),
+			FlaggedSpenders:     viper.GetStringSlice("FLAGGED_SPENDERS"),
+			ClusterWindow:       viper.GetDuration("CLUSTER_WINDOW"),
+			ClusterMinMembers:   viper.GetInt("CLUSTER_MIN_MEMBERS"),
+			EnableTracing:       viper.GetBool("ENABLE_TRACING"),
+			TraceDeepCallDepth:  viper.GetInt("TRACE_DEEP_CALL_DEPTH"),
+			SensitiveContracts:  viper.GetStringSlice("SENSITIVE_CONTRACTS"),
+			GRPCAddresses:       viper.GetStringSlice("INFERENCE_GRPC_ADDRESSES"),
+			Quorum:              viper.GetInt("INFERENCE_QUORUM"),
+			EnableSafeSelectors: viper.GetBool("ENABLE_SAFE_SELECTORS"),
+			SafeSelectors:       viper.GetStringSlice("SAFE_SELECTORS"),
+
+			EnableSenderHistory:     viper.GetBool("ENABLE_SENDER_HISTORY"),
+			SenderHistoryAlpha:      viper.GetFloat64("SENDER_HISTORY_ALPHA"),
+			SenderHistoryHalfLife:   viper.GetDuration("SENDER_HISTORY_HALF_LIFE"),
+			SenderHistoryMaxSenders: viper.GetInt("SENDER_HISTORY_MAX_SENDERS"),
+			UnclassifiedCallScore:   viper.GetFloat64("UNCLASSIFIED_CALL_SCORE"),
+			EnricherTimeout:         viper.GetDuration("ENRICHER_TIMEOUT"),
+
+			ResultCacheSize:        viper.GetInt("RESULT_CACHE_SIZE"),
+			ResultCacheTTL:         viper.GetDuration("RESULT_CACHE_TTL"),
+			ResultCacheFallbackTTL: viper.GetDuration("RESULT_CACHE_FALLBACK_TTL"),
+
+			TLS: InferenceTLSConfig{
+				Enabled:    viper.GetBool("INFERENCE_TLS_ENABLED"),
+				CAFile:     viper.GetString("INFERENCE_TLS_CA_FILE"),
+				CertFile:   viper.GetString("INFERENCE_TLS_CERT_FILE"),
+				KeyFile:    viper.GetString("INFERENCE_TLS_KEY_FILE"),
+				ServerName: viper.GetString("INFERENCE_TLS_SERVER_NAME"),
+			},
+			AllowInsecure: viper.GetBool("INFERENCE_ALLOW_INSECURE"),
+			Streaming:     viper.GetBool("INFERENCE_STREAMING"),
+
+			SelectorRulesFile:         viper.GetString("SELECTOR_RULES_FILE"),
+			SelectorRulesPollInterval: viper.GetDuration("SELECTOR_RULES_POLL_INTERVAL"),
+
+			HeuristicWeights: HeuristicWeightsConfig{
+				FlashLoan:         viper.GetFloat64("HEURISTIC_WEIGHT_FLASH_LOAN"),
+				HighSlippageSwap:  viper.GetFloat64("HEURISTIC_WEIGHT_HIGH_SLIPPAGE_SWAP"),
+				HighGasLimit:      viper.GetFloat64("HEURISTIC_WEIGHT_HIGH_GAS_LIMIT"),
+				LargeValue:        viper.GetFloat64("HEURISTIC_WEIGHT_LARGE_VALUE"),
+				ContractCreation:  viper.GetFloat64("HEURISTIC_WEIGHT_CONTRACT_CREATION"),
+				LargeCalldata:     viper.GetFloat64("HEURISTIC_WEIGHT_LARGE_CALLDATA"),
+				Reentrancy:        viper.GetFloat64("HEURISTIC_WEIGHT_REENTRANCY"),
+				MaxScore:          viper.GetFloat64("HEURISTIC_MAX_SCORE"),
+				CriticalThreshold: viper.GetFloat64("HEURISTIC_CRITICAL_THRESHOLD"),
+				HighThreshold:     viper.GetFloat64("HEURISTIC_HIGH_THRESHOLD"),
+				MediumThreshold:   viper.GetFloat64("HEURISTIC_MEDIUM_THRESHOLD"),
+			},
+			SandwichWindow: viper.GetDuration("SANDWICH_WINDOW"),
+			ABIDir:         viper.GetString("ABI_DIR"),
+			LoadBalance:    viper.GetBool("INFERENCE_LOAD_BALANCE"),
+
+			ReentrancyRepeats:         viper.GetInt("REENTRANCY_REPEATS"),
+			ReentrancyWithdrawRepeats: viper.GetInt("REENTRANCY_WITHDRAW_REPEATS"),
+		},
+		Verifier: VerifierConfig{
+			RegistrationFailurePolicy: viper.GetString("REGISTRATION_FAILURE_POLICY"),
+			PauseRequestFailurePolicy: viper.GetString("PAUSE_REQUEST_FAILURE_POLICY"),
+			MinPeerStake:              viper.GetString("MIN_PEER_STAKE"),
+		},
+		ResultSink: ResultSinkConfig{
+			Enabled:    viper.GetBool("RESULT_SINK_ENABLED"),
+			Type:       viper.GetString("RESULT_SINK_TYPE"),
+			Path:       viper.GetString("RESULT_SINK_PATH"),
+			SampleRate: viper.GetFloat64("RESULT_SINK_SAMPLE_RATE"),
+			BufferSize: viper.GetInt("RESULT_SINK_BUFFER_SIZE"),
+		},
+		Alerts: AlertsConfig{
+			LowTTL:      viper.GetDuration("ALERT_TTL_LOW"),
+			MediumTTL:   viper.GetDuration("ALERT_TTL_MEDIUM"),
+			HighTTL:     viper.GetDuration("ALERT_TTL_HIGH"),
+			CriticalTTL: viper.GetDuration("ALERT_TTL_CRITICAL"),
+			BroadcastRetries: map[string]int{
+				"low":      viper.GetInt("ALERT_BROADCAST_RETRIES_LOW"),
+				"medium":   viper.GetInt("ALERT_BROADCAST_RETRIES_MEDIUM"),
+				"high":     viper.GetInt("ALERT_BROADCAST_RETRIES_HIGH"),
+				"critical": viper.GetInt("ALERT_BROADCAST_RETRIES_CRITICAL"),
+			},
+			BroadcastRetryBackoff: viper.GetDuration("ALERT_BROADCAST_RETRY_BACKOFF"),
+			NotifyWebhook:         viper.GetString("ALERT_NOTIFY_WEBHOOK"),
+			ArchiveFile:           viper.GetString("ALERT_ARCHIVE_FILE"),
+			ArchiveMaxAge:         viper.GetDuration("ALERT_ARCHIVE_MAX_AGE"),
+			ArchiveMaxBytes:       viper.GetInt64("ALERT_ARCHIVE_MAX_BYTES"),
 		},
 		Logging: LoggingConfig{
 			Level:      viper.GetString("LOG_LEVEL"),
@@ -0,0 +1,166 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	defaultBanDuration    = time.Hour
+	defaultEvictionWindow = 10 * time.Minute
+)
+
+// evictionPolicy guards against banning a peer over a transient problem. A
+// peer below the ban threshold is only evicted once it has committed
+// minViolations violations within window, the earliest of them at least
+// grace ago, and not within cooldown of its previous ban expiring.
+type evictionPolicy struct {
+	window        time.Duration
+	minViolations int
+	grace         time.Duration
+	cooldown      time.Duration
+}
+
+// ready reports whether violations justify an eviction at now
+func (p evictionPolicy) ready(violations []Violation, now time.Time) bool {
+	var count int
+	var earliest time.Time
+	for _, v := range violations {
+		if now.Sub(v.At) > p.window {
+			continue
+		}
+		if count == 0 || v.At.Before(earliest) {
+			earliest = v.At
+		}
+		count++
+	}
+	return count >= p.minViolations && now.Sub(earliest) >= p.grace
+}
+
+// banList holds peers temporarily banned for misbehaviour. It is installed
+// as the host's connection gater, so banned peers can neither reconnect nor
+// be dialed until their ban expires.
+type banList struct {
+	mu    sync.Mutex
+	until map[peer.ID]time.Time
+	// released records when each expired ban ended, for the eviction cooldown
+	released map[peer.ID]time.Time
+
+	now func() time.Time
+}
+
+func newBanList() *banList {
+	return &banList{
+		until:    make(map[peer.ID]time.Time),
+		released: make(map[peer.ID]time.Time),
+		now:      time.Now,
+	}
+}
+
+// ban bans id for duration, extending any ban already in place
+func (b *banList) ban(id peer.ID, duration time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until := b.now().Add(duration)
+	if until.After(b.until[id]) {
+		b.until[id] = until
+	}
+}
+
+// banned reports whether id is currently banned, forgetting expired bans
+func (b *banList) banned(id peer.ID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until, ok := b.until[id]
+	if !ok {
+		return false
+	}
+	if !b.now().Before(until) {
+		delete(b.until, id)
+		b.released[id] = until
+		return false
+	}
+	return true
+}
+
+// coolingDown reports whether id's last ban ended less than cooldown ago. A
+// ban's end is the earliest the peer could have reconnected.
+func (b *banList) coolingDown(id peer.ID, cooldown time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	released, ok := b.released[id]
+	if !ok {
+		return false
+	}
+	if b.now().Sub(released) >= cooldown {
+		delete(b.released, id)
+		return false
+	}
+	return true
+}
+
+func (b *banList) InterceptPeerDial(p peer.ID) bool {
+	return !b.banned(p)
+}
+
+func (b *banList) InterceptAddrDial(p peer.ID, _ multiaddr.Multiaddr) bool {
+	return !b.banned(p)
+}
+
+// InterceptAccept allows every inbound connection; the remote peer ID is
+// only known once the connection is secured
+func (b *banList) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+func (b *banList) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return !b.banned(p)
+}
+
+func (b *banList) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// IsBanned reports whether a peer is currently banned
+func (g *GossipNode) IsBanned(id peer.ID) bool {
+	if g.bans == nil {
+		return false
+	}
+	return g.bans.banned(id)
+}
+
+// banIfBelowThreshold disconnects and bans a peer whose score has fallen to
+// the ban threshold, once the eviction policy agrees
+func (g *GossipNode) banIfBelowThreshold(id peer.ID, score float64) {
+	if g.bans == nil || score > g.banThreshold || g.bans.banned(id) {
+		return
+	}
+	if g.bans.coolingDown(id, g.eviction.cooldown) {
+		return
+	}
+	rep, _ := g.reputation.Get(id)
+	if !g.eviction.ready(rep.Violations, g.reputation.now()) {
+		return
+	}
+
+	g.bans.ban(id, g.banDuration)
+	g.logger.Warn().
+		Str("peer", id.String()).
+		Float64("score", score).
+		Dur("duration", g.banDuration).
+		Msg("Banned peer for repeated invalid messages")
+
+	if g.host != nil {
+		if err := g.host.Network().ClosePeer(id); err != nil {
+			g.logger.Debug().Err(err).Str("peer", id.String()).Msg("Failed to close banned peer")
+		}
+	}
+}
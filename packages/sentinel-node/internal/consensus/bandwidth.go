@@ -0,0 +1,147 @@
+package consensus
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxAlertDelay bounds how long an alert waits for bandwidth before it is shed
+const maxAlertDelay = time.Second
+
+// ErrBandwidthExceeded is returned when a message is shed by the outbound cap
+var ErrBandwidthExceeded = errors.New("outbound gossip bandwidth cap exceeded")
+
+// messagePriority orders gossip traffic for the outbound bandwidth cap
+type messagePriority int
+
+const (
+	// priorityLow messages are shed as soon as the cap is reached
+	priorityLow messagePriority = iota
+	// priorityNormal messages wait up to maxAlertDelay, then are shed
+	priorityNormal
+	// priorityCritical messages are always sent, borrowing against future budget
+	priorityCritical
+)
+
+// priorityFor maps a message type to its outbound priority. Pause requests
+// and their signatures drive on-chain action and are never shed.
+func priorityFor(msgType MessageType) messagePriority {
+	switch msgType {
+	case MessageTypePauseRequest, MessageTypeSignature:
+		return priorityCritical
+	case MessageTypeAlert, MessageTypeAlertAck:
+		return priorityNormal
+	default:
+		return priorityLow
+	}
+}
+
+// BandwidthStats reports outbound gossip traffic
+type BandwidthStats struct {
+	BytesSent       uint64 `json:"bytesSent"`
+	MessagesSent    uint64 `json:"messagesSent"`
+	MessagesDelayed uint64 `json:"messagesDelayed"`
+	MessagesShed    uint64 `json:"messagesShed"`
+	BytesShed       uint64 `json:"bytesShed"`
+	// RateLimit is the configured cap in bytes/sec; 0 means unlimited
+	RateLimit int64 `json:"rateLimit"`
+}
+
+// BandwidthLimiter is a token bucket capping outbound gossip bytes/sec. The
+// bucket holds up to one second of traffic.
+type BandwidthLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+	stats  BandwidthStats
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewBandwidthLimiter creates a limiter for bytesPerSec; 0 disables the cap
+// but still counts traffic
+func NewBandwidthLimiter(bytesPerSec int64) *BandwidthLimiter {
+	l := &BandwidthLimiter{
+		rate:   float64(bytesPerSec),
+		tokens: float64(bytesPerSec),
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+	l.last = l.now()
+	l.stats.RateLimit = bytesPerSec
+	return l
+}
+
+// admit accounts for size outbound bytes, blocking while a normal-priority
+// message waits for budget. It returns ErrBandwidthExceeded if the message
+// should be shed.
+func (l *BandwidthLimiter) admit(size int, priority messagePriority) error {
+	l.mu.Lock()
+
+	if l.rate <= 0 {
+		l.recordSentLocked(size)
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.refillLocked()
+
+	n := float64(size)
+	var wait time.Duration
+	if l.tokens < n {
+		switch priority {
+		case priorityCritical:
+		case priorityNormal:
+			wait = time.Duration((n - l.tokens) / l.rate * float64(time.Second))
+			if wait > maxAlertDelay {
+				l.recordShedLocked(size)
+				l.mu.Unlock()
+				return ErrBandwidthExceeded
+			}
+			l.stats.MessagesDelayed++
+		default:
+			l.recordShedLocked(size)
+			l.mu.Unlock()
+			return ErrBandwidthExceeded
+		}
+	}
+
+	// Reserve now so concurrent senders queue behind this message
+	l.tokens -= n
+	l.recordSentLocked(size)
+	l.mu.Unlock()
+
+	if wait > 0 {
+		l.sleep(wait)
+	}
+	return nil
+}
+
+func (l *BandwidthLimiter) refillLocked() {
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+}
+
+func (l *BandwidthLimiter) recordSentLocked(size int) {
+	l.stats.BytesSent += uint64(size)
+	l.stats.MessagesSent++
+}
+
+func (l *BandwidthLimiter) recordShedLocked(size int) {
+	l.stats.BytesShed += uint64(size)
+	l.stats.MessagesShed++
+}
+
+// Stats returns a snapshot of outbound traffic counters
+func (l *BandwidthLimiter) Stats() BandwidthStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,103 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter on a simulated clock that sleeping advances
+func newTestLimiter(bytesPerSec int64) (*BandwidthLimiter, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	l := NewBandwidthLimiter(bytesPerSec)
+	l.now = func() time.Time { return now }
+	l.sleep = func(d time.Duration) { now = now.Add(d) }
+	l.last = now
+	return l, &now
+}
+
+func TestBandwidthLimiter_ThrottlesSustainedBroadcast(t *testing.T) {
+	const rate = 1000
+	limiter, now := newTestLimiter(rate)
+	start := *now
+
+	// Alerts sent back to back for ten simulated seconds
+	sent := 0
+	for now.Sub(start) < 10*time.Second {
+		if err := limiter.admit(200, priorityNormal); err != nil {
+			t.Fatalf("Alert shed while it could wait for budget: %v", err)
+		}
+		sent += 200
+	}
+
+	// Throughput may exceed the cap only by the initial one-second burst
+	elapsed := now.Sub(start).Seconds()
+	if limit := rate*elapsed + rate; float64(sent) > limit {
+		t.Errorf("Sent %d bytes in %.1fs, exceeding the %d B/s cap", sent, elapsed, rate)
+	}
+
+	stats := limiter.Stats()
+	if stats.MessagesDelayed == 0 {
+		t.Error("Expected alerts to be delayed by the cap")
+	}
+	if stats.BytesSent != uint64(sent) {
+		t.Errorf("Expected %d bytes accounted, got %d", sent, stats.BytesSent)
+	}
+}
+
+func TestBandwidthLimiter_CriticalMessagesGetThrough(t *testing.T) {
+	limiter, _ := newTestLimiter(1000)
+
+	if err := limiter.admit(1000, priorityNormal); err != nil {
+		t.Fatalf("First alert should fit the burst: %v", err)
+	}
+
+	if err := limiter.admit(100, priorityLow); !errors.Is(err, ErrBandwidthExceeded) {
+		t.Errorf("Expected heartbeat to be shed, got %v", err)
+	}
+
+	// Pause requests go out even with the budget exhausted
+	for i := 0; i < 5; i++ {
+		if err := limiter.admit(500, priorityCritical); err != nil {
+			t.Fatalf("Critical message %d was shed: %v", i, err)
+		}
+	}
+
+	// Alerts behind that debt would wait too long and are shed instead
+	if err := limiter.admit(200, priorityNormal); !errors.Is(err, ErrBandwidthExceeded) {
+		t.Errorf("Expected alert to be shed behind critical traffic, got %v", err)
+	}
+
+	stats := limiter.Stats()
+	if stats.MessagesShed != 2 || stats.BytesShed != 300 {
+		t.Errorf("Expected 2 messages / 300 bytes shed, got %+v", stats)
+	}
+	if stats.MessagesSent != 6 {
+		t.Errorf("Expected 6 messages sent, got %d", stats.MessagesSent)
+	}
+}
+
+func TestBandwidthLimiter_Unlimited(t *testing.T) {
+	limiter, _ := newTestLimiter(0)
+
+	for i := 0; i < 100; i++ {
+		if err := limiter.admit(10000, priorityLow); err != nil {
+			t.Fatalf("Unlimited limiter shed a message: %v", err)
+		}
+	}
+	if stats := limiter.Stats(); stats.BytesSent != 1000000 || stats.RateLimit != 0 {
+		t.Errorf("Unexpected stats for unlimited limiter: %+v", stats)
+	}
+}
+
+func TestPriorityFor(t *testing.T) {
+	if priorityFor(MessageTypePauseRequest) != priorityCritical || priorityFor(MessageTypeSignature) != priorityCritical {
+		t.Error("Pause requests and signatures must be critical")
+	}
+	if priorityFor(MessageTypeAlert) != priorityNormal {
+		t.Error("Alerts should be normal priority")
+	}
+	if priorityFor(MessageTypeHeartbeat) != priorityLow {
+		t.Error("Heartbeats should be low priority")
+	}
+}
@@ -2,20 +2,36 @@ package consensus
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"os"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
 	ErrInvalidSignature = errors.New("invalid BLS signature")
 	ErrInvalidPublicKey = errors.New("invalid BLS public key")
 	ErrAggregationFailed = errors.New("signature aggregation failed")
+	ErrInvalidProofOfPossession = errors.New("invalid BLS proof of possession")
+	ErrDuplicateSigner = errors.New("duplicate signer in aggregation")
+	ErrInvalidSignatureLength = errors.New("invalid BLS signature length")
+	ErrHashToCurveFailed = errors.New("message could not be hashed to G1")
+)
+
+// Domain separation tags. Proofs of possession are hashed under their own
+// tag so a PoP can never be replayed as a message signature or vice versa.
+var (
+	signatureDST         = []byte("BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_")
+	proofOfPossessionDST = []byte("BLS_POP_BN254G1_XMD:SHA-256_SVDW_RO_POP_")
 )
 
 type BLSKeyPair struct {
@@ -28,7 +44,14 @@ type BLSSigner struct {
 }
 
 func NewBLSSigner(keyPath string) (*BLSSigner, error) {
-	keyPair, err := loadOrGenerateKey(keyPath)
+	return NewBLSSignerWithPassphrase(keyPath, "")
+}
+
+// NewBLSSignerWithPassphrase loads or creates the key at keyPath, encrypted
+// at rest under passphrase. A legacy unencrypted key file is re-written
+// encrypted the first time it is loaded with a passphrase.
+func NewBLSSignerWithPassphrase(keyPath, passphrase string) (*BLSSigner, error) {
+	keyPair, err := loadOrGenerateKey(keyPath, passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +66,10 @@ func GenerateKeyPair() (*BLSKeyPair, error) {
 		return nil, err
 	}
 
+	return keyPairFromPrivateKey(&privateKey), nil
+}
+
+func keyPairFromPrivateKey(privateKey *fr.Element) *BLSKeyPair {
 	_, _, _, g2Gen := bn254.Generators()
 
 	var scalar big.Int
@@ -52,35 +79,165 @@ func GenerateKeyPair() (*BLSKeyPair, error) {
 	publicKey.ScalarMultiplication(&g2Gen, &scalar)
 
 	return &BLSKeyPair{
-		PrivateKey: &privateKey,
+		PrivateKey: privateKey,
 		PublicKey:  &publicKey,
-	}, nil
+	}
 }
 
 func (s *BLSSigner) Sign(message []byte) ([]byte, error) {
-	msgPoint := hashToG1(message)
+	return s.signWithDST(message, signatureDST), nil
+}
+
+// SignForChain signs message under chainID's domain tag. Pause requests are
+// signed this way so they only verify on the chain they were made for.
+func (s *BLSSigner) SignForChain(message []byte, chainID uint64) ([]byte, error) {
+	return s.signWithDST(message, ChainDST(chainID)), nil
+}
+
+// SignForRouter signs message the way SentinelRouter checks pause
+// signatures on chain, hashing it with routerHashToG1 instead of under a
+// domain tag. The router puts the chain ID in the message itself.
+func (s *BLSSigner) SignForRouter(message []byte) ([]byte, error) {
+	msgPoint, err := routerHashToG1(message)
+	if err != nil {
+		return nil, err
+	}
+	return s.signPoint(&msgPoint), nil
+}
+
+func (s *BLSSigner) signWithDST(message, dst []byte) []byte {
+	msgPoint := hashToG1WithDST(message, dst)
+	return s.signPoint(&msgPoint)
+}
 
+func (s *BLSSigner) signPoint(msgPoint *bn254.G1Affine) []byte {
 	var scalar big.Int
 	s.keyPair.PrivateKey.BigInt(&scalar)
 
 	var signature bn254.G1Affine
-	signature.ScalarMultiplication(&msgPoint, &scalar)
+	signature.ScalarMultiplication(msgPoint, &scalar)
 
-	return signature.Marshal(), nil
+	return signature.Marshal()
 }
 
+// PublicKey returns the uncompressed G2 public key
+// (bn254.SizeOfG2AffineUncompressed = 128 bytes)
 func (s *BLSSigner) PublicKey() []byte {
 	return s.keyPair.PublicKey.Marshal()
 }
 
+// PublicKeyCompressed returns the compressed G2 public key: the x coordinate
+// with the y parity folded into the top bits (bn254.SizeOfG2AffineCompressed
+// = 64 bytes). The registry needs the uncompressed key, which the router
+// can decode.
+func (s *BLSSigner) PublicKeyCompressed() []byte {
+	compressed := s.keyPair.PublicKey.Bytes()
+	return compressed[:]
+}
+
 func (s *BLSSigner) PublicKeyHex() string {
 	return hex.EncodeToString(s.PublicKey())
 }
 
+// Zeroize overwrites the signer's private scalar. The signer must not sign
+// afterwards; the public key stays usable for verification.
+func (s *BLSSigner) Zeroize() {
+	s.keyPair.Zeroize()
+}
+
+// Zeroize overwrites the private scalar in place so key material doesn't
+// linger in process memory after shutdown
+func (kp *BLSKeyPair) Zeroize() {
+	if kp == nil || kp.PrivateKey == nil {
+		return
+	}
+	kp.PrivateKey.SetZero()
+}
+
+// PrivateKeyEqual reports whether two key pairs hold the same private key,
+// comparing in constant time
+func PrivateKeyEqual(a, b *BLSKeyPair) bool {
+	if a == nil || b == nil || a.PrivateKey == nil || b.PrivateKey == nil {
+		return false
+	}
+	aBytes := a.PrivateKey.Bytes()
+	bBytes := b.PrivateKey.Bytes()
+	return subtle.ConstantTimeCompare(aBytes[:], bBytes[:]) == 1
+}
+
 func VerifySignature(signature, message, publicKey []byte) (bool, error) {
-	var sig bn254.G1Affine
-	if err := sig.Unmarshal(signature); err != nil {
-		return false, ErrInvalidSignature
+	return verifyWithDST(signature, message, publicKey, signatureDST)
+}
+
+// VerifySignatureForChain verifies a signature made by SignForChain
+func VerifySignatureForChain(signature, message, publicKey []byte, chainID uint64) (bool, error) {
+	return verifyWithDST(signature, message, publicKey, ChainDST(chainID))
+}
+
+// VerifySignatureForRouter verifies a signature made by SignForRouter
+func VerifySignatureForRouter(signature, message, publicKey []byte) (bool, error) {
+	msgPoint, err := routerHashToG1(message)
+	if err != nil {
+		return false, err
+	}
+	return verifyPoint(signature, &msgPoint, publicKey)
+}
+
+// VerifySignatureCompressed verifies a signature against a public key in
+// the 64-byte compressed form, rejecting any other encoding
+func VerifySignatureCompressed(signature, message, compressedPubKey []byte) (bool, error) {
+	if len(compressedPubKey) != bn254.SizeOfG2AffineCompressed {
+		return false, ErrInvalidPublicKey
+	}
+	return VerifySignature(signature, message, compressedPubKey)
+}
+
+// GenerateProofOfPossession signs the signer's own public key under the PoP
+// domain tag, proving it holds the matching private key
+func (s *BLSSigner) GenerateProofOfPossession() ([]byte, error) {
+	return s.signWithDST(s.PublicKey(), proofOfPossessionDST), nil
+}
+
+// VerifyProofOfPossession checks a proof produced by GenerateProofOfPossession.
+// Keys must pass this before being aggregated; otherwise a node can register
+// a rogue key crafted to cancel out honest keys in the aggregate.
+func VerifyProofOfPossession(pubKey, proof []byte) (bool, error) {
+	return verifyWithDST(proof, pubKey, pubKey, proofOfPossessionDST)
+}
+
+// AggregateVerifiedPublicKeys aggregates public keys after checking each
+// key's proof of possession, rejecting the whole set if any proof fails
+func AggregateVerifiedPublicKeys(publicKeys, proofs [][]byte) ([]byte, error) {
+	if len(publicKeys) != len(proofs) {
+		return nil, ErrInvalidProofOfPossession
+	}
+
+	for i := range publicKeys {
+		valid, err := VerifyProofOfPossession(publicKeys[i], proofs[i])
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, ErrInvalidProofOfPossession
+		}
+	}
+
+	return AggregatePublicKeys(publicKeys)
+}
+
+// pairingCheck is bn254.PairingCheck; tests swap it to count the pairings a
+// verification runs, which is what its cost comes down to
+var pairingCheck = bn254.PairingCheck
+
+func verifyWithDST(signature, message, publicKey, dst []byte) (bool, error) {
+	msgPoint := hashToG1WithDST(message, dst)
+	return verifyPoint(signature, &msgPoint, publicKey)
+}
+
+func verifyPoint(signature []byte, msgPoint *bn254.G1Affine, publicKey []byte) (bool, error) {
+	sig, err := unmarshalSignature(signature)
+	if err != nil {
+		return false, err
 	}
 
 	var pubKey bn254.G2Affine
@@ -88,14 +245,12 @@ func VerifySignature(signature, message, publicKey []byte) (bool, error) {
 		return false, ErrInvalidPublicKey
 	}
 
-	msgPoint := hashToG1(message)
-
 	_, _, _, g2Gen := bn254.Generators()
 
 	var negMsgPoint bn254.G1Affine
-	negMsgPoint.Neg(&msgPoint)
+	negMsgPoint.Neg(msgPoint)
 
-	valid, err := bn254.PairingCheck(
+	valid, err := pairingCheck(
 		[]bn254.G1Affine{sig, negMsgPoint},
 		[]bn254.G2Affine{g2Gen, pubKey},
 	)
@@ -107,31 +262,62 @@ func VerifySignature(signature, message, publicKey []byte) (bool, error) {
 	return valid, nil
 }
 
+// AggregateSignatures sums signatures into one. A signature repeated
+// verbatim, e.g. echoed back by a peer, is only counted once.
 func AggregateSignatures(signatures [][]byte) ([]byte, error) {
+	aggSig, _, err := AggregateSignaturesWithSigners(signatures, nil)
+	return aggSig, err
+}
+
+// AggregateSignaturesWithSigners aggregates signatures, where signers[i]
+// produced signatures[i], and returns the signers actually included.
+// Duplicate signatures are dropped along with their signer; two different
+// signatures from one signer are an error. With nil signers it only dedups
+// signatures and returns no signer list.
+func AggregateSignaturesWithSigners(signatures [][]byte, signers []common.Address) ([]byte, []common.Address, error) {
 	if len(signatures) == 0 {
-		return nil, ErrAggregationFailed
+		return nil, nil, ErrAggregationFailed
 	}
-
-	var aggSig bn254.G1Affine
-	if err := aggSig.Unmarshal(signatures[0]); err != nil {
-		return nil, ErrInvalidSignature
+	if signers != nil && len(signers) != len(signatures) {
+		return nil, nil, fmt.Errorf("%w: %d signers for %d signatures", ErrAggregationFailed, len(signers), len(signatures))
 	}
 
-	for i := 1; i < len(signatures); i++ {
-		var sig bn254.G1Affine
-		if err := sig.Unmarshal(signatures[i]); err != nil {
-			return nil, ErrInvalidSignature
+	var (
+		agg      bn254.G1Jac
+		included []common.Address
+		seenSigs = make(map[string]bool, len(signatures))
+		seenBy   = make(map[common.Address]int, len(signers))
+	)
+
+	for i, raw := range signatures {
+		sig, err := unmarshalSignature(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key := string(raw)
+		if seenSigs[key] {
+			continue
 		}
 
-		var result bn254.G1Jac
-		result.FromAffine(&aggSig)
+		if signers != nil {
+			if first, ok := seenBy[signers[i]]; ok {
+				return nil, nil, fmt.Errorf("%w: signer %s contributed signatures %d and %d",
+					ErrDuplicateSigner, signers[i].Hex(), first, i)
+			}
+			seenBy[signers[i]] = i
+			included = append(included, signers[i])
+		}
+		seenSigs[key] = true
+
 		var sigJac bn254.G1Jac
 		sigJac.FromAffine(&sig)
-		result.AddAssign(&sigJac)
-		aggSig.FromJacobian(&result)
+		agg.AddAssign(&sigJac)
 	}
 
-	return aggSig.Marshal(), nil
+	var aggSig bn254.G1Affine
+	aggSig.FromJacobian(&agg)
+	return aggSig.Marshal(), included, nil
 }
 
 func AggregatePublicKeys(publicKeys [][]byte) ([]byte, error) {
@@ -166,9 +352,9 @@ func VerifyAggregatedSignature(aggSignature []byte, messages [][]byte, publicKey
 		return false, ErrInvalidSignature
 	}
 
-	var aggSig bn254.G1Affine
-	if err := aggSig.Unmarshal(aggSignature); err != nil {
-		return false, ErrInvalidSignature
+	aggSig, err := unmarshalSignature(aggSignature)
+	if err != nil {
+		return false, err
 	}
 
 	_, _, _, g2GenAff := bn254.Generators()
@@ -192,7 +378,7 @@ func VerifyAggregatedSignature(aggSignature []byte, messages [][]byte, publicKey
 		g2Points[i+1] = pubKey
 	}
 
-	valid, err := bn254.PairingCheck(g1Points, g2Points)
+	valid, err := pairingCheck(g1Points, g2Points)
 	if err != nil {
 		return false, err
 	}
@@ -200,8 +386,96 @@ func VerifyAggregatedSignature(aggSignature []byte, messages [][]byte, publicKey
 	return valid, nil
 }
 
+// VerifyAggregatedSignatureSameMessage verifies an aggregate in which every
+// signer signed the same message, e.g. one pause request. The public keys are
+// summed first so the check costs a single hash-to-curve and two pairings
+// regardless of signer count, instead of one pairing per signer. Callers must
+// only pass keys whose proof of possession was verified.
+func VerifyAggregatedSignatureSameMessage(aggSignature []byte, message []byte, publicKeys [][]byte) (bool, error) {
+	return verifyAggregatedSameMessageWithDST(aggSignature, message, publicKeys, signatureDST)
+}
+
+// VerifyAggregatedSignatureSameMessageForChain is
+// VerifyAggregatedSignatureSameMessage for signatures made by SignForChain
+func VerifyAggregatedSignatureSameMessageForChain(aggSignature []byte, message []byte, publicKeys [][]byte, chainID uint64) (bool, error) {
+	return verifyAggregatedSameMessageWithDST(aggSignature, message, publicKeys, ChainDST(chainID))
+}
+
+func verifyAggregatedSameMessageWithDST(aggSignature, message []byte, publicKeys [][]byte, dst []byte) (bool, error) {
+	if len(publicKeys) == 0 {
+		return false, ErrInvalidSignature
+	}
+
+	aggSig, err := unmarshalSignature(aggSignature)
+	if err != nil {
+		return false, err
+	}
+
+	var aggPubKeyJac bn254.G2Jac
+	for i := range publicKeys {
+		var pubKey bn254.G2Affine
+		if err := pubKey.Unmarshal(publicKeys[i]); err != nil {
+			return false, ErrInvalidPublicKey
+		}
+
+		var pubKeyJac bn254.G2Jac
+		pubKeyJac.FromAffine(&pubKey)
+		if i == 0 {
+			aggPubKeyJac = pubKeyJac
+		} else {
+			aggPubKeyJac.AddAssign(&pubKeyJac)
+		}
+	}
+
+	var aggPubKey bn254.G2Affine
+	aggPubKey.FromJacobian(&aggPubKeyJac)
+
+	msgPoint := hashToG1WithDST(message, dst)
+	var negMsgPoint bn254.G1Affine
+	negMsgPoint.Neg(&msgPoint)
+
+	_, _, _, g2Gen := bn254.Generators()
+
+	return pairingCheck(
+		[]bn254.G1Affine{aggSig, negMsgPoint},
+		[]bn254.G2Affine{g2Gen, aggPubKey},
+	)
+}
+
+// ChainDST is the signature domain tag for chainID. The hashed G1 point,
+// and so every signature, then depends on the chain, which stops a pause
+// request signed on mainnet from being replayed on a testnet deployment.
+func ChainDST(chainID uint64) []byte {
+	return []byte(fmt.Sprintf("BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_CHAIN_%d_", chainID))
+}
+
+// ValidPublicKey reports whether key is an uncompressed G2 public key, the
+// only form SentinelRouter can decode when it reads keys from the registry
+func ValidPublicKey(key []byte) bool {
+	var pubKey bn254.G2Affine
+	return len(key) == bn254.SizeOfG2AffineUncompressed && pubKey.Unmarshal(key) == nil
+}
+
+// unmarshalSignature decodes a marshaled G1 signature. The length is checked
+// first so malformed input from spamming peers is rejected before any curve
+// arithmetic.
+func unmarshalSignature(data []byte) (bn254.G1Affine, error) {
+	var sig bn254.G1Affine
+	if len(data) != bn254.SizeOfG1AffineUncompressed {
+		return sig, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidSignatureLength, len(data), bn254.SizeOfG1AffineUncompressed)
+	}
+	if err := sig.Unmarshal(data); err != nil {
+		return sig, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
 func hashToG1(message []byte) bn254.G1Affine {
-	point, err := bn254.HashToG1(message, []byte("BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_"))
+	return hashToG1WithDST(message, signatureDST)
+}
+
+func hashToG1WithDST(message, dst []byte) bn254.G1Affine {
+	point, err := bn254.HashToG1(message, dst)
 	if err != nil {
 		_, _, g1GenAff, _ := bn254.Generators()
 		return g1GenAff
@@ -209,7 +483,33 @@ func hashToG1(message []byte) bn254.G1Affine {
 	return point
 }
 
-func loadOrGenerateKey(keyPath string) (*BLSKeyPair, error) {
+// routerSqrtExponent is (p+1)/4; raising a square to it gives a square
+// root, since the base field modulus p is 3 mod 4
+var routerSqrtExponent = new(big.Int).Rsh(new(big.Int).Add(fp.Modulus(), big.NewInt(1)), 2)
+
+// routerHashToG1 maps message to G1 exactly as BLSVerifier.hashToG1 does on
+// chain: x starts at keccak256(message) mod p and is incremented until
+// x³+3 is a square, whose root y = (x³+3)^((p+1)/4) completes the point.
+// Choosing the other root, or any other starting x, would fail on chain.
+func routerHashToG1(message []byte) (bn254.G1Affine, error) {
+	var x, one, three fp.Element
+	x.SetBytes(crypto.Keccak256(message))
+	one.SetOne()
+	three.SetUint64(3)
+
+	for i := 0; i < 256; i++ {
+		var y2, y, check fp.Element
+		y2.Square(&x).Mul(&y2, &x).Add(&y2, &three)
+		y.Exp(y2, routerSqrtExponent)
+		if check.Square(&y).Equal(&y2) {
+			return bn254.G1Affine{X: x, Y: y}, nil
+		}
+		x.Add(&x, &one)
+	}
+	return bn254.G1Affine{}, ErrHashToCurveFailed
+}
+
+func loadOrGenerateKey(keyPath, passphrase string) (*BLSKeyPair, error) {
 	if keyPath == "" {
 		return GenerateKeyPair()
 	}
@@ -222,7 +522,7 @@ func loadOrGenerateKey(keyPath string) (*BLSKeyPair, error) {
 				return nil, err
 			}
 
-			if err := saveKey(keyPath, keyPair); err != nil {
+			if err := saveKey(keyPath, keyPair, passphrase); err != nil {
 				return nil, err
 			}
 
@@ -231,35 +531,116 @@ func loadOrGenerateKey(keyPath string) (*BLSKeyPair, error) {
 		return nil, err
 	}
 
-	return deserializeKeyPair(data)
+	return loadKey(keyPath, data, passphrase)
 }
 
-func saveKey(keyPath string, keyPair *BLSKeyPair) error {
+// loadKey decodes a key file, telling unencrypted files apart from
+// encrypted ones by their length
+func loadKey(keyPath string, data []byte, passphrase string) (*BLSKeyPair, error) {
+	if isPlaintextKeyFile(data) {
+		keyPair, err := deserializeKeyPair(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// Migrate the plaintext key in place now that a passphrase is set
+		if passphrase != "" {
+			if err := saveKey(keyPath, keyPair, passphrase); err != nil {
+				return nil, fmt.Errorf("failed to encrypt legacy key file: %w", err)
+			}
+		}
+		return keyPair, nil
+	}
+
+	if passphrase == "" {
+		return nil, ErrKeyPassphraseRequired
+	}
+
+	plaintext, err := decryptKeyData(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return deserializeKeyPair(plaintext)
+}
+
+// saveKey writes the key pair, encrypted when a passphrase is given. The
+// file is replaced atomically so a failed migration never loses the key.
+func saveKey(keyPath string, keyPair *BLSKeyPair, passphrase string) error {
 	data := serializeKeyPair(keyPair)
-	return os.WriteFile(keyPath, data, 0600)
+	if passphrase != "" {
+		encrypted, err := encryptKeyData(data, passphrase)
+		if err != nil {
+			return err
+		}
+		data = encrypted
+	}
+
+	tmpPath := keyPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, keyPath)
 }
 
+// Key pairs are serialized as magic || version || private scalar || public
+// key. Version 0 files predate the header and start directly with the
+// scalar, whose leading byte is always below keyFormatMagic since the curve
+// order is under 2^254.
+const (
+	keyFormatMagic   byte = 0xb5
+	keyFormatVersion byte = 1
+	keyHeaderSize         = 2
+	keyPairSize           = fr.Bytes + bn254.SizeOfG2AffineUncompressed
+)
+
+var ErrUnsupportedKeyVersion = errors.New("unsupported key version")
+
 func serializeKeyPair(keyPair *BLSKeyPair) []byte {
 	privBytes := keyPair.PrivateKey.Bytes()
 	pubBytes := keyPair.PublicKey.Marshal()
 
-	result := make([]byte, len(privBytes)+len(pubBytes))
-	copy(result[:len(privBytes)], privBytes[:])
-	copy(result[len(privBytes):], pubBytes)
+	result := make([]byte, 0, keyHeaderSize+len(privBytes)+len(pubBytes))
+	result = append(result, keyFormatMagic, keyFormatVersion)
+	result = append(result, privBytes[:]...)
+	result = append(result, pubBytes...)
 
 	return result
 }
 
+// deserializeKeyPair dispatches on the format version, reading headerless
+// files as version 0
 func deserializeKeyPair(data []byte) (*BLSKeyPair, error) {
-	if len(data) < 32 {
+	if len(data) == 0 {
+		return nil, errors.New("invalid key data")
+	}
+
+	if data[0] < keyFormatMagic {
+		return deserializeKeyPairBody(data)
+	}
+	if data[0] != keyFormatMagic || len(data) < keyHeaderSize {
+		return nil, ErrUnsupportedKeyVersion
+	}
+
+	switch data[1] {
+	case 1:
+		return deserializeKeyPairBody(data[keyHeaderSize:])
+	default:
+		return nil, ErrUnsupportedKeyVersion
+	}
+}
+
+// deserializeKeyPairBody parses the scalar and public key layout shared by
+// versions 0 and 1
+func deserializeKeyPairBody(data []byte) (*BLSKeyPair, error) {
+	if len(data) < fr.Bytes {
 		return nil, errors.New("invalid key data")
 	}
 
 	var privateKey fr.Element
-	privateKey.SetBytes(data[:32])
+	privateKey.SetBytes(data[:fr.Bytes])
 
 	var publicKey bn254.G2Affine
-	if err := publicKey.Unmarshal(data[32:]); err != nil {
+	if err := publicKey.Unmarshal(data[fr.Bytes:]); err != nil {
 		return nil, err
 	}
 
@@ -1,9 +1,18 @@
 package consensus
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func TestGenerateKeyPair(t *testing.T) {
@@ -61,6 +70,44 @@ func TestBLSSigner_PublicKeyHex(t *testing.T) {
 	}
 }
 
+func TestBLSSigner_Zeroize(t *testing.T) {
+	signer, err := NewBLSSigner("")
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed: %v", err)
+	}
+	pubKey := signer.PublicKey()
+
+	signer.Zeroize()
+
+	scalar := signer.keyPair.PrivateKey.Bytes()
+	if !bytes.Equal(scalar[:], make([]byte, len(scalar))) {
+		t.Errorf("Private scalar not zeroed: %x", scalar)
+	}
+	if !bytes.Equal(signer.PublicKey(), pubKey) {
+		t.Error("Zeroize should leave the public key intact")
+	}
+}
+
+func TestPrivateKeyEqual(t *testing.T) {
+	kp1, _ := GenerateKeyPair()
+	kp2, _ := GenerateKeyPair()
+
+	restored, err := deserializeKeyPair(serializeKeyPair(kp1))
+	if err != nil {
+		t.Fatalf("deserializeKeyPair failed: %v", err)
+	}
+
+	if !PrivateKeyEqual(kp1, restored) {
+		t.Error("Round-tripped key should equal the original")
+	}
+	if PrivateKeyEqual(kp1, kp2) {
+		t.Error("Distinct keys should not be equal")
+	}
+	if PrivateKeyEqual(kp1, nil) {
+		t.Error("A nil key pair should never be equal")
+	}
+}
+
 func TestVerifySignature(t *testing.T) {
 	signer, err := NewBLSSigner("")
 	if err != nil {
@@ -85,6 +132,98 @@ func TestVerifySignature(t *testing.T) {
 	}
 }
 
+func TestVerifySignatureCompressed(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+	message := []byte("test message")
+	sig, _ := signer.Sign(message)
+
+	compressed := signer.PublicKeyCompressed()
+	if len(compressed) != bn254.SizeOfG2AffineCompressed || len(signer.PublicKey()) != bn254.SizeOfG2AffineUncompressed {
+		t.Fatalf("Unexpected key sizes: compressed %d, uncompressed %d", len(compressed), len(signer.PublicKey()))
+	}
+
+	valid, err := VerifySignatureCompressed(sig, message, compressed)
+	if err != nil || !valid {
+		t.Errorf("Signature should verify against the compressed key, got %v, %v", valid, err)
+	}
+
+	if valid, _ := VerifySignatureCompressed(sig, []byte("other message"), compressed); valid {
+		t.Error("Signature should not verify for a different message")
+	}
+	if _, err := VerifySignatureCompressed(sig, message, signer.PublicKey()); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Errorf("Expected ErrInvalidPublicKey for an uncompressed key, got %v", err)
+	}
+}
+
+func TestVerifySignatureForChain(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+	message := []byte("pause request")
+	sig, _ := signer.SignForChain(message, 1)
+
+	if valid, err := VerifySignatureForChain(sig, message, signer.PublicKey(), 1); err != nil || !valid {
+		t.Errorf("Signature should verify on its own chain, got %v, %v", valid, err)
+	}
+	if valid, _ := VerifySignatureForChain(sig, message, signer.PublicKey(), 5); valid {
+		t.Error("Signature made for chain 1 must not verify under chain 5")
+	}
+	if valid, _ := VerifySignature(sig, message, signer.PublicKey()); valid {
+		t.Error("Chain-bound signature must not verify under the chainless domain")
+	}
+
+	valid, err := VerifyAggregatedSignatureSameMessageForChain(sig, message, [][]byte{signer.PublicKey()}, 1)
+	if err != nil || !valid {
+		t.Errorf("Aggregate check should accept the chain 1 signature, got %v, %v", valid, err)
+	}
+}
+
+func TestSignForRouter(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+	message := []byte("pause request")
+	sig, err := signer.SignForRouter(message)
+	if err != nil {
+		t.Fatalf("SignForRouter failed: %v", err)
+	}
+
+	if valid, err := VerifySignatureForRouter(sig, message, signer.PublicKey()); err != nil || !valid {
+		t.Errorf("Router signature should verify, got %v, %v", valid, err)
+	}
+	if valid, _ := VerifySignatureForRouter(sig, []byte("other message"), signer.PublicKey()); valid {
+		t.Error("Router signature must not verify over another message")
+	}
+	if valid, _ := VerifySignature(sig, message, signer.PublicKey()); valid {
+		t.Error("Router signature must not verify under the gossip hash")
+	}
+}
+
+func TestRouterHashToG1(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		message := []byte(fmt.Sprintf("message %d", i))
+		point, err := routerHashToG1(message)
+		if err != nil {
+			t.Fatalf("routerHashToG1 failed: %v", err)
+		}
+		if !point.IsOnCurve() || !point.IsInSubGroup() {
+			t.Fatalf("Hash of %q is not a G1 point", message)
+		}
+
+		// x is the first of keccak256(message) mod p, +1, +2, ... on the curve
+		start := new(big.Int).Mod(new(big.Int).SetBytes(crypto.Keccak256(message)), fp.Modulus())
+		var x big.Int
+		point.X.BigInt(&x)
+		if offset := new(big.Int).Sub(&x, start); offset.Sign() < 0 || offset.Cmp(big.NewInt(256)) >= 0 {
+			t.Errorf("Hash of %q has x %s, not within 256 of %s", message, &x, start)
+		}
+		// y is the root the contract takes, not its negation
+		var y big.Int
+		point.Y.BigInt(&y)
+		y2 := new(big.Int).Exp(&x, big.NewInt(3), fp.Modulus())
+		y2.Add(y2, big.NewInt(3))
+		if y.Cmp(new(big.Int).Exp(y2, routerSqrtExponent, fp.Modulus())) != 0 {
+			t.Errorf("Hash of %q picked the other square root", message)
+		}
+	}
+}
+
 func TestVerifySignature_InvalidSignature(t *testing.T) {
 	signer, err := NewBLSSigner("")
 	if err != nil {
@@ -113,6 +252,39 @@ func TestVerifySignature_InvalidSignature(t *testing.T) {
 	}
 }
 
+func TestSignatureLengthPrecheck(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+	message := []byte("test message")
+	sig, _ := signer.Sign(message)
+
+	tests := []struct {
+		name string
+		sig  []byte
+	}{
+		{"empty", nil},
+		{"truncated", sig[:len(sig)-1]},
+		{"compressed size", sig[:bn254.SizeOfG1AffineCompressed]},
+		{"padded", append(append([]byte{}, sig...), 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifySignature(tt.sig, message, signer.PublicKey()); !errors.Is(err, ErrInvalidSignatureLength) {
+				t.Errorf("VerifySignature: expected ErrInvalidSignatureLength, got %v", err)
+			}
+			if _, err := AggregateSignatures([][]byte{sig, tt.sig}); !errors.Is(err, ErrInvalidSignatureLength) {
+				t.Errorf("AggregateSignatures: expected ErrInvalidSignatureLength, got %v", err)
+			}
+		})
+	}
+
+	// Right length but not a curve point is still a generic invalid signature
+	garbage := bytes.Repeat([]byte{0x01}, bn254.SizeOfG1AffineUncompressed)
+	if _, err := VerifySignature(garbage, message, signer.PublicKey()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for an off-curve point, got %v", err)
+	}
+}
+
 func TestVerifySignature_InvalidPublicKey(t *testing.T) {
 	signer1, _ := NewBLSSigner("")
 	signer2, _ := NewBLSSigner("")
@@ -159,6 +331,54 @@ func TestAggregateSignatures_Empty(t *testing.T) {
 	}
 }
 
+func TestAggregateSignatures_DuplicateSignature(t *testing.T) {
+	signer1, _ := NewBLSSigner("")
+	signer2, _ := NewBLSSigner("")
+
+	message := []byte("shared message")
+	sig1, _ := signer1.Sign(message)
+	sig2, _ := signer2.Sign(message)
+
+	// An echoed signature must not be added to the aggregate twice
+	aggSig, err := AggregateSignatures([][]byte{sig1, sig2, sig1})
+	if err != nil {
+		t.Fatalf("AggregateSignatures failed: %v", err)
+	}
+	publicKeys := [][]byte{signer1.PublicKey(), signer2.PublicKey()}
+
+	valid, err := VerifyAggregatedSignatureSameMessage(aggSig, message, publicKeys)
+	if err != nil || !valid {
+		t.Errorf("Aggregate with a duplicate signature should verify, got %v %v", valid, err)
+	}
+
+	addr1 := common.HexToAddress("0x1")
+	addr2 := common.HexToAddress("0x2")
+	_, included, err := AggregateSignaturesWithSigners([][]byte{sig1, sig2, sig1}, []common.Address{addr1, addr2, addr1})
+	if err != nil {
+		t.Fatalf("AggregateSignaturesWithSigners failed: %v", err)
+	}
+	if len(included) != 2 || included[0] != addr1 || included[1] != addr2 {
+		t.Errorf("Expected signers [%s %s], got %v", addr1.Hex(), addr2.Hex(), included)
+	}
+}
+
+func TestAggregateSignatures_DuplicateSigner(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+	sig1, _ := signer.Sign([]byte("message one"))
+	sig2, _ := signer.Sign([]byte("message two"))
+
+	addr := common.HexToAddress("0x1")
+	_, _, err := AggregateSignaturesWithSigners([][]byte{sig1, sig2}, []common.Address{addr, addr})
+	if !errors.Is(err, ErrDuplicateSigner) {
+		t.Errorf("Expected ErrDuplicateSigner, got %v", err)
+	}
+
+	_, _, err = AggregateSignaturesWithSigners([][]byte{sig1, sig2}, []common.Address{addr})
+	if !errors.Is(err, ErrAggregationFailed) {
+		t.Errorf("Expected ErrAggregationFailed for mismatched signers, got %v", err)
+	}
+}
+
 func TestAggregatePublicKeys(t *testing.T) {
 	signer1, _ := NewBLSSigner("")
 	signer2, _ := NewBLSSigner("")
@@ -210,6 +430,235 @@ func TestVerifyAggregatedSignature(t *testing.T) {
 	}
 }
 
+func TestVerifyAggregatedSignatureSameMessage(t *testing.T) {
+	message := []byte("shared message")
+	aggSig, pubKeys := signSameMessage(t, 5, message)
+
+	valid, err := VerifyAggregatedSignatureSameMessage(aggSig, message, pubKeys)
+	if err != nil {
+		t.Fatalf("VerifyAggregatedSignatureSameMessage failed: %v", err)
+	}
+	if !valid {
+		t.Error("Aggregated signature should be valid")
+	}
+
+	if valid, _ := VerifyAggregatedSignatureSameMessage(aggSig, []byte("other message"), pubKeys); valid {
+		t.Error("Aggregated signature should not verify against a different message")
+	}
+	if valid, _ := VerifyAggregatedSignatureSameMessage(aggSig, message, pubKeys[:4]); valid {
+		t.Error("Aggregated signature should not verify with a signer missing")
+	}
+	if _, err := VerifyAggregatedSignatureSameMessage(aggSig, message, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for no public keys, got %v", err)
+	}
+}
+
+// sameMessageCrossover is the signer count from which the same-message path
+// beats the per-message one. The per-message check hashes n messages and runs
+// n+1 Miller loops; the same-message check hashes once, runs two Miller loops
+// and adds n-1 G2 points, which is negligible next to a Miller loop. So the
+// two are equal at one signer and the same-message path wins from two on
+// (roughly n/2 times faster, see BenchmarkVerifyAggregatedSignature).
+const sameMessageCrossover = 2
+
+// countPairings makes pairingCheck record the number of pairings each check
+// runs, until the test ends
+func countPairings(t *testing.T) *[]int {
+	t.Helper()
+
+	var counts []int
+	pairingCheck = func(P []bn254.G1Affine, Q []bn254.G2Affine) (bool, error) {
+		counts = append(counts, len(P))
+		return bn254.PairingCheck(P, Q)
+	}
+	t.Cleanup(func() { pairingCheck = bn254.PairingCheck })
+	return &counts
+}
+
+func TestVerifyAggregatedSignatureSameMessage_Crossover(t *testing.T) {
+	counts := countPairings(t)
+	message := []byte("shared message")
+
+	crossover := 0
+	for n := 1; n <= 4; n++ {
+		aggSig, pubKeys := signSameMessage(t, n, message)
+		messages := make([][]byte, n)
+		for i := range messages {
+			messages[i] = message
+		}
+
+		*counts = nil
+		if valid, err := VerifyAggregatedSignature(aggSig, messages, pubKeys); !valid || err != nil {
+			t.Fatalf("Per-message verification failed at %d signers: %v, %v", n, valid, err)
+		}
+		if valid, err := VerifyAggregatedSignatureSameMessage(aggSig, message, pubKeys); !valid || err != nil {
+			t.Fatalf("Same-message verification failed at %d signers: %v, %v", n, valid, err)
+		}
+		if len(*counts) != 2 {
+			t.Fatalf("Expected one pairing check per path, got %v", *counts)
+		}
+
+		perMessage, sameMessage := (*counts)[0], (*counts)[1]
+		if perMessage != n+1 {
+			t.Errorf("Expected %d pairings on the per-message path at %d signers, got %d", n+1, n, perMessage)
+		}
+		if sameMessage != 2 {
+			t.Errorf("Expected 2 pairings on the same-message path at %d signers, got %d", n, sameMessage)
+		}
+		if crossover == 0 && sameMessage < perMessage {
+			crossover = n
+		}
+	}
+
+	if crossover != sameMessageCrossover {
+		t.Errorf("Expected the same-message path to win from %d signers, got %d", sameMessageCrossover, crossover)
+	}
+}
+
+// BenchmarkVerifyAggregatedSignature compares the per-message and
+// same-message paths in time; see sameMessageCrossover
+func BenchmarkVerifyAggregatedSignature(b *testing.B) {
+	message := []byte("shared message")
+
+	for _, n := range []int{1, 2, 10, 30} {
+		aggSig, pubKeys := signSameMessage(b, n, message)
+		messages := make([][]byte, n)
+		for i := range messages {
+			messages[i] = message
+		}
+
+		b.Run(fmt.Sprintf("PerMessage/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				VerifyAggregatedSignature(aggSig, messages, pubKeys)
+			}
+		})
+		b.Run(fmt.Sprintf("SameMessage/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				VerifyAggregatedSignatureSameMessage(aggSig, message, pubKeys)
+			}
+		})
+	}
+}
+
+func signSameMessage(tb testing.TB, n int, message []byte) ([]byte, [][]byte) {
+	tb.Helper()
+
+	sigs := make([][]byte, n)
+	pubKeys := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		signer, err := NewBLSSigner("")
+		if err != nil {
+			tb.Fatalf("NewBLSSigner failed: %v", err)
+		}
+		sigs[i], _ = signer.Sign(message)
+		pubKeys[i] = signer.PublicKey()
+	}
+
+	aggSig, err := AggregateSignatures(sigs)
+	if err != nil {
+		tb.Fatalf("AggregateSignatures failed: %v", err)
+	}
+	return aggSig, pubKeys
+}
+
+func TestProofOfPossession(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+
+	proof, err := signer.GenerateProofOfPossession()
+	if err != nil {
+		t.Fatalf("GenerateProofOfPossession failed: %v", err)
+	}
+
+	valid, err := VerifyProofOfPossession(signer.PublicKey(), proof)
+	if err != nil || !valid {
+		t.Fatalf("Expected valid proof of possession, got %v, %v", valid, err)
+	}
+
+	other, _ := NewBLSSigner("")
+	if valid, _ := VerifyProofOfPossession(other.PublicKey(), proof); valid {
+		t.Error("Proof should not verify against another key")
+	}
+}
+
+func TestProofOfPossession_DomainSeparation(t *testing.T) {
+	signer, _ := NewBLSSigner("")
+
+	// A message signature over the public key bytes is not a PoP
+	sig, _ := signer.Sign(signer.PublicKey())
+	if valid, _ := VerifyProofOfPossession(signer.PublicKey(), sig); valid {
+		t.Error("Message signature over the public key must not verify as a PoP")
+	}
+
+	// ...and a PoP is not a message signature
+	proof, _ := signer.GenerateProofOfPossession()
+	if valid, _ := VerifySignature(proof, signer.PublicKey(), signer.PublicKey()); valid {
+		t.Error("PoP must not verify as a message signature")
+	}
+}
+
+func TestAggregateVerifiedPublicKeys_RejectsRogueKey(t *testing.T) {
+	honest, _ := NewBLSSigner("")
+
+	// The attacker picks a secret x and registers rogue = x*G2 - honest, so
+	// the aggregate of both keys is x*G2 and the attacker alone can sign for it
+	x := big.NewInt(424242)
+	_, _, _, g2Gen := bn254.Generators()
+	var xG2, honestKey bn254.G2Affine
+	xG2.ScalarMultiplication(&g2Gen, x)
+	if err := honestKey.Unmarshal(honest.PublicKey()); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	var rogueJac, negHonest bn254.G2Jac
+	rogueJac.FromAffine(&xG2)
+	negHonest.FromAffine(&honestKey)
+	negHonest.Neg(&negHonest)
+	rogueJac.AddAssign(&negHonest)
+	var rogueKey bn254.G2Affine
+	rogueKey.FromJacobian(&rogueJac)
+	rogue := rogueKey.Marshal()
+
+	message := []byte("pause protocol")
+	msgPoint := hashToG1(message)
+	var forged bn254.G1Affine
+	forged.ScalarMultiplication(&msgPoint, x)
+
+	// Without PoP the forgery verifies against the aggregate key
+	aggKey, _ := AggregatePublicKeys([][]byte{honest.PublicKey(), rogue})
+	if valid, _ := VerifySignature(forged.Marshal(), message, aggKey); !valid {
+		t.Fatal("Expected rogue-key forgery to succeed without PoP checks")
+	}
+
+	// The attacker cannot prove possession of the rogue key; the best it can
+	// do is sign under the PoP tag with x
+	popPoint := hashToG1WithDST(rogue, proofOfPossessionDST)
+	var rogueProof bn254.G1Affine
+	rogueProof.ScalarMultiplication(&popPoint, x)
+
+	honestProof, _ := honest.GenerateProofOfPossession()
+	_, err := AggregateVerifiedPublicKeys(
+		[][]byte{honest.PublicKey(), rogue},
+		[][]byte{honestProof, rogueProof.Marshal()},
+	)
+	if !errors.Is(err, ErrInvalidProofOfPossession) {
+		t.Errorf("Expected ErrInvalidProofOfPossession, got %v", err)
+	}
+
+	// Keys that all carry valid proofs aggregate as before
+	second, _ := NewBLSSigner("")
+	secondProof, _ := second.GenerateProofOfPossession()
+	agg, err := AggregateVerifiedPublicKeys(
+		[][]byte{honest.PublicKey(), second.PublicKey()},
+		[][]byte{honestProof, secondProof},
+	)
+	if err != nil || len(agg) == 0 {
+		t.Errorf("Expected verified keys to aggregate, got %v", err)
+	}
+
+	if _, err := AggregateVerifiedPublicKeys([][]byte{honest.PublicKey()}, nil); !errors.Is(err, ErrInvalidProofOfPossession) {
+		t.Errorf("Expected missing proofs to be rejected, got %v", err)
+	}
+}
+
 func TestBLSSigner_SaveAndLoad(t *testing.T) {
 	// Create temp directory
 	tempDir := t.TempDir()
@@ -242,6 +691,125 @@ func TestBLSSigner_SaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestBLSSigner_EncryptedKeyRoundTrip(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "test_key.bls")
+
+	signer1, err := NewBLSSignerWithPassphrase(keyPath, "correct horse")
+	if err != nil {
+		t.Fatalf("NewBLSSignerWithPassphrase failed: %v", err)
+	}
+
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("Reading key file failed: %v", err)
+	}
+	if isPlaintextKeyFile(data) {
+		t.Error("Key file should be encrypted, not the raw key pair")
+	}
+
+	signer2, err := NewBLSSignerWithPassphrase(keyPath, "correct horse")
+	if err != nil {
+		t.Fatalf("NewBLSSignerWithPassphrase (load) failed: %v", err)
+	}
+	if signer1.PublicKeyHex() != signer2.PublicKeyHex() {
+		t.Error("Decrypted public key doesn't match original")
+	}
+}
+
+func TestBLSSigner_WrongPassphrase(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "test_key.bls")
+
+	if _, err := NewBLSSignerWithPassphrase(keyPath, "correct horse"); err != nil {
+		t.Fatalf("NewBLSSignerWithPassphrase failed: %v", err)
+	}
+
+	if _, err := NewBLSSignerWithPassphrase(keyPath, "battery staple"); !errors.Is(err, ErrInvalidKeyPassphrase) {
+		t.Errorf("Expected ErrInvalidKeyPassphrase, got %v", err)
+	}
+	if _, err := NewBLSSigner(keyPath); !errors.Is(err, ErrKeyPassphraseRequired) {
+		t.Errorf("Expected ErrKeyPassphraseRequired, got %v", err)
+	}
+}
+
+func TestBLSSigner_MigratesLegacyKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "test_key.bls")
+
+	legacy, err := NewBLSSigner(keyPath)
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed: %v", err)
+	}
+
+	migrated, err := NewBLSSignerWithPassphrase(keyPath, "correct horse")
+	if err != nil {
+		t.Fatalf("Loading legacy key with passphrase failed: %v", err)
+	}
+	if legacy.PublicKeyHex() != migrated.PublicKeyHex() {
+		t.Error("Migrated public key doesn't match legacy key")
+	}
+
+	data, _ := os.ReadFile(keyPath)
+	if isPlaintextKeyFile(data) {
+		t.Error("Legacy key file should have been re-written encrypted")
+	}
+
+	reloaded, err := NewBLSSignerWithPassphrase(keyPath, "correct horse")
+	if err != nil {
+		t.Fatalf("Loading migrated key failed: %v", err)
+	}
+	if reloaded.PublicKeyHex() != legacy.PublicKeyHex() {
+		t.Error("Reloaded public key doesn't match legacy key")
+	}
+}
+
+func TestDeserializeKeyPair_Versions(t *testing.T) {
+	keyPair, _ := GenerateKeyPair()
+
+	serialized := serializeKeyPair(keyPair)
+	if serialized[0] != keyFormatMagic || serialized[1] != keyFormatVersion {
+		t.Fatalf("Expected a versioned header, got %x", serialized[:keyHeaderSize])
+	}
+
+	// Version 0 files have no header: just the scalar and public key
+	privBytes := keyPair.PrivateKey.Bytes()
+	v0 := append(privBytes[:], keyPair.PublicKey.Marshal()...)
+	loaded, err := deserializeKeyPair(v0)
+	if err != nil {
+		t.Fatalf("Loading a version 0 key failed: %v", err)
+	}
+	if !loaded.PublicKey.Equal(keyPair.PublicKey) || !loaded.PrivateKey.Equal(keyPair.PrivateKey) {
+		t.Error("Version 0 key pair doesn't match")
+	}
+
+	forged := append([]byte(nil), serialized...)
+	forged[1] = 0x7f
+	if _, err := deserializeKeyPair(forged); err == nil || err.Error() != "unsupported key version" {
+		t.Errorf("Expected unsupported key version error, got %v", err)
+	}
+
+	forged[0] = 0xff
+	if _, err := deserializeKeyPair(forged); !errors.Is(err, ErrUnsupportedKeyVersion) {
+		t.Errorf("Expected ErrUnsupportedKeyVersion for unknown magic, got %v", err)
+	}
+}
+
+func TestBLSSigner_LoadsVersion0KeyFile(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "test_key.bls")
+
+	keyPair, _ := GenerateKeyPair()
+	privBytes := keyPair.PrivateKey.Bytes()
+	if err := os.WriteFile(keyPath, append(privBytes[:], keyPair.PublicKey.Marshal()...), 0600); err != nil {
+		t.Fatalf("Writing key file failed: %v", err)
+	}
+
+	signer, err := NewBLSSigner(keyPath)
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed on a version 0 key file: %v", err)
+	}
+	if !bytes.Equal(signer.PublicKey(), keyPair.PublicKey.Marshal()) {
+		t.Error("Loaded public key doesn't match the version 0 file")
+	}
+}
+
 func TestSerializeDeserializeKeyPair(t *testing.T) {
 	keyPair, _ := GenerateKeyPair()
 
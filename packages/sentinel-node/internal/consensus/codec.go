@@ -0,0 +1,183 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WireFormat selects how outbound gossip messages are encoded. Inbound
+// messages are decoded by their leading byte whatever the local setting, so
+// a mesh can migrate one node at a time.
+type WireFormat string
+
+const (
+	// WireFormatJSON encodes messages as JSON objects (default)
+	WireFormatJSON WireFormat = "json"
+	// WireFormatBinary encodes messages as length-prefixed fields
+	WireFormatBinary WireFormat = "binary"
+)
+
+// binaryFormatV1 leads every binary-encoded message. JSON messages start
+// with '{', so the two can't be confused.
+const binaryFormatV1 byte = 0x01
+
+// binaryFormatV2 leads binary messages carrying an operator address, which
+// follows the sender. Messages without one are still written as V1, so
+// nodes that only read V1 keep receiving heartbeats.
+const binaryFormatV2 byte = 0x02
+
+var errMalformedBinary = errors.New("malformed binary gossip message")
+
+// ParseWireFormat converts a config string into a WireFormat. An empty
+// string yields WireFormatJSON.
+func ParseWireFormat(s string) (WireFormat, error) {
+	switch WireFormat(s) {
+	case "", WireFormatJSON:
+		return WireFormatJSON, nil
+	case WireFormatBinary:
+		return WireFormatBinary, nil
+	default:
+		return "", fmt.Errorf("unknown wire format %q (expected %q or %q)", s, WireFormatJSON, WireFormatBinary)
+	}
+}
+
+// encodeGossipMessage serializes msg in the given format
+func encodeGossipMessage(msg *GossipMessage, format WireFormat) ([]byte, error) {
+	if format != WireFormatBinary {
+		return json.Marshal(msg)
+	}
+
+	size := 1 + 5*binary.MaxVarintLen64 + 12 + common.AddressLength +
+		len(msg.Type) + len(msg.Sender) + len(msg.Payload) + len(msg.Signature)
+	buf := make([]byte, 0, size)
+
+	hasOperator := msg.Operator != (common.Address{})
+	if hasOperator {
+		buf = append(buf, binaryFormatV2)
+	} else {
+		buf = append(buf, binaryFormatV1)
+	}
+	buf = appendField(buf, []byte(msg.Type))
+	buf = appendField(buf, []byte(msg.Sender))
+	if hasOperator {
+		buf = append(buf, msg.Operator.Bytes()...)
+	}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(msg.Timestamp.Unix()))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(msg.Timestamp.Nanosecond()))
+	buf = appendField(buf, msg.Payload)
+	buf = appendField(buf, msg.Signature)
+	// Optional trailing field, omitted when unset
+	if msg.Seq != 0 {
+		buf = binary.AppendUvarint(buf, msg.Seq)
+	}
+	return buf, nil
+}
+
+// decodeGossipMessage parses a message in either format
+func decodeGossipMessage(data []byte) (GossipMessage, error) {
+	var msg GossipMessage
+	if len(data) == 0 || (data[0] != binaryFormatV1 && data[0] != binaryFormatV2) {
+		err := json.Unmarshal(data, &msg)
+		return msg, err
+	}
+
+	r := binaryReader{data: data[1:]}
+	msgType := r.field()
+	sender := r.field()
+	var operator []byte
+	if data[0] == binaryFormatV2 {
+		operator = r.take(common.AddressLength)
+	}
+	sec := r.uint64()
+	nsec := r.uint32()
+	payload := r.field()
+	signature := r.field()
+	if r.err != nil {
+		return msg, r.err
+	}
+	if len(r.data) > 0 {
+		seq, read := binary.Uvarint(r.data)
+		// A zero sequence is never encoded, so it can only be garbage
+		if read <= 0 || seq == 0 {
+			return msg, errMalformedBinary
+		}
+		msg.Seq = seq
+		r.data = r.data[read:]
+	}
+	if len(r.data) != 0 || nsec >= uint32(time.Second) {
+		return msg, errMalformedBinary
+	}
+
+	msg.Type = MessageType(msgType)
+	msg.Sender = string(sender)
+	if operator != nil {
+		msg.Operator = common.BytesToAddress(operator)
+	}
+	msg.Timestamp = time.Unix(int64(sec), int64(nsec)).UTC()
+	if len(payload) > 0 {
+		msg.Payload = json.RawMessage(payload)
+	}
+	if len(signature) > 0 {
+		msg.Signature = signature
+	}
+	return msg, nil
+}
+
+func appendField(buf, field []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(field)))
+	return append(buf, field...)
+}
+
+// binaryReader consumes fields, recording the first error so decoding can
+// check once at the end
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) take(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)) {
+		r.err = errMalformedBinary
+		return nil
+	}
+	out := r.data[:n:n]
+	r.data = r.data[n:]
+	return out
+}
+
+func (r *binaryReader) field() []byte {
+	if r.err != nil {
+		return nil
+	}
+	n, read := binary.Uvarint(r.data)
+	if read <= 0 {
+		r.err = errMalformedBinary
+		return nil
+	}
+	r.data = r.data[read:]
+	return r.take(n)
+}
+
+func (r *binaryReader) uint64() uint64 {
+	b := r.take(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *binaryReader) uint32() uint32 {
+	b := r.take(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
@@ -0,0 +1,137 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestBinaryFormat_RoundTripsAllTypes(t *testing.T) {
+	ts := time.Unix(1700000000, 123456789)
+	messages := []GossipMessage{
+		{Type: MessageTypePauseRequest, Sender: "node-a", Timestamp: ts, Payload: json.RawMessage(`{"signer":"0x1"}`), Signature: []byte{1, 2, 3}},
+		{Type: MessageTypeSignature, Sender: "node-b", Timestamp: ts, Payload: json.RawMessage(`{"requestId":"r1"}`), Signature: bytes.Repeat([]byte{0xff}, 64)},
+		{Type: MessageTypeAlert, Sender: "node-c", Timestamp: ts, Payload: json.RawMessage(`{"id":"0xabc"}`), Signature: []byte{4}, Seq: 7<<32 | 3},
+		{Type: MessageTypeAlertAck, Sender: "node-d", Timestamp: ts, Payload: json.RawMessage(`{"alertId":"0xabc"}`), Signature: []byte{5}},
+		{Type: MessageTypeEvidenceRequest, Sender: "node-f", Timestamp: ts, Payload: json.RawMessage(`{}`), Signature: []byte{6}, Seq: 9, Operator: common.HexToAddress("0xa1")},
+		{Type: MessageTypeHeartbeat, Sender: "node-e", Timestamp: ts},
+	}
+
+	for _, msg := range messages {
+		t.Run(string(msg.Type), func(t *testing.T) {
+			data, err := encodeGossipMessage(&msg, WireFormatBinary)
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+			format := binaryFormatV1
+			if msg.Operator != (common.Address{}) {
+				format = binaryFormatV2
+			}
+			if data[0] != format {
+				t.Fatalf("Expected format byte %#x, got %#x", format, data[0])
+			}
+
+			decoded, err := decodeGossipMessage(data)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if decoded.Type != msg.Type || decoded.Sender != msg.Sender ||
+				!decoded.Timestamp.Equal(msg.Timestamp) ||
+				!bytes.Equal(decoded.Payload, msg.Payload) || !bytes.Equal(decoded.Signature, msg.Signature) ||
+				decoded.Seq != msg.Seq || decoded.Operator != msg.Operator {
+				t.Errorf("Round trip mismatch:\n got  %+v\n want %+v", decoded, msg)
+			}
+			if !bytes.Equal(EnvelopeMessage(&decoded), EnvelopeMessage(&msg)) {
+				t.Error("Decoded message must sign to the same envelope")
+			}
+
+			jsonData, _ := encodeGossipMessage(&msg, WireFormatJSON)
+			if msg.Signature != nil && len(data) >= len(jsonData) {
+				t.Errorf("Binary encoding (%d bytes) should be smaller than JSON (%d bytes)", len(data), len(jsonData))
+			}
+		})
+	}
+}
+
+func TestBinaryFormat_RejectsTruncated(t *testing.T) {
+	msg := GossipMessage{Type: MessageTypeAlert, Sender: "node-a", Timestamp: time.Now(), Payload: json.RawMessage(`{}`)}
+	data, _ := encodeGossipMessage(&msg, WireFormatBinary)
+
+	for _, cut := range []int{1, 3, len(data) - 1} {
+		if _, err := decodeGossipMessage(data[:cut]); err == nil {
+			t.Errorf("Expected error decoding %d of %d bytes", cut, len(data))
+		}
+	}
+	if _, err := decodeGossipMessage(append(data, 0)); err == nil {
+		t.Error("Expected error for trailing bytes")
+	}
+}
+
+func TestGossipNode_MixedWireFormats(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	newNode := func(format WireFormat) (*GossipNode, *[]string) {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:       "test/v1/alerts",
+			Logger:          zerolog.Nop(),
+			Verifier:        verifier,
+			WireFormat:      format,
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		t.Cleanup(func() { node.Stop() })
+
+		var received []string
+		node.OnAlert(func(alert *types.Alert) {
+			received = append(received, alert.ID)
+		})
+		return node, &received
+	}
+
+	jsonNode, jsonReceived := newNode(WireFormatJSON)
+	binaryNode, binaryReceived := newNode(WireFormatBinary)
+
+	// Each node sends in its own format and receives the other's
+	send := func(from, to *GossipNode, id string) {
+		msg := GossipMessage{
+			Type:      MessageTypeAlert,
+			Sender:    from.host.ID().String(),
+			Timestamp: time.Now(),
+			Payload:   json.RawMessage(`{"id":"` + id + `"}`),
+			Signature: []byte{1},
+		}
+		data, err := encodeGossipMessage(&msg, from.wireFormat)
+		if err != nil {
+			t.Fatalf("encode failed: %v", err)
+		}
+		to.handleMessage(TopicAlerts, data, from.host.ID())
+	}
+	send(jsonNode, binaryNode, "from-json")
+	send(binaryNode, jsonNode, "from-binary")
+
+	if len(*binaryReceived) != 1 || (*binaryReceived)[0] != "from-json" {
+		t.Errorf("Binary node should handle JSON messages, got %v", *binaryReceived)
+	}
+	if len(*jsonReceived) != 1 || (*jsonReceived)[0] != "from-binary" {
+		t.Errorf("JSON node should handle binary messages, got %v", *jsonReceived)
+	}
+}
+
+func TestParseWireFormat(t *testing.T) {
+	for input, expected := range map[string]WireFormat{"": WireFormatJSON, "json": WireFormatJSON, "binary": WireFormatBinary} {
+		if format, err := ParseWireFormat(input); err != nil || format != expected {
+			t.Errorf("ParseWireFormat(%q) = %q, %v; want %q", input, format, err, expected)
+		}
+	}
+	if _, err := ParseWireFormat("protobuf"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
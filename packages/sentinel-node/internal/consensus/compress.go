@@ -0,0 +1,135 @@
+package consensus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression selects the codec applied to outbound gossip messages. Like
+// the wire format, inbound messages are recognised by their leading byte,
+// so every node decompresses both codecs whatever it sends.
+type Compression string
+
+const (
+	// CompressionNone sends messages as encoded (default)
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// Codec tags lead a compressed message, followed by the compressed JSON or
+// binary encoding. Neither clashes with '{' or binaryFormatV1.
+const (
+	codecTagGzip byte = 0x02
+	codecTagZstd byte = 0x03
+)
+
+// minCompressBytes is the smallest encoding worth compressing; below it
+// codec framing outweighs any saving
+const minCompressBytes = 256
+
+var errDecompressedTooLarge = errors.New("decompressed gossip message exceeds maximum size")
+
+var (
+	// EncodeAll is safe for concurrent use, so one encoder serves every node
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+
+	// Decoders are reused; with concurrency 1 they start no goroutines and
+	// need no Close
+	zstdDecoders = sync.Pool{
+		New: func() any {
+			dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+			return dec
+		},
+	}
+)
+
+// ParseCompression converts a config string into a Compression. An empty
+// string yields CompressionNone.
+func ParseCompression(s string) (Compression, error) {
+	switch Compression(s) {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionGzip:
+		return CompressionGzip, nil
+	case CompressionZstd:
+		return CompressionZstd, nil
+	default:
+		return "", fmt.Errorf("unknown compression %q (expected %q, %q or %q)", s, CompressionNone, CompressionGzip, CompressionZstd)
+	}
+}
+
+// compressGossipMessage compresses an encoded message with codec. Messages
+// too small to benefit, or that don't shrink, are returned unchanged.
+func compressGossipMessage(data []byte, codec Compression) ([]byte, error) {
+	if codec == CompressionNone || codec == "" || len(data) < minCompressBytes {
+		return data, nil
+	}
+
+	var out []byte
+	switch codec {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		buf.WriteByte(codecTagGzip)
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		out = buf.Bytes()
+	case CompressionZstd:
+		out = zstdEncoder.EncodeAll(data, []byte{codecTagZstd})
+	default:
+		return nil, fmt.Errorf("unknown compression %q", codec)
+	}
+
+	if len(out) >= len(data) {
+		return data, nil
+	}
+	return out, nil
+}
+
+// decompressGossipMessage reverses compressGossipMessage, refusing output
+// larger than maxBytes. Uncompressed messages are passed through.
+func decompressGossipMessage(data []byte, maxBytes int) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	var r io.Reader
+	switch data[0] {
+	case codecTagGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	case codecTagZstd:
+		dec := zstdDecoders.Get().(*zstd.Decoder)
+		defer zstdDecoders.Put(dec)
+		if err := dec.Reset(bytes.NewReader(data[1:])); err != nil {
+			return nil, err
+		}
+		r = dec
+	default:
+		return data, nil
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, int64(maxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxBytes {
+		return nil, errDecompressedTooLarge
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompression_RoundTripsEachCodec(t *testing.T) {
+	payload := `{"id":"0x` + strings.Repeat("ab", 64) + `","description":"` + strings.Repeat("suspicious approval ", 20) + `"}`
+	msg := GossipMessage{
+		Type:      MessageTypeAlert,
+		Sender:    "node-a",
+		Timestamp: time.Unix(1700000000, 42),
+		Payload:   json.RawMessage(payload),
+		Signature: bytes.Repeat([]byte{0x7f}, 64),
+	}
+
+	tags := map[Compression]byte{CompressionGzip: codecTagGzip, CompressionZstd: codecTagZstd}
+	for _, format := range []WireFormat{WireFormatJSON, WireFormatBinary} {
+		encoded, err := encodeGossipMessage(&msg, format)
+		if err != nil {
+			t.Fatalf("encode failed: %v", err)
+		}
+
+		for codec, tag := range tags {
+			t.Run(string(format)+"/"+string(codec), func(t *testing.T) {
+				compressed, err := compressGossipMessage(encoded, codec)
+				if err != nil {
+					t.Fatalf("compress failed: %v", err)
+				}
+				if compressed[0] != tag {
+					t.Fatalf("Expected codec tag %#x, got %#x", tag, compressed[0])
+				}
+				if len(compressed) >= len(encoded) {
+					t.Errorf("Compressed size %d should be below %d", len(compressed), len(encoded))
+				}
+
+				data, err := decompressGossipMessage(compressed, defaultMaxMessageBytes)
+				if err != nil {
+					t.Fatalf("decompress failed: %v", err)
+				}
+				decoded, err := decodeGossipMessage(data)
+				if err != nil {
+					t.Fatalf("decode failed: %v", err)
+				}
+				if !bytes.Equal(EnvelopeMessage(&decoded), EnvelopeMessage(&msg)) || !bytes.Equal(decoded.Signature, msg.Signature) {
+					t.Errorf("Round trip mismatch:\n got  %+v\n want %+v", decoded, msg)
+				}
+			})
+		}
+	}
+}
+
+func TestCompression_PassesThroughSmallMessages(t *testing.T) {
+	small := []byte(`{"type":"heartbeat","sender":"node-a"}`)
+	for _, codec := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		out, err := compressGossipMessage(small, codec)
+		if err != nil || !bytes.Equal(out, small) {
+			t.Errorf("%s: expected small message unchanged, got %q, %v", codec, out, err)
+		}
+	}
+
+	if out, err := decompressGossipMessage(small, defaultMaxMessageBytes); err != nil || !bytes.Equal(out, small) {
+		t.Errorf("Expected uncompressed message passed through, got %q, %v", out, err)
+	}
+}
+
+func TestCompression_BoundsDecompressedSize(t *testing.T) {
+	// Highly compressible input expands far beyond its wire size
+	bomb := bytes.Repeat([]byte{'a'}, 64<<10)
+	for _, codec := range []Compression{CompressionGzip, CompressionZstd} {
+		compressed, err := compressGossipMessage(bomb, codec)
+		if err != nil {
+			t.Fatalf("%s: compress failed: %v", codec, err)
+		}
+		if _, err := decompressGossipMessage(compressed, 1024); !errors.Is(err, errDecompressedTooLarge) {
+			t.Errorf("%s: expected errDecompressedTooLarge, got %v", codec, err)
+		}
+		if _, err := decompressGossipMessage(compressed[:len(compressed)/2], len(bomb)); err == nil {
+			t.Errorf("%s: expected error for truncated input", codec)
+		}
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	for input, expected := range map[string]Compression{"": CompressionNone, "none": CompressionNone, "gzip": CompressionGzip, "zstd": CompressionZstd} {
+		if codec, err := ParseCompression(input); err != nil || codec != expected {
+			t.Errorf("ParseCompression(%q) = %q, %v; want %q", input, codec, err, expected)
+		}
+	}
+	if _, err := ParseCompression("brotli"); err == nil {
+		t.Error("Expected error for unknown codec")
+	}
+}
@@ -0,0 +1,40 @@
+package consensus
+
+import (
+	"math"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+)
+
+// reputationTag carries a peer's reputation score into the connection
+// manager, which trims the lowest-valued peers first
+const reputationTag = "sentinel-reputation"
+
+// newConnManager limits the host to maxPeers connections. Once over the
+// limit it trims back to 80% of it. It returns nil, leaving connections
+// unbounded, when maxPeers is 0.
+func newConnManager(maxPeers int) (*connmgr.BasicConnMgr, error) {
+	if maxPeers <= 0 {
+		return nil, nil
+	}
+	low := max(1, maxPeers*4/5)
+	return connmgr.NewConnManager(low, maxPeers)
+}
+
+// tagReputation records from's current score with the connection manager
+// so penalized peers are the first to be disconnected when trimming
+func (g *GossipNode) tagReputation(from peer.ID, score float64) {
+	if g.host == nil {
+		return
+	}
+	g.host.ConnManager().TagPeer(from, reputationTag, int(math.Round(score)))
+}
+
+// ConnectionCount returns the number of open connections
+func (g *GossipNode) ConnectionCount() int {
+	if g.host == nil {
+		return 0
+	}
+	return len(g.host.Network().Conns())
+}
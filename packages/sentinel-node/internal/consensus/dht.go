@@ -0,0 +1,147 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	drouting "github.com/libp2p/go-libp2p/p2p/discovery/routing"
+)
+
+const (
+	// rendezvousPrefix namespaces the key sentinels advertise under; the
+	// topic name is appended so separate networks don't find each other
+	rendezvousPrefix = "/sentinel/rendezvous/"
+
+	// defaultAdvertiseInterval is how often the rendezvous advertisement is
+	// refreshed and the DHT searched for new sentinels
+	defaultAdvertiseInterval = 10 * time.Minute
+	// advertiseRetryInterval is used instead while advertising fails, e.g.
+	// before the routing table has any peers
+	advertiseRetryInterval = 30 * time.Second
+	// advertiseTimeout bounds a single advertise or lookup round
+	advertiseTimeout = time.Minute
+)
+
+// ErrDiscoveryDisabled is returned by FindPeers when the node was created
+// without EnableDHT
+var ErrDiscoveryDisabled = errors.New("peer discovery not enabled")
+
+// startDHT joins the Kademlia DHT seeded from the bootstrap peers. It runs
+// before the bootstrap dials so those connections populate the routing
+// table; advertising starts with the node.
+func (g *GossipNode) startDHT(bootstrapPeers []string) error {
+	var seeds []peer.AddrInfo
+	for _, addr := range bootstrapPeers {
+		if info, err := peer.AddrInfoFromString(addr); err == nil {
+			seeds = append(seeds, *info)
+		}
+	}
+
+	kdht, err := dht.New(context.Background(), g.host,
+		dht.Mode(dht.ModeAutoServer),
+		dht.BootstrapPeers(seeds...),
+	)
+	if err != nil {
+		return err
+	}
+	if err := kdht.Bootstrap(context.Background()); err != nil {
+		kdht.Close()
+		return err
+	}
+
+	g.dht = kdht
+	g.discovery = drouting.NewRoutingDiscovery(kdht)
+	return nil
+}
+
+// rendezvous is the key this network's sentinels advertise under
+func (g *GossipNode) rendezvous() string {
+	return rendezvousPrefix + g.topicName
+}
+
+// advertiseLoop keeps the node's rendezvous advertisement fresh and connects
+// to sentinels it discovers, until ctx is canceled
+func (g *GossipNode) advertiseLoop(ctx context.Context) {
+	defer g.wg.Done()
+
+	for {
+		wait := g.advertise(ctx)
+		g.connectDiscoveredPeers(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// advertise publishes the rendezvous record once and returns how long to
+// wait before refreshing it
+func (g *GossipNode) advertise(ctx context.Context) time.Duration {
+	advertiseCtx, cancel := context.WithTimeout(ctx, advertiseTimeout)
+	defer cancel()
+
+	ttl, err := g.discovery.Advertise(advertiseCtx, g.rendezvous())
+	if err != nil {
+		g.logger.Debug().Err(err).Msg("Failed to advertise on the DHT")
+		return advertiseRetryInterval
+	}
+	if ttl > 0 && ttl < defaultAdvertiseInterval {
+		return ttl
+	}
+	return defaultAdvertiseInterval
+}
+
+// connectDiscoveredPeers dials discovered sentinels not already connected
+func (g *GossipNode) connectDiscoveredPeers(ctx context.Context) {
+	findCtx, cancel := context.WithTimeout(ctx, advertiseTimeout)
+	defer cancel()
+
+	found, err := g.FindPeers(findCtx)
+	if err != nil {
+		g.logger.Debug().Err(err).Msg("Failed to find peers on the DHT")
+		return
+	}
+
+	for _, info := range found {
+		if g.host.Network().Connectedness(info.ID) == network.Connected {
+			continue
+		}
+
+		dialCtx, dialCancel := context.WithTimeout(ctx, defaultBootstrapPeerTimeout)
+		err := g.host.Connect(dialCtx, info)
+		dialCancel()
+		if err != nil {
+			g.logger.Debug().Err(err).Str("peer", info.ID.String()).Msg("Failed to connect to discovered peer")
+			continue
+		}
+		g.updatePeer(info.ID)
+	}
+}
+
+// FindPeers returns the sentinels currently advertised on the DHT, other
+// than this node and banned peers
+func (g *GossipNode) FindPeers(ctx context.Context) ([]peer.AddrInfo, error) {
+	if g.discovery == nil {
+		return nil, ErrDiscoveryDisabled
+	}
+
+	ch, err := g.discovery.FindPeers(ctx, g.rendezvous())
+	if err != nil {
+		return nil, err
+	}
+
+	var found []peer.AddrInfo
+	for info := range ch {
+		if info.ID == g.host.ID() || len(info.Addrs) == 0 || g.IsBanned(info.ID) {
+			continue
+		}
+		found = append(found, info)
+	}
+	return found, nil
+}
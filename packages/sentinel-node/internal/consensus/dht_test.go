@@ -0,0 +1,71 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/p2p/discovery/mocks"
+	"github.com/rs/zerolog"
+)
+
+// frozenClock drives the in-memory discovery server's record expiry. The
+// mock expires records at advertise time plus their TTL, and advertise
+// sets none, so time must stand still for them to be found.
+type frozenClock time.Time
+
+func (c frozenClock) Now() time.Time { return time.Time(c) }
+
+func TestGossipNode_AdvertiseAndFindPeers(t *testing.T) {
+	newNode := func() *GossipNode {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:       "test/v1/alerts",
+			Logger:          zerolog.Nop(),
+			Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		return node
+	}
+
+	a, b := newNode(), newNode()
+	defer a.Stop()
+	defer b.Stop()
+
+	ctx := context.Background()
+	if _, err := a.FindPeers(ctx); !errors.Is(err, ErrDiscoveryDisabled) {
+		t.Fatalf("Expected ErrDiscoveryDisabled without a DHT, got %v", err)
+	}
+
+	// Both nodes share an in-memory rendezvous in place of the DHT
+	server := mocks.NewDiscoveryServer(frozenClock(time.Now()))
+	a.discovery = mocks.NewDiscoveryClient(a.host, server)
+	b.discovery = mocks.NewDiscoveryClient(b.host, server)
+
+	if wait := a.advertise(ctx); wait <= 0 || wait > defaultAdvertiseInterval {
+		t.Errorf("Unexpected re-advertise interval %v", wait)
+	}
+	b.advertise(ctx)
+
+	found, err := b.FindPeers(ctx)
+	if err != nil {
+		t.Fatalf("FindPeers failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != a.host.ID() {
+		t.Fatalf("Expected to find only the other node, got %+v", found)
+	}
+
+	b.connectDiscoveredPeers(ctx)
+	if count := b.ActivePeerCount(); count != 1 {
+		t.Errorf("Expected the discovered peer to be connected and registered, got %d", count)
+	}
+
+	// Separate networks use separate rendezvous keys
+	b.topicName = "other/v1/alerts"
+	if found, _ := b.FindPeers(ctx); len(found) != 0 {
+		t.Errorf("Expected no peers under another topic, got %+v", found)
+	}
+}
@@ -0,0 +1,41 @@
+package consensus
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// Received messages wait in a queue per topic for that topic's dispatcher,
+// so a slow handler backs up its own topic rather than the subscription,
+// which pubsub starts dropping from once its small buffer fills
+const defaultHandlerQueueDepth = 256
+
+// enqueue hands a received message to its topic's dispatcher. The receive
+// loop never waits on handlers: with the queue full the message is dropped.
+func (g *GossipNode) enqueue(t *gossipTopic, msg *pubsub.Message) {
+	select {
+	case t.queue <- msg:
+	default:
+		g.metrics.handlerQueueFull(t.class)
+		g.logger.Debug().
+			Str("topic", t.name).
+			Str("peer", msg.ReceivedFrom.String()).
+			Msg("Handler queue full, dropped gossip message")
+	}
+}
+
+// dispatchLoop handles a topic's queued messages one at a time in arrival
+// order, so handlers see each type's messages in the order received
+func (g *GossipNode) dispatchLoop(ctx context.Context, t *gossipTopic) {
+	defer g.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-t.queue:
+			g.handleMessage(t.class, msg.Data, msg.ReceivedFrom)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestGossipNode_SlowHandlerDoesNotStallReceive(t *testing.T) {
+	newNode := func(queueDepth int) *GossipNode {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses:   []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:         "test/v1",
+			HandlerQueueDepth: queueDepth,
+			Logger:            zerolog.Nop(),
+			Verifier:          &MockVerifier{verifyResult: true, registeredNode: true},
+			Signer:            stubSigner{},
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := node.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		t.Cleanup(func() {
+			cancel()
+			node.Stop()
+		})
+		return node
+	}
+
+	sender := newNode(0)
+	receiver := newNode(2)
+
+	// The handler blocks until released; released on cleanup too, ahead of
+	// Stop, so a failed test doesn't hang
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	t.Cleanup(unblock)
+
+	handled := make(chan int, 100)
+	receiver.OnAlert(func(alert *types.Alert) {
+		var n int
+		fmt.Sscanf(alert.ID, "0x%d", &n)
+		handled <- n
+		<-release
+	})
+
+	info := peer.AddrInfo{ID: sender.host.ID(), Addrs: sender.host.Addrs()}
+	if err := receiver.host.Connect(context.Background(), info); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// Publish until an alert gets through the newly formed mesh; the
+	// handler is then stuck on it
+	next := 0
+	publish := func() {
+		sender.BroadcastAlert(&types.Alert{ID: fmt.Sprintf("0x%d", next)})
+		next++
+	}
+	deadline := time.After(15 * time.Second)
+	for {
+		publish()
+		select {
+		case <-handled:
+		case <-time.After(200 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("No alert reached the handler")
+		}
+		break
+	}
+
+	// With the handler stuck, further alerts fill the queue and are then
+	// dropped, which only happens if the receive loop keeps reading
+	drops := receiver.metrics.handlerQueueDrops.WithLabelValues(TopicAlerts)
+	for metricValue(t, drops) == 0 {
+		publish()
+		select {
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Receive loop stopped draining behind a slow handler")
+		}
+	}
+
+	// Once released, the queued alerts are handled in the order received
+	unblock()
+	last := -1
+	for {
+		select {
+		case n := <-handled:
+			if n <= last {
+				t.Errorf("Alert %d handled after alert %d", n, last)
+			}
+			last = n
+			continue
+		case <-time.After(500 * time.Millisecond):
+		}
+		break
+	}
+	if last < 0 {
+		t.Error("Queued alerts weren't handled once the handler was released")
+	}
+}
@@ -0,0 +1,235 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// Evidence a node originated is kept for serving, oldest dropped first
+	defaultEvidenceCacheSize = 256
+	// evidenceRequestRetry is how often an unanswered request is repeated,
+	// covering a mesh still forming or a lost response
+	evidenceRequestRetry = 2 * time.Second
+)
+
+// ErrEvidenceUnavailable is returned when requesting evidence without an
+// evidence topic configured
+var ErrEvidenceUnavailable = errors.New("evidence topic not joined")
+
+// evidenceRequest asks the originator of a pause request for the evidence
+// behind its EvidenceHash
+type evidenceRequest struct {
+	EvidenceHash common.Hash `json:"evidenceHash"`
+}
+
+// evidenceResponse carries evidence whose keccak256 is EvidenceHash
+type evidenceResponse struct {
+	EvidenceHash common.Hash `json:"evidenceHash"`
+	Evidence     []byte      `json:"evidence"`
+}
+
+// evidenceStore holds evidence this node originated, and routes fetched
+// evidence to the requests waiting on it
+type evidenceStore struct {
+	mu      sync.Mutex
+	size    int
+	bundles map[common.Hash][]byte
+	order   []common.Hash
+	waiters map[common.Hash][]chan []byte
+}
+
+func newEvidenceStore(size int) *evidenceStore {
+	if size <= 0 {
+		size = defaultEvidenceCacheSize
+	}
+	return &evidenceStore{
+		size:    size,
+		bundles: make(map[common.Hash][]byte),
+		waiters: make(map[common.Hash][]chan []byte),
+	}
+}
+
+func (s *evidenceStore) add(hash common.Hash, evidence []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.bundles[hash]; exists {
+		return
+	}
+	if len(s.order) >= s.size {
+		delete(s.bundles, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.bundles[hash] = evidence
+	s.order = append(s.order, hash)
+}
+
+func (s *evidenceStore) get(hash common.Hash) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	evidence, ok := s.bundles[hash]
+	return evidence, ok
+}
+
+// wait registers for evidence matching hash; the returned func must be
+// called once the caller stops waiting
+func (s *evidenceStore) wait(hash common.Hash) (<-chan []byte, func()) {
+	ch := make(chan []byte, 1)
+
+	s.mu.Lock()
+	s.waiters[hash] = append(s.waiters[hash], ch)
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		waiting := s.waiters[hash]
+		for i, c := range waiting {
+			if c == ch {
+				waiting = append(waiting[:i], waiting[i+1:]...)
+				break
+			}
+		}
+		if len(waiting) == 0 {
+			delete(s.waiters, hash)
+		} else {
+			s.waiters[hash] = waiting
+		}
+	}
+}
+
+// deliver hands evidence to everyone waiting on hash
+func (s *evidenceStore) deliver(hash common.Hash, evidence []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	waiting := s.waiters[hash]
+	delete(s.waiters, hash)
+	for _, ch := range waiting {
+		ch <- evidence
+	}
+}
+
+// StoreEvidence keeps evidence for a pause request this node originates and
+// returns its hash, the EvidenceHash to put in the request. Peers that
+// receive the request fetch the evidence with RequestEvidence.
+func (g *GossipNode) StoreEvidence(evidence []byte) common.Hash {
+	hash := crypto.Keccak256Hash(evidence)
+	g.evidence.add(hash, evidence)
+	return hash
+}
+
+// RequestEvidence fetches the evidence matching hash from its originator
+// over the evidence topic, repeating the request until it is answered or
+// ctx is done. The evidence is checked against hash before it is returned.
+func (g *GossipNode) RequestEvidence(ctx context.Context, hash common.Hash) ([]byte, error) {
+	if evidence, ok := g.evidence.get(hash); ok {
+		return evidence, nil
+	}
+	topic := g.topics[topicEvidence]
+	if topic == nil {
+		return nil, ErrEvidenceUnavailable
+	}
+
+	payload, err := json.Marshal(evidenceRequest{EvidenceHash: hash})
+	if err != nil {
+		return nil, err
+	}
+
+	received, done := g.evidence.wait(hash)
+	defer done()
+
+	ticker := time.NewTicker(evidenceRequestRetry)
+	defer ticker.Stop()
+
+	for {
+		msg := GossipMessage{
+			Type:      MessageTypeEvidenceRequest,
+			Sender:    g.host.ID().String(),
+			Timestamp: time.Now(),
+			Payload:   payload,
+		}
+		if err := g.publish(topic, msg); err != nil {
+			g.logger.Debug().Err(err).Str("evidence", hash.Hex()).Msg("Failed to publish evidence request")
+		}
+
+		select {
+		case evidence := <-received:
+			return evidence, nil
+		case <-ctx.Done():
+			return nil, fmt.Errorf("evidence %s: %w", hash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// handleEvidenceRequest answers a request for evidence this node holds.
+// Only the originator holds it, so a request is answered once. It reports
+// whether the request was well-formed.
+func (g *GossipNode) handleEvidenceRequest(payload json.RawMessage, from peer.ID) bool {
+	var request evidenceRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		g.logger.Warn().Err(err).Msg("Failed to unmarshal evidence request")
+		g.penalize(from, "malformed_payload")
+		return false
+	}
+
+	evidence, ok := g.evidence.get(request.EvidenceHash)
+	if !ok {
+		return true
+	}
+
+	response, err := json.Marshal(evidenceResponse{EvidenceHash: request.EvidenceHash, Evidence: evidence})
+	if err != nil {
+		return true
+	}
+	// Published off the receive path; signing and publishing can take a
+	// while. Called from a listen loop, so the wait group is still held.
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		msg := GossipMessage{
+			Type:      MessageTypeEvidenceResponse,
+			Sender:    g.host.ID().String(),
+			Timestamp: time.Now(),
+			Payload:   response,
+		}
+		if err := g.publish(g.topics[topicEvidence], msg); err != nil {
+			g.logger.Warn().Err(err).Str("evidence", request.EvidenceHash.Hex()).Msg("Failed to publish evidence")
+		}
+	}()
+	return true
+}
+
+// handleEvidenceResponse delivers evidence to pending requests. Evidence
+// nobody asked for is dropped rather than cached. It reports whether the
+// evidence matched its hash.
+func (g *GossipNode) handleEvidenceResponse(payload json.RawMessage, from peer.ID) bool {
+	var response evidenceResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		g.logger.Warn().Err(err).Msg("Failed to unmarshal evidence response")
+		g.penalize(from, "malformed_payload")
+		return false
+	}
+
+	if crypto.Keccak256Hash(response.Evidence) != response.EvidenceHash {
+		g.logger.Warn().
+			Str("peer", from.String()).
+			Str("evidence", response.EvidenceHash.Hex()).
+			Msg("Rejected evidence not matching its hash")
+		g.penalize(from, "invalid_evidence")
+		return false
+	}
+
+	g.evidence.deliver(response.EvidenceHash, response.Evidence)
+	return true
+}
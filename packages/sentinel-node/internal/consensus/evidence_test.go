@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+)
+
+// stubSigner signs every envelope with the same bytes; MockVerifier
+// accepts them
+type stubSigner struct{}
+
+func (stubSigner) SignMessage(message []byte) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func newEvidenceTestNode(t *testing.T) *GossipNode {
+	t.Helper()
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:   []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:         "test/v1/alerts",
+		EvidenceTopicName: "test/v1/evidence",
+		Logger:            zerolog.Nop(),
+		Verifier:          &MockVerifier{verifyResult: true, registeredNode: true},
+		Signer:            stubSigner{},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := node.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		node.Stop()
+	})
+	return node
+}
+
+func TestGossipNode_FetchesEvidenceFromOriginator(t *testing.T) {
+	originator := newEvidenceTestNode(t)
+	verifier := newEvidenceTestNode(t)
+
+	info := peer.AddrInfo{ID: originator.host.ID(), Addrs: originator.host.Addrs()}
+	if err := verifier.host.Connect(context.Background(), info); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	evidence := []byte(`{"tx":"0xabc","trace":["CALL","DELEGATECALL"]}`)
+	hash := originator.StoreEvidence(evidence)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	fetched, err := verifier.RequestEvidence(ctx, hash)
+	if err != nil {
+		t.Fatalf("RequestEvidence failed: %v", err)
+	}
+	if string(fetched) != string(evidence) {
+		t.Errorf("Expected the originator's evidence, got %s", fetched)
+	}
+
+	// Nobody holds evidence for an unknown hash
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := verifier.RequestEvidence(ctx, crypto.Keccak256Hash([]byte("unknown"))); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to time out, got %v", err)
+	}
+}
+
+func TestGossipNode_RejectsEvidenceNotMatchingHash(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:   []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:         "test/v1/alerts",
+		EvidenceTopicName: "test/v1/evidence",
+		Logger:            zerolog.Nop(),
+		Verifier:          &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	hash := crypto.Keccak256Hash([]byte("genuine"))
+	received, done := node.evidence.wait(hash)
+	defer done()
+
+	respond := func(evidence string) {
+		payload, _ := json.Marshal(evidenceResponse{EvidenceHash: hash, Evidence: []byte(evidence)})
+		data, _ := json.Marshal(GossipMessage{
+			Type:      MessageTypeEvidenceResponse,
+			Sender:    "node-a",
+			Timestamp: time.Now(),
+			Payload:   payload,
+		})
+		node.handleMessage(topicEvidence, data, newTestPeerID(t))
+	}
+
+	respond("forged")
+	select {
+	case evidence := <-received:
+		t.Fatalf("Evidence not matching its hash was delivered: %s", evidence)
+	default:
+	}
+
+	respond("genuine")
+	select {
+	case evidence := <-received:
+		if string(evidence) != "genuine" {
+			t.Errorf("Expected the genuine evidence, got %s", evidence)
+		}
+	default:
+		t.Error("Expected evidence matching its hash to be delivered")
+	}
+}
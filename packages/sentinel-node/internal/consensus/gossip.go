@@ -3,26 +3,62 @@ package consensus
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
-	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/rs/zerolog"
 
 	"github.com/sentinel-protocol/sentinel-node/pkg/types"
 )
 
+// Bootstrap dials are bounded so unreachable peers can't stall startup
+const (
+	defaultBootstrapPeerTimeout = 5 * time.Second
+	defaultBootstrapTimeout     = 15 * time.Second
+)
+
+// Inbound messages outside this window around the local clock are dropped
+const (
+	defaultMaxMessageAge = 10 * time.Minute
+	defaultMaxClockSkew  = time.Minute
+)
+
+// defaultMaxMessageBytes bounds a single encoded gossip message
+const defaultMaxMessageBytes = 256 << 10
+
+// ErrGossipUnavailable is returned when broadcasting before the gossip topic
+// has been joined
+var ErrGossipUnavailable = errors.New("gossip topic not joined")
+
+// ErrMessageTooLarge is returned when an encoded message exceeds the
+// configured maximum size, which peers would reject
+var ErrMessageTooLarge = errors.New("gossip message exceeds maximum size")
+
 type MessageType string
 
 const (
-	MessageTypePauseRequest    MessageType = "pause_request"
-	MessageTypeSignature       MessageType = "signature"
-	MessageTypeHeartbeat       MessageType = "heartbeat"
-	MessageTypeAlert           MessageType = "alert"
+	MessageTypePauseRequest MessageType = "pause_request"
+	MessageTypeSignature    MessageType = "signature"
+	MessageTypeHeartbeat    MessageType = "heartbeat"
+	MessageTypeAlert        MessageType = "alert"
+	// MessageTypeAlertAck marks an alert handled by the sending node
+	MessageTypeAlertAck MessageType = "alert_ack"
+	// Evidence is requested and served on the evidence topic
+	MessageTypeEvidenceRequest  MessageType = "evidence_request"
+	MessageTypeEvidenceResponse MessageType = "evidence_response"
 )
 
 type GossipMessage struct {
@@ -30,11 +66,25 @@ type GossipMessage struct {
 	Sender    string          `json:"sender"`
 	Timestamp time.Time       `json:"timestamp"`
 	Payload   json.RawMessage `json:"payload"`
+	// Signature is the sender's BLS signature over EnvelopeMessage; every
+	// type but heartbeats must carry one
+	Signature []byte `json:"signature,omitempty"`
+	// Seq increases by one with each signed message a sender publishes, so
+	// receivers can spot gaps and replays. Heartbeats, being unsigned,
+	// carry none; zero means unset.
+	Seq uint64 `json:"seq,omitempty"`
+	// Operator is the registered address the sender signs as. Senders are
+	// known by libp2p peer ID, which the registry doesn't record, so the
+	// registration and envelope checks resolve the operator instead, and
+	// anything attributed to a message's origin keys on it. Heartbeats
+	// leave it zero.
+	Operator common.Address `json:"operator"`
 }
 
 type PauseRequestHandler func(*types.SignedPauseRequest)
 type SignatureHandler func(requestID string, signature []byte, signer string)
 type AlertHandler func(*types.Alert)
+type AlertAckHandler func(*types.AlertAck)
 
 // SignatureVerifier validates message signatures from peers
 type SignatureVerifier interface {
@@ -42,27 +92,127 @@ type SignatureVerifier interface {
 	VerifyPauseRequest(request *types.SignedPauseRequest) bool
 	// IsRegisteredNode checks if an address is a registered active node
 	IsRegisteredNode(address string) bool
+	// AreRegisteredNodes checks many addresses at once, keyed by address
+	AreRegisteredNodes(addresses []string) map[string]bool
+	// VerifyMessage verifies an envelope signature against the registered
+	// key of the operator address it was sent as
+	VerifyMessage(operator string, message, signature []byte) bool
+}
+
+// FailurePolicy decides what a verification check does when the lookup it
+// depends on (registry RPC, key lookup) fails rather than returning an answer
+type FailurePolicy string
+
+const (
+	// FailClosed rejects the message when the lookup errors (secure default)
+	FailClosed FailurePolicy = "closed"
+	// FailOpen accepts the message when the lookup errors, favouring availability
+	FailOpen FailurePolicy = "open"
+)
+
+// ParseFailurePolicy converts a config string into a FailurePolicy.
+// An empty string yields FailClosed.
+func ParseFailurePolicy(s string) (FailurePolicy, error) {
+	switch FailurePolicy(s) {
+	case "", FailClosed:
+		return FailClosed, nil
+	case FailOpen:
+		return FailOpen, nil
+	default:
+		return "", fmt.Errorf("unknown failure policy %q (expected %q or %q)", s, FailOpen, FailClosed)
+	}
+}
+
+// Accept reports whether a check governed by this policy passes on lookup error
+func (p FailurePolicy) Accept() bool {
+	return p == FailOpen
 }
 
 type GossipNode struct {
-	host      host.Host
-	pubsub    *pubsub.PubSub
-	topic     *pubsub.Topic
-	sub       *pubsub.Subscription
+	host   host.Host
+	pubsub *pubsub.PubSub
+	// topics holds each joined topic by class; topicName is their prefix
+	topics    map[string]*gossipTopic
 	topicName string
 
+	// Evidence this node originated, served on the evidence topic
+	evidence *evidenceStore
+
 	pauseHandlers     []PauseRequestHandler
 	signatureHandlers []SignatureHandler
 	alertHandlers     []AlertHandler
+	alertAckHandlers  []AlertAckHandler
+	peerCountHandlers []PeerCountHandler
+
+	peers   map[peer.ID]*PeerInfo
+	peersMu sync.RWMutex
+	// lastSeq is the highest sequence number accepted from each operator,
+	// by topic class, guarded by peersMu
+	lastSeq map[common.Address]map[string]uint64
+	// peerHealth is whether enough peers are active, guarded by peersMu
+	peerHealth peerHealth
+
+	// Signature shares collected for pause requests this node broadcast
+	pendingPauses *PendingPauseTracker
+
+	// Peers remembered across restarts; loadedPeers is guarded by peersMu
+	knownPeers  *knownPeerStore
+	loadedPeers []knownPeer
+
+	// mdns discovers peers on the local network; nil unless EnableMDNS
+	mdns mdns.Service
 
-	peers    map[peer.ID]*PeerInfo
-	peersMu  sync.RWMutex
-	running  bool
-	mu       sync.RWMutex
-	wg       sync.WaitGroup
+	// discovery advertises and finds sentinels by rendezvous key; it is
+	// backed by dht and nil unless EnableDHT
+	dht       *dht.IpfsDHT
+	discovery discovery.Discovery
+
+	metrics *gossipMetrics
+
+	// publishMu holds each topic's sequence number steady from assignment
+	// to publish so messages leave in sequence order
+	publishMu sync.Mutex
+
+	running bool
+	mu      sync.RWMutex
+	wg      sync.WaitGroup
 
 	// FIX: Add signature verifier for message authentication
 	verifier SignatureVerifier
+	signer   MessageSigner
+	// operator is the registered address outbound envelopes are signed as
+	operator common.Address
+
+	// Peer reputation survives restarts; peers at or below blockThreshold are ignored
+	reputation     *ReputationStore
+	blockThreshold float64
+
+	// Peers at or below banThreshold are disconnected and refused for banDuration
+	bans         *banList
+	banThreshold float64
+	banDuration  time.Duration
+	eviction     evictionPolicy
+
+	// Outbound bytes are counted and optionally capped
+	bandwidth *BandwidthLimiter
+
+	// Recently handled messages; replays are dropped before any handler runs
+	seen *seenCache
+
+	// Encoding for outbound messages
+	wireFormat  WireFormat
+	compression Compression
+
+	// Larger messages are dropped before decoding and refused by pubsub
+	maxMessageBytes int
+
+	// Accepted window for message timestamps
+	maxMessageAge    time.Duration
+	maxClockSkew     time.Duration
+	measureClockSkew bool
+
+	// Inbound message budget per peer
+	peerLimiter *peerRateLimiter
 
 	logger zerolog.Logger
 }
@@ -71,15 +221,129 @@ type PeerInfo struct {
 	ID            peer.ID
 	LastHeartbeat time.Time
 	IsActive      bool
+	// PeerScore mirrors the peer's reputation: it drops on each rejected
+	// message and recovers slowly on valid ones
+	PeerScore float64
+	// ClockSkew is how far ahead of the local clock the peer's last
+	// heartbeat was timestamped, negative if behind; transit time is
+	// included. Only tracked with MeasureClockSkew.
+	ClockSkew time.Duration
 }
 
 type GossipConfig struct {
 	ListenAddresses []string
 	BootstrapPeers  []string
-	TopicName       string
-	Logger          zerolog.Logger
+	// TopicName prefixes the name of each topic joined
+	TopicName string
+	Logger    zerolog.Logger
+	// Topics lists the topic classes to join: TopicAlerts, TopicConsensus
+	// and TopicHeartbeat (empty = all)
+	Topics []string
 	// Verifier validates message signatures (REQUIRED for security)
-	Verifier        SignatureVerifier
+	Verifier SignatureVerifier
+	// Signer signs outbound envelopes; without it only heartbeats can be sent
+	Signer MessageSigner
+	// Operator is the registered address Signer's key belongs to. Peers
+	// checking against the registry reject envelopes without it.
+	Operator common.Address
+	// DataDir is where peer reputation is persisted (empty keeps it in memory)
+	DataDir string
+	// PersistPeers saves recently seen peers under DataDir on Stop and
+	// redials them on Start, keeping at most MaxPersistedPeers (0 = 100)
+	// seen within MaxPersistedPeerAge (0 = 24h)
+	PersistPeers        bool
+	MaxPersistedPeers   int
+	MaxPersistedPeerAge time.Duration
+	// EnableMDNS discovers and connects to other nodes on the local
+	// network, for development clusters without bootstrap peers
+	EnableMDNS bool
+	// EnableDHT joins a Kademlia DHT seeded from BootstrapPeers, advertises
+	// the node under a rendezvous key derived from TopicName and connects
+	// to other sentinels found there
+	EnableDHT bool
+	// ReputationHalfLife is how quickly a peer's negative score recovers
+	ReputationHalfLife time.Duration
+	// BlockThreshold is the score at or below which a peer's messages are dropped
+	BlockThreshold float64
+	// MaxOutboundBytesPerSec caps published gossip traffic (0 = unlimited)
+	MaxOutboundBytesPerSec int64
+	// BootstrapPeerTimeout bounds each bootstrap dial (0 = 5s)
+	BootstrapPeerTimeout time.Duration
+	// BootstrapTimeout bounds the whole bootstrap phase (0 = 15s)
+	BootstrapTimeout time.Duration
+	// SeenCacheSize bounds how many messages are remembered for replay
+	// protection (0 = 10000)
+	SeenCacheSize int
+	// SeenCacheTTL is how long a handled message is remembered (0 = 30m)
+	SeenCacheTTL time.Duration
+	// TopicPolicies restricts message types per topic name; topics without
+	// an entry accept and publish everything
+	TopicPolicies map[string]TopicPolicy
+	// MaxMessageAge drops messages timestamped longer ago than this (0 = 10m)
+	MaxMessageAge time.Duration
+	// MaxClockSkew is the tolerance for peers' clocks: messages up to this
+	// far ahead, or this far past MaxMessageAge, are still accepted (0 = 1m)
+	MaxClockSkew time.Duration
+	// MeasureClockSkew tracks each peer's clock offset from its heartbeats
+	// and warns when it exceeds MaxClockSkew
+	MeasureClockSkew bool
+	// PeerMessagesPerSec caps inbound messages from each peer, heartbeats
+	// included (0 = unlimited)
+	PeerMessagesPerSec float64
+	// PeerMessageBurst is how many messages a peer may send at once
+	// (0 = one second's worth)
+	PeerMessageBurst int
+	// WireFormat encodes outbound messages; both formats are always
+	// accepted inbound (empty = JSON)
+	WireFormat WireFormat
+	// Compression compresses outbound messages worth compressing; both
+	// codecs are always accepted inbound (empty = none)
+	Compression Compression
+	// MaxMessageBytes caps the size of an encoded message in either
+	// direction (0 = 256KB)
+	MaxMessageBytes int
+	// MaxPeers caps open connections; the lowest-reputation peers are
+	// trimmed first (0 = unbounded)
+	MaxPeers int
+	// BanThreshold is the score at or below which a peer is disconnected
+	// and banned (0 = BlockThreshold)
+	BanThreshold float64
+	// BanDuration is how long a banned peer is refused (0 = 1h)
+	BanDuration time.Duration
+	// EvictionWindow and EvictionMinViolations require this many violations
+	// within the window before a ban (0 = 10m, 1)
+	EvictionWindow        time.Duration
+	EvictionMinViolations int
+	// EvictionGracePeriod is how long a peer's earliest violation in the
+	// window must have persisted before it is banned (0 = none)
+	EvictionGracePeriod time.Duration
+	// EvictionCooldown protects a peer from another ban for this long
+	// after its previous one expires (0 = none)
+	EvictionCooldown time.Duration
+
+	// EvidenceTopicName is the topic pause request evidence is fetched on,
+	// kept off TopicName since evidence can be large (empty = disabled)
+	EvidenceTopicName string
+	// HandlerQueueDepth is how many received messages each topic holds for
+	// its handlers; more arriving while they lag are dropped (0 = 256)
+	HandlerQueueDepth int
+	// MinHealthyPeers is the fewest active peers for a healthy mesh;
+	// crossing it either way is logged and reported to OnPeerCountChange
+	// handlers (0 = not tracked). Recovering takes PeerCountHysteresis
+	// peers more than the minimum (0 = 1).
+	MinHealthyPeers     int
+	PeerCountHysteresis int
+
+	// PauseQuorum is how many distinct signers complete a pause request
+	// tracked with TrackPauseRequest (0 = 1); one still short of it after
+	// PauseRequestTimeout is dropped (0 = 5m)
+	PauseQuorum         int
+	PauseRequestTimeout time.Duration
+	// PauseStakes, when set, has pause requests complete once signers
+	// holding PauseStakeFraction of the stake it returns have signed
+	// (outside (0, 1] = 2/3), instead of at PauseQuorum signers
+	PauseStakes        func() map[common.Address]*big.Int
+	PauseStakeFraction float64
 }
 
 func NewGossipNode(cfg GossipConfig) (*GossipNode, error) {
@@ -88,43 +352,188 @@ func NewGossipNode(cfg GossipConfig) (*GossipNode, error) {
 		return nil, fmt.Errorf("signature verifier is required for secure gossip operation")
 	}
 
-	h, err := libp2p.New(
-		libp2p.ListenAddrStrings(cfg.ListenAddresses...),
-	)
+	if err := validateListenAddresses(cfg.ListenAddresses); err != nil {
+		return nil, err
+	}
+
+	wireFormat, err := ParseWireFormat(string(cfg.WireFormat))
+	if err != nil {
+		return nil, err
+	}
+	compression, err := ParseCompression(string(cfg.Compression))
 	if err != nil {
 		return nil, err
 	}
 
-	ps, err := pubsub.NewGossipSub(context.Background(), h)
+	names, err := topicNames(cfg)
 	if err != nil {
-		h.Close()
 		return nil, err
 	}
 
-	topic, err := ps.Join(cfg.TopicName)
+	bans := newBanList()
+	opts := []libp2p.Option{
+		libp2p.ListenAddrStrings(cfg.ListenAddresses...),
+		libp2p.ConnectionGater(bans),
+	}
+	connMgr, err := newConnManager(cfg.MaxPeers)
+	if err != nil {
+		return nil, fmt.Errorf("connection manager: %w", err)
+	}
+	if connMgr != nil {
+		opts = append(opts, libp2p.ConnectionManager(connMgr))
+	}
+
+	h, err := libp2p.New(opts...)
+	if err != nil {
+		if connMgr != nil {
+			connMgr.Close()
+		}
+		return nil, err
+	}
+
+	maxMessageBytes := cfg.MaxMessageBytes
+	if maxMessageBytes <= 0 {
+		maxMessageBytes = defaultMaxMessageBytes
+	}
+
+	ps, err := pubsub.NewGossipSub(context.Background(), h, pubsub.WithMaxMessageSize(maxMessageBytes))
 	if err != nil {
 		h.Close()
 		return nil, err
 	}
 
-	sub, err := topic.Subscribe()
+	topics, err := joinTopics(ps, cfg, names)
 	if err != nil {
-		topic.Close()
 		h.Close()
 		return nil, err
 	}
 
+	reputation := NewReputationStore(cfg.DataDir, cfg.ReputationHalfLife)
+	if err := reputation.Load(); err != nil {
+		cfg.Logger.Warn().Err(err).Msg("Failed to load peer reputation, starting fresh")
+	}
+
+	blockThreshold := cfg.BlockThreshold
+	if blockThreshold == 0 {
+		blockThreshold = defaultBlockThreshold
+	}
+	banThreshold := cfg.BanThreshold
+	if banThreshold == 0 {
+		banThreshold = blockThreshold
+	}
+	banDuration := cfg.BanDuration
+	if banDuration <= 0 {
+		banDuration = defaultBanDuration
+	}
+	eviction := evictionPolicy{
+		window:        cfg.EvictionWindow,
+		minViolations: cfg.EvictionMinViolations,
+		grace:         cfg.EvictionGracePeriod,
+		cooldown:      cfg.EvictionCooldown,
+	}
+	if eviction.window <= 0 {
+		eviction.window = defaultEvictionWindow
+	}
+	// Only the most recent violations are recorded per peer
+	eviction.minViolations = min(max(eviction.minViolations, 1), maxRecordedViolations)
+
+	maxMessageAge := cfg.MaxMessageAge
+	if maxMessageAge <= 0 {
+		maxMessageAge = defaultMaxMessageAge
+	}
+	maxClockSkew := cfg.MaxClockSkew
+	if maxClockSkew <= 0 {
+		maxClockSkew = defaultMaxClockSkew
+	}
+
 	node := &GossipNode{
-		host:      h,
-		pubsub:    ps,
-		topic:     topic,
-		sub:       sub,
-		topicName: cfg.TopicName,
-		peers:     make(map[peer.ID]*PeerInfo),
-		verifier:  cfg.Verifier,
-		logger:    cfg.Logger,
+		host:            h,
+		pubsub:          ps,
+		topics:          topics,
+		topicName:       cfg.TopicName,
+		peers:           make(map[peer.ID]*PeerInfo),
+		lastSeq:         make(map[common.Address]map[string]uint64),
+		verifier:        cfg.Verifier,
+		signer:          cfg.Signer,
+		operator:        cfg.Operator,
+		reputation:      reputation,
+		blockThreshold:  blockThreshold,
+		bans:            bans,
+		banThreshold:    banThreshold,
+		banDuration:     banDuration,
+		eviction:        eviction,
+		bandwidth:       NewBandwidthLimiter(cfg.MaxOutboundBytesPerSec),
+		seen:            newSeenCache(cfg.SeenCacheSize, cfg.SeenCacheTTL),
+		wireFormat:      wireFormat,
+		compression:     compression,
+		maxMessageBytes: maxMessageBytes,
+		maxMessageAge:   maxMessageAge,
+		maxClockSkew:    maxClockSkew,
+		peerLimiter:     newPeerRateLimiter(cfg.PeerMessagesPerSec, cfg.PeerMessageBurst),
+		logger:          cfg.Logger,
 	}
 
+	node.measureClockSkew = cfg.MeasureClockSkew
+	node.peerHealth = peerHealth{minPeers: cfg.MinHealthyPeers, hysteresis: cfg.PeerCountHysteresis}
+	if node.peerHealth.hysteresis <= 0 {
+		node.peerHealth.hysteresis = defaultPeerCountHysteresis
+	}
+	node.evidence = newEvidenceStore(0)
+	if cfg.PauseStakes != nil {
+		node.pendingPauses = NewWeightedPendingPauseTracker(cfg.PauseStakes, cfg.PauseStakeFraction, cfg.PauseRequestTimeout)
+	} else {
+		node.pendingPauses = NewPendingPauseTracker(cfg.PauseQuorum, cfg.PauseRequestTimeout)
+	}
+	node.metrics = newGossipMetrics(node.ActivePeerCount)
+	queueDepth := cfg.HandlerQueueDepth
+	if queueDepth <= 0 {
+		queueDepth = defaultHandlerQueueDepth
+	}
+	for _, t := range topics {
+		t.seq = seqEpochStart(time.Now())
+		t.queue = make(chan *pubsub.Message, queueDepth)
+	}
+
+	if cfg.PersistPeers {
+		node.knownPeers = newKnownPeerStore(cfg.DataDir, cfg.MaxPersistedPeers, cfg.MaxPersistedPeerAge)
+	}
+
+	if cfg.EnableDHT {
+		if err := node.startDHT(cfg.BootstrapPeers); err != nil {
+			h.Close()
+			return nil, fmt.Errorf("failed to start DHT: %w", err)
+		}
+	}
+
+	if cfg.EnableMDNS {
+		if err := node.startMDNS(); err != nil {
+			h.Close()
+			return nil, fmt.Errorf("failed to start mDNS discovery: %w", err)
+		}
+	}
+
+	connectBootstrapPeers(h, cfg)
+
+	return node, nil
+}
+
+// connectBootstrapPeers dials all bootstrap peers concurrently, each under
+// its own timeout and all within an overall budget. Failures are logged;
+// the node can still be reached by peers that bootstrap through it.
+func connectBootstrapPeers(h host.Host, cfg GossipConfig) {
+	peerTimeout := cfg.BootstrapPeerTimeout
+	if peerTimeout <= 0 {
+		peerTimeout = defaultBootstrapPeerTimeout
+	}
+	budget := cfg.BootstrapTimeout
+	if budget <= 0 {
+		budget = defaultBootstrapTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+
+	var wg sync.WaitGroup
 	for _, addr := range cfg.BootstrapPeers {
 		peerInfo, err := peer.AddrInfoFromString(addr)
 		if err != nil {
@@ -132,12 +541,32 @@ func NewGossipNode(cfg GossipConfig) (*GossipNode, error) {
 			continue
 		}
 
-		if err := h.Connect(context.Background(), *peerInfo); err != nil {
-			cfg.Logger.Warn().Err(err).Str("peer", peerInfo.ID.String()).Msg("Failed to connect to bootstrap peer")
-		}
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+
+			dialCtx, dialCancel := context.WithTimeout(ctx, peerTimeout)
+			defer dialCancel()
+
+			if err := h.Connect(dialCtx, info); err != nil {
+				cfg.Logger.Warn().Err(err).Str("peer", info.ID.String()).Msg("Failed to connect to bootstrap peer")
+			}
+		}(*peerInfo)
 	}
 
-	return node, nil
+	wg.Wait()
+}
+
+// validateListenAddresses checks each listen address is a well-formed
+// multiaddr so misconfiguration is reported clearly instead of failing
+// deep inside libp2p construction
+func validateListenAddresses(addrs []string) error {
+	for _, addr := range addrs {
+		if _, err := multiaddr.NewMultiaddr(addr); err != nil {
+			return fmt.Errorf("invalid listen address %q: %w", addr, err)
+		}
+	}
+	return nil
 }
 
 func (g *GossipNode) Start(ctx context.Context) error {
@@ -149,10 +578,25 @@ func (g *GossipNode) Start(ctx context.Context) error {
 	g.running = true
 	g.mu.Unlock()
 
-	g.wg.Add(2)
-	go g.listenLoop(ctx)
+	g.wg.Add(1)
 	go g.heartbeatLoop(ctx)
 
+	for _, t := range g.topics {
+		g.wg.Add(2)
+		go g.listenLoop(ctx, t)
+		go g.dispatchLoop(ctx, t)
+	}
+
+	if g.knownPeers != nil {
+		g.wg.Add(1)
+		go g.reconnectKnownPeers(ctx)
+	}
+
+	if g.discovery != nil {
+		g.wg.Add(1)
+		go g.advertiseLoop(ctx)
+	}
+
 	g.logger.Info().
 		Str("peerID", g.host.ID().String()).
 		Strs("addrs", g.ListenAddresses()).
@@ -168,10 +612,21 @@ func (g *GossipNode) Stop() {
 
 	g.wg.Wait()
 
-	g.sub.Cancel()
-	g.topic.Close()
+	if g.mdns != nil {
+		g.mdns.Close()
+	}
+	if g.dht != nil {
+		g.dht.Close()
+	}
+	g.persistKnownPeers()
+
+	closeTopics(g.topics)
 	g.host.Close()
 
+	if err := g.reputation.Save(); err != nil {
+		g.logger.Warn().Err(err).Msg("Failed to persist peer reputation")
+	}
+
 	g.logger.Info().Msg("Gossip node stopped")
 }
 
@@ -181,6 +636,8 @@ func (g *GossipNode) OnPauseRequest(handler PauseRequestHandler) {
 	g.pauseHandlers = append(g.pauseHandlers, handler)
 }
 
+// OnSignature registers a handler for co-sign shares, given the operator
+// address that sent each one
 func (g *GossipNode) OnSignature(handler SignatureHandler) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -193,7 +650,18 @@ func (g *GossipNode) OnAlert(handler AlertHandler) {
 	g.alertHandlers = append(g.alertHandlers, handler)
 }
 
+// OnAlertAck registers a handler for peers' acknowledgments of handled alerts
+func (g *GossipNode) OnAlertAck(handler AlertAckHandler) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.alertAckHandlers = append(g.alertAckHandlers, handler)
+}
+
 func (g *GossipNode) BroadcastPauseRequest(request *types.SignedPauseRequest) error {
+	if g.topics[TopicConsensus] == nil {
+		return ErrGossipUnavailable
+	}
+
 	payload, err := json.Marshal(request)
 	if err != nil {
 		return err
@@ -209,12 +677,17 @@ func (g *GossipNode) BroadcastPauseRequest(request *types.SignedPauseRequest) er
 	return g.broadcast(msg)
 }
 
-func (g *GossipNode) BroadcastSignature(requestID string, signature []byte) error {
-	payload := struct {
-		RequestID string `json:"requestId"`
-		Signature []byte `json:"signature"`
-	}{
+// BroadcastSignature sends signer's share of the pause request requestID
+// back to its originator. The share names its signer, which must be the
+// operator this node's envelopes are signed as.
+func (g *GossipNode) BroadcastSignature(requestID string, signer common.Address, signature []byte) error {
+	if g.topics[TopicConsensus] == nil {
+		return ErrGossipUnavailable
+	}
+
+	payload := signaturePayload{
 		RequestID: requestID,
+		Signer:    signer,
 		Signature: signature,
 	}
 
@@ -234,6 +707,10 @@ func (g *GossipNode) BroadcastSignature(requestID string, signature []byte) erro
 }
 
 func (g *GossipNode) BroadcastAlert(alert *types.Alert) error {
+	if g.topics[TopicAlerts] == nil {
+		return ErrGossipUnavailable
+	}
+
 	payload, err := json.Marshal(alert)
 	if err != nil {
 		return err
@@ -249,20 +726,112 @@ func (g *GossipNode) BroadcastAlert(alert *types.Alert) error {
 	return g.broadcast(msg)
 }
 
+// BroadcastAlertAck tells peers this node has acted on alertID so they can
+// suppress their own escalation of it
+func (g *GossipNode) BroadcastAlertAck(alertID, action string) error {
+	if g.topics[TopicAlerts] == nil {
+		return ErrGossipUnavailable
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(types.AlertAck{
+		AlertID:   alertID,
+		Action:    action,
+		HandledBy: g.operator.Hex(),
+		Timestamp: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := GossipMessage{
+		Type:      MessageTypeAlertAck,
+		Sender:    g.host.ID().String(),
+		Timestamp: now,
+		Payload:   payload,
+	}
+
+	return g.broadcast(msg)
+}
+
+// broadcast publishes msg on the topic its type is carried on
 func (g *GossipNode) broadcast(msg GossipMessage) error {
-	data, err := json.Marshal(msg)
+	return g.publish(g.topics[topicClass(msg.Type)], msg)
+}
+
+// publish signs, encodes and publishes msg on topic. Each topic is
+// sequenced separately, since a peer may not join them all and ordering
+// doesn't hold across them.
+func (g *GossipNode) publish(topic *gossipTopic, msg GossipMessage) error {
+	started := time.Now()
+
+	if topic == nil {
+		return ErrGossipUnavailable
+	}
+
+	if !topic.policy.Outbound.Permits(msg.Type) {
+		return fmt.Errorf("%w: %s", ErrMessageTypeDenied, msg.Type)
+	}
+
+	g.publishMu.Lock()
+	defer g.publishMu.Unlock()
+
+	if requiresEnvelope(msg.Type) {
+		if g.signer == nil {
+			return ErrNoMessageSigner
+		}
+		// Only consumed once published, so a dropped message leaves no gap
+		msg.Seq = topic.seq + 1
+		msg.Operator = g.operator
+		sig, err := g.signer.SignMessage(EnvelopeMessage(&msg))
+		if err != nil {
+			return fmt.Errorf("signing %s message: %w", msg.Type, err)
+		}
+		msg.Signature = sig
+	}
+
+	data, err := encodeGossipMessage(&msg, g.wireFormat)
 	if err != nil {
 		return err
 	}
+	// Receivers bound the decompressed size too
+	if len(data) > g.maxMessageBytes {
+		return fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, len(data))
+	}
+	if data, err = compressGossipMessage(data, g.compression); err != nil {
+		return err
+	}
+
+	if g.bandwidth != nil {
+		if err := g.bandwidth.admit(len(data), priorityFor(msg.Type)); err != nil {
+			g.logger.Debug().Str("type", string(msg.Type)).Int("bytes", len(data)).Msg("Shed outbound gossip message")
+			return err
+		}
+	}
+
+	if err := topic.topic.Publish(context.Background(), data); err != nil {
+		return err
+	}
+	if msg.Seq != 0 {
+		topic.seq = msg.Seq
+	}
+	g.metrics.messagePublished(msg.Type, started)
+	return nil
+}
 
-	return g.topic.Publish(context.Background(), data)
+// BandwidthStats returns outbound gossip traffic counters
+func (g *GossipNode) BandwidthStats() BandwidthStats {
+	if g.bandwidth == nil {
+		return BandwidthStats{}
+	}
+	return g.bandwidth.Stats()
 }
 
-func (g *GossipNode) listenLoop(ctx context.Context) {
+func (g *GossipNode) listenLoop(ctx context.Context, topic *gossipTopic) {
 	defer g.wg.Done()
 
 	for {
-		msg, err := g.sub.Next(ctx)
+		msg, err := topic.sub.Next(ctx)
 		if err != nil {
 			g.mu.RLock()
 			running := g.running
@@ -278,31 +847,152 @@ func (g *GossipNode) listenLoop(ctx context.Context) {
 			continue
 		}
 
-		g.handleMessage(msg.Data, msg.ReceivedFrom)
+		g.enqueue(topic, msg)
 	}
 }
 
-func (g *GossipNode) handleMessage(data []byte, from peer.ID) {
-	var msg GossipMessage
-	if err := json.Unmarshal(data, &msg); err != nil {
+// timestampRejection returns why ts falls outside the accepted window, or
+// "" if it is acceptable. The skew tolerance widens the window both ways so
+// a sender whose clock runs slow isn't treated as replaying.
+func (g *GossipNode) timestampRejection(ts time.Time) string {
+	age := time.Since(ts)
+	switch {
+	case age > g.maxMessageAge+g.maxClockSkew:
+		return "stale"
+	case age < -g.maxClockSkew:
+		return "future"
+	default:
+		return ""
+	}
+}
+
+// handleMessage validates a message received on the topic of the given
+// class and hands it to the handlers for its type
+func (g *GossipNode) handleMessage(class string, data []byte, from peer.ID) {
+	if g.IsBanned(from) || g.IsBlocked(from) {
+		g.logger.Debug().Str("peer", from.String()).Msg("Dropped message from blocked peer")
+		return
+	}
+
+	// Spent before decoding so a flooding peer costs as little as possible
+	if !g.peerLimiter.allow(from) {
+		g.logger.Debug().Str("peer", from.String()).Msg("Dropped message from peer over its rate limit")
+		return
+	}
+
+	// Pubsub already enforces this; checked again so nothing oversized is
+	// ever decoded, whatever path delivered it
+	if len(data) > g.maxMessageBytes {
+		g.logger.Warn().
+			Str("peer", from.String()).
+			Int("bytes", len(data)).
+			Msg("Dropped oversized gossip message")
+		g.penalize(from, "oversized_message")
+		return
+	}
+
+	data, err := decompressGossipMessage(data, g.maxMessageBytes)
+	if err != nil {
+		g.logger.Warn().Err(err).Str("peer", from.String()).Msg("Failed to decompress gossip message")
+		g.penalize(from, "malformed_message")
+		return
+	}
+
+	msg, err := decodeGossipMessage(data)
+	if err != nil {
 		g.logger.Warn().Err(err).Msg("Failed to unmarshal gossip message")
+		g.penalize(from, "malformed_message")
+		return
+	}
+
+	g.metrics.messageReceived(msg.Type)
+
+	// Each topic carries only its own types, so handlers only ever see
+	// traffic from the topic they belong to
+	topic := g.topics[class]
+	if topic == nil || topicClass(msg.Type) != class {
+		g.logger.Warn().
+			Str("sender", msg.Sender).
+			Str("type", string(msg.Type)).
+			Str("topic", class).
+			Msg("Dropped message type not carried on its topic")
+		g.penalize(from, "wrong_topic")
+		return
+	}
+
+	// Measured before the timestamp check so a peer skewed past the
+	// tolerance is reported rather than just silently dropped
+	if g.measureClockSkew && msg.Type == MessageTypeHeartbeat {
+		g.observeClockSkew(from, msg.Timestamp)
+	}
+
+	// Checked before any registry lookup or signature verification so
+	// stale replays and far-future junk are dropped cheaply
+	if reason := g.timestampRejection(msg.Timestamp); reason != "" {
+		g.logger.Warn().
+			Str("sender", msg.Sender).
+			Str("type", string(msg.Type)).
+			Time("timestamp", msg.Timestamp).
+			Str("reason", reason).
+			Msg("Rejected gossip message timestamp")
 		return
 	}
 
 	g.updatePeer(from)
 
+	// Policy drops are local configuration, not peer misbehaviour
+	if !topic.policy.Inbound.Permits(msg.Type) {
+		g.logger.Debug().Str("type", string(msg.Type)).Msg("Dropped message type denied by topic policy")
+		return
+	}
+
 	// FIX: Validate sender is a registered node (except for heartbeats)
 	// Verifier is guaranteed non-nil since NewGossipNode requires it
 	if msg.Type != MessageTypeHeartbeat {
-		if !g.verifier.IsRegisteredNode(msg.Sender) {
+		if !g.verifier.IsRegisteredNode(msg.Operator.Hex()) {
 			g.logger.Warn().
 				Str("sender", msg.Sender).
+				Str("operator", msg.Operator.Hex()).
 				Str("type", string(msg.Type)).
 				Msg("Rejected message from unregistered node")
+			g.metrics.rejectedUnregistered()
+			g.penalize(from, "unregistered_sender")
 			return
 		}
 	}
 
+	if g.seen.checkAndAdd(messageKeyFor(&msg)) {
+		g.logger.Debug().
+			Str("sender", msg.Sender).
+			Str("type", string(msg.Type)).
+			Msg("Dropped replayed gossip message")
+		return
+	}
+
+	// A registered sender must also prove it wrote this payload, so a
+	// compromised peer can't forge messages attributed to others
+	if requiresEnvelope(msg.Type) && !g.verifier.VerifyMessage(msg.Operator.Hex(), EnvelopeMessage(&msg), msg.Signature) {
+		g.logger.Warn().
+			Str("sender", msg.Sender).
+			Str("operator", msg.Operator.Hex()).
+			Str("type", string(msg.Type)).
+			Msg("Rejected message with invalid envelope signature")
+		g.metrics.verificationFailed("envelope")
+		g.penalize(from, "invalid_signature")
+		return
+	}
+
+	// Checked once the envelope proves the sequence number is the operator's
+	if !g.observeSequence(class, &msg) {
+		g.logger.Warn().
+			Str("sender", msg.Sender).
+			Str("operator", msg.Operator.Hex()).
+			Str("type", string(msg.Type)).
+			Uint64("seq", msg.Seq).
+			Msg("Rejected gossip message with decreasing sequence number")
+		return
+	}
+
 	g.mu.RLock()
 	pauseHandlers := make([]PauseRequestHandler, len(g.pauseHandlers))
 	copy(pauseHandlers, g.pauseHandlers)
@@ -310,6 +1000,8 @@ func (g *GossipNode) handleMessage(data []byte, from peer.ID) {
 	copy(signatureHandlers, g.signatureHandlers)
 	alertHandlers := make([]AlertHandler, len(g.alertHandlers))
 	copy(alertHandlers, g.alertHandlers)
+	alertAckHandlers := make([]AlertAckHandler, len(g.alertAckHandlers))
+	copy(alertAckHandlers, g.alertAckHandlers)
 	g.mu.RUnlock()
 
 	switch msg.Type {
@@ -317,6 +1009,23 @@ func (g *GossipNode) handleMessage(data []byte, from peer.ID) {
 		var request types.SignedPauseRequest
 		if err := json.Unmarshal(msg.Payload, &request); err != nil {
 			g.logger.Warn().Err(err).Msg("Failed to unmarshal pause request")
+			g.penalize(from, "malformed_payload")
+			return
+		}
+
+		// Gossipsub relays envelopes untouched, so the operator a pause
+		// request was sent as, which the envelope signature vouches for,
+		// must be the node that signed it; anything else is a peer passing
+		// someone else's request off under its own identity
+		if msg.Operator != request.Signer {
+			g.logger.Warn().
+				Str("sender", msg.Sender).
+				Str("operator", msg.Operator.Hex()).
+				Str("signer", request.Signer.Hex()).
+				Str("peer", from.String()).
+				Msg("Rejected pause request sent under a different identity than its signer")
+			g.metrics.verificationFailed("pause_request_sender")
+			g.penalize(from, "sender_mismatch")
 			return
 		}
 
@@ -325,6 +1034,8 @@ func (g *GossipNode) handleMessage(data []byte, from peer.ID) {
 			g.logger.Warn().
 				Str("signer", request.Signer.Hex()).
 				Msg("Rejected pause request with invalid signature")
+			g.metrics.verificationFailed("pause_request")
+			g.penalize(from, "invalid_signature")
 			return
 		}
 
@@ -333,31 +1044,86 @@ func (g *GossipNode) handleMessage(data []byte, from peer.ID) {
 		}
 
 	case MessageTypeSignature:
-		var payload struct {
-			RequestID string `json:"requestId"`
-			Signature []byte `json:"signature"`
-		}
+		var payload signaturePayload
 		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 			g.logger.Warn().Err(err).Msg("Failed to unmarshal signature")
+			g.penalize(from, "malformed_payload")
+			return
+		}
+		if !g.collectSignature(payload, msg.Operator, from) {
 			return
 		}
 		for _, handler := range signatureHandlers {
-			handler(payload.RequestID, payload.Signature, msg.Sender)
+			handler(payload.RequestID, payload.Signature, msg.Operator.Hex())
 		}
 
 	case MessageTypeAlert:
 		var alert types.Alert
 		if err := json.Unmarshal(msg.Payload, &alert); err != nil {
 			g.logger.Warn().Err(err).Msg("Failed to unmarshal alert")
+			g.penalize(from, "malformed_payload")
 			return
 		}
 		for _, handler := range alertHandlers {
 			handler(&alert)
 		}
 
+	case MessageTypeAlertAck:
+		var ack types.AlertAck
+		if err := json.Unmarshal(msg.Payload, &ack); err != nil || ack.AlertID == "" {
+			g.logger.Warn().Err(err).Msg("Failed to unmarshal alert ack")
+			g.penalize(from, "malformed_payload")
+			return
+		}
+		// Attribute the ack to the operator the envelope was verified
+		// against, not whatever it claims
+		ack.HandledBy = msg.Operator.Hex()
+		for _, handler := range alertAckHandlers {
+			handler(&ack)
+		}
+
+	case MessageTypeEvidenceRequest:
+		if !g.handleEvidenceRequest(msg.Payload, from) {
+			return
+		}
+
+	case MessageTypeEvidenceResponse:
+		if !g.handleEvidenceResponse(msg.Payload, from) {
+			return
+		}
+
 	case MessageTypeHeartbeat:
 		// Already handled by updatePeer
 	}
+
+	score := g.reputation.Reward(from, reputationReward)
+	g.tagReputation(from, score)
+	g.setPeerScore(from, score)
+}
+
+// penalize lowers a peer's reputation after a rejected message
+func (g *GossipNode) penalize(from peer.ID, reason string) {
+	score := g.reputation.Penalize(from, reason, reputationPenalty)
+	g.tagReputation(from, score)
+	g.setPeerScore(from, score)
+	g.banIfBelowThreshold(from, score)
+	if score <= g.blockThreshold {
+		g.logger.Warn().
+			Str("peer", from.String()).
+			Str("reason", reason).
+			Float64("score", score).
+			Msg("Peer reputation below block threshold")
+	}
+}
+
+// IsBlocked reports whether a peer's reputation is too low for its messages to be processed
+func (g *GossipNode) IsBlocked(id peer.ID) bool {
+	return g.reputation.Score(id) <= g.blockThreshold
+}
+
+// PeerReputation returns the tracked reputation of a peer
+func (g *GossipNode) PeerReputation(id peer.ID) (PeerReputation, bool) {
+	return g.reputation.Get(id)
 }
 
 func (g *GossipNode) heartbeatLoop(ctx context.Context) {
@@ -387,14 +1153,13 @@ func (g *GossipNode) heartbeatLoop(ctx context.Context) {
 			g.broadcast(msg)
 
 			g.cleanupInactivePeers()
+			g.expirePauseRequests()
 		}
 	}
 }
 
 func (g *GossipNode) updatePeer(peerID peer.ID) {
 	g.peersMu.Lock()
-	defer g.peersMu.Unlock()
-
 	if info, exists := g.peers[peerID]; exists {
 		info.LastHeartbeat = time.Now()
 		info.IsActive = true
@@ -403,14 +1168,121 @@ func (g *GossipNode) updatePeer(peerID peer.ID) {
 			ID:            peerID,
 			LastHeartbeat: time.Now(),
 			IsActive:      true,
+			PeerScore:     g.reputation.Score(peerID),
 		}
 	}
+	count, healthy, changed := g.observePeerCount()
+	g.peersMu.Unlock()
+
+	if changed {
+		g.notifyPeerCount(count, healthy)
+	}
 }
 
-func (g *GossipNode) cleanupInactivePeers() {
+// observeClockSkew records the offset between a peer's heartbeat timestamp
+// and the local clock, warning when it first exceeds the tolerance and
+// noting when it recovers
+func (g *GossipNode) observeClockSkew(peerID peer.ID, sent time.Time) {
+	now := time.Now()
+	skew := sent.Sub(now)
+
+	g.peersMu.Lock()
+	info, exists := g.peers[peerID]
+	if !exists {
+		// Not active until one of its messages is accepted
+		info = &PeerInfo{ID: peerID, LastHeartbeat: now, PeerScore: g.reputation.Score(peerID)}
+		g.peers[peerID] = info
+	}
+	wasSkewed := exists && g.exceedsClockSkew(info.ClockSkew)
+	info.ClockSkew = skew
+	g.peersMu.Unlock()
+
+	skewed := g.exceedsClockSkew(skew)
+	switch {
+	case skewed && !wasSkewed:
+		g.logger.Warn().
+			Str("peer", peerID.String()).
+			Dur("skew", skew).
+			Dur("tolerance", g.maxClockSkew).
+			Msg("Peer clock skew exceeds tolerance")
+	case !skewed && wasSkewed:
+		g.logger.Info().
+			Str("peer", peerID.String()).
+			Dur("skew", skew).
+			Msg("Peer clock skew back within tolerance")
+	}
+}
+
+func (g *GossipNode) exceedsClockSkew(skew time.Duration) bool {
+	return skew > g.maxClockSkew || skew < -g.maxClockSkew
+}
+
+// Sequence numbers carry the sender's start time in their top 32 bits, so
+// they keep increasing across restarts and a restart isn't taken for a gap
+const seqEpochShift = 32
+
+// seqEpochStart returns the sequence number preceding the first message
+// published by a node started at t
+func seqEpochStart(t time.Time) uint64 {
+	return uint64(t.Unix()) << seqEpochShift
+}
+
+func seqEpoch(seq uint64) uint64 {
+	return seq >> seqEpochShift
+}
+
+// observeSequence records the sequence number of a verified message against
+// its operator and topic, warning on a gap. It returns false if the number
+// is lower than one already accepted from the operator, which is most
+// likely a replay. Tracking by operator rather than the claimed sender
+// means a node can only advance its own sequence. An operator that
+// restarted starts a new epoch and sets a new baseline. Messages without a
+// sequence number are not tracked.
+func (g *GossipNode) observeSequence(class string, msg *GossipMessage) bool {
+	if msg.Seq == 0 {
+		return true
+	}
+
+	g.peersMu.Lock()
+	seqs, exists := g.lastSeq[msg.Operator]
+	if !exists {
+		seqs = make(map[string]uint64)
+		g.lastSeq[msg.Operator] = seqs
+	}
+	last := seqs[class]
+	if msg.Seq < last {
+		g.peersMu.Unlock()
+		return false
+	}
+	seqs[class] = msg.Seq
+	g.peersMu.Unlock()
+
+	// The first message seen from a sender, or since it restarted, sets
+	// the baseline
+	if last != 0 && seqEpoch(msg.Seq) == seqEpoch(last) && msg.Seq > last+1 {
+		g.logger.Warn().
+			Str("sender", msg.Sender).
+			Str("operator", msg.Operator.Hex()).
+			Str("topic", class).
+			Uint64("lastSeq", last).
+			Uint64("seq", msg.Seq).
+			Uint64("missed", msg.Seq-last-1).
+			Msg("Gap in gossip sequence from sender")
+	}
+	return true
+}
+
+func (g *GossipNode) setPeerScore(peerID peer.ID, score float64) {
 	g.peersMu.Lock()
 	defer g.peersMu.Unlock()
 
+	if info, exists := g.peers[peerID]; exists {
+		info.PeerScore = score
+	}
+}
+
+func (g *GossipNode) cleanupInactivePeers() {
+	g.peersMu.Lock()
 	inactiveThreshold := time.Now().Add(-30 * time.Second)
 	deleteThreshold := time.Now().Add(-5 * time.Minute) // FIX: Delete after 5 min of inactivity
 
@@ -423,6 +1295,12 @@ func (g *GossipNode) cleanupInactivePeers() {
 			info.IsActive = false
 		}
 	}
+	count, healthy, changed := g.observePeerCount()
+	g.peersMu.Unlock()
+
+	if changed {
+		g.notifyPeerCount(count, healthy)
+	}
 }
 
 func (g *GossipNode) PeerID() string {
@@ -438,6 +1316,16 @@ func (g *GossipNode) ListenAddresses() []string {
 	return result
 }
 
+// Topics returns the names of the topics the node joined
+func (g *GossipNode) Topics() []string {
+	names := make([]string, 0, len(g.topics))
+	for _, t := range g.topics {
+		names = append(names, t.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (g *GossipNode) ConnectedPeers() []string {
 	peers := g.host.Network().Peers()
 	result := make([]string, len(peers))
@@ -450,7 +1338,10 @@ func (g *GossipNode) ConnectedPeers() []string {
 func (g *GossipNode) ActivePeerCount() int {
 	g.peersMu.RLock()
 	defer g.peersMu.RUnlock()
+	return g.activePeerCountLocked()
+}
 
+func (g *GossipNode) activePeerCountLocked() int {
 	count := 0
 	for _, info := range g.peers {
 		if info.IsActive {
@@ -1,9 +1,18 @@
 package consensus
 
 import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	"github.com/rs/zerolog"
 
 	"github.com/sentinel-protocol/sentinel-node/pkg/types"
@@ -23,6 +32,33 @@ func (m *MockVerifier) IsRegisteredNode(address string) bool {
 	return m.registeredNode
 }
 
+func (m *MockVerifier) VerifyMessage(sender string, message, signature []byte) bool {
+	return m.verifyResult
+}
+
+func (m *MockVerifier) AreRegisteredNodes(addresses []string) map[string]bool {
+	registered := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		registered[address] = m.registeredNode
+	}
+	return registered
+}
+
+// testMessage encodes a gossip message as a peer would send it
+func testMessage(t *testing.T, msgType MessageType, payload string, ts time.Time) []byte {
+	t.Helper()
+	data, err := json.Marshal(GossipMessage{
+		Type:      msgType,
+		Sender:    "node-a",
+		Timestamp: ts,
+		Payload:   json.RawMessage(payload),
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	return data
+}
+
 func TestNewGossipNode_RequiresVerifier(t *testing.T) {
 	logger := zerolog.Nop()
 
@@ -60,6 +96,51 @@ func TestNewGossipNode_Success(t *testing.T) {
 	}
 }
 
+func TestNewGossipNode_UnreachableBootstrapPeers(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	// Non-routable addresses: dials hang until their context expires
+	bootstrap := make([]string, 5)
+	for i := range bootstrap {
+		bootstrap[i] = fmt.Sprintf("/ip4/10.255.255.%d/tcp/9000/p2p/%s", i+1, randomPeerID(t))
+	}
+
+	start := time.Now()
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:      []string{"/ip4/127.0.0.1/tcp/0"},
+		BootstrapPeers:       bootstrap,
+		TopicName:            "test/v1/alerts",
+		Logger:               zerolog.Nop(),
+		Verifier:             verifier,
+		BootstrapPeerTimeout: 2 * time.Second,
+		BootstrapTimeout:     500 * time.Millisecond,
+	})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	// Serial dials would take 5 x 2s; the overall budget must cut them short
+	if elapsed > 3*time.Second {
+		t.Errorf("Construction took %v, expected it to be bounded by the bootstrap budget", elapsed)
+	}
+}
+
+func randomPeerID(t *testing.T) string {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key failed: %v", err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("IDFromPublicKey failed: %v", err)
+	}
+	return id.String()
+}
+
 // Note: Start/Stop test is skipped to avoid libp2p goroutine cleanup issues
 // The start/stop logic is tested manually in integration tests
 func TestGossipNode_StartStop(t *testing.T) {
@@ -206,6 +287,698 @@ func TestGossipNode_OnAlert(t *testing.T) {
 	}
 }
 
+func TestGossipNode_OnAlertAck(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var received *types.AlertAck
+	node.OnAlertAck(func(ack *types.AlertAck) {
+		received = ack
+	})
+
+	operator := common.HexToAddress("0xa1")
+	msg, err := json.Marshal(GossipMessage{
+		Type:      MessageTypeAlertAck,
+		Sender:    "node-a",
+		Timestamp: time.Now(),
+		Payload:   json.RawMessage(`{"alertId":"0xabc","action":"pause","handledBy":"spoofed"}`),
+		Operator:  operator,
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	node.handleMessage(TopicAlerts, msg, newTestPeerID(t))
+
+	if received == nil || received.AlertID != "0xabc" || received.Action != "pause" {
+		t.Fatalf("Expected the ack to reach the handler, got %+v", received)
+	}
+	if received.HandledBy != operator.Hex() {
+		t.Errorf("Ack should be attributed to the envelope's operator, got %q", received.HandledBy)
+	}
+}
+
+func TestGossipNode_DropsReplayedMessage(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	calls := 0
+	node.OnAlert(func(alert *types.Alert) {
+		calls++
+	})
+
+	sent := time.Now()
+	msg := testMessage(t, MessageTypeAlert, `{"id":"0xabc"}`, sent)
+	from := newTestPeerID(t)
+	node.handleMessage(TopicAlerts, msg, from)
+	node.handleMessage(TopicAlerts, msg, from)
+
+	if calls != 1 {
+		t.Errorf("Expected the handler to fire once, fired %d times", calls)
+	}
+
+	// The same payload at a new timestamp is a new message
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0xabc"}`, sent.Add(time.Second)), from)
+	if calls != 2 {
+		t.Errorf("Expected a distinct message to be handled, got %d calls", calls)
+	}
+}
+
+func TestSeenCache_EvictsAndExpires(t *testing.T) {
+	cache := newSeenCache(2, time.Minute)
+	now := time.Unix(1700000000, 0)
+	cache.now = func() time.Time { return now }
+
+	keys := make([]messageKey, 3)
+	for i := range keys {
+		keys[i] = messageKeyFor(&GossipMessage{Type: MessageTypeAlert, Sender: fmt.Sprintf("node-%d", i)})
+		if cache.checkAndAdd(keys[i]) {
+			t.Fatalf("Key %d reported as seen on first sight", i)
+		}
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected cache bounded at 2, got %d", cache.Len())
+	}
+	if cache.checkAndAdd(keys[0]) {
+		t.Error("Least recently seen key should have been evicted")
+	}
+
+	if !cache.checkAndAdd(keys[0]) {
+		t.Error("Expected a replay within the TTL to be caught")
+	}
+	now = now.Add(2 * time.Minute)
+	if cache.checkAndAdd(keys[0]) {
+		t.Error("Expired entry should not count as a replay")
+	}
+}
+
+func TestGossipNode_TopicPolicyDeniesPauseRequests(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+	deny := MessageTypeFilter{Deny: []MessageType{MessageTypePauseRequest}}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+		TopicPolicies: map[string]TopicPolicy{
+			"test/v1/alerts": {Inbound: deny, Outbound: deny},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	pauses, alerts := 0, 0
+	node.OnPauseRequest(func(request *types.SignedPauseRequest) { pauses++ })
+	node.OnAlert(func(alert *types.Alert) { alerts++ })
+
+	from := newTestPeerID(t)
+	node.handleMessage(TopicConsensus, testMessage(t, MessageTypePauseRequest, `{}`, time.Now()), from)
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0xabc"}`, time.Now()), from)
+
+	if pauses != 0 {
+		t.Error("Denied pause request reached the handler")
+	}
+	if alerts != 1 {
+		t.Errorf("Expected the alert to be handled, got %d", alerts)
+	}
+
+	if err := node.BroadcastPauseRequest(&types.SignedPauseRequest{}); !errors.Is(err, ErrMessageTypeDenied) {
+		t.Errorf("Expected ErrMessageTypeDenied, got %v", err)
+	}
+}
+
+func TestGossipNode_RejectsPauseRequestFromOtherSender(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var received []common.Address
+	node.OnPauseRequest(func(request *types.SignedPauseRequest) {
+		received = append(received, request.Signer)
+	})
+
+	signer := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	relay := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	// Senders are peer IDs; the operator is what the envelope is signed as
+	pauseFrom := func(operator common.Address) []byte {
+		payload, _ := json.Marshal(types.SignedPauseRequest{Signer: signer, Signature: []byte{1}})
+		data, err := json.Marshal(GossipMessage{
+			Type:      MessageTypePauseRequest,
+			Sender:    newTestPeerID(t).String(),
+			Timestamp: time.Now(),
+			Payload:   payload,
+			Operator:  operator,
+		})
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		return data
+	}
+
+	from := newTestPeerID(t)
+	node.handleMessage(TopicConsensus, pauseFrom(relay), from)
+	node.handleMessage(TopicConsensus, pauseFrom(common.Address{}), from)
+	if len(received) != 0 {
+		t.Fatal("Pause request sent under another identity reached the handler")
+	}
+	if score := node.reputation.Score(from); score >= 0 {
+		t.Errorf("Expected the sending peer to be penalized, score %v", score)
+	}
+
+	node.handleMessage(TopicConsensus, pauseFrom(signer), newTestPeerID(t))
+	if len(received) != 1 || received[0] != signer {
+		t.Errorf("Expected the signer's own pause request to be handled, got %v", received)
+	}
+}
+
+func TestNewGossipNode_RejectsUnknownPolicyType(t *testing.T) {
+	_, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+		TopicPolicies: map[string]TopicPolicy{
+			"test/v1/alerts": {Outbound: MessageTypeFilter{Allow: []MessageType{"pause"}}},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"pause"`) {
+		t.Errorf("Expected an error naming the unknown type, got %v", err)
+	}
+}
+
+func TestMessageTypeFilter_Permits(t *testing.T) {
+	relayAlerts := MessageTypeFilter{Allow: []MessageType{MessageTypeAlert, MessageTypeHeartbeat}}
+	if !relayAlerts.Permits(MessageTypeAlert) || relayAlerts.Permits(MessageTypeSignature) {
+		t.Error("Allow list should permit only the listed types")
+	}
+
+	both := MessageTypeFilter{Allow: []MessageType{MessageTypeAlert}, Deny: []MessageType{MessageTypeAlert}}
+	if both.Permits(MessageTypeAlert) {
+		t.Error("Deny should win over Allow")
+	}
+
+	if !(MessageTypeFilter{}).Permits(MessageTypePauseRequest) {
+		t.Error("Empty filter should permit everything")
+	}
+}
+
+func TestGossipNode_RejectsStaleAndFutureTimestamps(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+		MaxMessageAge:   10 * time.Minute,
+		MaxClockSkew:    time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	calls := 0
+	node.OnAlert(func(alert *types.Alert) {
+		calls++
+	})
+
+	from := newTestPeerID(t)
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x1"}`, time.Now().Add(-time.Hour)), from)
+	if calls != 0 {
+		t.Error("Message timestamped an hour ago should be rejected")
+	}
+
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x2"}`, time.Now().Add(10*time.Minute)), from)
+	if calls != 0 {
+		t.Error("Message timestamped 10 minutes ahead should be rejected")
+	}
+
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x3"}`, time.Now().Add(-time.Minute)), from)
+	if calls != 1 {
+		t.Errorf("Message within the window should be handled, got %d calls", calls)
+	}
+}
+
+func TestGossipNode_ClockSkewTolerance(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+		MaxMessageAge:   10 * time.Minute,
+		MaxClockSkew:    2 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	calls := 0
+	node.OnAlert(func(alert *types.Alert) {
+		calls++
+	})
+
+	from := newTestPeerID(t)
+	cases := []struct {
+		offset time.Duration
+		accept bool
+	}{
+		{90 * time.Second, true},   // sender clock ahead, within tolerance
+		{-11 * time.Minute, true},  // past MaxMessageAge but within tolerance
+		{3 * time.Minute, false},   // ahead beyond tolerance
+		{-13 * time.Minute, false}, // stale even allowing for skew
+	}
+	for i, tc := range cases {
+		before := calls
+		node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, fmt.Sprintf(`{"id":"0x%d"}`, i), time.Now().Add(tc.offset)), from)
+		if accepted := calls > before; accepted != tc.accept {
+			t.Errorf("Offset %v: expected accepted=%v, got %v", tc.offset, tc.accept, accepted)
+		}
+	}
+}
+
+func TestGossipNode_LogsExcessiveClockSkew(t *testing.T) {
+	var logs strings.Builder
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:  []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:        "test/v1/alerts",
+		Logger:           zerolog.New(&logs),
+		Verifier:         &MockVerifier{verifyResult: true, registeredNode: true},
+		MaxClockSkew:     time.Minute,
+		MeasureClockSkew: true,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	from := newTestPeerID(t)
+	skewWarnings := func() int {
+		return strings.Count(logs.String(), "Peer clock skew exceeds tolerance")
+	}
+
+	node.handleMessage(TopicHeartbeat, testMessage(t, MessageTypeHeartbeat, `null`, time.Now().Add(20*time.Second)), from)
+	if n := skewWarnings(); n != 0 {
+		t.Fatalf("Expected no warning within tolerance, got %d", n)
+	}
+
+	// Rejected as too far ahead, but the skew is still reported, once
+	for i := 0; i < 2; i++ {
+		node.handleMessage(TopicHeartbeat, testMessage(t, MessageTypeHeartbeat, `null`, time.Now().Add(5*time.Minute)), from)
+	}
+	if n := skewWarnings(); n != 1 {
+		t.Errorf("Expected one skew warning, got %d", n)
+	}
+
+	node.peersMu.RLock()
+	skew := node.peers[from].ClockSkew
+	node.peersMu.RUnlock()
+	if skew < 4*time.Minute || skew > 5*time.Minute {
+		t.Errorf("Expected about 5m observed skew, got %v", skew)
+	}
+}
+
+func TestGossipNode_TracksSenderSequence(t *testing.T) {
+	var logs strings.Builder
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.New(&logs),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var received []string
+	node.OnAlert(func(alert *types.Alert) {
+		received = append(received, alert.ID)
+	})
+
+	sender := newTestPeerID(t)
+	epoch := seqEpochStart(time.Now())
+	send := func(id string, seq uint64) {
+		data, err := json.Marshal(GossipMessage{
+			Type:      MessageTypeAlert,
+			Sender:    sender.String(),
+			Timestamp: time.Now(),
+			Payload:   json.RawMessage(`{"id":"` + id + `"}`),
+			Seq:       seq,
+		})
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		node.handleMessage(TopicAlerts, data, sender)
+	}
+	gapWarnings := func() int {
+		return strings.Count(logs.String(), "Gap in gossip sequence from sender")
+	}
+
+	send("0x1", epoch+1)
+	send("0x2", epoch+2)
+	if n := gapWarnings(); n != 0 {
+		t.Fatalf("Expected no gap warning for consecutive sequences, got %d", n)
+	}
+
+	// Messages 3 and 4 never arrive
+	send("0x5", epoch+5)
+	if n := gapWarnings(); n != 1 || !strings.Contains(logs.String(), `"missed":2`) {
+		t.Errorf("Expected one gap warning for 2 missed messages, got %d: %s", n, logs.String())
+	}
+
+	// An earlier sequence arriving late looks like a replay
+	send("0x3", epoch+3)
+
+	// A restarted sender starts a new epoch without a gap warning
+	send("0x6", seqEpochStart(time.Now().Add(time.Hour))+1)
+	if n := gapWarnings(); n != 1 {
+		t.Errorf("Expected a restart not to count as a gap, got %d warnings", n)
+	}
+
+	want := []string{"0x1", "0x2", "0x5", "0x6"}
+	if strings.Join(received, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected alerts %v delivered, got %v", want, received)
+	}
+	if !strings.Contains(logs.String(), "Rejected gossip message with decreasing sequence number") {
+		t.Error("Expected the decreasing sequence to be logged as rejected")
+	}
+}
+
+func TestGossipNode_SequenceTrackedByOperator(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var received []string
+	node.OnAlert(func(alert *types.Alert) {
+		received = append(received, alert.ID)
+	})
+
+	victim := newTestPeerID(t)
+	send := func(id string, operator common.Address, seq uint64) {
+		data, err := json.Marshal(GossipMessage{
+			Type:      MessageTypeAlert,
+			Sender:    victim.String(),
+			Timestamp: time.Now(),
+			Payload:   json.RawMessage(`{"id":"` + id + `"}`),
+			Seq:       seq,
+			Operator:  operator,
+		})
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		node.handleMessage(TopicAlerts, data, newTestPeerID(t))
+	}
+
+	// Another operator signs a message naming the victim's peer ID with a
+	// sequence far ahead of anything the victim has sent
+	epoch := seqEpochStart(time.Now())
+	send("0xpoison", common.HexToAddress("0xbad"), epoch+1<<20)
+	send("0xgenuine", common.HexToAddress("0xa1"), epoch+1)
+
+	want := []string{"0xpoison", "0xgenuine"}
+	if strings.Join(received, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected the victim's own sequence to be unaffected, got %v", received)
+	}
+}
+
+func TestGossipNode_PeerRateLimit(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:    []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:          "test/v1/alerts",
+		Logger:             zerolog.Nop(),
+		Verifier:           verifier,
+		PeerMessagesPerSec: 1,
+		PeerMessageBurst:   5,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	// Freeze the clock so no budget refills during the flood
+	now := time.Now()
+	node.peerLimiter.now = func() time.Time { return now }
+
+	calls := 0
+	node.OnAlert(func(alert *types.Alert) {
+		calls++
+	})
+
+	flooder := newTestPeerID(t)
+	// Heartbeats spend the same budget as alerts
+	node.handleMessage(TopicHeartbeat, testMessage(t, MessageTypeHeartbeat, `{}`, time.Now()), flooder)
+	for i := 0; i < 20; i++ {
+		node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, fmt.Sprintf(`{"id":"0x%d"}`, i), time.Now()), flooder)
+	}
+
+	if calls != 4 {
+		t.Errorf("Expected 4 alerts within the burst after a heartbeat, got %d", calls)
+	}
+
+	// Other peers have their own budget
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0xother"}`, time.Now()), newTestPeerID(t))
+	if calls != 5 {
+		t.Errorf("Expected another peer's message to be handled, got %d calls", calls)
+	}
+}
+
+func TestPeerRateLimiter_Refills(t *testing.T) {
+	limiter := newPeerRateLimiter(2, 2)
+	now := time.Unix(1700000000, 0)
+	limiter.now = func() time.Time { return now }
+
+	id := peer.ID("peer-a")
+	if !limiter.allow(id) || !limiter.allow(id) || limiter.allow(id) {
+		t.Fatal("Expected exactly the burst to be admitted")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if !limiter.allow(id) || limiter.allow(id) {
+		t.Error("Expected one message to refill after half a second at 2/s")
+	}
+
+	if newPeerRateLimiter(0, 0) != nil || !(*peerRateLimiter)(nil).allow(id) {
+		t.Error("Zero rate should disable the limiter")
+	}
+}
+
+func TestGossipNode_DropsOversizedMessage(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+		MaxMessageBytes: 1024,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	calls := 0
+	node.OnAlert(func(alert *types.Alert) {
+		calls++
+	})
+
+	from := newTestPeerID(t)
+	padding := strings.Repeat("a", 2048)
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x1","padding":"`+padding+`"}`, time.Now()), from)
+
+	if calls != 0 {
+		t.Errorf("Expected oversized alert to be dropped, got %d calls", calls)
+	}
+	if node.reputation.Score(from) >= 0 {
+		t.Error("Expected sender to be penalized for an oversized message")
+	}
+
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x2"}`, time.Now()), newTestPeerID(t))
+	if calls != 1 {
+		t.Errorf("Expected message within the limit to be handled, got %d calls", calls)
+	}
+}
+
+// envelopeVerifier checks envelopes against one operator's BLS key
+type envelopeVerifier struct {
+	MockVerifier
+	operator  common.Address
+	publicKey []byte
+}
+
+func (v *envelopeVerifier) VerifyMessage(operator string, message, signature []byte) bool {
+	if operator != v.operator.Hex() {
+		return false
+	}
+	valid, err := VerifySignature(signature, message, v.publicKey)
+	return err == nil && valid
+}
+
+func TestGossipNode_EnvelopeSignature(t *testing.T) {
+	signer, err := NewBLSSigner("")
+	if err != nil {
+		t.Fatalf("NewBLSSigner failed: %v", err)
+	}
+	verifier := &envelopeVerifier{
+		MockVerifier: MockVerifier{verifyResult: true, registeredNode: true},
+		operator:     common.HexToAddress("0xa1"),
+		publicKey:    signer.PublicKey(),
+	}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var received []string
+	node.OnAlert(func(alert *types.Alert) {
+		received = append(received, alert.ID)
+	})
+
+	msg := GossipMessage{
+		Type:      MessageTypeAlert,
+		Sender:    "node-a",
+		Timestamp: time.Now(),
+		Payload:   json.RawMessage(`{"id":"0xgenuine"}`),
+		Operator:  verifier.operator,
+	}
+	msg.Signature, err = signer.Sign(EnvelopeMessage(&msg))
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	// Same signature over a swapped payload
+	tampered := msg
+	tampered.Payload = json.RawMessage(`{"id":"0xforged"}`)
+	unsigned := msg
+	unsigned.Signature = nil
+	// Claimed as another operator's message
+	reattributed := msg
+	reattributed.Operator = common.HexToAddress("0xb0b")
+	// Replayed after the genuine copy under another sender, which would
+	// otherwise slip past the seen-message cache
+	resent := msg
+	resent.Sender = "node-b"
+
+	from := newTestPeerID(t)
+	for _, m := range []GossipMessage{tampered, unsigned, reattributed, msg, resent} {
+		data, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		node.handleMessage(TopicAlerts, data, from)
+	}
+
+	if len(received) != 1 || received[0] != "0xgenuine" {
+		t.Errorf("Expected only the genuine alert to be handled, got %v", received)
+	}
+	if score := node.reputation.Score(from); score >= 0 {
+		t.Errorf("Expected invalid signatures to penalize the peer, score %.1f", score)
+	}
+
+	if err := node.BroadcastAlert(&types.Alert{ID: "0x1"}); !errors.Is(err, ErrNoMessageSigner) {
+		t.Errorf("Expected ErrNoMessageSigner without a signer, got %v", err)
+	}
+}
+
+func TestEnvelopeMessage_CoversTypePayloadAndTimestamp(t *testing.T) {
+	base := GossipMessage{Type: MessageTypeAlert, Sender: "node-a", Timestamp: time.Unix(1700000000, 0), Payload: json.RawMessage(`{}`)}
+	digest := string(EnvelopeMessage(&base))
+
+	variants := []GossipMessage{base, base, base, base, base}
+	variants[0].Type = MessageTypeAlertAck
+	variants[1].Payload = json.RawMessage(`{"id":"x"}`)
+	variants[2].Timestamp = base.Timestamp.Add(time.Second)
+	variants[3].Operator = common.HexToAddress("0xa1")
+	variants[4].Sender = "node-b"
+	for i, v := range variants {
+		if string(EnvelopeMessage(&v)) == digest {
+			t.Errorf("Variant %d should change the signed message", i)
+		}
+	}
+}
+
+func TestNewGossipNode_MaxPeers(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+		MaxPeers:        25,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	cm, ok := node.host.ConnManager().(*connmgr.BasicConnMgr)
+	if !ok {
+		t.Fatalf("Expected a BasicConnMgr on the host, got %T", node.host.ConnManager())
+	}
+	info := cm.GetInfo()
+	if info.HighWater != 25 || info.LowWater != 20 {
+		t.Errorf("Expected watermarks 20/25, got %d/%d", info.LowWater, info.HighWater)
+	}
+	if node.ConnectionCount() != 0 {
+		t.Errorf("Expected no connections, got %d", node.ConnectionCount())
+	}
+
+	// Penalized peers carry their score into trimming decisions
+	from := newTestPeerID(t)
+	node.penalize(from, "test")
+	if tag := cm.GetTagInfo(from); tag == nil || tag.Tags[reputationTag] >= 0 {
+		t.Errorf("Expected a negative reputation tag, got %+v", tag)
+	}
+}
+
 func TestGossipMessage_Types(t *testing.T) {
 	// Test message type constants
 	if MessageTypePauseRequest != "pause_request" {
@@ -238,3 +1011,84 @@ func TestPeerInfo(t *testing.T) {
 		t.Error("PeerInfo should be active")
 	}
 }
+
+func TestParseFailurePolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FailurePolicy
+		wantErr  bool
+	}{
+		{input: "", expected: FailClosed},
+		{input: "closed", expected: FailClosed},
+		{input: "open", expected: FailOpen},
+		{input: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		policy, err := ParseFailurePolicy(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseFailurePolicy(%q) failed: %v", tt.input, err)
+		}
+		if policy != tt.expected {
+			t.Errorf("ParseFailurePolicy(%q) = %q, want %q", tt.input, policy, tt.expected)
+		}
+	}
+
+	if FailClosed.Accept() {
+		t.Error("FailClosed should not accept on lookup error")
+	}
+	if !FailOpen.Accept() {
+		t.Error("FailOpen should accept on lookup error")
+	}
+}
+
+func TestNewGossipNode_InvalidListenAddress(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+
+	_, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0", "127.0.0.1:9000"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+	})
+	if err == nil {
+		t.Fatal("Expected error for malformed listen address")
+	}
+	if !strings.Contains(err.Error(), `"127.0.0.1:9000"`) {
+		t.Errorf("Error should name the offending address, got: %v", err)
+	}
+}
+
+func TestValidateListenAddresses(t *testing.T) {
+	valid := []string{"/ip4/0.0.0.0/tcp/9000", "/ip6/::1/udp/9000/quic-v1"}
+	if err := validateListenAddresses(valid); err != nil {
+		t.Errorf("Valid addresses rejected: %v", err)
+	}
+
+	if err := validateListenAddresses([]string{"/ip4/999.0.0.1/tcp/9000"}); err == nil {
+		t.Error("Expected error for invalid IPv4 component")
+	}
+}
+
+func TestGossipNode_BroadcastWithoutTopic(t *testing.T) {
+	// A node whose topic join never happened
+	node := &GossipNode{logger: zerolog.Nop()}
+
+	if err := node.BroadcastAlert(&types.Alert{ID: "alert-1"}); !errors.Is(err, ErrGossipUnavailable) {
+		t.Errorf("BroadcastAlert: expected ErrGossipUnavailable, got %v", err)
+	}
+
+	if err := node.BroadcastPauseRequest(&types.SignedPauseRequest{}); !errors.Is(err, ErrGossipUnavailable) {
+		t.Errorf("BroadcastPauseRequest: expected ErrGossipUnavailable, got %v", err)
+	}
+
+	if err := node.BroadcastSignature("request-1", common.HexToAddress("0x1"), []byte{0x01}); !errors.Is(err, ErrGossipUnavailable) {
+		t.Errorf("BroadcastSignature: expected ErrGossipUnavailable, got %v", err)
+	}
+}
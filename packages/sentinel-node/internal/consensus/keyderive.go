@@ -0,0 +1,84 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Changing any of these changes every derived key
+var (
+	keyDerivationSalt = []byte("SENTINEL-BLS-KEYGEN-SALT")
+	keyDerivationInfo = []byte("sentinel/bls/bn254/v1")
+)
+
+const (
+	// minSeedSize is the smallest seed accepted, matching 256 bits of entropy
+	minSeedSize = 32
+	// derivedKeyBytes is the HKDF output reduced into a scalar. 128 bits above
+	// the 254-bit curve order keeps the modular bias negligible.
+	derivedKeyBytes = 48
+	// BIP-39 seed stretching parameters
+	mnemonicIterations = 2048
+	mnemonicSeedSize   = 64
+)
+
+var (
+	ErrSeedTooShort    = errors.New("BLS key seed must be at least 32 bytes")
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+// NewBLSSignerFromSeed derives the signing key deterministically from seed,
+// so the same seed always restores the same key. It is account 0 of the
+// derivation used by DeriveKeyPairFromMnemonic.
+func NewBLSSignerFromSeed(seed []byte) (*BLSSigner, error) {
+	keyPair, err := deriveKeyPair(seed, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BLSSigner{keyPair: keyPair}, nil
+}
+
+// DeriveKeyPairFromMnemonic turns a BIP-39 mnemonic into its seed and derives
+// the key pair for account. Different accounts give unrelated keys from one
+// backed-up phrase. The words are not checked against a wordlist.
+func DeriveKeyPairFromMnemonic(mnemonic string, account uint32) (*BLSKeyPair, error) {
+	normalized := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	if normalized == "" {
+		return nil, ErrInvalidMnemonic
+	}
+
+	seed := pbkdf2.Key([]byte(normalized), []byte("mnemonic"), mnemonicIterations, mnemonicSeedSize, sha512.New)
+	return deriveKeyPair(seed, account)
+}
+
+// deriveKeyPair expands seed with HKDF-SHA256 and reduces the output modulo
+// the curve order, so every seed maps to a valid, near-uniform scalar
+func deriveKeyPair(seed []byte, account uint32) (*BLSKeyPair, error) {
+	if len(seed) < minSeedSize {
+		return nil, ErrSeedTooShort
+	}
+
+	info := binary.BigEndian.AppendUint32(append([]byte(nil), keyDerivationInfo...), account)
+	okm := make([]byte, derivedKeyBytes)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, keyDerivationSalt, info), okm); err != nil {
+		return nil, err
+	}
+
+	var privateKey fr.Element
+	privateKey.SetBytes(okm)
+	if privateKey.IsZero() {
+		return nil, errors.New("derived BLS private key is zero")
+	}
+
+	return keyPairFromPrivateKey(&privateKey), nil
+}
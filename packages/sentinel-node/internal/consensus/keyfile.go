@@ -0,0 +1,166 @@
+package consensus
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for deriving the key-file encryption key
+const (
+	keyFileSaltSize = 16
+	scryptN         = 1 << 15
+	scryptR         = 8
+	scryptP         = 1
+	scryptKeyLen    = 32
+)
+
+// isPlaintextKeyFile reports whether data is an unencrypted serialized key
+// pair, in either the headerless version 0 layout or a versioned one. The
+// encrypted layout is longer than both.
+func isPlaintextKeyFile(data []byte) bool {
+	switch len(data) {
+	case keyPairSize:
+		return true
+	case keyHeaderSize + keyPairSize:
+		return data[0] == keyFormatMagic
+	default:
+		return false
+	}
+}
+
+var (
+	ErrKeyPassphraseRequired = errors.New("BLS key file is encrypted: passphrase required")
+	ErrInvalidKeyPassphrase  = errors.New("invalid BLS key passphrase or corrupted key file")
+	ErrKeyFileExists         = errors.New("BLS key file already exists")
+	ErrEmptyNewPassphrase    = errors.New("new BLS key passphrase must not be empty")
+)
+
+// GenerateKeyFile creates a fresh key pair and writes it to keyPath,
+// encrypted when passphrase is set. An existing file is only replaced when
+// overwrite is true, so a registered key isn't lost by accident.
+func GenerateKeyFile(keyPath, passphrase string, overwrite bool) (*BLSSigner, error) {
+	if !overwrite {
+		if _, err := os.Stat(keyPath); err == nil {
+			return nil, ErrKeyFileExists
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyPath), 0700); err != nil {
+		return nil, err
+	}
+
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveKey(keyPath, keyPair, passphrase); err != nil {
+		return nil, err
+	}
+
+	return &BLSSigner{keyPair: keyPair}, nil
+}
+
+// RotateKeyPassphrase re-encrypts the key file at keyPath under
+// newPassphrase, keeping the same key pair. oldPassphrase may be empty for
+// an unencrypted file. The file is replaced atomically, and the decrypted
+// key material never touches the disk.
+func RotateKeyPassphrase(keyPath, oldPassphrase, newPassphrase string) (*BLSSigner, error) {
+	if newPassphrase == "" {
+		return nil, ErrEmptyNewPassphrase
+	}
+
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := data
+	if !isPlaintextKeyFile(data) {
+		if oldPassphrase == "" {
+			return nil, ErrKeyPassphraseRequired
+		}
+		if plaintext, err = decryptKeyData(data, oldPassphrase); err != nil {
+			return nil, err
+		}
+	}
+	defer clear(plaintext)
+
+	keyPair, err := deserializeKeyPair(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveKey(keyPath, keyPair, newPassphrase); err != nil {
+		return nil, err
+	}
+
+	return &BLSSigner{keyPair: keyPair}, nil
+}
+
+// encryptKeyData seals plaintext under a scrypt-derived AES-256-GCM key. The
+// output is salt || nonce || ciphertext.
+func encryptKeyData(plaintext []byte, passphrase string) ([]byte, error) {
+	salt, err := randomBytes(keyFileSaltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := keyFileCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(salt)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+// decryptKeyData reverses encryptKeyData. A wrong passphrase and a tampered
+// file are indistinguishable and both return ErrInvalidKeyPassphrase.
+func decryptKeyData(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < keyFileSaltSize {
+		return nil, ErrInvalidKeyPassphrase
+	}
+	salt := data[:keyFileSaltSize]
+
+	aead, err := keyFileCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := data[keyFileSaltSize:]
+	if len(rest) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidKeyPassphrase
+	}
+
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidKeyPassphrase
+	}
+	return plaintext, nil
+}
+
+func keyFileCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
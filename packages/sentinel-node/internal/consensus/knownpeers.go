@@ -0,0 +1,203 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	knownPeersFileName       = "known_peers.json"
+	defaultMaxPersistedPeers = 100
+	defaultMaxPersistedAge   = 24 * time.Hour
+)
+
+// knownPeer is a peer remembered across restarts
+type knownPeer struct {
+	ID       string    `json:"id"`
+	Addrs    []string  `json:"addrs"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// knownPeerStore persists recently seen peers so a restarted node can
+// rejoin the mesh without relying on its bootstrap peers
+type knownPeerStore struct {
+	path     string
+	maxPeers int
+	maxAge   time.Duration
+	now      func() time.Time
+}
+
+// newKnownPeerStore returns a store under dataDir, or nil when dataDir is
+// empty
+func newKnownPeerStore(dataDir string, maxPeers int, maxAge time.Duration) *knownPeerStore {
+	if dataDir == "" {
+		return nil
+	}
+	if maxPeers <= 0 {
+		maxPeers = defaultMaxPersistedPeers
+	}
+	if maxAge <= 0 {
+		maxAge = defaultMaxPersistedAge
+	}
+	return &knownPeerStore{
+		path:     filepath.Join(dataDir, knownPeersFileName),
+		maxPeers: maxPeers,
+		maxAge:   maxAge,
+		now:      time.Now,
+	}
+}
+
+// load returns the persisted peers that are still fresh
+func (s *knownPeerStore) load() ([]knownPeer, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var stored []knownPeer
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	return s.trim(stored), nil
+}
+
+// save writes the freshest peers to disk atomically
+func (s *knownPeerStore) save(peers []knownPeer) error {
+	data, err := json.Marshal(s.trim(peers))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// trim drops peers older than maxAge or without addresses and keeps the
+// maxPeers most recently seen
+func (s *knownPeerStore) trim(peers []knownPeer) []knownPeer {
+	cutoff := s.now().Add(-s.maxAge)
+	fresh := make([]knownPeer, 0, len(peers))
+	for _, p := range peers {
+		if p.LastSeen.After(cutoff) && len(p.Addrs) > 0 {
+			fresh = append(fresh, p)
+		}
+	}
+
+	sort.Slice(fresh, func(i, j int) bool {
+		return fresh[i].LastSeen.After(fresh[j].LastSeen)
+	})
+	if len(fresh) > s.maxPeers {
+		fresh = fresh[:s.maxPeers]
+	}
+	return fresh
+}
+
+// reconnectKnownPeers loads the persisted peers and dials each of them.
+// Dial failures are expected for peers that have since gone away.
+func (g *GossipNode) reconnectKnownPeers(ctx context.Context) {
+	defer g.wg.Done()
+
+	known, err := g.knownPeers.load()
+	if err != nil {
+		g.logger.Warn().Err(err).Msg("Failed to load known peers")
+		return
+	}
+
+	g.peersMu.Lock()
+	g.loadedPeers = known
+	g.peersMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, kp := range known {
+		info, ok := kp.addrInfo()
+		if !ok || info.ID == g.host.ID() || g.IsBanned(info.ID) {
+			continue
+		}
+		g.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.RecentlyConnectedAddrTTL)
+
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+
+			dialCtx, cancel := context.WithTimeout(ctx, defaultBootstrapPeerTimeout)
+			defer cancel()
+
+			if err := g.host.Connect(dialCtx, info); err != nil {
+				g.logger.Debug().Err(err).Str("peer", info.ID.String()).Msg("Failed to reconnect to known peer")
+			}
+		}(info)
+	}
+	wg.Wait()
+
+	if len(known) > 0 {
+		g.logger.Info().Int("peers", len(known)).Msg("Reconnected to known peers")
+	}
+}
+
+func (kp knownPeer) addrInfo() (peer.AddrInfo, bool) {
+	id, err := peer.Decode(kp.ID)
+	if err != nil {
+		return peer.AddrInfo{}, false
+	}
+
+	info := peer.AddrInfo{ID: id}
+	for _, s := range kp.Addrs {
+		if addr, err := multiaddr.NewMultiaddr(s); err == nil {
+			info.Addrs = append(info.Addrs, addr)
+		}
+	}
+	return info, len(info.Addrs) > 0
+}
+
+// persistKnownPeers saves the peers seen this session, plus those loaded at
+// startup that weren't, so a restart with no reachable peers doesn't forget
+// them all. It must run before the host is closed.
+func (g *GossipNode) persistKnownPeers() {
+	if g.knownPeers == nil {
+		return
+	}
+
+	g.peersMu.RLock()
+	peers := make([]knownPeer, 0, len(g.peers)+len(g.loadedPeers))
+	seen := make(map[string]bool, len(g.peers))
+	for id, info := range g.peers {
+		if g.IsBanned(id) {
+			continue
+		}
+		kp := knownPeer{ID: id.String(), LastSeen: info.LastHeartbeat}
+		for _, addr := range g.host.Peerstore().Addrs(id) {
+			kp.Addrs = append(kp.Addrs, addr.String())
+		}
+		peers = append(peers, kp)
+		seen[kp.ID] = true
+	}
+	for _, kp := range g.loadedPeers {
+		if !seen[kp.ID] {
+			peers = append(peers, kp)
+		}
+	}
+	g.peersMu.RUnlock()
+
+	if err := g.knownPeers.save(peers); err != nil {
+		g.logger.Warn().Err(err).Msg("Failed to persist known peers")
+	}
+}
@@ -0,0 +1,102 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+)
+
+func TestGossipNode_ReloadsPersistedPeers(t *testing.T) {
+	dataDir := t.TempDir()
+	newNode := func() *GossipNode {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:       "test/v1/alerts",
+			Logger:          zerolog.Nop(),
+			Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+			DataDir:         dataDir,
+			PersistPeers:    true,
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		return node
+	}
+
+	// Nothing listens on the remembered address, so redials fail fast
+	addr := multiaddr.StringCast("/ip4/127.0.0.1/tcp/1")
+	remembered := newTestPeerID(t)
+
+	first := newNode()
+	first.host.Peerstore().AddAddrs(remembered, []multiaddr.Multiaddr{addr}, peerstore.PermanentAddrTTL)
+	first.updatePeer(remembered)
+	first.Stop()
+
+	second := newNode()
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := second.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(second.host.Peerstore().Addrs(remembered)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	addrs := second.host.Peerstore().Addrs(remembered)
+	if len(addrs) != 1 || !addrs[0].Equal(addr) {
+		t.Errorf("Expected the persisted peer's address to be reloaded, got %v", addrs)
+	}
+
+	cancel()
+	second.Stop()
+
+	// Peers not seen again are kept for the next restart
+	known, err := newKnownPeerStore(dataDir, 0, 0).load()
+	if err != nil || len(known) != 1 || known[0].ID != remembered.String() {
+		t.Errorf("Expected the unreachable peer to stay persisted, got %+v, %v", known, err)
+	}
+}
+
+func TestKnownPeerStore_TrimsByAgeAndCount(t *testing.T) {
+	store := newKnownPeerStore(t.TempDir(), 2, time.Hour)
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+
+	peers := []knownPeer{
+		{ID: "stale", Addrs: []string{"/ip4/10.0.0.1/tcp/9000"}, LastSeen: now.Add(-2 * time.Hour)},
+		{ID: "no-addrs", LastSeen: now},
+	}
+	for i := 0; i < 3; i++ {
+		peers = append(peers, knownPeer{
+			ID:       fmt.Sprintf("recent-%d", i),
+			Addrs:    []string{"/ip4/10.0.0.2/tcp/9000"},
+			LastSeen: now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+
+	if err := store.save(peers); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	loaded, err := store.load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].ID != "recent-0" || loaded[1].ID != "recent-1" {
+		t.Errorf("Expected the 2 most recent peers, got %+v", loaded)
+	}
+
+	// Entries age out between restarts too
+	now = now.Add(2 * time.Hour)
+	if loaded, _ := store.load(); len(loaded) != 0 {
+		t.Errorf("Expected all peers expired, got %+v", loaded)
+	}
+
+	if newKnownPeerStore("", 0, 0) != nil {
+		t.Error("Expected no store without a data directory")
+	}
+}
@@ -0,0 +1,49 @@
+package consensus
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+)
+
+// mdnsServiceName scopes LAN discovery to sentinel nodes rather than every
+// libp2p host on the network
+const mdnsServiceName = "sentinel-node"
+
+// mdnsNotifee connects to peers announced over mDNS. The mdns service calls
+// HandlePeerFound on its own goroutine for each discovered peer.
+type mdnsNotifee struct {
+	node *GossipNode
+}
+
+func (n mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	g := n.node
+	if info.ID == g.host.ID() || g.IsBanned(info.ID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultBootstrapPeerTimeout)
+	defer cancel()
+
+	g.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+	if err := g.host.Connect(ctx, info); err != nil {
+		g.logger.Debug().Err(err).Str("peer", info.ID.String()).Msg("Failed to connect to mDNS peer")
+		return
+	}
+
+	g.updatePeer(info.ID)
+	g.logger.Info().Str("peer", info.ID.String()).Msg("Connected to peer discovered over mDNS")
+}
+
+// startMDNS advertises the node on the LAN and connects to other nodes
+// found there
+func (g *GossipNode) startMDNS() error {
+	service := mdns.NewMdnsService(g.host, mdnsServiceName, mdnsNotifee{node: g})
+	if err := service.Start(); err != nil {
+		return err
+	}
+	g.mdns = service
+	return nil
+}
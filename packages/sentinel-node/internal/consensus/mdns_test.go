@@ -0,0 +1,44 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+)
+
+func TestMDNSNotifee_ConnectsDiscoveredPeer(t *testing.T) {
+	newNode := func() *GossipNode {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:       "test/v1/alerts",
+			Logger:          zerolog.Nop(),
+			Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		return node
+	}
+
+	local, remote := newNode(), newNode()
+	defer local.Stop()
+	defer remote.Stop()
+
+	notifee := mdnsNotifee{node: local}
+
+	// The node's own announcement is ignored
+	notifee.HandlePeerFound(peer.AddrInfo{ID: local.host.ID(), Addrs: local.host.Addrs()})
+	if count := local.ActivePeerCount(); count != 0 {
+		t.Fatalf("Expected no peers after own announcement, got %d", count)
+	}
+
+	notifee.HandlePeerFound(peer.AddrInfo{ID: remote.host.ID(), Addrs: remote.host.Addrs()})
+	if local.host.Network().Connectedness(remote.host.ID()) != network.Connected {
+		t.Error("Expected a connection to the discovered peer")
+	}
+	if count := local.ActivePeerCount(); count != 1 {
+		t.Errorf("Expected the discovered peer to be registered, got %d active peers", count)
+	}
+}
@@ -0,0 +1,131 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// gossipMetrics counts gossip traffic and rejections. It is a
+// prometheus.Collector rather than registering itself, so several nodes can
+// live in one process (as in tests) without colliding.
+type gossipMetrics struct {
+	received             *prometheus.CounterVec
+	published            *prometheus.CounterVec
+	verificationFailures *prometheus.CounterVec
+	unregistered         prometheus.Counter
+	activePeers          prometheus.GaugeFunc
+	publishLatency       prometheus.Histogram
+	handlerQueueDrops    *prometheus.CounterVec
+}
+
+func newGossipMetrics(activePeers func() int) *gossipMetrics {
+	return &gossipMetrics{
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_gossip_messages_received_total",
+			Help: "Gossip messages received and decoded, by type",
+		}, []string{"type"}),
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_gossip_messages_published_total",
+			Help: "Gossip messages published, by type",
+		}, []string{"type"}),
+		verificationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_gossip_verification_failures_total",
+			Help: "Inbound messages rejected for an invalid signature, by what was checked",
+		}, []string{"check"}),
+		unregistered: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "sentinel_gossip_rejected_unregistered_total",
+			Help: "Inbound messages rejected because the sender is not a registered node",
+		}),
+		activePeers: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "sentinel_gossip_active_peers",
+			Help: "Peers heard from recently",
+		}, func() float64 { return float64(activePeers()) }),
+		publishLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "sentinel_gossip_publish_latency_seconds",
+			Help:    "Time to sign, encode and publish an outbound gossip message",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12),
+		}),
+		handlerQueueDrops: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_gossip_handler_queue_dropped_total",
+			Help: "Received messages dropped because handlers fell behind, by topic",
+		}, []string{"topic"}),
+	}
+}
+
+func (m *gossipMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.received.Describe(ch)
+	m.published.Describe(ch)
+	m.verificationFailures.Describe(ch)
+	m.unregistered.Describe(ch)
+	m.activePeers.Describe(ch)
+	m.publishLatency.Describe(ch)
+	m.handlerQueueDrops.Describe(ch)
+}
+
+func (m *gossipMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.received.Collect(ch)
+	m.published.Collect(ch)
+	m.verificationFailures.Collect(ch)
+	m.unregistered.Collect(ch)
+	m.activePeers.Collect(ch)
+	m.publishLatency.Collect(ch)
+	m.handlerQueueDrops.Collect(ch)
+}
+
+// The recording methods are no-ops on a nil receiver so a GossipNode not
+// built by NewGossipNode can still be exercised
+
+func (m *gossipMetrics) messageReceived(t MessageType) {
+	if m == nil {
+		return
+	}
+	m.received.WithLabelValues(metricType(t)).Inc()
+}
+
+func (m *gossipMetrics) messagePublished(t MessageType, started time.Time) {
+	if m == nil {
+		return
+	}
+	m.published.WithLabelValues(metricType(t)).Inc()
+	m.publishLatency.Observe(time.Since(started).Seconds())
+}
+
+func (m *gossipMetrics) verificationFailed(check string) {
+	if m == nil {
+		return
+	}
+	m.verificationFailures.WithLabelValues(check).Inc()
+}
+
+func (m *gossipMetrics) rejectedUnregistered() {
+	if m == nil {
+		return
+	}
+	m.unregistered.Inc()
+}
+
+func (m *gossipMetrics) handlerQueueFull(class string) {
+	if m == nil {
+		return
+	}
+	m.handlerQueueDrops.WithLabelValues(class).Inc()
+}
+
+// metricType bounds the type label to known types; the type of an inbound
+// message is chosen by its sender
+func metricType(t MessageType) string {
+	switch t {
+	case MessageTypePauseRequest, MessageTypeSignature, MessageTypeHeartbeat, MessageTypeAlert, MessageTypeAlertAck,
+		MessageTypeEvidenceRequest, MessageTypeEvidenceResponse:
+		return string(t)
+	default:
+		return "unknown"
+	}
+}
+
+// Metrics returns the node's gossip metrics for registration with a
+// Prometheus registry
+func (g *GossipNode) Metrics() prometheus.Collector {
+	return g.metrics
+}
@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog"
+)
+
+// metricValue reads a counter, gauge or histogram sample count
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	switch {
+	case out.Counter != nil:
+		return out.Counter.GetValue()
+	case out.Gauge != nil:
+		return out.Gauge.GetValue()
+	case out.Histogram != nil:
+		return float64(out.Histogram.GetSampleCount())
+	}
+	return 0
+}
+
+func TestGossipNode_MetricsCountMessages(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: true}
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	m := node.metrics
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x1"}`, time.Now()), newTestPeerID(t))
+
+	verifier.registeredNode = false
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x2"}`, time.Now()), newTestPeerID(t))
+
+	verifier.registeredNode, verifier.verifyResult = true, false
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x3"}`, time.Now()), newTestPeerID(t))
+
+	// A type the sender made up doesn't get its own series
+	node.handleMessage(TopicAlerts, testMessage(t, MessageType("made_up"), `{}`, time.Now()), newTestPeerID(t))
+
+	if v := metricValue(t, m.received.WithLabelValues("alert")); v != 3 {
+		t.Errorf("Expected 3 alerts received, got %v", v)
+	}
+	if v := metricValue(t, m.received.WithLabelValues("unknown")); v != 1 {
+		t.Errorf("Expected 1 unknown message received, got %v", v)
+	}
+	if v := metricValue(t, m.unregistered); v != 1 {
+		t.Errorf("Expected 1 unregistered rejection, got %v", v)
+	}
+	if v := metricValue(t, m.verificationFailures.WithLabelValues("envelope")); v != 1 {
+		t.Errorf("Expected 1 envelope verification failure, got %v", v)
+	}
+	if v := metricValue(t, m.activePeers); v < 1 {
+		t.Errorf("Expected the accepted sender counted as active, got %v", v)
+	}
+
+	if err := node.broadcast(GossipMessage{Type: MessageTypeHeartbeat, Sender: node.PeerID(), Timestamp: time.Now()}); err != nil {
+		t.Fatalf("broadcast failed: %v", err)
+	}
+	if v := metricValue(t, m.published.WithLabelValues("heartbeat")); v != 1 {
+		t.Errorf("Expected 1 heartbeat published, got %v", v)
+	}
+	if v := metricValue(t, m.publishLatency); v != 1 {
+		t.Errorf("Expected 1 publish latency sample, got %v", v)
+	}
+
+	// The collector registers cleanly
+	if err := prometheus.NewRegistry().Register(node.Metrics()); err != nil {
+		t.Errorf("Register failed: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package consensus
+
+// PeerCountHandler is told the active peer count whenever the mesh falls
+// below MinHealthyPeers (healthy false) or recovers (healthy true)
+type PeerCountHandler func(count int, healthy bool)
+
+const defaultPeerCountHysteresis = 1
+
+// peerHealth tracks whether enough peers are active for the mesh to be
+// considered healthy. Nothing is reported until the count first reaches
+// the minimum, so a node still finding its peers at startup doesn't raise
+// a false alarm. After falling below the minimum, the count has to climb
+// hysteresis past it to recover, so a peer dropping in and out at the
+// threshold doesn't flap. It is guarded by the node's peersMu.
+type peerHealth struct {
+	minPeers   int
+	hysteresis int
+	known      bool
+	healthy    bool
+}
+
+// observe reports whether count moves the mesh across the threshold, and
+// which way
+func (h *peerHealth) observe(count int) (healthy, changed bool) {
+	if h.minPeers <= 0 {
+		return false, false
+	}
+
+	switch {
+	case !h.known && count >= h.minPeers:
+		h.known, h.healthy = true, true
+	case h.known && h.healthy && count < h.minPeers:
+		h.healthy = false
+	case h.known && !h.healthy && count >= h.minPeers+h.hysteresis:
+		h.healthy = true
+	default:
+		return h.healthy, false
+	}
+	return h.healthy, true
+}
+
+// OnPeerCountChange registers handler to run on each transition across
+// MinHealthyPeers
+func (g *GossipNode) OnPeerCountChange(handler PeerCountHandler) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.peerCountHandlers = append(g.peerCountHandlers, handler)
+}
+
+// observePeerCount checks the active peer count against the healthy
+// minimum. peersMu must be held; a transition is returned for
+// notifyPeerCount to report once it is released.
+func (g *GossipNode) observePeerCount() (count int, healthy, changed bool) {
+	count = g.activePeerCountLocked()
+	healthy, changed = g.peerHealth.observe(count)
+	return count, healthy, changed
+}
+
+func (g *GossipNode) notifyPeerCount(count int, healthy bool) {
+	if healthy {
+		g.logger.Info().
+			Int("activePeers", count).
+			Int("minHealthyPeers", g.peerHealth.minPeers).
+			Msg("Active peer count back above healthy minimum")
+	} else {
+		g.logger.Warn().
+			Int("activePeers", count).
+			Int("minHealthyPeers", g.peerHealth.minPeers).
+			Msg("Active peer count fell below healthy minimum")
+	}
+
+	g.mu.RLock()
+	handlers := make([]PeerCountHandler, len(g.peerCountHandlers))
+	copy(handlers, g.peerCountHandlers)
+	g.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(count, healthy)
+	}
+}
@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+)
+
+func TestGossipNode_PeerCountTransitions(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1",
+		MinHealthyPeers: 2,
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	type event struct {
+		count   int
+		healthy bool
+	}
+	var events []event
+	node.OnPeerCountChange(func(count int, healthy bool) {
+		events = append(events, event{count, healthy})
+	})
+	expect := func(step string, want ...event) {
+		t.Helper()
+		if len(events) != len(want) {
+			t.Fatalf("%s: expected events %v, got %v", step, want, events)
+		}
+		for i := range want {
+			if events[i] != want[i] {
+				t.Fatalf("%s: expected events %v, got %v", step, want, events)
+			}
+		}
+	}
+	expire := func(ids ...peer.ID) {
+		node.peersMu.Lock()
+		for _, id := range ids {
+			node.peers[id].LastHeartbeat = time.Now().Add(-time.Minute)
+		}
+		node.peersMu.Unlock()
+		node.cleanupInactivePeers()
+	}
+
+	a, b, c, d := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	// Below the minimum while the mesh forms is not reported
+	node.updatePeer(a)
+	expect("startup")
+
+	node.updatePeer(b)
+	node.updatePeer(a)
+	node.updatePeer(c)
+	expect("reached minimum", event{2, true})
+
+	expire(a, b)
+	expire(a, b)
+	expect("fell below", event{2, true}, event{1, false})
+
+	// Back at the minimum isn't enough to recover
+	node.updatePeer(a)
+	expect("hysteresis", event{2, true}, event{1, false})
+
+	node.updatePeer(d)
+	expect("recovered", event{2, true}, event{1, false}, event{3, true})
+}
@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// peerBucket is one peer's inbound message budget
+type peerBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// peerRateLimiter is a token bucket per peer capping inbound gossip
+// messages, so one peer can't make the node verify signatures at will.
+// Buckets that have refilled are forgotten to bound memory.
+type peerRateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[peer.ID]*peerBucket
+	pruned  time.Time
+
+	now func() time.Time
+}
+
+// newPeerRateLimiter creates a limiter allowing perSec messages per peer
+// with bursts of up to burst. It returns nil, which admits everything, when
+// perSec is 0.
+func newPeerRateLimiter(perSec float64, burst int) *peerRateLimiter {
+	if perSec <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = max(1, int(perSec))
+	}
+	return &peerRateLimiter{
+		rate:    perSec,
+		burst:   float64(burst),
+		buckets: make(map[peer.ID]*peerBucket),
+		now:     time.Now,
+	}
+}
+
+// allow spends one message from the peer's budget, reporting false when it
+// is exhausted
+func (l *peerRateLimiter) allow(from peer.ID) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneLocked(now)
+
+	b, ok := l.buckets[from]
+	if !ok {
+		b = &peerBucket{tokens: l.burst, last: now}
+		l.buckets[from] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// pruneLocked drops buckets idle long enough to have refilled, at most once
+// per refill period
+func (l *peerRateLimiter) pruneLocked(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.pruned) < refill {
+		return
+	}
+	l.pruned = now
+
+	for id, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, id)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package consensus
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// QuorumHandler receives a tracked pause request once enough signature
+// shares came back to submit it on chain
+type QuorumHandler func(requestID string, agg *types.AggregatedPauseRequest)
+
+const defaultPauseRequestTimeout = 5 * time.Minute
+
+// PendingPauseTracker correlates the signature shares peers send back with
+// the pause requests this node broadcast. Shares for request IDs it isn't
+// tracking are ignored, and a request that hasn't reached quorum within the
+// timeout is given up on so late shares can't revive it.
+type PendingPauseTracker struct {
+	mu         sync.Mutex
+	aggregator *ThresholdAggregator
+	// stakes, when set, weighs shares by stake instead of counting signers
+	stakes func() map[common.Address]*big.Int
+	// deadlines holds each tracked request's expiry by request ID
+	deadlines map[string]time.Time
+	timeout   time.Duration
+	handlers  []QuorumHandler
+
+	// now is swapped out by tests
+	now func() time.Time
+}
+
+// NewPendingPauseTracker creates a tracker completing requests at quorum
+// distinct signers (below 1 = 1) and expiring them after timeout (0 = 5m)
+func NewPendingPauseTracker(quorum int, timeout time.Duration) *PendingPauseTracker {
+	if timeout <= 0 {
+		timeout = defaultPauseRequestTimeout
+	}
+	return &PendingPauseTracker{
+		aggregator: NewThresholdAggregator(quorum),
+		deadlines:  make(map[string]time.Time),
+		timeout:    timeout,
+		now:        time.Now,
+	}
+}
+
+// NewWeightedPendingPauseTracker creates a tracker completing requests once
+// signers holding fraction of the total stake have signed (outside (0, 1] =
+// 2/3), so a few high-stake nodes can pause quickly while many low-stake
+// Sybils cannot. stakes is read each time a request is tracked.
+func NewWeightedPendingPauseTracker(stakes func() map[common.Address]*big.Int, fraction float64, timeout time.Duration) *PendingPauseTracker {
+	p := NewPendingPauseTracker(1, timeout)
+	p.aggregator = NewWeightedThresholdAggregator(nil, fraction)
+	p.stakes = stakes
+	return p
+}
+
+// Track starts collecting shares for requestID. Tracking an ID again keeps
+// its original request and deadline.
+func (p *PendingPauseTracker) Track(requestID string, request types.PauseRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.deadlines[requestID]; ok {
+		return
+	}
+	if p.stakes != nil {
+		p.aggregator.SetStakes(p.stakes())
+	}
+	p.deadlines[requestID] = p.now().Add(p.timeout)
+	p.aggregator.setRequest(requestID, request)
+}
+
+// Request returns the pause request tracked under requestID, or false if it
+// isn't tracked or its timeout passed
+func (p *PendingPauseTracker) Request(requestID string) (types.PauseRequest, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	deadline, ok := p.deadlines[requestID]
+	if !ok || !p.now().Before(deadline) {
+		return types.PauseRequest{}, false
+	}
+	return p.aggregator.request(requestID), true
+}
+
+// AddShare records signer's share for requestID. The share reaching quorum
+// completes the request: it stops being tracked, the quorum handlers run
+// and the aggregate is returned. Otherwise AddShare returns nil.
+func (p *PendingPauseTracker) AddShare(requestID string, signer common.Address, sig []byte) *types.AggregatedPauseRequest {
+	p.mu.Lock()
+	deadline, ok := p.deadlines[requestID]
+	if !ok || !p.now().Before(deadline) {
+		p.mu.Unlock()
+		return nil
+	}
+
+	add := p.aggregator.Add
+	if p.stakes != nil {
+		add = p.aggregator.AddWeighted
+	}
+	complete, agg := add(requestID, signer, sig)
+	if !complete {
+		p.mu.Unlock()
+		return nil
+	}
+	delete(p.deadlines, requestID)
+	p.aggregator.Remove(requestID)
+	handlers := make([]QuorumHandler, len(p.handlers))
+	copy(handlers, p.handlers)
+	p.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(requestID, agg)
+	}
+	return agg
+}
+
+// OnQuorumReached registers handler to run for each request that reaches
+// quorum
+func (p *PendingPauseTracker) OnQuorumReached(handler QuorumHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+// Pending returns how many requests are still collecting shares
+func (p *PendingPauseTracker) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.deadlines)
+}
+
+// Expire stops tracking requests whose timeout passed, returning how many
+// shares each had collected, keyed by request ID
+func (p *PendingPauseTracker) Expire() map[string]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var expired map[string]int
+	for requestID, deadline := range p.deadlines {
+		if now.Before(deadline) {
+			continue
+		}
+		if expired == nil {
+			expired = make(map[string]int)
+		}
+		expired[requestID] = p.aggregator.Signers(requestID)
+		delete(p.deadlines, requestID)
+		p.aggregator.Remove(requestID)
+	}
+	return expired
+}
+
+// signaturePayload is a share of a pause request's signature. Signer, when
+// set, must be the operator the share's envelope was sent as; nodes that
+// predate it leave it zero.
+type signaturePayload struct {
+	RequestID string         `json:"requestId"`
+	Signer    common.Address `json:"signer"`
+	Signature []byte         `json:"signature"`
+}
+
+// TrackPauseRequest starts collecting the signature shares peers send back
+// for a pause request this node broadcast under requestID, counting its
+// own signature as the first
+func (g *GossipNode) TrackPauseRequest(requestID string, signed *types.SignedPauseRequest) {
+	g.pendingPauses.Track(requestID, signed.Request)
+	g.pendingPauses.AddShare(requestID, signed.Signer, signed.Signature)
+}
+
+// OnQuorumReached registers handler to receive each tracked pause request
+// once PauseQuorum signers, or PauseStakeFraction of the stake, have
+// contributed, ready for on-chain submission
+func (g *GossipNode) OnQuorumReached(handler QuorumHandler) {
+	g.pendingPauses.OnQuorumReached(handler)
+}
+
+// collectSignature counts a received share toward its tracked request. The
+// share is attributed to the operator its envelope was sent as and verified
+// against that operator's registered key first, so one peer can't pad the
+// quorum with shares claimed for others. It reports false, with the peer
+// penalized, when the share is rejected.
+func (g *GossipNode) collectSignature(payload signaturePayload, operator common.Address, from peer.ID) bool {
+	if payload.Signer != (common.Address{}) && payload.Signer != operator {
+		g.logger.Warn().
+			Str("operator", operator.Hex()).
+			Str("signer", payload.Signer.Hex()).
+			Str("peer", from.String()).
+			Msg("Rejected signature share sent under a different identity than its signer")
+		g.metrics.verificationFailed("signature_share_sender")
+		g.penalize(from, "sender_mismatch")
+		return false
+	}
+
+	// Shares for requests other nodes broadcast aren't ours to count
+	requestID := payload.RequestID
+	request, ok := g.pendingPauses.Request(requestID)
+	if !ok || operator == (common.Address{}) {
+		return true
+	}
+
+	share := &types.SignedPauseRequest{Request: request, Signer: operator, Signature: payload.Signature}
+	if !g.verifier.VerifyPauseRequest(share) {
+		g.logger.Warn().
+			Str("requestId", requestID).
+			Str("signer", operator.Hex()).
+			Msg("Rejected signature share with invalid signature")
+		g.metrics.verificationFailed("signature_share")
+		g.penalize(from, "invalid_signature")
+		return false
+	}
+
+	if agg := g.pendingPauses.AddShare(requestID, operator, payload.Signature); agg != nil {
+		g.logger.Info().
+			Str("requestId", requestID).
+			Str("protocol", agg.Request.TargetProtocol.Hex()).
+			Int("signers", len(agg.Signers)).
+			Msg("Pause request reached quorum")
+	}
+	return true
+}
+
+func (g *GossipNode) expirePauseRequests() {
+	for requestID, signers := range g.pendingPauses.Expire() {
+		g.logger.Warn().
+			Str("requestId", requestID).
+			Int("signers", signers).
+			Msg("Pause request timed out before reaching quorum")
+	}
+}
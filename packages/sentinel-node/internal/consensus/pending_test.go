@@ -0,0 +1,217 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// testShares signs message with n fresh keys, one share per signer address,
+// returning each signer's public key alongside
+func testShares(t *testing.T, n int, message []byte) ([]common.Address, [][]byte, map[common.Address][]byte) {
+	t.Helper()
+	signers := make([]common.Address, n)
+	sigs := make([][]byte, n)
+	keys := make(map[common.Address][]byte, n)
+	for i := range signers {
+		signer, err := NewBLSSigner("")
+		if err != nil {
+			t.Fatalf("NewBLSSigner failed: %v", err)
+		}
+		if sigs[i], err = signer.Sign(message); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		signers[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		keys[signers[i]] = signer.PublicKey()
+	}
+	return signers, sigs, keys
+}
+
+func TestPendingPauseTracker_QuorumReached(t *testing.T) {
+	request, message := testPauseRequest()
+	signers, sigs, _ := testShares(t, 3, message)
+
+	tracker := NewPendingPauseTracker(3, time.Minute)
+	var reached []string
+	var agg *types.AggregatedPauseRequest
+	tracker.OnQuorumReached(func(requestID string, result *types.AggregatedPauseRequest) {
+		reached = append(reached, requestID)
+		agg = result
+	})
+
+	// Shares for requests this node never broadcast are ignored
+	if tracker.AddShare("unknown", signers[0], sigs[0]) != nil {
+		t.Fatal("Expected a share for an untracked request to be ignored")
+	}
+
+	tracker.Track("req-1", request)
+	tracker.AddShare("req-1", signers[0], sigs[0])
+	// A repeated share from the same signer doesn't count toward quorum
+	tracker.AddShare("req-1", signers[0], sigs[0])
+	tracker.AddShare("req-1", signers[1], sigs[1])
+	if len(reached) != 0 {
+		t.Fatalf("Quorum reached with only two signers")
+	}
+
+	if tracker.AddShare("req-1", signers[2], sigs[2]) == nil {
+		t.Fatal("Expected the third signer to complete the request")
+	}
+	if len(reached) != 1 || reached[0] != "req-1" {
+		t.Fatalf("Expected one quorum callback for req-1, got %v", reached)
+	}
+	if agg.Request.TargetProtocol != request.TargetProtocol || len(agg.Signers) != 3 {
+		t.Errorf("Unexpected aggregate: %d signers, target %s", len(agg.Signers), agg.Request.TargetProtocol.Hex())
+	}
+	if tracker.Pending() != 0 {
+		t.Errorf("Expected the completed request to stop being tracked, %d pending", tracker.Pending())
+	}
+}
+
+func TestPendingPauseTracker_Timeout(t *testing.T) {
+	request, message := testPauseRequest()
+	signers, sigs, _ := testShares(t, 2, message)
+
+	now := time.Unix(1700000000, 0)
+	tracker := NewPendingPauseTracker(2, time.Minute)
+	tracker.now = func() time.Time { return now }
+	tracker.OnQuorumReached(func(requestID string, _ *types.AggregatedPauseRequest) {
+		t.Errorf("Quorum reached for %s after it timed out", requestID)
+	})
+
+	tracker.Track("req-1", request)
+	tracker.Track("req-2", request)
+	tracker.AddShare("req-1", signers[0], sigs[0])
+
+	now = now.Add(30 * time.Second)
+	if expired := tracker.Expire(); len(expired) != 0 {
+		t.Fatalf("Expected nothing expired inside the window, got %v", expired)
+	}
+
+	// Past the deadline a late share can't complete it, even before the
+	// sweep removes it
+	now = now.Add(31 * time.Second)
+	if tracker.AddShare("req-1", signers[1], sigs[1]) != nil {
+		t.Fatal("Expected a share after the timeout to be ignored")
+	}
+
+	expired := tracker.Expire()
+	if len(expired) != 2 || expired["req-1"] != 1 || expired["req-2"] != 0 {
+		t.Errorf("Expected req-1 (1 share) and req-2 (0 shares) expired, got %v", expired)
+	}
+	if tracker.Pending() != 0 {
+		t.Errorf("Expected no requests pending after expiry, got %d", tracker.Pending())
+	}
+}
+
+// shareVerifier checks pause request signatures against each signer's key
+type shareVerifier struct {
+	MockVerifier
+	keys map[common.Address][]byte
+}
+
+func (v *shareVerifier) VerifyPauseRequest(request *types.SignedPauseRequest) bool {
+	message := append(request.Request.TargetProtocol.Bytes(), request.Request.EvidenceHash.Bytes()...)
+	valid, err := VerifySignature(request.Signature, message, v.keys[request.Signer])
+	return err == nil && valid
+}
+
+func TestGossipNode_CollectSignature(t *testing.T) {
+	request, message := testPauseRequest()
+	signers, sigs, keys := testShares(t, 3, message)
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1",
+		Logger:          zerolog.Nop(),
+		Verifier:        &shareVerifier{MockVerifier: MockVerifier{verifyResult: true, registeredNode: true}, keys: keys},
+		PauseQuorum:     3,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	var agg *types.AggregatedPauseRequest
+	node.OnQuorumReached(func(_ string, result *types.AggregatedPauseRequest) {
+		agg = result
+	})
+
+	// The originator's own signature is the first share
+	node.TrackPauseRequest("req-1", &types.SignedPauseRequest{Request: request, Signer: signers[0], Signature: sigs[0]})
+
+	// A peer can't name a signer other than the operator it sent as, or
+	// pass another key's signature off as its own
+	forger := newTestPeerID(t)
+	for _, share := range []struct {
+		payload  signaturePayload
+		operator common.Address
+	}{
+		{signaturePayload{RequestID: "req-1", Signer: signers[1], Signature: sigs[1]}, signers[2]},
+		{signaturePayload{RequestID: "req-1", Signature: sigs[1]}, signers[2]},
+	} {
+		if node.collectSignature(share.payload, share.operator, forger) {
+			t.Errorf("Expected share %+v sent as %s rejected", share.payload, share.operator.Hex())
+		}
+	}
+	if agg != nil || node.pendingPauses.aggregator.Signers("req-1") != 1 {
+		t.Fatal("Forged shares counted toward quorum")
+	}
+	if score := node.reputation.Score(forger); score >= 0 {
+		t.Errorf("Expected the forging peer penalized, score %.1f", score)
+	}
+
+	// Genuine shares count, whether or not they name their signer
+	node.collectSignature(signaturePayload{RequestID: "req-1", Signer: signers[1], Signature: sigs[1]}, signers[1], newTestPeerID(t))
+	node.collectSignature(signaturePayload{RequestID: "req-1", Signature: sigs[2]}, signers[2], newTestPeerID(t))
+
+	if agg == nil {
+		t.Fatal("Expected the third share to complete the request")
+	}
+	if len(agg.Signers) != 3 {
+		t.Errorf("Expected all three signers in the aggregate, got %v", agg.Signers)
+	}
+}
+
+func TestPendingPauseTracker_StakeWeighted(t *testing.T) {
+	request, message := testPauseRequest()
+	signers, sigs, _ := testShares(t, 3, message)
+
+	// One whale against two small nodes: 2/3 of 1000 needs the whale
+	stakes := map[common.Address]*big.Int{
+		signers[0]: big.NewInt(100),
+		signers[1]: big.NewInt(100),
+		signers[2]: big.NewInt(800),
+	}
+	reads := 0
+	tracker := NewWeightedPendingPauseTracker(func() map[common.Address]*big.Int {
+		reads++
+		return stakes
+	}, 2.0/3.0, time.Minute)
+
+	tracker.Track("req-1", request)
+	if reads != 1 {
+		t.Fatalf("Expected stakes read when the request was tracked, got %d reads", reads)
+	}
+	tracker.AddShare("req-1", signers[0], sigs[0])
+	if tracker.AddShare("req-1", signers[1], sigs[1]) != nil {
+		t.Fatal("Quorum reached with 20% of the stake")
+	}
+	agg := tracker.AddShare("req-1", signers[2], sigs[2])
+	if agg == nil || len(agg.Signers) != 3 {
+		t.Fatalf("Expected the whale's share to complete the request, got %+v", agg)
+	}
+
+	// Stakes are re-read for the next request: with the whale gone the two
+	// small nodes hold all of it
+	delete(stakes, signers[2])
+	tracker.Track("req-2", request)
+	tracker.AddShare("req-2", signers[0], sigs[0])
+	if tracker.AddShare("req-2", signers[1], sigs[1]) == nil {
+		t.Error("Expected the remaining stake to complete the request")
+	}
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrMessageTypeDenied is returned when broadcasting a message type the
+// topic policy refuses to publish
+var ErrMessageTypeDenied = errors.New("message type not permitted on topic")
+
+// MessageTypeFilter permits message types. An empty Allow permits every type
+// not listed in Deny; Deny wins when a type is in both.
+type MessageTypeFilter struct {
+	Allow []MessageType
+	Deny  []MessageType
+}
+
+// Permits reports whether msgType passes the filter
+func (f MessageTypeFilter) Permits(msgType MessageType) bool {
+	if slices.Contains(f.Deny, msgType) {
+		return false
+	}
+	return len(f.Allow) == 0 || slices.Contains(f.Allow, msgType)
+}
+
+func (f MessageTypeFilter) validate() error {
+	for _, msgType := range append(slices.Clone(f.Allow), f.Deny...) {
+		switch msgType {
+		case MessageTypePauseRequest, MessageTypeSignature, MessageTypeHeartbeat,
+			MessageTypeAlert, MessageTypeAlertAck,
+			MessageTypeEvidenceRequest, MessageTypeEvidenceResponse:
+		default:
+			return fmt.Errorf("unknown message type %q", msgType)
+		}
+	}
+	return nil
+}
+
+// TopicPolicy restricts which message types a node handles from a topic and
+// publishes to it, e.g. a listen-only node that never relays pause requests
+type TopicPolicy struct {
+	Inbound  MessageTypeFilter
+	Outbound MessageTypeFilter
+}
+
+func (p TopicPolicy) validate() error {
+	if err := p.Inbound.validate(); err != nil {
+		return fmt.Errorf("inbound: %w", err)
+	}
+	if err := p.Outbound.validate(); err != nil {
+		return fmt.Errorf("outbound: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSeenCacheSize = 10000
+	// Outlives both pubsub's own seen-message window and the default
+	// MaxMessageAge, so a replay is either remembered or too old to accept
+	defaultSeenCacheTTL = 30 * time.Minute
+)
+
+type messageKey [sha256.Size]byte
+
+// messageKeyFor identifies a message by operator, sender, type, payload,
+// timestamp and envelope signature. Including the signature means a copy
+// with a forged signature can't get the genuine message marked as already
+// seen; every other field is covered by that signature, so a copy with any
+// of them rewritten fails verification instead of passing as new.
+func messageKeyFor(msg *GossipMessage) messageKey {
+	h := sha256.New()
+	writeFields(h,
+		msg.Operator.Bytes(),
+		[]byte(msg.Sender),
+		[]byte(msg.Type),
+		msg.Payload,
+		[]byte(msg.Timestamp.UTC().Format(time.RFC3339Nano)),
+		msg.Signature,
+	)
+
+	var key messageKey
+	h.Sum(key[:0])
+	return key
+}
+
+type seenEntry struct {
+	key    messageKey
+	expiry time.Time
+}
+
+// seenCache remembers recently handled gossip messages so replays are
+// dropped before reaching handlers. It is an LRU bounded by size whose
+// entries also expire after ttl.
+type seenCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[messageKey]*list.Element
+
+	now func() time.Time
+}
+
+// newSeenCache creates a cache, applying defaults for unset limits
+func newSeenCache(size int, ttl time.Duration) *seenCache {
+	if size <= 0 {
+		size = defaultSeenCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultSeenCacheTTL
+	}
+	return &seenCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[messageKey]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// checkAndAdd records key and reports whether it had already been seen and
+// not yet expired. A nil cache never reports a replay.
+func (c *seenCache) checkAndAdd(key messageKey) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*seenEntry)
+		if now.Before(entry.expiry) {
+			c.order.MoveToFront(elem)
+			return true
+		}
+		entry.expiry = now.Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return false
+	}
+
+	c.entries[key] = c.order.PushFront(&seenEntry{key: key, expiry: now.Add(c.ttl)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*seenEntry).key)
+	}
+	return false
+}
+
+// Len returns the number of remembered messages
+func (c *seenCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,216 @@
+package consensus
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	reputationFileName        = "peer_reputation.json"
+	defaultReputationHalfLife = 1 * time.Hour
+	defaultBlockThreshold     = -50.0
+	maxRecordedViolations     = 10
+
+	// Score adjustments applied by the gossip layer
+	reputationPenalty = 10.0
+	reputationReward  = 0.1
+)
+
+// Violation records a single rejected message from a peer
+type Violation struct {
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// PeerReputation is the persisted trust state of a peer. Score is 0 for an
+// unknown peer, negative after misbehaviour, and decays back towards 0 with
+// the store's half-life.
+type PeerReputation struct {
+	Score      float64     `json:"score"`
+	Violations []Violation `json:"violations,omitempty"`
+	UpdatedAt  time.Time   `json:"updatedAt"`
+}
+
+// ReputationStore tracks per-peer reputation and persists it to disk so a
+// restart doesn't forget which peers were misbehaving
+type ReputationStore struct {
+	mu       sync.Mutex
+	path     string
+	halfLife time.Duration
+	peers    map[peer.ID]*PeerReputation
+	now      func() time.Time
+}
+
+// NewReputationStore creates a store persisted under dataDir. An empty
+// dataDir keeps reputation in memory only.
+func NewReputationStore(dataDir string, halfLife time.Duration) *ReputationStore {
+	if halfLife == 0 {
+		halfLife = defaultReputationHalfLife
+	}
+
+	var path string
+	if dataDir != "" {
+		path = filepath.Join(dataDir, reputationFileName)
+	}
+
+	return &ReputationStore{
+		path:     path,
+		halfLife: halfLife,
+		peers:    make(map[peer.ID]*PeerReputation),
+		now:      time.Now,
+	}
+}
+
+// Load reads persisted reputation. Scores decay lazily from their saved
+// UpdatedAt, so time the node spent offline counts towards recovery.
+func (s *ReputationStore) Load() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var stored map[string]*PeerReputation
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for idStr, rep := range stored {
+		id, err := peer.Decode(idStr)
+		if err != nil || rep == nil {
+			continue
+		}
+		s.peers[id] = rep
+	}
+	return nil
+}
+
+// Save writes the current reputation to disk atomically
+func (s *ReputationStore) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	stored := make(map[string]*PeerReputation, len(s.peers))
+	for id, rep := range s.peers {
+		s.decay(rep)
+		// Fully recovered peers carry no information worth persisting
+		if rep.Score == 0 && len(rep.Violations) == 0 {
+			continue
+		}
+		copied := *rep
+		stored[id.String()] = &copied
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Penalize lowers a peer's score and records the violation, returning the new score
+func (s *ReputationStore) Penalize(id peer.ID, reason string, amount float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep := s.get(id)
+	rep.Score -= amount
+	rep.Violations = append(rep.Violations, Violation{Reason: reason, At: s.now()})
+	if len(rep.Violations) > maxRecordedViolations {
+		rep.Violations = rep.Violations[len(rep.Violations)-maxRecordedViolations:]
+	}
+	return rep.Score
+}
+
+// Reward raises a peer's score, capped at 0, returning the new score
+func (s *ReputationStore) Reward(id peer.ID, amount float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep := s.get(id)
+	rep.Score = math.Min(rep.Score+amount, 0)
+	return rep.Score
+}
+
+// Score returns a peer's current (decayed) score
+func (s *ReputationStore) Score(id peer.ID) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep, ok := s.peers[id]
+	if !ok {
+		return 0
+	}
+	s.decay(rep)
+	return rep.Score
+}
+
+// Get returns a copy of a peer's reputation
+func (s *ReputationStore) Get(id peer.ID) (PeerReputation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep, ok := s.peers[id]
+	if !ok {
+		return PeerReputation{}, false
+	}
+	s.decay(rep)
+
+	copied := *rep
+	copied.Violations = append([]Violation(nil), rep.Violations...)
+	return copied, true
+}
+
+// get returns the decayed reputation for a peer, creating it if needed.
+// Caller must hold s.mu.
+func (s *ReputationStore) get(id peer.ID) *PeerReputation {
+	rep, ok := s.peers[id]
+	if !ok {
+		rep = &PeerReputation{UpdatedAt: s.now()}
+		s.peers[id] = rep
+		return rep
+	}
+	s.decay(rep)
+	return rep
+}
+
+// decay moves the score towards 0 by the elapsed number of half-lives.
+// Caller must hold s.mu.
+func (s *ReputationStore) decay(rep *PeerReputation) {
+	now := s.now()
+	elapsed := now.Sub(rep.UpdatedAt)
+	if elapsed > 0 && rep.Score != 0 {
+		rep.Score *= math.Pow(0.5, float64(elapsed)/float64(s.halfLife))
+		if math.Abs(rep.Score) < 0.01 {
+			rep.Score = 0
+		}
+	}
+	rep.UpdatedAt = now
+}
@@ -0,0 +1,293 @@
+package consensus
+
+import (
+	"crypto/rand"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+)
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key failed: %v", err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatalf("IDFromPublicKey failed: %v", err)
+	}
+	return id
+}
+
+func TestReputationStore_PenalizeAndReward(t *testing.T) {
+	store := NewReputationStore("", time.Hour)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	id := newTestPeerID(t)
+
+	if score := store.Penalize(id, "invalid_signature", 10); score != -10 {
+		t.Errorf("Expected score -10, got %f", score)
+	}
+
+	if score := store.Reward(id, 4); score != -6 {
+		t.Errorf("Expected score -6, got %f", score)
+	}
+
+	// Rewards never push a peer above neutral
+	if score := store.Reward(id, 100); score != 0 {
+		t.Errorf("Expected score capped at 0, got %f", score)
+	}
+
+	rep, ok := store.Get(id)
+	if !ok {
+		t.Fatal("Expected peer to be tracked")
+	}
+	if len(rep.Violations) != 1 || rep.Violations[0].Reason != "invalid_signature" {
+		t.Errorf("Unexpected violations: %v", rep.Violations)
+	}
+}
+
+func TestReputationStore_ViolationsBounded(t *testing.T) {
+	store := NewReputationStore("", time.Hour)
+	id := newTestPeerID(t)
+
+	for i := 0; i < maxRecordedViolations*2; i++ {
+		store.Penalize(id, "malformed_message", 1)
+	}
+
+	rep, _ := store.Get(id)
+	if len(rep.Violations) != maxRecordedViolations {
+		t.Errorf("Expected %d violations, got %d", maxRecordedViolations, len(rep.Violations))
+	}
+}
+
+func TestReputationStore_RestoredAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	id := newTestPeerID(t)
+	now := time.Now()
+
+	store := NewReputationStore(dir, time.Hour)
+	store.now = func() time.Time { return now }
+	for i := 0; i < 6; i++ {
+		store.Penalize(id, "invalid_signature", reputationPenalty)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Restart immediately: the low score is restored unchanged
+	restarted := NewReputationStore(dir, time.Hour)
+	restarted.now = func() time.Time { return now }
+	if err := restarted.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if score := restarted.Score(id); score != -60 {
+		t.Errorf("Expected restored score -60, got %f", score)
+	}
+	rep, _ := restarted.Get(id)
+	if len(rep.Violations) != 6 {
+		t.Errorf("Expected 6 restored violations, got %d", len(rep.Violations))
+	}
+}
+
+func TestReputationStore_DecaysOverDowntime(t *testing.T) {
+	dir := t.TempDir()
+	id := newTestPeerID(t)
+	now := time.Now()
+
+	store := NewReputationStore(dir, time.Hour)
+	store.now = func() time.Time { return now }
+	store.Penalize(id, "invalid_signature", 80)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Node was down for two half-lives
+	restarted := NewReputationStore(dir, time.Hour)
+	restarted.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if err := restarted.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if score := restarted.Score(id); math.Abs(score-(-20)) > 0.001 {
+		t.Errorf("Expected score decayed to -20, got %f", score)
+	}
+
+	// After a long enough downtime the peer is fully rehabilitated
+	restarted.now = func() time.Time { return now.Add(48 * time.Hour) }
+	if score := restarted.Score(id); score != 0 {
+		t.Errorf("Expected score decayed to 0, got %f", score)
+	}
+}
+
+func TestGossipNode_BlocksLowReputationPeer(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: false}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+		DataDir:         t.TempDir(),
+		BlockThreshold:  -30,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	now := time.Now()
+	node.reputation.now = func() time.Time { return now }
+
+	id := newTestPeerID(t)
+	msg := testMessage(t, MessageTypeAlert, `{}`, now)
+
+	for i := 0; i < 3; i++ {
+		node.handleMessage(TopicAlerts, msg, id)
+	}
+
+	if !node.IsBlocked(id) {
+		t.Error("Peer should be blocked after repeated unregistered messages")
+	}
+}
+
+func TestGossipNode_BansPeerBelowThreshold(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: false}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1/alerts",
+		Logger:          zerolog.Nop(),
+		Verifier:        verifier,
+		DataDir:         t.TempDir(),
+		BlockThreshold:  -50,
+		BanThreshold:    -30,
+		BanDuration:     time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	// Freeze decay so three penalties land exactly on the threshold
+	now := time.Now()
+	node.reputation.now = func() time.Time { return now }
+
+	id := newTestPeerID(t)
+	msg := testMessage(t, MessageTypeAlert, `{}`, now)
+
+	for i := 0; i < 2; i++ {
+		node.handleMessage(TopicAlerts, msg, id)
+	}
+	if node.IsBanned(id) {
+		t.Fatal("Peer should not be banned above the threshold")
+	}
+
+	node.handleMessage(TopicAlerts, msg, id)
+	if !node.IsBanned(id) {
+		t.Fatal("Peer should be banned after 3 invalid messages")
+	}
+	if node.bans.InterceptSecured(network.DirInbound, id, nil) {
+		t.Error("Banned peer should be refused on reconnect")
+	}
+
+	node.peersMu.RLock()
+	info := node.peers[id]
+	node.peersMu.RUnlock()
+	if info == nil || info.PeerScore != -30 {
+		t.Errorf("Expected PeerInfo score -30, got %+v", info)
+	}
+
+	// Bans are temporary
+	node.bans.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if node.IsBanned(id) {
+		t.Error("Ban should expire after BanDuration")
+	}
+}
+
+func TestGossipNode_EvictionPolicy(t *testing.T) {
+	verifier := &MockVerifier{verifyResult: true, registeredNode: false}
+
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses:       []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:             "test/v1/alerts",
+		Logger:                zerolog.Nop(),
+		Verifier:              verifier,
+		BlockThreshold:        -1000,
+		BanThreshold:          -5,
+		BanDuration:           time.Minute,
+		EvictionWindow:        time.Minute,
+		EvictionMinViolations: 3,
+		EvictionGracePeriod:   10 * time.Second,
+		EvictionCooldown:      5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	node.reputation.now = clock
+	node.bans.now = clock
+
+	id := newTestPeerID(t)
+	violate := func(n int) {
+		for i := 0; i < n; i++ {
+			node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{}`, time.Now()), id)
+		}
+	}
+
+	// A single violation is below the threshold but isolated
+	violate(1)
+	if node.IsBanned(id) {
+		t.Fatal("An isolated violation should not evict")
+	}
+
+	// Once it has left the window it no longer counts
+	now = now.Add(2 * time.Minute)
+	violate(2)
+	if node.IsBanned(id) {
+		t.Fatal("Two violations in the window should not evict")
+	}
+
+	// Enough violations, but the earliest is still within the grace period
+	now = now.Add(5 * time.Second)
+	violate(1)
+	if node.IsBanned(id) {
+		t.Fatal("Violations within the grace period should not evict")
+	}
+
+	now = now.Add(6 * time.Second)
+	violate(1)
+	if !node.IsBanned(id) {
+		t.Fatal("Repeated violations within the window should evict")
+	}
+
+	// After the ban expires the reconnected peer is briefly protected
+	now = now.Add(61 * time.Second)
+	if node.IsBanned(id) {
+		t.Fatal("Ban should have expired")
+	}
+	violate(3)
+	now = now.Add(11 * time.Second)
+	violate(1)
+	if node.IsBanned(id) {
+		t.Fatal("Peer should not be evicted again during the cooldown")
+	}
+
+	now = now.Add(5 * time.Minute)
+	violate(3)
+	now = now.Add(11 * time.Second)
+	violate(1)
+	if !node.IsBanned(id) {
+		t.Error("Peer should be evictable once the cooldown has passed")
+	}
+}
@@ -0,0 +1,261 @@
+package consensus
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// defaultStakeFraction is the share of total stake that must sign a pause
+// when an out-of-range fraction is configured
+const defaultStakeFraction = 2.0 / 3.0
+
+// pendingAggregate is the signature set collected so far for one request
+type pendingAggregate struct {
+	request    types.PauseRequest
+	signers    []common.Address
+	signatures [][]byte
+	seen       map[common.Address]bool
+	stake      *big.Int
+	complete   bool
+}
+
+// ThresholdAggregator collects pause request signatures per request ID and
+// produces an aggregated, on-chain-submittable request once threshold
+// distinct signers have contributed, or, via AddWeighted, once contributing
+// signers hold the required share of stake
+type ThresholdAggregator struct {
+	mu        sync.Mutex
+	threshold int
+	pending   map[string]*pendingAggregate
+
+	stakes        map[common.Address]*big.Int
+	fraction      float64
+	requiredStake *big.Int
+}
+
+// NewThresholdAggregator creates an aggregator requiring threshold signers
+// (e.g. 7 of 10 registered nodes). A threshold below 1 is treated as 1.
+func NewThresholdAggregator(threshold int) *ThresholdAggregator {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &ThresholdAggregator{
+		threshold: threshold,
+		pending:   make(map[string]*pendingAggregate),
+	}
+}
+
+// NewWeightedThresholdAggregator creates an aggregator whose AddWeighted
+// completes once signers holding fraction of the total stake have signed,
+// so a few high-stake nodes can pause quickly while many low-stake Sybils
+// cannot. Stakes typically come from NodeInfo.Stake; a fraction outside
+// (0, 1] is treated as 2/3.
+func NewWeightedThresholdAggregator(stakes map[common.Address]*big.Int, fraction float64) *ThresholdAggregator {
+	if fraction <= 0 || fraction > 1 {
+		fraction = defaultStakeFraction
+	}
+
+	a := NewThresholdAggregator(1)
+	a.fraction = fraction
+	a.SetStakes(stakes)
+	return a
+}
+
+// SetStakes replaces the stakes AddWeighted counts, e.g. after a registry
+// refresh. The required stake is recomputed from the new total, and
+// requests still collecting are measured against it.
+func (a *ThresholdAggregator) SetStakes(stakes map[common.Address]*big.Int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stakes = make(map[common.Address]*big.Int, len(stakes))
+	total := new(big.Int)
+	for signer, stake := range stakes {
+		if stake == nil || stake.Sign() <= 0 {
+			continue
+		}
+		a.stakes[signer] = new(big.Int).Set(stake)
+		total.Add(total, stake)
+	}
+
+	// required = ceil(total * fraction)
+	required := new(big.Rat).Mul(new(big.Rat).SetInt(total), new(big.Rat).SetFloat64(a.fraction))
+	a.requiredStake = new(big.Int).Quo(required.Num(), required.Denom())
+	if new(big.Int).Mul(a.requiredStake, required.Denom()).Cmp(required.Num()) < 0 {
+		a.requiredStake.Add(a.requiredStake, big.NewInt(1))
+	}
+
+	for _, entry := range a.pending {
+		entry.stake = a.stakeOf(entry.signers)
+	}
+}
+
+// AddSigned records a signed pause request, remembering the request itself
+// so the aggregate carries it
+func (a *ThresholdAggregator) AddSigned(requestID string, signed *types.SignedPauseRequest) (bool, *types.AggregatedPauseRequest) {
+	a.setRequest(requestID, signed.Request)
+	return a.Add(requestID, signed.Signer, signed.Signature)
+}
+
+// setRequest records the request requestID's aggregate is for, unless one
+// already is
+func (a *ThresholdAggregator) setRequest(requestID string, request types.PauseRequest) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry := a.entryLocked(requestID)
+	if entry.request.TargetProtocol == (common.Address{}) {
+		entry.request = request
+	}
+}
+
+// request returns the request requestID's aggregate is for
+func (a *ThresholdAggregator) request(requestID string) types.PauseRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if entry, ok := a.pending[requestID]; ok {
+		return entry.request
+	}
+	return types.PauseRequest{}
+}
+
+// Add records signer's signature for requestID. It returns the aggregate
+// exactly once, on the signature that reaches the threshold; repeated
+// signatures from a signer are ignored so they can't inflate the count.
+func (a *ThresholdAggregator) Add(requestID string, signer common.Address, sig []byte) (complete bool, agg *types.AggregatedPauseRequest) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.recordLocked(requestID, signer, sig)
+	if !ok || len(entry.signers) < a.threshold {
+		return false, nil
+	}
+
+	return a.completeLocked(entry, func(signers []common.Address) bool {
+		return len(signers) >= a.threshold
+	})
+}
+
+// AddWeighted records signer's signature for requestID and returns the
+// aggregate exactly once, when the summed stake of distinct signers reaches
+// the required stake. Signers without stake are not counted.
+func (a *ThresholdAggregator) AddWeighted(requestID string, signer common.Address, sig []byte) (complete bool, agg *types.AggregatedPauseRequest) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stake, staked := a.stakes[signer]
+	if !staked {
+		return false, nil
+	}
+
+	entry, ok := a.recordLocked(requestID, signer, sig)
+	if !ok {
+		return false, nil
+	}
+	entry.stake.Add(entry.stake, stake)
+	if entry.stake.Cmp(a.requiredStake) < 0 {
+		return false, nil
+	}
+
+	return a.completeLocked(entry, func(signers []common.Address) bool {
+		return a.stakeOf(signers).Cmp(a.requiredStake) >= 0
+	})
+}
+
+// StakeProgress returns the stake collected for requestID and the stake
+// required to complete it. Both are zero for an unweighted aggregator.
+func (a *ThresholdAggregator) StakeProgress(requestID string) (collected, required *big.Int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	collected = new(big.Int)
+	if entry, ok := a.pending[requestID]; ok {
+		collected.Set(entry.stake)
+	}
+	required = new(big.Int)
+	if a.requiredStake != nil {
+		required.Set(a.requiredStake)
+	}
+	return collected, required
+}
+
+// recordLocked adds a well-formed signature from a new signer to requestID's
+// entry, reporting false if it was malformed, repeated or too late
+func (a *ThresholdAggregator) recordLocked(requestID string, signer common.Address, sig []byte) (*pendingAggregate, bool) {
+	// Malformed signatures would poison the aggregate, so never count them
+	if _, err := AggregateSignatures([][]byte{sig}); err != nil {
+		return nil, false
+	}
+
+	entry := a.entryLocked(requestID)
+	if entry.complete || entry.seen[signer] {
+		return nil, false
+	}
+
+	entry.seen[signer] = true
+	entry.signers = append(entry.signers, signer)
+	entry.signatures = append(entry.signatures, sig)
+	return entry, true
+}
+
+// completeLocked aggregates entry's signatures and marks it complete if the
+// signers actually included still satisfy enough
+func (a *ThresholdAggregator) completeLocked(entry *pendingAggregate, enough func([]common.Address) bool) (bool, *types.AggregatedPauseRequest) {
+	aggSig, signers, err := AggregateSignaturesWithSigners(entry.signatures, entry.signers)
+	if err != nil || !enough(signers) {
+		return false, nil
+	}
+
+	entry.complete = true
+
+	request := entry.request
+	request.Signers = signers
+
+	return true, &types.AggregatedPauseRequest{
+		Request:             request,
+		AggregatedSignature: aggSig,
+		Signers:             signers,
+	}
+}
+
+func (a *ThresholdAggregator) stakeOf(signers []common.Address) *big.Int {
+	total := new(big.Int)
+	for _, signer := range signers {
+		if stake, ok := a.stakes[signer]; ok {
+			total.Add(total, stake)
+		}
+	}
+	return total
+}
+
+// Signers returns how many distinct signers have contributed to requestID
+func (a *ThresholdAggregator) Signers(requestID string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if entry, ok := a.pending[requestID]; ok {
+		return len(entry.signers)
+	}
+	return 0
+}
+
+// Remove forgets a request, e.g. once it was submitted or expired
+func (a *ThresholdAggregator) Remove(requestID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, requestID)
+}
+
+func (a *ThresholdAggregator) entryLocked(requestID string) *pendingAggregate {
+	entry, ok := a.pending[requestID]
+	if !ok {
+		entry = &pendingAggregate{seen: make(map[common.Address]bool), stake: new(big.Int)}
+		a.pending[requestID] = entry
+	}
+	return entry
+}
@@ -0,0 +1,180 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func testPauseRequest() (types.PauseRequest, []byte) {
+	request := types.PauseRequest{
+		TargetProtocol: common.HexToAddress("0xdead"),
+		EvidenceHash:   common.HexToHash("0xbeef"),
+	}
+	message := append(request.TargetProtocol.Bytes(), request.EvidenceHash.Bytes()...)
+	return request, message
+}
+
+func TestThresholdAggregator_SevenOfTen(t *testing.T) {
+	request, message := testPauseRequest()
+	aggregator := NewThresholdAggregator(7)
+
+	signers := make([]*BLSSigner, 10)
+	for i := range signers {
+		signers[i], _ = NewBLSSigner("")
+	}
+
+	var agg *types.AggregatedPauseRequest
+	for i, signer := range signers[:7] {
+		sig, _ := signer.Sign(message)
+		complete, result := aggregator.AddSigned("req-1", &types.SignedPauseRequest{
+			Request:   request,
+			Signature: sig,
+			Signer:    common.BigToAddress(big.NewInt(int64(i + 1))),
+		})
+
+		if i < 6 && complete {
+			t.Fatalf("Completed with only %d signers", i+1)
+		}
+		if i == 6 {
+			if !complete || result == nil {
+				t.Fatal("Expected the seventh signer to complete the aggregate")
+			}
+			agg = result
+		}
+	}
+
+	if len(agg.Signers) != 7 || agg.Request.TargetProtocol != request.TargetProtocol {
+		t.Errorf("Unexpected aggregate: %d signers, target %s", len(agg.Signers), agg.Request.TargetProtocol.Hex())
+	}
+
+	messages := make([][]byte, 7)
+	pubKeys := make([][]byte, 7)
+	for i, signer := range signers[:7] {
+		messages[i] = message
+		pubKeys[i] = signer.PublicKey()
+	}
+	valid, err := VerifyAggregatedSignature(agg.AggregatedSignature, messages, pubKeys)
+	if err != nil || !valid {
+		t.Errorf("Aggregate should verify against the contributing keys, got %v, %v", valid, err)
+	}
+
+	// Late signers don't produce a second aggregate
+	sig, _ := signers[7].Sign(message)
+	if complete, _ := aggregator.Add("req-1", common.BigToAddress(big.NewInt(8)), sig); complete {
+		t.Error("Aggregate should only be emitted once")
+	}
+}
+
+func TestThresholdAggregator_DuplicateSigners(t *testing.T) {
+	_, message := testPauseRequest()
+	aggregator := NewThresholdAggregator(3)
+
+	signer, _ := NewBLSSigner("")
+	sig, _ := signer.Sign(message)
+	addr := common.HexToAddress("0x1")
+
+	for i := 0; i < 5; i++ {
+		if complete, _ := aggregator.Add("req-1", addr, sig); complete {
+			t.Fatal("Repeated signatures from one signer must not reach the threshold")
+		}
+	}
+	if n := aggregator.Signers("req-1"); n != 1 {
+		t.Errorf("Expected 1 distinct signer, got %d", n)
+	}
+}
+
+func TestThresholdAggregator_IgnoresMalformedSignatures(t *testing.T) {
+	_, message := testPauseRequest()
+	aggregator := NewThresholdAggregator(2)
+
+	if complete, _ := aggregator.Add("req-1", common.HexToAddress("0x1"), []byte("garbage")); complete {
+		t.Fatal("Malformed signature should not count")
+	}
+
+	for i := int64(2); i <= 3; i++ {
+		signer, _ := NewBLSSigner("")
+		sig, _ := signer.Sign(message)
+		complete, agg := aggregator.Add("req-1", common.BigToAddress(big.NewInt(i)), sig)
+		if i == 3 && (!complete || agg == nil) {
+			t.Error("Expected two valid signers to complete the aggregate")
+		}
+	}
+
+	aggregator.Remove("req-1")
+	if n := aggregator.Signers("req-1"); n != 0 {
+		t.Errorf("Expected removed request to be forgotten, got %d signers", n)
+	}
+}
+
+func TestThresholdAggregator_Weighted(t *testing.T) {
+	_, message := testPauseRequest()
+
+	whale := common.HexToAddress("0xa1")
+	midsize := common.HexToAddress("0xa2")
+	sybils := make([]common.Address, 10)
+	stakes := map[common.Address]*big.Int{
+		whale:   big.NewInt(600),
+		midsize: big.NewInt(300),
+	}
+	for i := range sybils {
+		sybils[i] = common.BigToAddress(big.NewInt(int64(0x100 + i)))
+		stakes[sybils[i]] = big.NewInt(10)
+	}
+	// Total stake 1000; two thirds requires 667
+
+	sign := func() []byte {
+		signer, _ := NewBLSSigner("")
+		sig, _ := signer.Sign(message)
+		return sig
+	}
+
+	t.Run("many low-stake signers fall short", func(t *testing.T) {
+		aggregator := NewWeightedThresholdAggregator(stakes, 2.0/3.0)
+		for _, sybil := range sybils {
+			if complete, _ := aggregator.AddWeighted("req", sybil, sign()); complete {
+				t.Fatal("Ten low-stake signers must not complete the pause")
+			}
+		}
+
+		collected, required := aggregator.StakeProgress("req")
+		if collected.Int64() != 100 || required.Int64() != 667 {
+			t.Errorf("Expected 100/667 stake, got %s/%s", collected, required)
+		}
+	})
+
+	t.Run("two high-stake signers complete", func(t *testing.T) {
+		aggregator := NewWeightedThresholdAggregator(stakes, 2.0/3.0)
+		if complete, _ := aggregator.AddWeighted("req", whale, sign()); complete {
+			t.Fatal("600 of 667 required stake should not complete")
+		}
+		// A repeated signature from the same signer adds no stake
+		if complete, _ := aggregator.AddWeighted("req", whale, sign()); complete {
+			t.Fatal("Repeated signer should not add stake")
+		}
+		if complete, _ := aggregator.AddWeighted("req", sybils[0], sign()); complete {
+			t.Fatal("610 of 667 required stake should not complete")
+		}
+
+		complete, agg := aggregator.AddWeighted("req", midsize, sign())
+		if !complete || agg == nil {
+			t.Fatal("Expected 910 stake to complete the aggregate")
+		}
+		if len(agg.Signers) != 3 {
+			t.Errorf("Expected 3 signers in the aggregate, got %d", len(agg.Signers))
+		}
+	})
+
+	t.Run("unstaked signers are ignored", func(t *testing.T) {
+		aggregator := NewWeightedThresholdAggregator(stakes, 0.5)
+		if complete, _ := aggregator.AddWeighted("req", common.HexToAddress("0xbad"), sign()); complete {
+			t.Fatal("Unstaked signer should not count")
+		}
+		if collected, _ := aggregator.StakeProgress("req"); collected.Sign() != 0 {
+			t.Errorf("Unstaked signer contributed %s stake", collected)
+		}
+	})
+}
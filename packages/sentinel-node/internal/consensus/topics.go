@@ -0,0 +1,111 @@
+package consensus
+
+import (
+	"fmt"
+	"slices"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// Gossip is split across topics by traffic class, so a flood of alerts
+// can't hold up pause request signatures and a node can leave out traffic
+// it has no use for. Each class's topic is named TopicName + "/" + class.
+const (
+	TopicAlerts    = "alerts"
+	TopicConsensus = "pause-consensus"
+	TopicHeartbeat = "heartbeat"
+	// topicEvidence is named by EvidenceTopicName rather than the prefix
+	topicEvidence = "evidence"
+)
+
+// gossipTopic is a joined topic with its subscription and policy. seq is
+// the sequence number of the last signed message published on it, guarded
+// by the node's publishMu.
+type gossipTopic struct {
+	class  string
+	name   string
+	topic  *pubsub.Topic
+	sub    *pubsub.Subscription
+	policy TopicPolicy
+	seq    uint64
+	// queue holds received messages until the topic's dispatcher runs
+	// their handlers
+	queue chan *pubsub.Message
+}
+
+// topicClass returns the class of topic msgType is carried on, or "" for
+// a type no topic carries
+func topicClass(msgType MessageType) string {
+	switch msgType {
+	case MessageTypeAlert, MessageTypeAlertAck:
+		return TopicAlerts
+	case MessageTypePauseRequest, MessageTypeSignature:
+		return TopicConsensus
+	case MessageTypeHeartbeat:
+		return TopicHeartbeat
+	case MessageTypeEvidenceRequest, MessageTypeEvidenceResponse:
+		return topicEvidence
+	default:
+		return ""
+	}
+}
+
+// topicNames maps each class the node joins to its topic name
+func topicNames(cfg GossipConfig) (map[string]string, error) {
+	classes := cfg.Topics
+	if len(classes) == 0 {
+		classes = []string{TopicAlerts, TopicConsensus, TopicHeartbeat}
+	}
+
+	names := make(map[string]string, len(classes)+1)
+	for _, class := range classes {
+		if !slices.Contains([]string{TopicAlerts, TopicConsensus, TopicHeartbeat}, class) {
+			return nil, fmt.Errorf("unknown topic %q (expected %q, %q or %q)", class, TopicAlerts, TopicConsensus, TopicHeartbeat)
+		}
+		names[class] = cfg.TopicName + "/" + class
+	}
+	if cfg.EvidenceTopicName != "" {
+		names[topicEvidence] = cfg.EvidenceTopicName
+	}
+	return names, nil
+}
+
+// joinTopics joins and subscribes to each named topic. A topic's policy is
+// its own entry in TopicPolicies, or else the entry for the TopicName
+// prefix. On error, topics already joined are closed.
+func joinTopics(ps *pubsub.PubSub, cfg GossipConfig, names map[string]string) (map[string]*gossipTopic, error) {
+	topics := make(map[string]*gossipTopic, len(names))
+	fail := func(err error) (map[string]*gossipTopic, error) {
+		closeTopics(topics)
+		return nil, err
+	}
+
+	for class, name := range names {
+		policy, ok := cfg.TopicPolicies[name]
+		if !ok {
+			policy = cfg.TopicPolicies[cfg.TopicName]
+		}
+		if err := policy.validate(); err != nil {
+			return fail(fmt.Errorf("topic policy for %q: %w", name, err))
+		}
+
+		topic, err := ps.Join(name)
+		if err != nil {
+			return fail(fmt.Errorf("failed to join topic %q: %w", name, err))
+		}
+		sub, err := topic.Subscribe()
+		if err != nil {
+			topic.Close()
+			return fail(fmt.Errorf("failed to subscribe to topic %q: %w", name, err))
+		}
+		topics[class] = &gossipTopic{class: class, name: name, topic: topic, sub: sub, policy: policy}
+	}
+	return topics, nil
+}
+
+func closeTopics(topics map[string]*gossipTopic) {
+	for _, t := range topics {
+		t.sub.Cancel()
+		t.topic.Close()
+	}
+}
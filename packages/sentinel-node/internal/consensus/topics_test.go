@@ -0,0 +1,136 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestGossipNode_RoutesByTopic(t *testing.T) {
+	node, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1",
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err != nil {
+		t.Fatalf("NewGossipNode failed: %v", err)
+	}
+	defer node.Stop()
+
+	for class, name := range map[string]string{
+		TopicAlerts:    "test/v1/alerts",
+		TopicConsensus: "test/v1/pause-consensus",
+		TopicHeartbeat: "test/v1/heartbeat",
+	} {
+		if topic := node.topics[class]; topic == nil || topic.name != name {
+			t.Errorf("Expected %s joined as %q, got %+v", class, name, topic)
+		}
+	}
+
+	pauses, alerts := 0, 0
+	node.OnPauseRequest(func(request *types.SignedPauseRequest) { pauses++ })
+	node.OnAlert(func(alert *types.Alert) { alerts++ })
+
+	// Each type is only handled from the topic that carries it
+	node.handleMessage(TopicConsensus, testMessage(t, MessageTypeAlert, `{"id":"0x1"}`, time.Now()), newTestPeerID(t))
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypePauseRequest, `{}`, time.Now()), newTestPeerID(t))
+	if pauses != 0 || alerts != 0 {
+		t.Errorf("Messages on the wrong topic reached handlers: %d pauses, %d alerts", pauses, alerts)
+	}
+
+	node.handleMessage(TopicAlerts, testMessage(t, MessageTypeAlert, `{"id":"0x2"}`, time.Now()), newTestPeerID(t))
+	node.handleMessage(TopicConsensus, testMessage(t, MessageTypePauseRequest, `{}`, time.Now()), newTestPeerID(t))
+	if pauses != 1 || alerts != 1 {
+		t.Errorf("Expected one of each handled on its own topic, got %d pauses, %d alerts", pauses, alerts)
+	}
+}
+
+func TestGossipNode_AlertsOnlyNodeSkipsConsensus(t *testing.T) {
+	newNode := func(topics ...string) *GossipNode {
+		node, err := NewGossipNode(GossipConfig{
+			ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+			TopicName:       "test/v1",
+			Topics:          topics,
+			Logger:          zerolog.Nop(),
+			Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+			Signer:          stubSigner{},
+		})
+		if err != nil {
+			t.Fatalf("NewGossipNode failed: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := node.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		t.Cleanup(func() {
+			cancel()
+			node.Stop()
+		})
+		return node
+	}
+
+	sender := newNode()
+	listener := newNode(TopicAlerts)
+
+	if _, ok := listener.topics[TopicConsensus]; ok {
+		t.Fatal("An alerts-only node should not join the consensus topic")
+	}
+	if err := listener.BroadcastPauseRequest(&types.SignedPauseRequest{}); !errors.Is(err, ErrGossipUnavailable) {
+		t.Errorf("Expected ErrGossipUnavailable publishing off its topics, got %v", err)
+	}
+
+	alerts := make(chan string, 100)
+	pauses := make(chan struct{}, 100)
+	listener.OnAlert(func(alert *types.Alert) { alerts <- alert.ID })
+	listener.OnPauseRequest(func(request *types.SignedPauseRequest) { pauses <- struct{}{} })
+
+	info := peer.AddrInfo{ID: sender.host.ID(), Addrs: sender.host.Addrs()}
+	if err := listener.host.Connect(context.Background(), info); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// Publish both until an alert gets through the newly formed mesh
+	deadline := time.After(15 * time.Second)
+	for i := 0; ; i++ {
+		sender.BroadcastPauseRequest(&types.SignedPauseRequest{Signer: common.BigToAddress(common.Big1)})
+		sender.BroadcastAlert(&types.Alert{ID: fmt.Sprintf("0x%d", i)})
+
+		select {
+		case <-alerts:
+		case <-time.After(200 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("No alert reached the alerts-only node")
+		}
+		break
+	}
+
+	if len(pauses) != 0 {
+		t.Errorf("Consensus traffic reached an alerts-only node %d times", len(pauses))
+	}
+	if _, err := listener.RequestEvidence(context.Background(), common.Hash{1}); !errors.Is(err, ErrEvidenceUnavailable) {
+		t.Errorf("Expected ErrEvidenceUnavailable without an evidence topic, got %v", err)
+	}
+}
+
+func TestNewGossipNode_RejectsUnknownTopic(t *testing.T) {
+	_, err := NewGossipNode(GossipConfig{
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		TopicName:       "test/v1",
+		Topics:          []string{TopicAlerts, "blocks"},
+		Logger:          zerolog.Nop(),
+		Verifier:        &MockVerifier{verifyResult: true, registeredNode: true},
+	})
+	if err == nil {
+		t.Error("Expected error for an unknown topic class")
+	}
+}
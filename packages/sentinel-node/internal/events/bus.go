@@ -0,0 +1,232 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// Type identifies the kind of an event; subscribers register per type
+type Type string
+
+const (
+	TypeAlertCreated     Type = "alert_created"
+	TypeAlertUndelivered Type = "alert_undelivered"
+)
+
+// Event is anything published on the bus
+type Event interface {
+	Type() Type
+}
+
+// AlertCreated is published when the node raises an alert for a
+// transaction it flagged
+type AlertCreated struct {
+	Alert *types.Alert
+	// HandledByPeer is set when a peer already acted on the alert, so it
+	// is recorded but not escalated again
+	HandledByPeer bool
+}
+
+func (AlertCreated) Type() Type { return TypeAlertCreated }
+
+// AlertUndelivered is published when an alert couldn't be broadcast to
+// peers, even after retrying
+type AlertUndelivered struct {
+	Alert *types.Alert
+	Err   error
+}
+
+func (AlertUndelivered) Type() Type { return TypeAlertUndelivered }
+
+// Handler consumes an event; an error is logged and counted against the
+// subscriber without affecting any other
+type Handler func(Event) error
+
+const defaultQueueDepth = 256
+
+// Config configures an event bus
+type Config struct {
+	// QueueDepth is how many events wait for each asynchronous subscriber
+	// before further ones are dropped for it (0 = 256)
+	QueueDepth int
+	Logger     zerolog.Logger
+}
+
+type subscription struct {
+	name    string
+	handler Handler
+	// queue feeds an asynchronous subscriber's goroutine; nil for one run
+	// inline by Publish
+	queue chan Event
+}
+
+// Bus delivers events from the code that detects something to the
+// consumers that act on it, so either side can change without the other.
+// Asynchronous subscribers each get their own queue and goroutine: they see
+// events in publish order, and one that is slow or failing only loses its
+// own events, never holding up Publish or other subscribers.
+type Bus struct {
+	mu         sync.RWMutex
+	subs       map[Type][]*subscription
+	closed     bool
+	wg         sync.WaitGroup
+	queueDepth int
+	logger     zerolog.Logger
+
+	dropped  *prometheus.CounterVec
+	failures *prometheus.CounterVec
+}
+
+func NewBus(cfg Config) *Bus {
+	queueDepth := cfg.QueueDepth
+	if queueDepth <= 0 {
+		queueDepth = defaultQueueDepth
+	}
+
+	return &Bus{
+		subs:       make(map[Type][]*subscription),
+		queueDepth: queueDepth,
+		logger:     cfg.Logger,
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_events_dropped_total",
+			Help: "Events dropped because a subscriber's queue was full, by subscriber",
+		}, []string{"subscriber"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_event_handler_failures_total",
+			Help: "Event handlers that returned an error or panicked, by subscriber",
+		}, []string{"subscriber"}),
+	}
+}
+
+// Subscribe runs handler on its own goroutine for each event of type t
+func (b *Bus) Subscribe(t Type, name string, handler Handler) {
+	sub := &subscription{name: name, handler: handler, queue: make(chan Event, b.queueDepth)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.subs[t] = append(b.subs[t], sub)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for event := range sub.queue {
+			b.deliver(sub, event)
+		}
+	}()
+}
+
+// SubscribeSync runs handler inside Publish, for consumers whose effect
+// must be visible as soon as Publish returns. It must be quick.
+func (b *Bus) SubscribeSync(t Type, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[t] = append(b.subs[t], &subscription{name: name, handler: handler})
+}
+
+// Publish runs the synchronous subscribers to event's type in subscription
+// order, then queues it for the asynchronous ones. It never waits on an
+// asynchronous subscriber. Events published after Close are discarded.
+func (b *Bus) Publish(event Event) {
+	b.mu.RLock()
+	subs := b.subs[event.Type()]
+	closed := b.closed
+	b.mu.RUnlock()
+	if closed {
+		return
+	}
+
+	// Inline handlers run unlocked so they may publish in turn
+	for _, sub := range subs {
+		if sub.queue == nil {
+			b.deliver(sub, event)
+		}
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, sub := range subs {
+		if sub.queue == nil {
+			continue
+		}
+		select {
+		case sub.queue <- event:
+		default:
+			b.dropped.WithLabelValues(sub.name).Inc()
+			b.logger.Warn().
+				Str("subscriber", sub.name).
+				Str("event", string(event.Type())).
+				Msg("Event subscriber queue full, dropped event")
+		}
+	}
+}
+
+// deliver runs one handler, containing its failure
+func (b *Bus) deliver(sub *subscription, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.failed(sub, event, fmt.Errorf("panic: %v", r))
+		}
+	}()
+	if err := sub.handler(event); err != nil {
+		b.failed(sub, event, err)
+	}
+}
+
+func (b *Bus) failed(sub *subscription, event Event, err error) {
+	b.failures.WithLabelValues(sub.name).Inc()
+	b.logger.Error().
+		Err(err).
+		Str("subscriber", sub.name).
+		Str("event", string(event.Type())).
+		Msg("Event handler failed")
+}
+
+// Close stops accepting events and waits for asynchronous subscribers to
+// finish those already queued
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, subs := range b.subs {
+		for _, sub := range subs {
+			if sub.queue != nil {
+				close(sub.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+// Metrics returns the bus's drop and failure counters for registration
+// with a Prometheus registry
+func (b *Bus) Metrics() prometheus.Collector {
+	return busMetrics{b}
+}
+
+type busMetrics struct{ b *Bus }
+
+func (m busMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.b.dropped.Describe(ch)
+	m.b.failures.Describe(ch)
+}
+
+func (m busMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.b.dropped.Collect(ch)
+	m.b.failures.Collect(ch)
+}
@@ -0,0 +1,143 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+	var out dto.Metric
+	if err := c.Write(&out); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return out.Counter.GetValue()
+}
+
+func alertEvent(id string) AlertCreated {
+	return AlertCreated{Alert: &types.Alert{ID: id, Level: types.AlertLevelCritical}}
+}
+
+func TestBus_EverySubscriberReceivesEvents(t *testing.T) {
+	bus := NewBus(Config{Logger: zerolog.Nop()})
+
+	var stored []string
+	bus.SubscribeSync(TypeAlertCreated, "store", func(e Event) error {
+		stored = append(stored, e.(AlertCreated).Alert.ID)
+		return nil
+	})
+	broadcast := make(chan string, 10)
+	bus.Subscribe(TypeAlertCreated, "broadcast", func(e Event) error {
+		broadcast <- e.(AlertCreated).Alert.ID
+		return nil
+	})
+	notified := make(chan string, 10)
+	bus.Subscribe(TypeAlertCreated, "notify", func(e Event) error {
+		notified <- e.(AlertCreated).Alert.ID
+		return nil
+	})
+	bus.Subscribe(TypeAlertUndelivered, "undelivered", func(e Event) error {
+		t.Errorf("Subscriber got an event of another type: %v", e)
+		return nil
+	})
+
+	bus.Publish(alertEvent("a1"))
+	bus.Publish(alertEvent("a2"))
+
+	// Synchronous subscribers have run by the time Publish returns
+	if len(stored) != 2 || stored[0] != "a1" || stored[1] != "a2" {
+		t.Errorf("Expected a1, a2 stored, got %v", stored)
+	}
+
+	bus.Close()
+	for name, ch := range map[string]chan string{"broadcast": broadcast, "notify": notified} {
+		if len(ch) != 2 || <-ch != "a1" || <-ch != "a2" {
+			t.Errorf("Expected %s to receive a1 then a2", name)
+		}
+	}
+}
+
+func TestBus_FailingSubscriberDoesNotBlockOthers(t *testing.T) {
+	bus := NewBus(Config{QueueDepth: 2, Logger: zerolog.Nop()})
+	defer bus.Close()
+
+	bus.SubscribeSync(TypeAlertCreated, "panics", func(e Event) error {
+		panic("boom")
+	})
+	failed := make(chan struct{}, 10)
+	bus.Subscribe(TypeAlertCreated, "errors", func(e Event) error {
+		failed <- struct{}{}
+		return errors.New("unreachable")
+	})
+	stuck := make(chan struct{})
+	defer close(stuck)
+	bus.Subscribe(TypeAlertCreated, "stuck", func(e Event) error {
+		<-stuck
+		return nil
+	})
+	received := make(chan string, 10)
+	bus.Subscribe(TypeAlertCreated, "healthy", func(e Event) error {
+		received <- e.(AlertCreated).Alert.ID
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for _, id := range []string{"a1", "a2", "a3", "a4", "a5"} {
+			bus.Publish(alertEvent(id))
+			// Let the healthy and failing subscribers keep up with their
+			// small queues
+			<-received
+			<-failed
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked behind a failing or stuck subscriber")
+	}
+
+	if v := counterValue(t, bus.failures.WithLabelValues("panics")); v != 5 {
+		t.Errorf("Expected 5 panics counted, got %v", v)
+	}
+	deadline := time.Now().Add(time.Second)
+	for counterValue(t, bus.failures.WithLabelValues("errors")) < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if v := counterValue(t, bus.failures.WithLabelValues("errors")); v != 5 {
+		t.Errorf("Expected 5 handler errors counted, got %v", v)
+	}
+	// The stuck subscriber holds one event and queues two; the rest are
+	// dropped for it alone
+	if v := counterValue(t, bus.dropped.WithLabelValues("stuck")); v < 2 {
+		t.Errorf("Expected events dropped for the stuck subscriber, got %v", v)
+	}
+	if v := counterValue(t, bus.dropped.WithLabelValues("healthy")); v != 0 {
+		t.Errorf("Expected no events dropped for the healthy subscriber, got %v", v)
+	}
+}
+
+func TestBus_PublishAfterCloseIsDiscarded(t *testing.T) {
+	bus := NewBus(Config{Logger: zerolog.Nop()})
+	calls := 0
+	bus.SubscribeSync(TypeAlertCreated, "store", func(e Event) error {
+		calls++
+		return nil
+	})
+	bus.Close()
+	bus.Close()
+
+	bus.Publish(alertEvent("a1"))
+	if calls != 0 {
+		t.Errorf("Expected no delivery after Close, got %d", calls)
+	}
+}
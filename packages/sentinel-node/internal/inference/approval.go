@@ -0,0 +1,195 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultFreshContractWindow = 24 * time.Hour
+	// Spender code lookups are cached; the cache is reset once it hits this size
+	maxSpenderCacheSize = 10000
+	approvalScoreBoost  = 0.3
+)
+
+// Approvals at or above 2^128 are treated as unlimited; wallets commonly use
+// MaxUint256 but some drainers use smaller "effectively infinite" amounts
+var unlimitedApprovalThreshold = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// CodeReader is the subset of an Ethereum client used to inspect spenders
+type CodeReader interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// ApprovalConfig configures detection of approvals to suspicious spenders
+type ApprovalConfig struct {
+	// TrustedSpenders are never flagged (routers, known protocols)
+	TrustedSpenders []common.Address
+	// FlaggedSpenders are low-reputation addresses that are always flagged
+	FlaggedSpenders []common.Address
+	// FreshContractWindow is how long a contract deployed in the observed
+	// mempool is considered freshly deployed
+	FreshContractWindow time.Duration
+	// CodeReader looks up spender code to detect EOAs; nil skips the check
+	CodeReader CodeReader
+}
+
+// ApprovalParams describes a decoded token approval
+type ApprovalParams struct {
+	Method    string
+	Spender   common.Address
+	Unlimited bool
+	// Amount is the approved amount; nil for setApprovalForAll
+	Amount *big.Int
+}
+
+// DecodeApproval decodes approve and setApprovalForAll calldata
+func DecodeApproval(input []byte) (*ApprovalParams, bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+
+	method, err := parsedKnownABI.MethodById(input[:4])
+	if err != nil {
+		return nil, false
+	}
+
+	switch method.Name {
+	case "approve", "setApprovalForAll":
+	default:
+		return nil, false
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil || len(args) != 2 {
+		return nil, false
+	}
+
+	spender, ok := args[0].(common.Address)
+	if !ok {
+		return nil, false
+	}
+
+	params := &ApprovalParams{Method: method.Name, Spender: spender}
+	switch v := args[1].(type) {
+	case *big.Int:
+		params.Amount = v
+		params.Unlimited = v.Cmp(unlimitedApprovalThreshold) >= 0
+	case bool:
+		params.Unlimited = v
+	}
+
+	return params, true
+}
+
+// approvalChecker decides whether an unlimited approval's spender is
+// suspicious: an EOA, a contract deployed moments ago, or a known-bad address
+type approvalChecker struct {
+	mu          sync.Mutex
+	trusted     map[common.Address]bool
+	flagged     map[common.Address]bool
+	fresh       map[common.Address]time.Time
+	freshWindow time.Duration
+	isContract  map[common.Address]bool
+	code        CodeReader
+
+	now func() time.Time
+}
+
+func newApprovalChecker(cfg ApprovalConfig) *approvalChecker {
+	window := cfg.FreshContractWindow
+	if window == 0 {
+		window = defaultFreshContractWindow
+	}
+
+	c := &approvalChecker{
+		trusted:     make(map[common.Address]bool, len(cfg.TrustedSpenders)),
+		flagged:     make(map[common.Address]bool, len(cfg.FlaggedSpenders)),
+		fresh:       make(map[common.Address]time.Time),
+		freshWindow: window,
+		isContract:  make(map[common.Address]bool),
+		code:        cfg.CodeReader,
+		now:         time.Now,
+	}
+	for _, addr := range cfg.TrustedSpenders {
+		c.trusted[addr] = true
+	}
+	for _, addr := range cfg.FlaggedSpenders {
+		c.flagged[addr] = true
+	}
+	return c
+}
+
+// observe remembers contracts deployed by pending transactions
+func (c *approvalChecker) observe(tx *types.PendingTransaction) {
+	if !tx.IsContractCreation() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	cutoff := now.Add(-c.freshWindow)
+	for addr, at := range c.fresh {
+		if at.Before(cutoff) {
+			delete(c.fresh, addr)
+		}
+	}
+	c.fresh[crypto.CreateAddress(tx.From, tx.Nonce)] = now
+}
+
+// trustedSpender reports whether spender is on the trusted list
+func (c *approvalChecker) trustedSpender(spender common.Address) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.trusted[spender]
+}
+
+// suspicious reports whether spender is an unsafe approval target
+func (c *approvalChecker) suspicious(ctx context.Context, spender common.Address) bool {
+	c.mu.Lock()
+	if c.trusted[spender] {
+		c.mu.Unlock()
+		return false
+	}
+	if c.flagged[spender] {
+		c.mu.Unlock()
+		return true
+	}
+	if deployedAt, ok := c.fresh[spender]; ok && c.now().Sub(deployedAt) < c.freshWindow {
+		c.mu.Unlock()
+		return true
+	}
+	isContract, cached := c.isContract[spender]
+	c.mu.Unlock()
+
+	if cached {
+		return !isContract
+	}
+	if c.code == nil {
+		return false
+	}
+
+	code, err := c.code.CodeAt(ctx, spender, nil)
+	if err != nil {
+		// Unknown rather than suspicious; don't cache so the next approval retries
+		return false
+	}
+
+	c.mu.Lock()
+	if len(c.isContract) >= maxSpenderCacheSize {
+		c.isContract = make(map[common.Address]bool)
+	}
+	c.isContract[spender] = len(code) > 0
+	c.mu.Unlock()
+
+	return len(code) == 0
+}
@@ -0,0 +1,176 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var (
+	testRouter  = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	testDrainer = common.HexToAddress("0xbad0000000000000000000000000000000000001")
+	maxUint256  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// mockCodeReader returns code for addresses in code and errors for those in failing
+type mockCodeReader struct {
+	code    map[common.Address][]byte
+	failing map[common.Address]bool
+	calls   int
+}
+
+func (m *mockCodeReader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	m.calls++
+	if m.failing[account] {
+		return nil, errors.New("rpc unavailable")
+	}
+	return m.code[account], nil
+}
+
+func newApprovalTestBridge(t *testing.T, reader *mockCodeReader) *Bridge {
+	t.Helper()
+	bridge, err := NewBridge(BridgeConfig{
+		Approvals: ApprovalConfig{
+			TrustedSpenders: []common.Address{testRouter},
+			FlaggedSpenders: []common.Address{testDrainer},
+			CodeReader:      reader,
+		},
+		Logger: zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	return bridge
+}
+
+func approvalTx(input []byte) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.HexToHash("0xa1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(testUSDC),
+		Value: big.NewInt(0),
+		Gas:   50000,
+		Input: input,
+	}
+}
+
+func TestDecodeApproval(t *testing.T) {
+	approval, ok := DecodeApproval(packKnown(t, "approve", testDrainer, maxUint256))
+	if !ok || approval.Spender != testDrainer || !approval.Unlimited {
+		t.Errorf("Unexpected unlimited approve decode: %+v", approval)
+	}
+
+	approval, ok = DecodeApproval(packKnown(t, "approve", testDrainer, big.NewInt(1000)))
+	if !ok || approval.Unlimited {
+		t.Errorf("Expected bounded approve, got %+v", approval)
+	}
+
+	approval, ok = DecodeApproval(packKnown(t, "setApprovalForAll", testDrainer, true))
+	if !ok || approval.Method != "setApprovalForAll" || !approval.Unlimited {
+		t.Errorf("Unexpected setApprovalForAll decode: %+v", approval)
+	}
+
+	if _, ok := DecodeApproval(packKnown(t, "transfer", testDrainer, big.NewInt(1))); ok {
+		t.Error("transfer should not decode as an approval")
+	}
+}
+
+func TestBridge_SuspiciousApprovalToFlaggedSpender(t *testing.T) {
+	bridge := newApprovalTestBridge(t, &mockCodeReader{})
+
+	tx := approvalTx(packKnown(t, "approve", testDrainer, maxUint256))
+	if !bridge.QuickFilter(tx) {
+		t.Fatal("Unlimited approval should pass the quick filter despite low gas")
+	}
+
+	result, _ := bridge.Analyze(context.Background(), tx)
+	if !hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Expected suspicious_approval_target, got %v", result.RiskIndicators)
+	}
+}
+
+func TestBridge_ApprovalToTrustedSpender(t *testing.T) {
+	reader := &mockCodeReader{code: map[common.Address][]byte{testRouter: {0x60, 0x80}}}
+	bridge := newApprovalTestBridge(t, reader)
+
+	result, _ := bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", testRouter, maxUint256)))
+	if hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Approval to a trusted router should not be flagged, got %v", result.RiskIndicators)
+	}
+	if reader.calls != 0 {
+		t.Errorf("Trusted spender should not require a code lookup, got %d calls", reader.calls)
+	}
+}
+
+func TestBridge_ApprovalToEOA(t *testing.T) {
+	eoa := common.HexToAddress("0xe0a")
+	contract := common.HexToAddress("0xc0de")
+	reader := &mockCodeReader{code: map[common.Address][]byte{contract: {0x60, 0x80}}}
+	bridge := newApprovalTestBridge(t, reader)
+
+	result, _ := bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", eoa, maxUint256)))
+	if !hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Expected unlimited approval to an EOA to be flagged, got %v", result.RiskIndicators)
+	}
+
+	result, _ = bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", contract, maxUint256)))
+	if hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Approval to an established contract should not be flagged, got %v", result.RiskIndicators)
+	}
+
+	result, _ = bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", eoa, big.NewInt(1000))))
+	if hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Bounded approval should not be flagged, got %v", result.RiskIndicators)
+	}
+
+	// Repeat lookups are served from the cache
+	calls := reader.calls
+	bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", eoa, maxUint256)))
+	if reader.calls != calls {
+		t.Error("Expected cached code lookup for a repeated spender")
+	}
+}
+
+func TestBridge_ApprovalForAllToFreshContract(t *testing.T) {
+	deployer := common.HexToAddress("0xde9")
+	fresh := crypto.CreateAddress(deployer, 7)
+	reader := &mockCodeReader{code: map[common.Address][]byte{fresh: {0x60, 0x80}}}
+	bridge := newApprovalTestBridge(t, reader)
+
+	bridge.Observe(&types.PendingTransaction{
+		Hash:  common.HexToHash("0xd1"),
+		From:  deployer,
+		Nonce: 7,
+		Value: big.NewInt(0),
+		Gas:   2_000_000,
+		Input: []byte{0x60, 0x80, 0x60, 0x40},
+	})
+
+	result, _ := bridge.Analyze(context.Background(), approvalTx(packKnown(t, "setApprovalForAll", fresh, true)))
+	if !hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Expected approval to a fresh contract to be flagged, got %v", result.RiskIndicators)
+	}
+
+	result, _ = bridge.Analyze(context.Background(), approvalTx(packKnown(t, "setApprovalForAll", fresh, false)))
+	if hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Revoking approval should not be flagged, got %v", result.RiskIndicators)
+	}
+}
+
+func TestBridge_ApprovalLookupFailureNotFlagged(t *testing.T) {
+	spender := common.HexToAddress("0x5e9")
+	reader := &mockCodeReader{failing: map[common.Address]bool{spender: true}}
+	bridge := newApprovalTestBridge(t, reader)
+
+	result, _ := bridge.Analyze(context.Background(), approvalTx(packKnown(t, "approve", spender, maxUint256)))
+	if hasIndicator(result, "suspicious_approval_target") {
+		t.Errorf("Failed code lookup should not flag the spender, got %v", result.RiskIndicators)
+	}
+}
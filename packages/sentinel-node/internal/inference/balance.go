@@ -0,0 +1,102 @@
+package inference
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// EndpointStatus is the circuit breaker state of one inference server
+type EndpointStatus struct {
+	Address  string
+	Circuit  CircuitState
+	Failures int
+	ReopenAt time.Time
+	// Outstanding is how many analyses the server is working on
+	Outstanding int64
+}
+
+// endpointLoad counts the analyses in flight at each endpoint
+type endpointLoad struct {
+	outstanding []atomic.Int64
+	// next rotates the starting endpoint so equally loaded servers share
+	// traffic round-robin
+	next atomic.Uint64
+}
+
+func newEndpointLoad(endpoints int) *endpointLoad {
+	return &endpointLoad{outstanding: make([]atomic.Int64, endpoints)}
+}
+
+// order returns the endpoint indexes least loaded first
+func (l *endpointLoad) order() []int {
+	n := len(l.outstanding)
+	start := int(l.next.Add(1) % uint64(n))
+
+	order := make([]int, n)
+	loads := make([]int64, n)
+	for i := range order {
+		order[i] = (start + i) % n
+		loads[order[i]] = l.outstanding[order[i]].Load()
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return loads[order[i]] < loads[order[j]]
+	})
+	return order
+}
+
+// balancedAnalysis sends tx to the least loaded endpoint whose circuit is
+// closed, moving on to the next when one fails. It returns nil when no
+// endpoint answered.
+func (m *MultiBridge) balancedAnalysis(ctx context.Context, tx *types.PendingTransaction) *types.InferenceResult {
+	for _, i := range m.load.order() {
+		endpoint := m.endpoints[i]
+		if endpoint.isCircuitOpen() {
+			continue
+		}
+		if !endpoint.IsConnected() {
+			endpoint.triggerReconnect()
+			continue
+		}
+
+		server := endpoint.serverAnalysis
+		if endpoint.streaming {
+			server = endpoint.streamAnalysis
+		}
+
+		m.load.outstanding[i].Add(1)
+		result, err := server(ctx, tx)
+		m.load.outstanding[i].Add(-1)
+		if err == nil {
+			return result
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Status reports the circuit breaker of the bridge's inference server
+func (b *Bridge) Status() EndpointStatus {
+	state, failures, reopenAt := b.GetCircuitBreakerStatus()
+	return EndpointStatus{
+		Address:  b.address,
+		Circuit:  state,
+		Failures: failures,
+		ReopenAt: reopenAt,
+	}
+}
+
+// GetCircuitBreakerStatus reports the circuit breaker of every endpoint
+func (m *MultiBridge) GetCircuitBreakerStatus() []EndpointStatus {
+	statuses := make([]EndpointStatus, len(m.endpoints))
+	for i, endpoint := range m.endpoints {
+		statuses[i] = endpoint.Status()
+		statuses[i].Outstanding = m.load.outstanding[i].Load()
+	}
+	return statuses
+}
@@ -0,0 +1,76 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+func newBalancedBridge(t *testing.T, clients ...*verdictClient) *MultiBridge {
+	t.Helper()
+
+	addresses := make([]string, len(clients))
+	multi, err := NewMultiBridge(MultiBridgeConfig{
+		Bridge:      BridgeConfig{Timeout: 300 * time.Millisecond, MaxRetries: 1, Logger: zerolog.Nop()},
+		Addresses:   addresses,
+		LoadBalance: true,
+	})
+	if err != nil {
+		t.Fatalf("NewMultiBridge failed: %v", err)
+	}
+
+	for i, client := range clients {
+		multi.endpoints[i].client = client
+		multi.endpoints[i].connected = true
+	}
+	return multi
+}
+
+func TestMultiBridge_LoadBalance_SpreadsTraffic(t *testing.T) {
+	a, b := clears(0.1), clears(0.1)
+	multi := newBalancedBridge(t, a, b)
+
+	for i := 0; i < 10; i++ {
+		tx := multiTestTx()
+		tx.Hash = common.BigToHash(big.NewInt(int64(i + 1)))
+		multi.Analyze(context.Background(), tx)
+	}
+
+	if a.calls.Load() != 5 || b.calls.Load() != 5 {
+		t.Errorf("Expected traffic split evenly, got %d and %d calls", a.calls.Load(), b.calls.Load())
+	}
+}
+
+func TestMultiBridge_LoadBalance_ShiftsFromFailingServer(t *testing.T) {
+	failing, healthy := &verdictClient{fail: true}, clears(0.1)
+	multi := newBalancedBridge(t, failing, healthy)
+
+	for i := 0; i < 20; i++ {
+		tx := multiTestTx()
+		tx.Hash = common.BigToHash(big.NewInt(int64(i + 1)))
+		result, err := multi.Analyze(context.Background(), tx)
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if !hasIndicator(result, "server_clean") {
+			t.Fatalf("Expected the healthy server to answer tx %d, got %v", i, result.RiskIndicators)
+		}
+	}
+
+	// Once its circuit opens the failing server gets no more traffic
+	if failing.calls.Load() != maxConsecutiveFailures {
+		t.Errorf("Expected %d calls to the failing server, got %d", maxConsecutiveFailures, failing.calls.Load())
+	}
+	if healthy.calls.Load() != 20 {
+		t.Errorf("Expected the healthy server to analyze all 20, got %d", healthy.calls.Load())
+	}
+
+	statuses := multi.GetCircuitBreakerStatus()
+	if len(statuses) != 2 || statuses[0].Circuit != CircuitOpen || statuses[1].Circuit != CircuitClosed {
+		t.Errorf("Expected only the failing server's circuit open, got %+v", statuses)
+	}
+}
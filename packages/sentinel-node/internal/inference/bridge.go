@@ -2,8 +2,9 @@ package inference
 
 import (
 	"context"
-	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 	"time"
@@ -11,18 +12,59 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 
 	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
 	"github.com/sentinel-protocol/sentinel-node/pkg/types"
 )
 
 type BridgeConfig struct {
-	Address          string
+	Address string
+	// TLS secures the connection to Address; without it the bridge only
+	// connects in plaintext when AllowInsecure is set
+	TLS           TLSConfig
+	AllowInsecure bool
+	// Streaming sends Analyze calls over one long-lived stream, as
+	// AnalyzeStream does
+	Streaming bool
+
 	Timeout          time.Duration
 	MaxRetries       int
 	AnomalyThreshold float64
-	Logger           zerolog.Logger
+	// ThinLiquidityTokens are tokens whose pools are shallow enough that any
+	// swap through them is treated as a potential price manipulation
+	ThinLiquidityTokens []common.Address
+	// Cluster configures detection of coordinated freshly-funded senders
+	Cluster ClusterConfig
+	// Approvals configures detection of unlimited approvals to suspicious spenders
+	Approvals ApprovalConfig
+	// Trace configures internal call tracing of flagged transactions
+	Trace TraceConfig
+	// SafeSelectors configures skipping inference for allowlisted calls
+	SafeSelectors SafeSelectorConfig
+	// SelectorRules score calls by selector in the heuristic analysis; nil
+	// uses the built-in flash-loan rules
+	SelectorRules *SelectorRules
+	// Weights tune the heuristic scores, the score cap and the risk levels
+	Weights HeuristicWeights
+	// Sandwich configures AnalyzeWithContext's sandwich detection
+	Sandwich SandwichConfig
+	// Reentrancy configures the heuristic for batches that repeat a call
+	Reentrancy ReentrancyConfig
+	// SenderHistory configures score smoothing across a sender's transactions
+	SenderHistory SenderHistoryConfig
+	// Cache configures reuse of results for transactions seen repeatedly
+	Cache ResultCacheConfig
+	// DecodeCalldata attaches decoded arguments for known selectors to each
+	// request so the inference server can skip its own ABI decoding
+	DecodeCalldata bool
+	// ABIDir holds extra ABI files (*.json) to decode calldata with, on top
+	// of the built-in token, router and flash-loan ABIs
+	ABIDir string
+	// UnclassifiedCallScore is the minimum heuristic score of a contract
+	// call whose selector the node doesn't recognise (0 = no minimum)
+	UnclassifiedCallScore float64
+	Logger                zerolog.Logger
 }
 
 type Bridge struct {
@@ -34,25 +76,89 @@ type Bridge struct {
 	logger           zerolog.Logger
 	connected        bool
 
+	unclassifiedCallScore float64
+
+	thinLiquidityTokens map[common.Address]bool
+	decoder             *CalldataDecoder
+	clusters            *ClusterTracker
+	approvals           *approvalChecker
+	traces              *traceAnalyzer
+	safeSelectors       *safeSelectors
+	selectorRules       *SelectorRules
+	weights             HeuristicWeights
+	sandwiches          *sandwichDetector
+	reentrancy          *reentrancyDetector
+	metrics             *bridgeMetrics
+	senders             *SenderHistory
+	cache               *resultCache
+
 	// FIX: Add fields for error recovery
 	address             string
+	creds               credentials.TransportCredentials
 	mu                  sync.RWMutex
 	consecutiveFailures int
-	circuitOpen         bool
+	circuitState        CircuitState
 	circuitOpenUntil    time.Time
+	// circuitCooldown is how long the circuit stays open, doubled each time
+	// a half-open probe fails
+	circuitCooldown time.Duration
+	// halfOpenProbes counts the calls let through since going half-open
+	halfOpenProbes      int
 	lastHealthCheck     time.Time
 	healthCheckInterval time.Duration
 	reconnectChan       chan struct{}
 	stopChan            chan struct{}
+
+	// The shared analysis stream, opened on first use
+	streaming         bool
+	streamMu          sync.Mutex
+	stream            *inferenceStream
+	streamUnsupported bool
 }
 
 // FIX: Circuit breaker constants
 const (
 	maxConsecutiveFailures = 5
 	circuitOpenDuration    = 1 * time.Minute
+	maxCircuitOpenDuration = 10 * time.Minute
+	maxHalfOpenProbes      = 3
 	defaultHealthInterval  = 30 * time.Second
 )
 
+// CircuitState is the state of a bridge's circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through to the server
+	CircuitClosed CircuitState = iota
+	// CircuitOpen answers every analysis with the fallback until the
+	// cooldown has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a few probe calls through; a success closes the
+	// circuit and a failure reopens it with a longer cooldown
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Fraction of the Analyze timeout reserved for fallback analysis
+const fallbackReserveFraction = 0.1
+
+var (
+	errCircuitOpen  = errors.New("inference circuit breaker open")
+	errNotConnected = errors.New("inference server not connected")
+)
+
 func NewBridge(cfg BridgeConfig) (*Bridge, error) {
 	timeout := cfg.Timeout
 	if timeout == 0 {
@@ -69,18 +175,72 @@ func NewBridge(cfg BridgeConfig) (*Bridge, error) {
 		threshold = 0.65
 	}
 
+	thinLiquidity := make(map[common.Address]bool, len(cfg.ThinLiquidityTokens))
+	for _, token := range cfg.ThinLiquidityTokens {
+		thinLiquidity[token] = true
+	}
+
 	bridge := &Bridge{
 		timeout:             timeout,
 		maxRetries:          maxRetries,
 		anomalyThreshold:    threshold,
 		logger:              cfg.Logger,
 		connected:           false,
+		thinLiquidityTokens: thinLiquidity,
+		clusters:            NewClusterTracker(cfg.Cluster),
+		approvals:           newApprovalChecker(cfg.Approvals),
+		traces:              newTraceAnalyzer(cfg.Trace),
+		senders:             NewSenderHistory(cfg.SenderHistory),
+		cache:               newResultCache(cfg.Cache),
+		sandwiches:          newSandwichDetector(cfg.Sandwich),
+		reentrancy:          newReentrancyDetector(cfg.Reentrancy),
 		address:             cfg.Address,
+		streaming:           cfg.Streaming,
 		healthCheckInterval: defaultHealthInterval,
 		reconnectChan:       make(chan struct{}, 1),
 		stopChan:            make(chan struct{}),
 	}
 
+	if cfg.Address != "" {
+		creds, err := transportCredentials(cfg.TLS, cfg.AllowInsecure)
+		if err != nil {
+			return nil, err
+		}
+		bridge.creds = creds
+	}
+
+	bridge.metrics = newBridgeMetrics(func() int {
+		if bridge.isCircuitOpen() {
+			return 1
+		}
+		return 0
+	})
+
+	bridge.safeSelectors = newSafeSelectors(cfg.SafeSelectors, bridge.approvals, cfg.Logger)
+	bridge.unclassifiedCallScore = cfg.UnclassifiedCallScore
+
+	weights, err := cfg.Weights.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	bridge.weights = weights
+
+	bridge.selectorRules = cfg.SelectorRules
+	if bridge.selectorRules == nil {
+		bridge.selectorRules = &SelectorRules{rules: defaultSelectorRules()}
+	}
+
+	if cfg.DecodeCalldata {
+		bridge.decoder = NewCalldataDecoder()
+		if cfg.ABIDir != "" {
+			loaded, err := bridge.decoder.LoadABIDir(cfg.ABIDir)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Logger.Info().Str("dir", cfg.ABIDir).Int("files", loaded).Msg("Loaded calldata ABIs")
+		}
+	}
+
 	// Try to connect to the gRPC server
 	if cfg.Address != "" {
 		bridge.attemptConnect()
@@ -104,17 +264,15 @@ func (b *Bridge) attemptConnect() bool {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	conn, err := grpc.DialContext(
-		ctx,
-		b.address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-	)
+	conn, err := grpc.DialContext(ctx, b.address, b.dialOptions()...)
 	if err != nil {
 		b.logger.Warn().Err(err).Str("address", b.address).Msg("failed to connect to inference server, using fallback")
 		return false
 	}
 
+	// A stream on the old connection would only fail from here on
+	b.resetStream()
+
 	b.mu.Lock()
 	// Close old connection if exists
 	if b.conn != nil {
@@ -123,14 +281,21 @@ func (b *Bridge) attemptConnect() bool {
 	b.conn = conn
 	b.client = pb.NewSentinelInferenceClient(conn)
 	b.connected = true
-	b.consecutiveFailures = 0
-	b.circuitOpen = false
+	// The breaker is left as it is: a reachable server can still be
+	// failing, and half-open probes find out whether it recovered
 	b.mu.Unlock()
 
 	b.logger.Info().Str("address", b.address).Msg("connected to inference server")
 	return true
 }
 
+func (b *Bridge) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(b.creds),
+		grpc.WithBlock(),
+	}
+}
+
 // FIX: Background health check loop
 func (b *Bridge) healthCheckLoop(ctx context.Context) {
 	ticker := time.NewTicker(b.healthCheckInterval)
@@ -216,6 +381,7 @@ func (b *Bridge) triggerReconnect() {
 func (b *Bridge) Close() error {
 	// FIX: Signal background goroutines to stop
 	close(b.stopChan)
+	b.resetStream()
 
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -235,7 +401,41 @@ func (b *Bridge) IsConnected() bool {
 func (b *Bridge) isCircuitOpen() bool {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	return b.circuitOpen && time.Now().Before(b.circuitOpenUntil)
+	return b.circuitState == CircuitOpen && time.Now().Before(b.circuitOpenUntil)
+}
+
+// allowRequest reports whether a call may go to the server. Once an open
+// circuit has cooled down it turns half-open and lets maxHalfOpenProbes
+// calls through to find out whether the server recovered; the rest keep
+// falling back until one of them succeeds.
+func (b *Bridge) allowRequest() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.circuitState {
+	case CircuitOpen:
+		if time.Now().Before(b.circuitOpenUntil) {
+			return false
+		}
+		b.circuitState = CircuitHalfOpen
+		b.halfOpenProbes = 0
+		b.logger.Info().Msg("circuit breaker half-open, probing inference server")
+		fallthrough
+	case CircuitHalfOpen:
+		if b.halfOpenProbes >= maxHalfOpenProbes {
+			return false
+		}
+		b.halfOpenProbes++
+	}
+	return true
+}
+
+// openCircuit opens the breaker for cooldown. Callers hold b.mu.
+func (b *Bridge) openCircuit(cooldown time.Duration) {
+	b.circuitState = CircuitOpen
+	b.circuitCooldown = cooldown
+	b.circuitOpenUntil = time.Now().Add(cooldown)
+	b.connected = false
 }
 
 // FIX: Record a failure and potentially open circuit breaker
@@ -243,11 +443,18 @@ func (b *Bridge) recordFailure() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	b.metrics.callFailed()
 	b.consecutiveFailures++
-	if b.consecutiveFailures >= maxConsecutiveFailures {
-		b.circuitOpen = true
-		b.circuitOpenUntil = time.Now().Add(circuitOpenDuration)
-		b.connected = false
+
+	switch {
+	case b.circuitState == CircuitHalfOpen:
+		// The server hasn't recovered; back off for longer
+		b.openCircuit(min(max(2*b.circuitCooldown, circuitOpenDuration), maxCircuitOpenDuration))
+		b.logger.Warn().
+			Time("reopenAt", b.circuitOpenUntil).
+			Msg("circuit breaker probe failed, reopening")
+	case b.circuitState == CircuitClosed && b.consecutiveFailures >= maxConsecutiveFailures:
+		b.openCircuit(circuitOpenDuration)
 		b.logger.Warn().
 			Int("failures", b.consecutiveFailures).
 			Time("reopenAt", b.circuitOpenUntil).
@@ -260,29 +467,85 @@ func (b *Bridge) recordSuccess() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	b.metrics.callSucceeded()
 	if b.consecutiveFailures > 0 {
 		b.logger.Debug().Int("previousFailures", b.consecutiveFailures).Msg("inference call succeeded, resetting failure count")
 	}
+	if b.circuitState != CircuitClosed {
+		b.logger.Info().Msg("circuit breaker closed, inference server recovered")
+	}
 	b.consecutiveFailures = 0
-	b.circuitOpen = false
+	b.circuitState = CircuitClosed
+	b.circuitCooldown = 0
 }
 
 func (b *Bridge) Analyze(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
+	if b.streaming {
+		return b.AnalyzeStream(ctx, tx)
+	}
+	return b.analyze(ctx, tx, b.serverAnalysis)
+}
+
+// analyze runs the analysis pipeline around server, which asks the
+// inference server for its verdict
+func (b *Bridge) analyze(ctx context.Context, tx *types.PendingTransaction, server func(context.Context, *types.PendingTransaction) (*types.InferenceResult, error)) (*types.InferenceResult, error) {
 	start := time.Now()
 
+	// A transaction echoed by several providers is analyzed once; node-side
+	// signals such as sender history must not count it twice either
+	if result, ok := b.cache.get(tx.Hash); ok {
+		result.LatencyMs = float64(time.Since(start).Milliseconds())
+		b.metrics.analyzed(pathCache, start)
+		return result, nil
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, b.timeout)
 	defer cancel()
 
-	var result *types.InferenceResult
-	var err error
+	if result, ok := b.preClassify(ctx, tx, start); ok {
+		b.cache.put(tx.Hash, result, false)
+		b.metrics.analyzed(pathSafe, start)
+		return result, nil
+	}
+
+	path := pathGRPC
+	result, err := server(ctx, tx)
+	if err != nil {
+		path = pathFallback
+		result = b.fallbackAnalysis(tx, start)
+		if errors.Is(err, errCircuitOpen) {
+			result.RiskIndicators = append(result.RiskIndicators, "circuit_breaker_open")
+			b.metrics.circuitOpenFallback()
+		}
+	}
+
+	b.applySignals(ctx, tx, result)
+	result.LatencyMs = float64(time.Since(start).Milliseconds())
+	b.cache.put(tx.Hash, result, err != nil)
+	b.metrics.analyzed(path, start)
+	return result, nil
+}
+
+// preClassify returns an immediate low-risk verdict for allowlisted calls,
+// saving an inference round trip. Node-side signals still apply.
+func (b *Bridge) preClassify(ctx context.Context, tx *types.PendingTransaction, start time.Time) (*types.InferenceResult, bool) {
+	if b.safeSelectors == nil || !b.safeSelectors.safe(tx) {
+		return nil, false
+	}
+
+	result := knownSafeResult(tx)
+	b.applySignals(ctx, tx, result)
+	result.LatencyMs = float64(time.Since(start).Milliseconds())
+	return result, true
+}
 
+// serverAnalysis asks the inference server for a verdict, going through the
+// circuit breaker. It returns an error whenever the caller must fall back.
+func (b *Bridge) serverAnalysis(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
 	// FIX: Check circuit breaker first
-	if b.isCircuitOpen() {
+	if !b.allowRequest() {
 		b.logger.Debug().Str("txHash", tx.Hash.Hex()).Msg("circuit breaker open, using fallback")
-		result = b.fallbackAnalysis(tx, start)
-		result.RiskIndicators = append(result.RiskIndicators, "circuit_breaker_open")
-		result.LatencyMs = float64(time.Since(start).Milliseconds())
-		return result, nil
+		return nil, errCircuitOpen
 	}
 
 	// FIX: Thread-safe check for connection
@@ -290,30 +553,88 @@ func (b *Bridge) Analyze(ctx context.Context, tx *types.PendingTransaction) (*ty
 	connected := b.connected
 	b.mu.RUnlock()
 
-	// Try gRPC first if connected
-	if connected {
-		result, err = b.callInference(ctx, tx)
-		if err != nil {
-			b.logger.Warn().Err(err).Str("txHash", tx.Hash.Hex()).Msg("gRPC call failed, using fallback")
-			// FIX: Record failure for circuit breaker
-			b.recordFailure()
-			// FIX: Trigger reconnection attempt
-			b.triggerReconnect()
-			result = b.fallbackAnalysis(tx, start)
-		} else {
-			// FIX: Record success
-			b.recordSuccess()
-		}
-	} else {
-		result = b.fallbackAnalysis(tx, start)
+	if !connected {
 		// FIX: Trigger reconnection if not connected
 		b.triggerReconnect()
+		return nil, errNotConnected
 	}
 
-	result.LatencyMs = float64(time.Since(start).Milliseconds())
+	result, err := b.callInference(ctx, tx)
+	if err != nil {
+		b.logger.Warn().Err(err).Str("txHash", tx.Hash.Hex()).Msg("gRPC call failed, using fallback")
+		// FIX: Record failure for circuit breaker
+		b.recordFailure()
+		// FIX: Trigger reconnection attempt
+		b.triggerReconnect()
+		return nil, err
+	}
+
+	// FIX: Record success
+	b.recordSuccess()
 	return result, nil
 }
 
+// Observe feeds every mempool transaction, including the simple transfers
+// and deployments QuickFilter skips, into cluster and deployment tracking
+func (b *Bridge) Observe(tx *types.PendingTransaction) {
+	b.clusters.Observe(tx)
+	b.approvals.observe(tx)
+}
+
+// applySignals adds the node-side signals that depend on state across
+// transactions to a result, whether it came from the server or the fallback
+func (b *Bridge) applySignals(ctx context.Context, tx *types.PendingTransaction, result *types.InferenceResult) {
+	// Internal calls that the top-level calldata doesn't reveal
+	if b.traces != nil && result.AnomalyScore >= traceMinScore {
+		b.applyTrace(ctx, tx, result)
+	}
+
+	// A sender whose funding cluster is acting suspiciously in concert
+	if b.clusters.Correlate(tx, result) {
+		b.raiseScore(result, "coordinated_cluster", clusterScoreBoost)
+	}
+
+	// An unlimited approval handing a wallet's tokens to an unsafe spender
+	if approval, ok := DecodeApproval(tx.Input); ok && approval.Unlimited {
+		if b.approvals.suspicious(ctx, approval.Spender) {
+			b.raiseScore(result, "suspicious_approval_target", approvalScoreBoost)
+		}
+	}
+
+	// A throwaway contract that self-destructs to force ETH onto a target
+	if isForcedEthSend(tx) {
+		b.raiseScore(result, "forced_eth_send", forcedSendScoreBoost)
+	}
+
+	// A sender whose recent transactions form an escalating pattern
+	if boost := b.senders.Record(tx.From, result.AnomalyScore); boost > 0 {
+		b.raiseScore(result, "escalating_sender_pattern", boost)
+	}
+}
+
+// applyTrace traces tx and raises the score for patterns in its call tree.
+// Tracing failures leave the result unchanged since not every provider
+// supports debug_traceCall.
+func (b *Bridge) applyTrace(ctx context.Context, tx *types.PendingTransaction, result *types.InferenceResult) {
+	frame, err := b.traces.tracer.TraceCall(ctx, tx)
+	if err != nil {
+		b.logger.Debug().Err(err).Str("txHash", tx.Hash.Hex()).Msg("call trace failed")
+		return
+	}
+
+	for _, indicator := range b.traces.indicators(frame) {
+		b.raiseScore(result, indicator.Name, indicator.Boost)
+	}
+}
+
+// raiseScore adds an indicator and re-derives the verdict from the new score
+func (b *Bridge) raiseScore(result *types.InferenceResult, indicator string, boost float64) {
+	result.RiskIndicators = append(result.RiskIndicators, indicator)
+	result.AnomalyScore = b.weights.clamp(result.AnomalyScore + boost)
+	result.IsSuspicious = result.AnomalyScore >= b.anomalyThreshold
+	result.RiskLevel, result.Recommendation = b.weights.Thresholds.Classify(result.AnomalyScore)
+}
+
 func (b *Bridge) AnalyzeBatch(ctx context.Context, txs []*types.PendingTransaction) ([]*types.InferenceResult, error) {
 	// FIX: Thread-safe check for connection and circuit breaker
 	if b.isCircuitOpen() {
@@ -369,12 +690,22 @@ func (b *Bridge) callInference(ctx context.Context, tx *types.PendingTransaction
 	var err error
 
 	for attempt := 0; attempt < b.maxRetries; attempt++ {
-		resp, err = b.client.Analyze(ctx, req)
+		attemptCtx, cancel := b.attemptContext(ctx, b.maxRetries-attempt)
+		resp, err = b.client.Analyze(attemptCtx, req)
+		cancel()
 		if err == nil {
 			break
 		}
 		b.logger.Debug().Err(err).Int("attempt", attempt+1).Msg("inference call failed, retrying")
-		time.Sleep(time.Duration(attempt+1) * 10 * time.Millisecond)
+
+		if attempt == b.maxRetries-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("inference deadline exhausted after %d attempts: %w", attempt+1, err)
+		case <-time.After(time.Duration(attempt+1) * 10 * time.Millisecond):
+		}
 	}
 
 	if err != nil {
@@ -385,6 +716,23 @@ func (b *Bridge) callInference(ctx context.Context, tx *types.PendingTransaction
 	return b.responseToResult(resp, tx.Hash), nil
 }
 
+// attemptContext gives one retry attempt its share of the remaining budget.
+// A slice of the overall timeout is held back so the heuristic fallback
+// still completes inside the Analyze deadline when every attempt fails.
+func (b *Bridge) attemptContext(ctx context.Context, attemptsLeft int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithTimeout(ctx, b.timeout/time.Duration(b.maxRetries))
+	}
+
+	reserve := time.Duration(float64(b.timeout) * fallbackReserveFraction)
+	budget := time.Until(deadline) - reserve
+	if budget <= 0 {
+		budget = time.Until(deadline)
+	}
+	return context.WithTimeout(ctx, budget/time.Duration(attemptsLeft))
+}
+
 func (b *Bridge) callBatchInference(ctx context.Context, txs []*types.PendingTransaction) ([]*types.InferenceResult, error) {
 	if b.client == nil {
 		return nil, fmt.Errorf("gRPC client not initialized")
@@ -439,6 +787,13 @@ func (b *Bridge) txToRequest(tx *types.PendingTransaction) *pb.AnalyzeRequest {
 		req.ChainId = tx.ChainID.Uint64()
 	}
 
+	// Unknown selectors leave DecodedCall unset; the server falls back to InputData
+	if b.decoder != nil {
+		if call, ok := b.decoder.Decode(tx.Input); ok {
+			req.DecodedCall = call
+		}
+	}
+
 	return req
 }
 
@@ -508,10 +863,10 @@ func (b *Bridge) GetStats(ctx context.Context) (*pb.StatsResponse, error) {
 }
 
 // FIX: Get circuit breaker status for monitoring
-func (b *Bridge) GetCircuitBreakerStatus() (isOpen bool, failures int, reopenAt time.Time) {
+func (b *Bridge) GetCircuitBreakerStatus() (state CircuitState, failures int, reopenAt time.Time) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	return b.circuitOpen, b.consecutiveFailures, b.circuitOpenUntil
+	return b.circuitState, b.consecutiveFailures, b.circuitOpenUntil
 }
 
 func (b *Bridge) heuristicAnalysis(tx *types.PendingTransaction) *types.InferenceResult {
@@ -530,61 +885,59 @@ func (b *Bridge) heuristicAnalysis(tx *types.PendingTransaction) *types.Inferenc
 		}
 	}
 
-	selector := tx.Selector()
-	if selector != nil {
-		selectorHex := hex.EncodeToString(selector)
-
-		flashLoanSelectors := map[string]bool{
-			"5cffe9de": true, // flashLoan
-			"ab9c4b5d": true, // flashLoan (Aave v3)
-			"c1a8a1f5": true, // flash
-			"490e6cbc": true, // flash (Uniswap v3)
+	if rule, ok := b.selectorRules.Match(tx.Selector()); ok {
+		riskIndicators = append(riskIndicators, rule.Indicator)
+		if rule.builtin {
+			anomalyScore += b.weights.FlashLoan
+		} else {
+			anomalyScore += rule.Score
 		}
+	}
 
-		if flashLoanSelectors[selectorHex] {
-			riskIndicators = append(riskIndicators, "flash_loan_detected")
-			anomalyScore += 0.4
+	// Unknown router ABIs simply don't decode and contribute nothing
+	if swap, ok := DecodeSwap(tx.Input, tx.Value); ok {
+		if swap.HasExtremeSlippage() || swap.TouchesAny(b.thinLiquidityTokens) {
+			riskIndicators = append(riskIndicators, "high_slippage_swap")
+			anomalyScore += b.weights.HighSlippageSwap
 		}
 	}
 
+	if b.reentrancy.suspected(tx) {
+		riskIndicators = append(riskIndicators, "reentrancy_suspected")
+		anomalyScore += b.weights.Reentrancy
+	}
+
 	if tx.Gas > 1_000_000 {
 		riskIndicators = append(riskIndicators, "high_gas_limit")
-		anomalyScore += 0.1
+		anomalyScore += b.weights.HighGasLimit
 	}
 
 	if tx.Value != nil && tx.Value.Cmp(big1ETH) >= 0 {
 		riskIndicators = append(riskIndicators, "large_value_transfer")
-		anomalyScore += 0.1
+		anomalyScore += b.weights.LargeValue
 	}
 
 	if tx.IsContractCreation() {
 		riskIndicators = append(riskIndicators, "contract_creation")
-		anomalyScore += 0.2
+		anomalyScore += b.weights.ContractCreation
 	}
 
 	if len(tx.Input) > 10000 {
 		riskIndicators = append(riskIndicators, "large_calldata")
-		anomalyScore += 0.1
+		anomalyScore += b.weights.LargeCalldata
 	}
 
-	if anomalyScore > 1.0 {
-		anomalyScore = 1.0
+	// Novel exploits rarely match a known selector, so unrecognised calls
+	// get at least minimal scrutiny rather than scoring like transfers
+	if b.unclassifiedCallScore > 0 && tx.IsContractInteraction() && !b.knownSelector(tx.Input) {
+		riskIndicators = append(riskIndicators, "unclassified_contract_call")
+		anomalyScore = math.Max(anomalyScore, b.unclassifiedCallScore)
 	}
 
-	isSuspicious := anomalyScore >= b.anomalyThreshold
-	riskLevel := "low"
-	recommendation := "allow"
+	anomalyScore = b.weights.clamp(anomalyScore)
 
-	if anomalyScore >= 0.8 {
-		riskLevel = "critical"
-		recommendation = "block"
-	} else if anomalyScore >= 0.65 {
-		riskLevel = "high"
-		recommendation = "block"
-	} else if anomalyScore >= 0.4 {
-		riskLevel = "medium"
-		recommendation = "flag"
-	}
+	isSuspicious := anomalyScore >= b.anomalyThreshold
+	riskLevel, recommendation := b.weights.Thresholds.Classify(anomalyScore)
 
 	confidence := 0.5 + (0.5 * (1.0 - anomalyScore))
 	if isSuspicious {
@@ -602,6 +955,16 @@ func (b *Bridge) heuristicAnalysis(tx *types.PendingTransaction) *types.Inferenc
 	}
 }
 
+// knownSelector is IsKnownSelector extended with the configured rules and
+// ABIs
+func (b *Bridge) knownSelector(input []byte) bool {
+	if IsKnownSelector(input) || (b.decoder != nil && b.decoder.knows(input)) {
+		return true
+	}
+	_, ok := b.selectorRules.Match(input)
+	return ok
+}
+
 func (b *Bridge) fallbackAnalysis(tx *types.PendingTransaction, start time.Time) *types.InferenceResult {
 	result := b.heuristicAnalysis(tx)
 	result.LatencyMs = float64(time.Since(start).Milliseconds())
@@ -614,6 +977,16 @@ func (b *Bridge) QuickFilter(tx *types.PendingTransaction) bool {
 		return false
 	}
 
+	// Approvals are cheap in gas but can hand over a wallet's tokens
+	if approval, ok := DecodeApproval(tx.Input); ok && approval.Unlimited {
+		return true
+	}
+
+	// Forced sends need little gas, so they would otherwise be skipped
+	if isForcedEthSend(tx) {
+		return true
+	}
+
 	if tx.Gas < 100_000 {
 		return false
 	}
@@ -3,12 +3,15 @@ package inference
 import (
 	"context"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
 
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
 	"github.com/sentinel-protocol/sentinel-node/pkg/types"
 )
 
@@ -242,6 +245,47 @@ func TestBridge_Analyze_FlashLoan(t *testing.T) {
 	}
 }
 
+func TestBridge_Analyze_UnclassifiedContractCall(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{
+		Logger:                zerolog.Nop(),
+		UnclassifiedCallScore: 0.35,
+	})
+
+	tx := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1234"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   2000000,
+		Input: []byte{0xde, 0xad, 0xbe, 0xef, 0x01},
+	}
+
+	result, err := bridge.Analyze(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if !hasIndicator(result, "unclassified_contract_call") {
+		t.Errorf("Expected unclassified_contract_call indicator, got %v", result.RiskIndicators)
+	}
+	// high_gas_limit alone scores 0.1; the baseline lifts it
+	if result.AnomalyScore != 0.35 {
+		t.Errorf("Expected baseline score 0.35, got %f", result.AnomalyScore)
+	}
+
+	// A recognised selector is classified by its own signals
+	tx.Input = []byte{0x5c, 0xff, 0xe9, 0xde}
+	if result := bridge.heuristicAnalysis(tx); hasIndicator(result, "unclassified_contract_call") {
+		t.Error("Flash loan selector should not be unclassified")
+	}
+
+	// Without a baseline unknown calls keep their heuristic score
+	plain, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+	tx.Input = []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+	if result := plain.heuristicAnalysis(tx); hasIndicator(result, "unclassified_contract_call") || result.AnomalyScore != 0.1 {
+		t.Errorf("Expected no baseline when disabled, got %f %v", result.AnomalyScore, result.RiskIndicators)
+	}
+}
+
 func TestBridge_SetThreshold(t *testing.T) {
 	logger := zerolog.Nop()
 
@@ -262,8 +306,8 @@ func TestBridge_CircuitBreaker(t *testing.T) {
 		Logger: logger,
 	})
 
-	isOpen, failures, _ := bridge.GetCircuitBreakerStatus()
-	if isOpen {
+	state, failures, _ := bridge.GetCircuitBreakerStatus()
+	if state != CircuitClosed {
 		t.Error("Circuit breaker should not be open initially")
 	}
 	if failures != 0 {
@@ -271,6 +315,57 @@ func TestBridge_CircuitBreaker(t *testing.T) {
 	}
 }
 
+func TestBridge_CircuitBreakerHalfOpen(t *testing.T) {
+	client := &mockInferenceClient{}
+	bridge := newMockClientBridge(client, time.Second)
+
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		bridge.recordFailure()
+	}
+	if state, _, _ := bridge.GetCircuitBreakerStatus(); state != CircuitOpen {
+		t.Fatalf("Expected the circuit open, got %v", state)
+	}
+	if bridge.allowRequest() {
+		t.Fatal("Expected calls refused while the circuit cools down")
+	}
+
+	// Once the cooldown passes a limited number of probes are let through
+	bridge.mu.Lock()
+	bridge.circuitOpenUntil = time.Now().Add(-time.Second)
+	bridge.mu.Unlock()
+	for i := 0; i < maxHalfOpenProbes; i++ {
+		if !bridge.allowRequest() {
+			t.Fatalf("Expected probe %d allowed", i)
+		}
+	}
+	if bridge.allowRequest() {
+		t.Error("Expected calls beyond the probe budget refused")
+	}
+
+	// A failed probe reopens the circuit for longer
+	bridge.recordFailure()
+	state, _, reopenAt := bridge.GetCircuitBreakerStatus()
+	if state != CircuitOpen {
+		t.Fatalf("Expected a failed probe to reopen the circuit, got %v", state)
+	}
+	if time.Until(reopenAt) <= circuitOpenDuration {
+		t.Errorf("Expected a longer cooldown after a failed probe, reopens in %v", time.Until(reopenAt))
+	}
+
+	// A successful probe closes it
+	bridge.mu.Lock()
+	bridge.circuitOpenUntil = time.Now().Add(-time.Second)
+	bridge.connected = true
+	bridge.mu.Unlock()
+	bridge.Analyze(context.Background(), multiTestTx())
+	if client.calls.Load() != 1 {
+		t.Fatalf("Expected the probe to reach the server, got %d calls", client.calls.Load())
+	}
+	if state, failures, _ := bridge.GetCircuitBreakerStatus(); state != CircuitClosed || failures != 0 {
+		t.Errorf("Expected the circuit closed after a successful probe, got %v with %d failures", state, failures)
+	}
+}
+
 func TestBridge_AnalyzeBatch(t *testing.T) {
 	logger := zerolog.Nop()
 
@@ -321,6 +416,102 @@ func TestBridge_Close(t *testing.T) {
 	}
 }
 
+// mockInferenceClient hangs until the attempt deadline for the first
+// slowAttempts calls and answers immediately afterwards
+type mockInferenceClient struct {
+	pb.SentinelInferenceClient
+	slowAttempts int32
+	calls        atomic.Int32
+}
+
+func (m *mockInferenceClient) Analyze(ctx context.Context, in *pb.AnalyzeRequest, opts ...grpc.CallOption) (*pb.AnalyzeResponse, error) {
+	if m.calls.Add(1) <= m.slowAttempts {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &pb.AnalyzeResponse{
+		TxHash:         in.TxHash,
+		AnomalyScore:   0.3,
+		RiskLevel:      pb.RiskLevel_RISK_LOW,
+		RiskIndicators: []string{"server_analysis"},
+	}, nil
+}
+
+func newMockClientBridge(client pb.SentinelInferenceClient, timeout time.Duration) *Bridge {
+	bridge, _ := NewBridge(BridgeConfig{
+		Timeout:    timeout,
+		MaxRetries: 3,
+		Logger:     zerolog.Nop(),
+	})
+	bridge.client = client
+	bridge.connected = true
+	return bridge
+}
+
+func TestBridge_Analyze_SlowAttemptLeavesBudgetForRetry(t *testing.T) {
+	timeout := 300 * time.Millisecond
+	client := &mockInferenceClient{slowAttempts: 1}
+	bridge := newMockClientBridge(client, timeout)
+
+	tx := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+
+	start := time.Now()
+	result, err := bridge.Analyze(context.Background(), tx)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if !hasIndicator(result, "server_analysis") {
+		t.Errorf("Expected retry to reach the server, got %v", result.RiskIndicators)
+	}
+	if client.calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", client.calls.Load())
+	}
+	if elapsed >= timeout {
+		t.Errorf("Expected retry within the %v deadline, took %v", timeout, elapsed)
+	}
+}
+
+func TestBridge_Analyze_SlowAttemptsFallBackWithinDeadline(t *testing.T) {
+	timeout := 300 * time.Millisecond
+	client := &mockInferenceClient{slowAttempts: 3}
+	bridge := newMockClientBridge(client, timeout)
+
+	tx := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+
+	start := time.Now()
+	result, err := bridge.Analyze(context.Background(), tx)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if !hasIndicator(result, "fallback_analysis") {
+		t.Errorf("Expected fallback analysis, got %v", result.RiskIndicators)
+	}
+	if client.calls.Load() != 3 {
+		t.Errorf("Expected all 3 attempts to run, got %d", client.calls.Load())
+	}
+	if elapsed >= timeout {
+		t.Errorf("Expected retries and fallback within the %v deadline, took %v", timeout, elapsed)
+	}
+}
+
 // Helper to create pointer to address
 func ptrAddr(addr common.Address) *common.Address {
 	return &addr
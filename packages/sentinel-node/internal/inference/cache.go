@@ -0,0 +1,146 @@
+package inference
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultResultCacheTTL         = 2 * time.Minute
+	defaultResultCacheFallbackTTL = 5 * time.Second
+)
+
+// ResultCacheConfig configures reuse of analysis results for transactions
+// seen more than once, as happens when several RPC providers echo the same
+// pending transaction
+type ResultCacheConfig struct {
+	// Size bounds the cache; the least recently used result is evicted
+	// (0 = no caching)
+	Size int
+	// TTL is how long a result is reused (0 = 2m)
+	TTL time.Duration
+	// FallbackTTL is how long a heuristic fallback result is reused, kept
+	// short so the server gets to judge the transaction once it is back
+	// (0 = 5s)
+	FallbackTTL time.Duration
+}
+
+type cachedResult struct {
+	hash   common.Hash
+	result types.InferenceResult
+	expiry time.Time
+}
+
+// resultCache is an LRU of analysis results by transaction hash whose
+// entries also expire
+type resultCache struct {
+	mu          sync.Mutex
+	size        int
+	ttl         time.Duration
+	fallbackTTL time.Duration
+	order       *list.List
+	entries     map[common.Hash]*list.Element
+
+	now func() time.Time
+}
+
+// newResultCache creates a cache, applying defaults for unset TTLs. It
+// returns nil when caching is disabled; a nil cache never hits.
+func newResultCache(cfg ResultCacheConfig) *resultCache {
+	if cfg.Size <= 0 {
+		return nil
+	}
+
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultResultCacheTTL
+	}
+	fallbackTTL := cfg.FallbackTTL
+	if fallbackTTL <= 0 {
+		fallbackTTL = defaultResultCacheFallbackTTL
+	}
+
+	return &resultCache{
+		size:        cfg.Size,
+		ttl:         ttl,
+		fallbackTTL: fallbackTTL,
+		order:       list.New(),
+		entries:     make(map[common.Hash]*list.Element),
+		now:         time.Now,
+	}
+}
+
+// get returns a copy of the unexpired result for hash, if any
+func (c *resultCache) get(hash common.Hash) (*types.InferenceResult, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cachedResult)
+	if !c.now().Before(entry.expiry) {
+		c.order.Remove(elem)
+		delete(c.entries, hash)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return copyResult(&entry.result), true
+}
+
+// put stores a copy of result for hash, for the shorter fallback TTL if it
+// came from the heuristic fallback rather than a server verdict
+func (c *resultCache) put(hash common.Hash, result *types.InferenceResult, fallback bool) {
+	if c == nil {
+		return
+	}
+
+	ttl := c.ttl
+	if fallback {
+		ttl = c.fallbackTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiry := c.now().Add(ttl)
+	if elem, ok := c.entries[hash]; ok {
+		entry := elem.Value.(*cachedResult)
+		entry.result = *copyResult(result)
+		entry.expiry = expiry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[hash] = c.order.PushFront(&cachedResult{hash: hash, result: *copyResult(result), expiry: expiry})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResult).hash)
+	}
+}
+
+// copyResult copies a result deeply enough that callers adjusting a score,
+// appending indicators or attaching metadata don't change the cached one
+func copyResult(result *types.InferenceResult) *types.InferenceResult {
+	c := *result
+	c.RiskIndicators = append([]string(nil), result.RiskIndicators...)
+	if result.Metadata != nil {
+		c.Metadata = make(map[string]string, len(result.Metadata))
+		for k, v := range result.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	return &c
+}
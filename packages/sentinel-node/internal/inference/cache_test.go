@@ -0,0 +1,144 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func newCachingBridge(t *testing.T, client *mockInferenceClient) (*Bridge, *time.Time) {
+	t.Helper()
+	bridge, err := NewBridge(BridgeConfig{
+		Timeout: 300 * time.Millisecond,
+		Cache:   ResultCacheConfig{Size: 10, TTL: time.Minute, FallbackTTL: 5 * time.Second},
+		Logger:  zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	bridge.client = client
+	bridge.connected = true
+
+	now := time.Now()
+	bridge.cache.now = func() time.Time { return now }
+	return bridge, &now
+}
+
+func cacheTestTx() *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.HexToHash("0xc0ffee"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+}
+
+func TestBridge_Analyze_CacheHit(t *testing.T) {
+	client := &mockInferenceClient{}
+	bridge, _ := newCachingBridge(t, client)
+	tx := cacheTestTx()
+
+	first, err := bridge.Analyze(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	// Callers adjust results; that must not leak into the cached copy
+	first.AnomalyScore = 1
+	first.RiskIndicators = append(first.RiskIndicators, "caller_added")
+
+	second, err := bridge.Analyze(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if client.calls.Load() != 1 {
+		t.Errorf("Expected one server call for a repeated hash, got %d", client.calls.Load())
+	}
+	if second.AnomalyScore != 0.3 || hasIndicator(second, "caller_added") {
+		t.Errorf("Cached result was changed by the caller: score %v, indicators %v", second.AnomalyScore, second.RiskIndicators)
+	}
+}
+
+func TestBridge_Analyze_CacheExpires(t *testing.T) {
+	client := &mockInferenceClient{}
+	bridge, now := newCachingBridge(t, client)
+	tx := cacheTestTx()
+
+	bridge.Analyze(context.Background(), tx)
+	*now = now.Add(59 * time.Second)
+	bridge.Analyze(context.Background(), tx)
+	if client.calls.Load() != 1 {
+		t.Fatalf("Expected the result reused within the TTL, got %d server calls", client.calls.Load())
+	}
+
+	*now = now.Add(2 * time.Second)
+	bridge.Analyze(context.Background(), tx)
+	if client.calls.Load() != 2 {
+		t.Errorf("Expected a fresh analysis after the TTL, got %d server calls", client.calls.Load())
+	}
+}
+
+func TestBridge_Analyze_FallbackCachedBriefly(t *testing.T) {
+	client := &mockInferenceClient{}
+	bridge, now := newCachingBridge(t, client)
+	tx := cacheTestTx()
+
+	bridge.mu.Lock()
+	bridge.circuitState = CircuitOpen
+	bridge.circuitOpenUntil = time.Now().Add(time.Hour)
+	bridge.mu.Unlock()
+
+	result, _ := bridge.Analyze(context.Background(), tx)
+	if !hasIndicator(result, "circuit_breaker_open") {
+		t.Fatalf("Expected a fallback result, got %v", result.RiskIndicators)
+	}
+
+	// The server recovers, but the fallback is still served for a moment
+	bridge.mu.Lock()
+	bridge.circuitState = CircuitClosed
+	bridge.mu.Unlock()
+	result, _ = bridge.Analyze(context.Background(), tx)
+	if !hasIndicator(result, "fallback_analysis") || client.calls.Load() != 0 {
+		t.Fatalf("Expected the fallback result reused within its TTL, got %v", result.RiskIndicators)
+	}
+
+	*now = now.Add(6 * time.Second)
+	result, _ = bridge.Analyze(context.Background(), tx)
+	if !hasIndicator(result, "server_analysis") || client.calls.Load() != 1 {
+		t.Fatalf("Expected the server verdict once the fallback expired, got %v", result.RiskIndicators)
+	}
+
+	// The server verdict replaces the fallback for the full TTL
+	*now = now.Add(30 * time.Second)
+	result, _ = bridge.Analyze(context.Background(), tx)
+	if !hasIndicator(result, "server_analysis") || client.calls.Load() != 1 {
+		t.Errorf("Expected the server verdict reused, got %v after %d calls", result.RiskIndicators, client.calls.Load())
+	}
+}
+
+func TestResultCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newResultCache(ResultCacheConfig{Size: 2})
+	a, b, c := common.HexToHash("0xa"), common.HexToHash("0xb"), common.HexToHash("0xc")
+
+	cache.put(a, &types.InferenceResult{TxHash: a}, false)
+	cache.put(b, &types.InferenceResult{TxHash: b}, false)
+	cache.get(a)
+	cache.put(c, &types.InferenceResult{TxHash: c}, false)
+
+	if _, ok := cache.get(b); ok {
+		t.Error("Expected the least recently used result evicted")
+	}
+	if _, ok := cache.get(a); !ok {
+		t.Error("Expected the recently read result kept")
+	}
+	if newResultCache(ResultCacheConfig{}) != nil {
+		t.Error("Expected no cache when Size is 0")
+	}
+}
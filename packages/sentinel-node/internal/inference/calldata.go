@@ -0,0 +1,211 @@
+package inference
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+)
+
+// knownABI holds the function signatures the node decodes client-side:
+// ERC20/721 token operations and flash-loan entrypoints. Router swaps are
+// covered by routerABI.
+const knownABI = `[
+	{"name":"transfer","type":"function","inputs":[
+		{"name":"to","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"name":"approve","type":"function","inputs":[
+		{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"name":"transferFrom","type":"function","inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"name":"setApprovalForAll","type":"function","inputs":[
+		{"name":"operator","type":"address"},{"name":"approved","type":"bool"}]},
+	{"name":"flashLoan","type":"function","inputs":[
+		{"name":"receiverAddress","type":"address"},{"name":"assets","type":"address[]"},
+		{"name":"amounts","type":"uint256[]"},{"name":"interestRateModes","type":"uint256[]"},
+		{"name":"onBehalfOf","type":"address"},{"name":"params","type":"bytes"},{"name":"referralCode","type":"uint16"}]},
+	{"name":"flash","type":"function","inputs":[
+		{"name":"recipient","type":"address"},{"name":"amount0","type":"uint256"},
+		{"name":"amount1","type":"uint256"},{"name":"data","type":"bytes"}]}
+]`
+
+var parsedKnownABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(knownABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// IsKnownSelector reports whether input calls a function the node
+// recognises: one of the decoded ABIs or a flash-loan entrypoint
+func IsKnownSelector(input []byte) bool {
+	if len(input) < 4 {
+		return false
+	}
+	if _, err := parsedKnownABI.MethodById(input[:4]); err == nil {
+		return true
+	}
+	if _, err := parsedRouterABI.MethodById(input[:4]); err == nil {
+		return true
+	}
+	return flashLoanSelectors[hex.EncodeToString(input[:4])]
+}
+
+// CalldataDecoder decodes transaction calldata for selectors with a known ABI
+type CalldataDecoder struct {
+	methods map[[4]byte]abi.Method
+}
+
+// NewCalldataDecoder creates a decoder covering the built-in ABIs
+func NewCalldataDecoder() *CalldataDecoder {
+	d := &CalldataDecoder{methods: make(map[[4]byte]abi.Method)}
+	d.addABI(parsedKnownABI)
+	d.addABI(parsedRouterABI)
+
+	return d
+}
+
+// LoadABIDir adds the functions of every ABI in dir's .json files, either
+// a bare ABI array or a build artifact with an "abi" field. Functions loaded
+// later replace earlier ones with the same selector, so operator ABIs take
+// precedence over the built-in ones. It returns the number of files loaded.
+func (d *CalldataDecoder) LoadABIDir(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read ABI: %w", err)
+		}
+		parsed, err := parseABIFile(data)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ABI in %s: %w", path, err)
+		}
+		d.addABI(parsed)
+	}
+	return len(paths), nil
+}
+
+func parseABIFile(data []byte) (abi.ABI, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return abi.ABI{}, err
+		}
+		if artifact.ABI == nil {
+			return abi.ABI{}, fmt.Errorf("no abi field")
+		}
+		data = artifact.ABI
+	}
+	return abi.JSON(bytes.NewReader(data))
+}
+
+// knows reports whether the decoder has an ABI for input's selector
+func (d *CalldataDecoder) knows(input []byte) bool {
+	if len(input) < 4 {
+		return false
+	}
+	var selector [4]byte
+	copy(selector[:], input[:4])
+	_, ok := d.methods[selector]
+	return ok
+}
+
+func (d *CalldataDecoder) addABI(parsed abi.ABI) {
+	for _, method := range parsed.Methods {
+		var selector [4]byte
+		copy(selector[:], method.ID)
+		d.methods[selector] = method
+	}
+}
+
+// Decode returns the structured form of input, or false when the selector
+// is unknown or the arguments don't match its ABI
+func (d *CalldataDecoder) Decode(input []byte) (*pb.DecodedCall, bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+
+	var selector [4]byte
+	copy(selector[:], input[:4])
+
+	method, ok := d.methods[selector]
+	if !ok {
+		return nil, false
+	}
+
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, false
+	}
+
+	call := &pb.DecodedCall{
+		Selector:  hexutil.Encode(selector[:]),
+		Method:    method.Name,
+		Arguments: make([]*pb.DecodedArgument, len(values)),
+	}
+	for i, value := range values {
+		call.Arguments[i] = &pb.DecodedArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: formatArgument(value),
+		}
+	}
+
+	return call, true
+}
+
+// formatArgument renders a decoded ABI value in a canonical string form:
+// decimal integers, hex addresses and bytes, and bracketed lists and tuples
+func formatArgument(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bytes), rv)
+			return hexutil.Encode(bytes)
+		}
+		fallthrough
+	case reflect.Slice:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = formatArgument(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	case reflect.Struct:
+		parts := make([]string, rv.NumField())
+		for i := range parts {
+			parts[i] = formatArgument(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(parts, ",") + ")"
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,182 @@
+package inference
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func packKnown(t *testing.T, method string, args ...interface{}) []byte {
+	t.Helper()
+	data, err := parsedKnownABI.Pack(method, args...)
+	if err != nil {
+		t.Fatalf("Pack(%s) failed: %v", method, err)
+	}
+	return data
+}
+
+func TestCalldataDecoder_Transfer(t *testing.T) {
+	decoder := NewCalldataDecoder()
+	input := packKnown(t, "transfer", testUSDC, big.NewInt(1_000_000))
+
+	call, ok := decoder.Decode(input)
+	if !ok {
+		t.Fatal("Expected transfer to decode")
+	}
+
+	if call.Selector != "0xa9059cbb" {
+		t.Errorf("Expected selector 0xa9059cbb, got %s", call.Selector)
+	}
+	if call.Method != "transfer" {
+		t.Errorf("Expected method transfer, got %s", call.Method)
+	}
+	if len(call.Arguments) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(call.Arguments))
+	}
+	if arg := call.Arguments[0]; arg.Name != "to" || arg.Type != "address" || arg.Value != testUSDC.Hex() {
+		t.Errorf("Unexpected first argument: %+v", arg)
+	}
+	if arg := call.Arguments[1]; arg.Name != "amount" || arg.Type != "uint256" || arg.Value != "1000000" {
+		t.Errorf("Unexpected second argument: %+v", arg)
+	}
+}
+
+func TestCalldataDecoder_RouterSwap(t *testing.T) {
+	decoder := NewCalldataDecoder()
+	input := packSwap(t, "swapExactTokensForTokens",
+		big.NewInt(1000), big.NewInt(990),
+		[]common.Address{testWETH, testUSDC},
+		common.HexToAddress("0x1"), big.NewInt(1700000000),
+	)
+
+	call, ok := decoder.Decode(input)
+	if !ok {
+		t.Fatal("Expected router swap to decode")
+	}
+
+	path := call.Arguments[2]
+	expected := "[" + testWETH.Hex() + "," + testUSDC.Hex() + "]"
+	if path.Type != "address[]" || path.Value != expected {
+		t.Errorf("Expected path %s, got %s (%s)", expected, path.Value, path.Type)
+	}
+}
+
+func TestCalldataDecoder_UnknownSelector(t *testing.T) {
+	decoder := NewCalldataDecoder()
+
+	if _, ok := decoder.Decode([]byte{0xde, 0xad, 0xbe, 0xef, 0x01}); ok {
+		t.Error("Expected unknown selector not to decode")
+	}
+	if _, ok := decoder.Decode([]byte{0xa9, 0x05}); ok {
+		t.Error("Expected short input not to decode")
+	}
+
+	// Known selector with truncated arguments
+	input := packKnown(t, "transfer", testUSDC, big.NewInt(1))
+	if _, ok := decoder.Decode(input[:20]); ok {
+		t.Error("Expected truncated arguments not to decode")
+	}
+}
+
+func TestTxToRequest_DecodedCall(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{DecodeCalldata: true, Logger: zerolog.Nop()})
+	to := testUSDC
+
+	known := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1"),
+		To:    &to,
+		Input: packKnown(t, "approve", testWETH, big.NewInt(5)),
+	}
+	req := bridge.txToRequest(known)
+	if req.DecodedCall == nil || req.DecodedCall.Method != "approve" {
+		t.Fatalf("Expected decoded approve call, got %+v", req.DecodedCall)
+	}
+	if len(req.InputData) != len(known.Input) {
+		t.Error("Expected raw input to be sent alongside the decoded call")
+	}
+
+	unknown := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x2"),
+		To:    &to,
+		Input: []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	req = bridge.txToRequest(unknown)
+	if req.DecodedCall != nil {
+		t.Errorf("Expected no decoded call for unknown selector, got %+v", req.DecodedCall)
+	}
+	if string(req.InputData) != string(unknown.Input) {
+		t.Error("Expected raw input fallback for unknown selector")
+	}
+}
+
+func TestTxToRequest_DecodingDisabled(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+
+	req := bridge.txToRequest(&types.PendingTransaction{
+		Input: packKnown(t, "transfer", testUSDC, big.NewInt(1)),
+	})
+	if req.DecodedCall != nil {
+		t.Error("Expected no decoded call when decoding is disabled")
+	}
+}
+
+// vaultABI is a protocol function the built-in ABIs don't cover
+const vaultABI = `[{"name":"withdrawAll","type":"function","inputs":[
+	{"name":"receiver","type":"address"},{"name":"shares","type":"uint256"}]}]`
+
+func TestCalldataDecoder_LoadABIDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "vault.json"), []byte(vaultABI), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	// Build artifacts carry the ABI under "abi"
+	artifact := `{"contractName":"Token","abi":[{"name":"mint","type":"function","inputs":[{"name":"to","type":"address"}]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "Token.json"), []byte(artifact), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	bridge, err := NewBridge(BridgeConfig{DecodeCalldata: true, ABIDir: dir, Logger: zerolog.Nop()})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+
+	parsed, _ := abi.JSON(strings.NewReader(vaultABI))
+	input, _ := parsed.Pack("withdrawAll", testWETH, big.NewInt(42))
+	call, ok := bridge.decoder.Decode(input)
+	if !ok {
+		t.Fatal("Expected the loaded ABI to decode")
+	}
+	if call.Method != "withdrawAll" || call.Arguments[1].Value != "42" {
+		t.Errorf("Unexpected decoded call: %+v", call)
+	}
+	if !bridge.knownSelector(input) {
+		t.Error("Expected a loaded selector to count as known")
+	}
+
+	// Built-in ABIs still decode, and unknown selectors still don't
+	if _, ok := bridge.decoder.Decode(packKnown(t, "transfer", testUSDC, big.NewInt(1))); !ok {
+		t.Error("Expected the built-in transfer ABI kept")
+	}
+	if _, ok := bridge.decoder.Decode([]byte{0xde, 0xad, 0xbe, 0xef}); ok {
+		t.Error("Expected unknown selector not to decode")
+	}
+}
+
+func TestCalldataDecoder_LoadABIDirRejectsInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"contractName":"NoABI"}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := NewBridge(BridgeConfig{DecodeCalldata: true, ABIDir: dir, Logger: zerolog.Nop()}); err == nil {
+		t.Error("Expected an error for a file without an ABI")
+	}
+}
@@ -0,0 +1,162 @@
+package inference
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultClusterWindow     = 10 * time.Minute
+	defaultClusterMinMembers = 3
+
+	// Senders past this nonce aren't treated as freshly funded
+	maxFreshNonce = 10
+	// Score at which a cluster member's transaction counts as suspicious activity
+	clusterActivityScore = 0.3
+	clusterScoreBoost    = 0.2
+)
+
+// ClusterConfig configures address cluster tracking
+type ClusterConfig struct {
+	// Window bounds how long fundings and member activity are remembered
+	Window time.Duration
+	// MinMembers is how many distinct addresses sharing a funder must act
+	// suspiciously within the window before the cluster is flagged
+	MinMembers int
+}
+
+type funding struct {
+	funder common.Address
+	at     time.Time
+}
+
+// ClusterTracker correlates senders by the address that recently funded them
+// and detects funding clusters whose members act suspiciously in concert
+type ClusterTracker struct {
+	mu         sync.Mutex
+	window     time.Duration
+	minMembers int
+
+	// recipient -> most recent funding seen in the mempool
+	fundedBy map[common.Address]funding
+	// funder -> member -> last suspicious activity
+	activity  map[common.Address]map[common.Address]time.Time
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+// NewClusterTracker creates a tracker, applying defaults for unset fields
+func NewClusterTracker(cfg ClusterConfig) *ClusterTracker {
+	window := cfg.Window
+	if window == 0 {
+		window = defaultClusterWindow
+	}
+
+	minMembers := cfg.MinMembers
+	if minMembers == 0 {
+		minMembers = defaultClusterMinMembers
+	}
+
+	return &ClusterTracker{
+		window:     window,
+		minMembers: minMembers,
+		fundedBy:   make(map[common.Address]funding),
+		activity:   make(map[common.Address]map[common.Address]time.Time),
+		now:        time.Now,
+	}
+}
+
+// Observe records plain ETH transfers as fundings of the recipient
+func (c *ClusterTracker) Observe(tx *types.PendingTransaction) {
+	if tx.To == nil || !tx.IsSimpleTransfer() || tx.Value == nil || tx.Value.Cmp(big.NewInt(0)) <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+	c.fundedBy[*tx.To] = funding{funder: tx.From, at: now}
+}
+
+// Correlate records the analysis outcome for a freshly funded sender and
+// reports whether the sender belongs to a cluster with at least MinMembers
+// members acting suspiciously within the window
+func (c *ClusterTracker) Correlate(tx *types.PendingTransaction, result *types.InferenceResult) bool {
+	if tx.Nonce > maxFreshNonce {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+
+	cutoff := now.Add(-c.window)
+	fund, ok := c.fundedBy[tx.From]
+	if !ok || fund.at.Before(cutoff) {
+		return false
+	}
+
+	members := c.activity[fund.funder]
+	if result.AnomalyScore >= clusterActivityScore {
+		if members == nil {
+			members = make(map[common.Address]time.Time)
+			c.activity[fund.funder] = members
+		}
+		members[tx.From] = now
+	}
+
+	if at, active := members[tx.From]; !active || at.Before(cutoff) {
+		return false
+	}
+
+	count := 0
+	for _, at := range members {
+		if !at.Before(cutoff) {
+			count++
+		}
+	}
+	return count >= c.minMembers
+}
+
+// pruneLocked drops fundings and activity older than the window. It runs at
+// most a few times per window so Observe stays cheap under load.
+func (c *ClusterTracker) pruneLocked(now time.Time) {
+	if now.Sub(c.lastPrune) < c.window/4 {
+		return
+	}
+	c.lastPrune = now
+
+	cutoff := now.Add(-c.window)
+	for addr, fund := range c.fundedBy {
+		if fund.at.Before(cutoff) {
+			delete(c.fundedBy, addr)
+		}
+	}
+	for funder, members := range c.activity {
+		for member, at := range members {
+			if at.Before(cutoff) {
+				delete(members, member)
+			}
+		}
+		if len(members) == 0 {
+			delete(c.activity, funder)
+		}
+	}
+}
+
+// Size returns the number of tracked fundings
+func (c *ClusterTracker) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.fundedBy)
+}
@@ -0,0 +1,152 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var testFunder = common.HexToAddress("0xf00d")
+
+func fundingTx(from, to common.Address) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.BigToHash(to.Big()),
+		From:  from,
+		To:    ptrAddr(to),
+		Value: big.NewInt(1e17),
+		Gas:   21000,
+	}
+}
+
+func suspiciousTx(from common.Address, seed int64) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.BigToHash(big.NewInt(seed)),
+		From:  from,
+		To:    ptrAddr(common.HexToAddress("0xdead")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde, 0x00}, // flashLoan
+	}
+}
+
+func newClusterTestBridge(t *testing.T) (*Bridge, *time.Time) {
+	t.Helper()
+	bridge, err := NewBridge(BridgeConfig{
+		Cluster: ClusterConfig{Window: 5 * time.Minute, MinMembers: 3},
+		Logger:  zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	bridge.clusters.now = func() time.Time { return now }
+	return bridge, &now
+}
+
+func TestBridge_CoordinatedClusterFires(t *testing.T) {
+	bridge, _ := newClusterTestBridge(t)
+
+	members := []common.Address{
+		common.HexToAddress("0xa1"),
+		common.HexToAddress("0xa2"),
+		common.HexToAddress("0xa3"),
+	}
+	for _, member := range members {
+		bridge.Observe(fundingTx(testFunder, member))
+	}
+
+	for i, member := range members {
+		result, _ := bridge.Analyze(context.Background(), suspiciousTx(member, int64(i)))
+		flagged := hasIndicator(result, "coordinated_cluster")
+
+		// Only the member completing the cluster (and later ones) is flagged
+		if i < 2 && flagged {
+			t.Errorf("Member %d flagged before the cluster reached its minimum size", i)
+		}
+		if i == 2 && !flagged {
+			t.Errorf("Expected coordinated_cluster on member %d, got %v", i, result.RiskIndicators)
+		}
+	}
+
+	// Earlier members are flagged on their next transaction
+	result, _ := bridge.Analyze(context.Background(), suspiciousTx(members[0], 10))
+	if !hasIndicator(result, "coordinated_cluster") {
+		t.Errorf("Expected coordinated_cluster on repeat member, got %v", result.RiskIndicators)
+	}
+}
+
+func TestBridge_CoordinatedClusterIgnoresIsolatedAddresses(t *testing.T) {
+	bridge, _ := newClusterTestBridge(t)
+
+	// Three suspicious senders with no observed funding
+	for i := int64(0); i < 3; i++ {
+		sender := common.BigToAddress(big.NewInt(0xb0 + i))
+		result, _ := bridge.Analyze(context.Background(), suspiciousTx(sender, i))
+		if hasIndicator(result, "coordinated_cluster") {
+			t.Errorf("Unfunded sender %s flagged as clustered", sender.Hex())
+		}
+	}
+
+	// Three suspicious senders, each funded by a different address
+	for i := int64(0); i < 3; i++ {
+		sender := common.BigToAddress(big.NewInt(0xc0 + i))
+		bridge.Observe(fundingTx(common.BigToAddress(big.NewInt(0xd0+i)), sender))
+		result, _ := bridge.Analyze(context.Background(), suspiciousTx(sender, 10+i))
+		if hasIndicator(result, "coordinated_cluster") {
+			t.Errorf("Independently funded sender %s flagged as clustered", sender.Hex())
+		}
+	}
+}
+
+func TestBridge_CoordinatedClusterRequiresSuspiciousActivity(t *testing.T) {
+	bridge, _ := newClusterTestBridge(t)
+
+	for i := int64(0); i < 3; i++ {
+		member := common.BigToAddress(big.NewInt(0xe0 + i))
+		bridge.Observe(fundingTx(testFunder, member))
+
+		benign := &types.PendingTransaction{
+			Hash:  common.BigToHash(big.NewInt(i)),
+			From:  member,
+			To:    ptrAddr(common.HexToAddress("0xdead")),
+			Value: big.NewInt(0),
+			Gas:   150000,
+			Input: []byte{0xa9, 0x05, 0x9c, 0xbb},
+		}
+		result, _ := bridge.Analyze(context.Background(), benign)
+		if hasIndicator(result, "coordinated_cluster") {
+			t.Error("Cluster flagged without suspicious member activity")
+		}
+	}
+}
+
+func TestClusterTracker_WindowBoundsMemory(t *testing.T) {
+	bridge, now := newClusterTestBridge(t)
+	tracker := bridge.clusters
+
+	for i := int64(0); i < 100; i++ {
+		tracker.Observe(fundingTx(testFunder, common.BigToAddress(big.NewInt(0x1000+i))))
+	}
+	if tracker.Size() != 100 {
+		t.Fatalf("Expected 100 tracked fundings, got %d", tracker.Size())
+	}
+
+	// Fundings that fall out of the window no longer correlate
+	*now = now.Add(6 * time.Minute)
+	member := common.BigToAddress(big.NewInt(0x1000))
+	if tracker.Correlate(suspiciousTx(member, 1), &types.InferenceResult{AnomalyScore: 0.9}) {
+		t.Error("Expired funding should not correlate")
+	}
+
+	tracker.Observe(fundingTx(testFunder, common.HexToAddress("0x2000")))
+	if tracker.Size() != 1 {
+		t.Errorf("Expected expired fundings to be pruned, got %d tracked", tracker.Size())
+	}
+}
@@ -0,0 +1,98 @@
+package inference
+
+import (
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	forcedSendScoreBoost = 0.35
+	// Bounds the walk of init code so jump cycles can't stall analysis
+	maxInitCodeSteps = 1024
+)
+
+// EVM opcodes the init code walk cares about
+const (
+	opStop         = 0x00
+	opJump         = 0x56
+	opJumpDest     = 0x5b
+	opPush1        = 0x60
+	opPush32       = 0x7f
+	opReturn       = 0xf3
+	opRevert       = 0xfd
+	opInvalid      = 0xfe
+	opSelfDestruct = 0xff
+)
+
+// SelfDestructsOnCreation reports whether init code reaches SELFDESTRUCT on
+// its straight-line path, i.e. the contract destroys itself during
+// construction instead of returning runtime code. Such a contract exists
+// only to push its balance to a target, which SELFDESTRUCT does even to
+// contracts that reject plain transfers.
+//
+// The walk follows constant jumps (PUSH then JUMP) and falls through
+// conditional ones; code that computes its jump targets isn't followed.
+func SelfDestructsOnCreation(code []byte) bool {
+	visited := make(map[int]bool)
+	pc := 0
+	lastPush := -1
+
+	for steps := 0; pc < len(code) && steps < maxInitCodeSteps; steps++ {
+		op := code[pc]
+		switch {
+		case op == opSelfDestruct:
+			return true
+
+		case op == opStop, op == opReturn, op == opRevert, op == opInvalid:
+			return false
+
+		case op >= opPush1 && op <= opPush32:
+			size := int(op-opPush1) + 1
+			lastPush = pushValue(code, pc+1, size)
+			pc += 1 + size
+			continue
+
+		case op == opJump:
+			if lastPush < 0 || lastPush >= len(code) || code[lastPush] != opJumpDest || visited[lastPush] {
+				return false
+			}
+			visited[lastPush] = true
+			pc = lastPush
+			lastPush = -1
+			continue
+		}
+
+		lastPush = -1
+		pc++
+	}
+	return false
+}
+
+// pushValue reads a PUSH immediate as a code offset, returning -1 for
+// values that can't be one. Immediates cut off by the end of the code are
+// zero-padded as the EVM does.
+func pushValue(code []byte, start, size int) int {
+	value := 0
+	for i := 0; i < size; i++ {
+		if value > len(code) {
+			return -1
+		}
+		value <<= 8
+		if start+i < len(code) {
+			value |= int(code[start+i])
+		}
+	}
+	if value > len(code) {
+		return -1
+	}
+	return value
+}
+
+// isForcedEthSend reports whether tx deploys a contract that self-destructs
+// in its constructor while carrying value, forcing ETH onto the beneficiary
+// without invoking its code
+func isForcedEthSend(tx *types.PendingTransaction) bool {
+	if !tx.IsContractCreation() || tx.Value == nil || tx.Value.Sign() <= 0 {
+		return false
+	}
+	return SelfDestructsOnCreation(tx.Input)
+}
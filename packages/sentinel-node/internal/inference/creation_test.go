@@ -0,0 +1,109 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// forceSendInitCode is PUSH20 <target> SELFDESTRUCT
+func forceSendInitCode(target common.Address) []byte {
+	return append(append([]byte{0x73}, target.Bytes()...), 0xff)
+}
+
+func TestSelfDestructsOnCreation(t *testing.T) {
+	target := common.HexToAddress("0xdead")
+
+	tests := []struct {
+		name     string
+		code     []byte
+		expected bool
+	}{
+		{name: "direct self-destruct", code: forceSendInitCode(target), expected: true},
+		{
+			// PUSH1 0x04 JUMP INVALID JUMPDEST CALLER SELFDESTRUCT
+			name:     "constant jump to self-destruct",
+			code:     []byte{0x60, 0x04, 0x56, 0xfe, 0x5b, 0x33, 0xff},
+			expected: true,
+		},
+		{
+			// Typical constructor: CODECOPY the runtime and RETURN it. The
+			// runtime contains 0xff bytes that are never reached.
+			// PUSH1 0x0a DUP1 PUSH1 0x0c PUSH1 0x00 CODECOPY PUSH1 0x00 RETURN
+			name:     "returns runtime code",
+			code:     []byte{0x60, 0x0a, 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3, 0x00, 0x73, 0xff},
+			expected: false,
+		},
+		{
+			// 0xff inside PUSH data is not an opcode: PUSH2 0xffff STOP
+			name:     "selfdestruct byte in push data",
+			code:     []byte{0x61, 0xff, 0xff, 0x00},
+			expected: false,
+		},
+		{
+			// PUSH1 0x00 JUMP: target is not a JUMPDEST
+			name:     "invalid jump",
+			code:     []byte{0x60, 0x00, 0x56, 0xff},
+			expected: false,
+		},
+		{
+			// JUMPDEST PUSH1 0x00 JUMP: loops forever without self-destructing
+			name:     "jump cycle",
+			code:     []byte{0x5b, 0x60, 0x00, 0x56, 0xff},
+			expected: false,
+		},
+		{name: "empty", code: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelfDestructsOnCreation(tt.code); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBridge_ForcedEthSend(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+
+	tx := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1234"),
+		From:  common.HexToAddress("0x1"),
+		Value: big.NewInt(1e17),
+		Gas:   60000,
+		Input: forceSendInitCode(common.HexToAddress("0xdead")),
+	}
+
+	if !bridge.QuickFilter(tx) {
+		t.Error("Low-gas forced send should pass the quick filter")
+	}
+
+	result, err := bridge.Analyze(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if !hasIndicator(result, "forced_eth_send") {
+		t.Errorf("Expected forced_eth_send, got %v", result.RiskIndicators)
+	}
+
+	// Without value there is nothing to force onto the target
+	tx.Value = big.NewInt(0)
+	result, _ = bridge.Analyze(context.Background(), tx)
+	if hasIndicator(result, "forced_eth_send") {
+		t.Error("Self-destruct without value should not be flagged")
+	}
+
+	// A regular deployment carrying value is not a forced send
+	tx.Value = big.NewInt(1e17)
+	tx.Input = []byte{0x60, 0x0a, 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3, 0x00, 0x73, 0xff}
+	result, _ = bridge.Analyze(context.Background(), tx)
+	if hasIndicator(result, "forced_eth_send") {
+		t.Error("Benign creation code should not be flagged")
+	}
+}
@@ -0,0 +1,147 @@
+package inference
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// NativeToken is the price-table key for ETH moved as transaction value
+var NativeToken = common.Address{}
+
+// alertLevels in escalation order
+var alertLevels = []types.AlertLevel{
+	types.AlertLevelLow,
+	types.AlertLevelMedium,
+	types.AlertLevelHigh,
+	types.AlertLevelCritical,
+}
+
+// TokenPrice values one whole token in USD
+type TokenPrice struct {
+	USD      float64
+	Decimals uint8
+}
+
+// PriceOracle values tokens for escalation; NativeToken is ETH
+type PriceOracle interface {
+	Price(token common.Address) (TokenPrice, bool)
+}
+
+// StaticPrices is a fixed price table
+type StaticPrices map[common.Address]TokenPrice
+
+// Price implements PriceOracle
+func (p StaticPrices) Price(token common.Address) (TokenPrice, bool) {
+	price, ok := p[token]
+	return price, ok
+}
+
+// EscalationTier raises an alert by Levels once a transaction moves at
+// least MinUSD
+type EscalationTier struct {
+	MinUSD float64
+	Levels int
+}
+
+// ValueEscalator raises alert levels for transactions moving large value,
+// so a $10M drain outranks a $100 one at the same anomaly score
+type ValueEscalator struct {
+	tiers  []EscalationTier
+	prices PriceOracle
+}
+
+// NewValueEscalator creates an escalator; with no tiers or prices it never
+// changes a level
+func NewValueEscalator(tiers []EscalationTier, prices PriceOracle) *ValueEscalator {
+	sorted := append([]EscalationTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinUSD > sorted[j].MinUSD })
+
+	return &ValueEscalator{tiers: sorted, prices: prices}
+}
+
+// ValueUSD estimates the USD moved by tx: its ETH value plus the amount of
+// a decoded ERC20 transfer. Unpriced tokens count as zero.
+func (e *ValueEscalator) ValueUSD(tx *types.PendingTransaction) float64 {
+	if e.prices == nil {
+		return 0
+	}
+
+	total := 0.0
+	if tx.Value != nil && tx.Value.Sign() > 0 {
+		if price, ok := e.prices.Price(NativeToken); ok {
+			total += valueUSD(tx.Value, price)
+		}
+	}
+
+	if tx.To != nil {
+		if amount, ok := decodeTransferAmount(tx.Input); ok {
+			if price, ok := e.prices.Price(*tx.To); ok {
+				total += valueUSD(amount, price)
+			}
+		}
+	}
+
+	return total
+}
+
+// Escalate raises level by the highest tier tx's value reaches, capped at
+// critical. Levels outside the escalation order are returned unchanged, as
+// is every level on a nil escalator.
+func (e *ValueEscalator) Escalate(tx *types.PendingTransaction, level types.AlertLevel) types.AlertLevel {
+	if e == nil || len(e.tiers) == 0 {
+		return level
+	}
+
+	index := -1
+	for i, l := range alertLevels {
+		if l == level {
+			index = i
+		}
+	}
+	if index < 0 {
+		return level
+	}
+
+	value := e.ValueUSD(tx)
+	for _, tier := range e.tiers {
+		if value >= tier.MinUSD {
+			index += tier.Levels
+			break
+		}
+	}
+
+	if index >= len(alertLevels) {
+		index = len(alertLevels) - 1
+	}
+	return alertLevels[index]
+}
+
+// decodeTransferAmount returns the amount of an ERC20 transfer or transferFrom
+func decodeTransferAmount(input []byte) (*big.Int, bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+
+	method, err := parsedKnownABI.MethodById(input[:4])
+	if err != nil || (method.Name != "transfer" && method.Name != "transferFrom") {
+		return nil, false
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil || len(args) == 0 {
+		return nil, false
+	}
+
+	amount, ok := args[len(args)-1].(*big.Int)
+	return amount, ok
+}
+
+func valueUSD(amount *big.Int, price TokenPrice) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(price.Decimals)), nil))
+	tokens, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), scale).Float64()
+	return tokens * price.USD
+}
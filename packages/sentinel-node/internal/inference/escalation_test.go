@@ -0,0 +1,89 @@
+package inference
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func newTestEscalator() *ValueEscalator {
+	return NewValueEscalator(
+		[]EscalationTier{
+			{MinUSD: 100_000, Levels: 1},
+			{MinUSD: 10_000_000, Levels: 2},
+		},
+		StaticPrices{
+			NativeToken: {USD: 2000, Decimals: 18},
+			testUSDC:    {USD: 1, Decimals: 6},
+		},
+	)
+}
+
+func ethTx(ether int64) *types.PendingTransaction {
+	value := new(big.Int).Mul(big.NewInt(ether), big.NewInt(1e18))
+	return &types.PendingTransaction{
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: value,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+}
+
+func TestValueEscalator_HighValueEscalates(t *testing.T) {
+	escalator := newTestEscalator()
+
+	tests := []struct {
+		name  string
+		tx    *types.PendingTransaction
+		level types.AlertLevel
+		want  types.AlertLevel
+	}{
+		{"$100 stays", ethTx(0), types.AlertLevelMedium, types.AlertLevelMedium},
+		{"$200k ETH raises one level", ethTx(100), types.AlertLevelMedium, types.AlertLevelHigh},
+		{"$20M ETH raises two levels", ethTx(10_000), types.AlertLevelMedium, types.AlertLevelCritical},
+		{"capped at critical", ethTx(10_000), types.AlertLevelHigh, types.AlertLevelCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escalator.Escalate(tt.tx, tt.level); got != tt.want {
+				t.Errorf("Escalate = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueEscalator_TokenTransfer(t *testing.T) {
+	escalator := newTestEscalator()
+
+	// 50M USDC (6 decimals) via transfer(to, amount)
+	amount := new(big.Int).Mul(big.NewInt(50_000_000), big.NewInt(1_000_000))
+	tx := &types.PendingTransaction{
+		To:    &testUSDC,
+		Value: big.NewInt(0),
+		Input: packKnown(t, "transfer", common.HexToAddress("0x3"), amount),
+	}
+
+	if value := escalator.ValueUSD(tx); value != 50_000_000 {
+		t.Errorf("ValueUSD = %v, want 50000000", value)
+	}
+	if got := escalator.Escalate(tx, types.AlertLevelLow); got != types.AlertLevelHigh {
+		t.Errorf("Escalate = %s, want high", got)
+	}
+
+	// The same transfer of an unpriced token counts as zero value
+	unpriced := *tx
+	unpriced.To = ptrAddr(common.HexToAddress("0xdead"))
+	if got := escalator.Escalate(&unpriced, types.AlertLevelLow); got != types.AlertLevelLow {
+		t.Errorf("Unpriced token should not escalate, got %s", got)
+	}
+}
+
+func TestValueEscalator_Disabled(t *testing.T) {
+	escalator := NewValueEscalator(nil, nil)
+	if got := escalator.Escalate(ethTx(1_000_000), types.AlertLevelLow); got != types.AlertLevelLow {
+		t.Errorf("Escalator without tiers should not change the level, got %s", got)
+	}
+}
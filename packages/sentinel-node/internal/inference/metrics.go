@@ -0,0 +1,97 @@
+package inference
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Paths a result can take, the label of the latency histogram
+const (
+	pathGRPC     = "grpc"
+	pathFallback = "fallback"
+	pathCache    = "cache"
+	// pathSafe is an allowlisted call answered without the server
+	pathSafe = "safe_selector"
+)
+
+// bridgeMetrics records how analyses are answered and how long they take.
+// Like the gossip metrics it is a prometheus.Collector for the caller to
+// register, and a MultiBridge shares one between all its endpoints.
+type bridgeMetrics struct {
+	latency      *prometheus.HistogramVec
+	calls        *prometheus.CounterVec
+	openCircuits prometheus.GaugeFunc
+}
+
+func newBridgeMetrics(openCircuits func() int) *bridgeMetrics {
+	return &bridgeMetrics{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sentinel_inference_latency_seconds",
+			Help:    "Time to analyze a transaction, by how it was answered",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"path"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sentinel_inference_calls_total",
+			Help: "Inference server calls by outcome; circuit_open counts analyses that fell back without a call",
+		}, []string{"outcome"}),
+		openCircuits: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "sentinel_inference_open_circuits",
+			Help: "Inference servers whose circuit breaker is open",
+		}, func() float64 { return float64(openCircuits()) }),
+	}
+}
+
+func (m *bridgeMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.latency.Describe(ch)
+	m.calls.Describe(ch)
+	m.openCircuits.Describe(ch)
+}
+
+func (m *bridgeMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.latency.Collect(ch)
+	m.calls.Collect(ch)
+	m.openCircuits.Collect(ch)
+}
+
+// The recording methods are no-ops on a nil receiver so a Bridge built as
+// a struct literal in tests still works
+
+func (m *bridgeMetrics) analyzed(path string, started time.Time) {
+	if m == nil {
+		return
+	}
+	m.latency.WithLabelValues(path).Observe(time.Since(started).Seconds())
+}
+
+func (m *bridgeMetrics) callSucceeded() {
+	if m == nil {
+		return
+	}
+	m.calls.WithLabelValues("success").Inc()
+}
+
+func (m *bridgeMetrics) callFailed() {
+	if m == nil {
+		return
+	}
+	m.calls.WithLabelValues("failure").Inc()
+}
+
+func (m *bridgeMetrics) circuitOpenFallback() {
+	if m == nil {
+		return
+	}
+	m.calls.WithLabelValues("circuit_open").Inc()
+}
+
+// Metrics returns the bridge's inference metrics for registration with a
+// Prometheus registry
+func (b *Bridge) Metrics() prometheus.Collector {
+	return b.metrics
+}
+
+// Metrics returns the inference metrics of all endpoints
+func (m *MultiBridge) Metrics() prometheus.Collector {
+	return m.local.metrics
+}
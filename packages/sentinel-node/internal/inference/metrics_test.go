@@ -0,0 +1,66 @@
+package inference
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// metricValue reads a counter, gauge or histogram sample count
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	switch {
+	case out.Counter != nil:
+		return out.Counter.GetValue()
+	case out.Gauge != nil:
+		return out.Gauge.GetValue()
+	case out.Histogram != nil:
+		return float64(out.Histogram.GetSampleCount())
+	}
+	return 0
+}
+
+func TestBridge_MetricsObserveAnalyses(t *testing.T) {
+	bridge := newMockClientBridge(&mockInferenceClient{}, 300*time.Millisecond)
+	bridge.cache = newResultCache(ResultCacheConfig{Size: 10})
+	m := bridge.metrics
+
+	tx := cacheTestTx()
+	bridge.Analyze(context.Background(), tx)
+	bridge.Analyze(context.Background(), tx)
+
+	// The server goes away; the circuit is opened and analyses fall back
+	bridge.client = failingUnaryClient{}
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		bridge.recordFailure()
+	}
+	other := cacheTestTx()
+	other.Hash[0] = 1
+	bridge.Analyze(context.Background(), other)
+
+	if v := metricValue(t, m.latency.WithLabelValues(pathGRPC).(prometheus.Metric)); v != 1 {
+		t.Errorf("Expected 1 grpc latency observation, got %v", v)
+	}
+	if v := metricValue(t, m.latency.WithLabelValues(pathCache).(prometheus.Metric)); v != 1 {
+		t.Errorf("Expected 1 cache latency observation, got %v", v)
+	}
+	if v := metricValue(t, m.latency.WithLabelValues(pathFallback).(prometheus.Metric)); v != 1 {
+		t.Errorf("Expected 1 fallback latency observation, got %v", v)
+	}
+	if v := metricValue(t, m.calls.WithLabelValues("success")); v != 1 {
+		t.Errorf("Expected 1 successful call, got %v", v)
+	}
+	if v := metricValue(t, m.calls.WithLabelValues("circuit_open")); v != 1 {
+		t.Errorf("Expected 1 circuit-open fallback, got %v", v)
+	}
+	if v := metricValue(t, m.openCircuits); v != 1 {
+		t.Errorf("Expected the open circuit reported, got %v", v)
+	}
+}
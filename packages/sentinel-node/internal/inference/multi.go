@@ -0,0 +1,305 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// MultiBridgeConfig configures analysis against redundant inference servers
+type MultiBridgeConfig struct {
+	// Bridge holds the settings shared by every endpoint and the node-side
+	// signals; its Address is ignored
+	Bridge BridgeConfig
+	// Addresses are the inference servers queried for every transaction
+	Addresses []string
+	// Quorum is how many servers must flag a transaction for it to count as
+	// suspicious (0 = simple majority)
+	Quorum int
+	// LoadBalance sends each transaction to a single server, the least
+	// loaded one with a closed circuit, instead of all of them; Quorum is
+	// ignored
+	LoadBalance bool
+}
+
+// MultiBridge fans each transaction out to several inference servers and
+// only reports it as suspicious when a quorum of them agree, or with
+// LoadBalance spreads transactions across them. Every endpoint keeps its own
+// circuit breaker, so one failing server is skipped without affecting the
+// others.
+type MultiBridge struct {
+	local     *Bridge
+	endpoints []*Bridge
+	quorum    int
+	balance   bool
+	load      *endpointLoad
+}
+
+// NewMultiBridge connects to every configured address
+func NewMultiBridge(cfg MultiBridgeConfig) (*MultiBridge, error) {
+	if len(cfg.Addresses) == 0 {
+		return nil, fmt.Errorf("at least one inference address is required")
+	}
+
+	quorum := cfg.Quorum
+	if cfg.LoadBalance {
+		// Any one server's verdict stands
+		quorum = 1
+	} else if quorum == 0 {
+		quorum = len(cfg.Addresses)/2 + 1
+	}
+	if quorum < 0 || quorum > len(cfg.Addresses) {
+		return nil, fmt.Errorf("quorum %d out of range for %d inference servers", quorum, len(cfg.Addresses))
+	}
+
+	// Node-side signals and the heuristic fallback run once, locally
+	localCfg := cfg.Bridge
+	localCfg.Address = ""
+	local, err := NewBridge(localCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*Bridge, 0, len(cfg.Addresses))
+	for _, addr := range cfg.Addresses {
+		endpoint, err := NewBridge(BridgeConfig{
+			Address:          addr,
+			TLS:              cfg.Bridge.TLS,
+			AllowInsecure:    cfg.Bridge.AllowInsecure,
+			Streaming:        cfg.Bridge.Streaming,
+			Timeout:          cfg.Bridge.Timeout,
+			MaxRetries:       cfg.Bridge.MaxRetries,
+			AnomalyThreshold: cfg.Bridge.AnomalyThreshold,
+			DecodeCalldata:   cfg.Bridge.DecodeCalldata,
+			ABIDir:           cfg.Bridge.ABIDir,
+			Logger:           cfg.Bridge.Logger.With().Str("endpoint", addr).Logger(),
+		})
+		if err != nil {
+			for _, started := range endpoints {
+				started.Close()
+			}
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	// One set of metrics covers every endpoint
+	metrics := newBridgeMetrics(func() int {
+		open := 0
+		for _, endpoint := range endpoints {
+			if endpoint.isCircuitOpen() {
+				open++
+			}
+		}
+		return open
+	})
+	local.metrics = metrics
+	for _, endpoint := range endpoints {
+		endpoint.metrics = metrics
+	}
+
+	return &MultiBridge{
+		local:     local,
+		endpoints: endpoints,
+		quorum:    quorum,
+		balance:   cfg.LoadBalance,
+		load:      newEndpointLoad(len(endpoints)),
+	}, nil
+}
+
+// Start runs health checks and reconnection for every endpoint
+func (m *MultiBridge) Start(ctx context.Context) {
+	for _, endpoint := range m.endpoints {
+		endpoint.Start(ctx)
+	}
+}
+
+// Close closes every endpoint connection
+func (m *MultiBridge) Close() error {
+	var firstErr error
+	for _, endpoint := range m.endpoints {
+		if err := endpoint.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// AnalyzeWithContext is Bridge.AnalyzeWithContext across the endpoints
+func (m *MultiBridge) AnalyzeWithContext(ctx context.Context, tx *types.PendingTransaction, recent []*types.PendingTransaction) (*types.InferenceResult, error) {
+	result, err := m.Analyze(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if m.local.sandwiches.brackets(tx, recent) {
+		m.local.raiseScore(result, "sandwich_suspected", sandwichScoreBoost)
+	}
+	return result, nil
+}
+
+// Analyze queries all endpoints concurrently and combines their verdicts,
+// or with LoadBalance asks just one. When fewer than a quorum of servers
+// answer, the local heuristics decide.
+func (m *MultiBridge) Analyze(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
+	start := time.Now()
+
+	if result, ok := m.local.cache.get(tx.Hash); ok {
+		result.LatencyMs = float64(time.Since(start).Milliseconds())
+		m.local.metrics.analyzed(pathCache, start)
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.local.timeout)
+	defer cancel()
+
+	if result, ok := m.local.preClassify(ctx, tx, start); ok {
+		m.local.cache.put(tx.Hash, result, false)
+		m.local.metrics.analyzed(pathSafe, start)
+		return result, nil
+	}
+
+	var result *types.InferenceResult
+	if m.balance {
+		result = m.balancedAnalysis(ctx, tx)
+	} else {
+		result = m.quorumAnalysis(ctx, tx)
+	}
+	path := pathGRPC
+	fallback := result == nil
+	if fallback {
+		path = pathFallback
+		result = m.local.fallbackAnalysis(tx, start)
+		result.RiskIndicators = append(result.RiskIndicators, "inference_quorum_unavailable")
+	}
+
+	m.local.applySignals(ctx, tx, result)
+	result.LatencyMs = float64(time.Since(start).Milliseconds())
+	m.local.cache.put(tx.Hash, result, fallback)
+	m.local.metrics.analyzed(path, start)
+	return result, nil
+}
+
+// quorumAnalysis asks every endpoint and combines their verdicts
+func (m *MultiBridge) quorumAnalysis(ctx context.Context, tx *types.PendingTransaction) *types.InferenceResult {
+	verdicts := make([]*types.InferenceResult, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range m.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *Bridge) {
+			defer wg.Done()
+			server := endpoint.serverAnalysis
+			if endpoint.streaming {
+				server = endpoint.streamAnalysis
+			}
+			m.load.outstanding[i].Add(1)
+			defer m.load.outstanding[i].Add(-1)
+			if result, err := server(ctx, tx); err == nil {
+				verdicts[i] = result
+			}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	return m.combine(verdicts)
+}
+
+// combine reduces the endpoint verdicts (nil for servers that didn't answer)
+// to one result, or returns nil when too few servers answered to decide.
+// A quorum of flags yields the score every quorum member reached, with their
+// indicators merged; otherwise the highest-scoring clean verdict is used.
+func (m *MultiBridge) combine(verdicts []*types.InferenceResult) *types.InferenceResult {
+	var flagged, cleared []*types.InferenceResult
+	for _, verdict := range verdicts {
+		switch {
+		case verdict == nil:
+		case verdict.IsSuspicious:
+			flagged = append(flagged, verdict)
+		default:
+			cleared = append(cleared, verdict)
+		}
+	}
+
+	if len(flagged)+len(cleared) < m.quorum {
+		return nil
+	}
+
+	byScore := func(results []*types.InferenceResult) {
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].AnomalyScore > results[j].AnomalyScore
+		})
+	}
+	byScore(flagged)
+	byScore(cleared)
+
+	if len(flagged) >= m.quorum {
+		result := *flagged[m.quorum-1]
+		result.RiskIndicators = mergeIndicators(flagged)
+		return &result
+	}
+
+	result := *cleared[0]
+	result.RiskIndicators = append([]string(nil), result.RiskIndicators...)
+	if len(flagged) > 0 {
+		result.RiskIndicators = append(result.RiskIndicators, "inference_quorum_disagreement")
+	}
+	return &result
+}
+
+// Health checks every endpoint and succeeds when at least a quorum of them
+// report healthy, since fewer can't flag anything. The response is that of
+// the first healthy endpoint.
+func (m *MultiBridge) Health(ctx context.Context) (*pb.HealthResponse, error) {
+	responses := make([]*pb.HealthResponse, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range m.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *Bridge) {
+			defer wg.Done()
+			if resp, err := endpoint.Health(ctx); err == nil && resp.GetHealthy() {
+				responses[i] = resp
+			}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	var healthy []*pb.HealthResponse
+	for _, resp := range responses {
+		if resp != nil {
+			healthy = append(healthy, resp)
+		}
+	}
+	if len(healthy) < m.quorum {
+		return nil, fmt.Errorf("%d of %d inference servers healthy, quorum is %d", len(healthy), len(m.endpoints), m.quorum)
+	}
+	return healthy[0], nil
+}
+
+// QuickFilter delegates to the local bridge's pre-filter
+func (m *MultiBridge) QuickFilter(tx *types.PendingTransaction) bool {
+	return m.local.QuickFilter(tx)
+}
+
+// Observe feeds the local cross-transaction signals
+func (m *MultiBridge) Observe(tx *types.PendingTransaction) {
+	m.local.Observe(tx)
+}
+
+// mergeIndicators returns the union of the results' indicators in first-seen order
+func mergeIndicators(results []*types.InferenceResult) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, result := range results {
+		for _, indicator := range result.RiskIndicators {
+			if !seen[indicator] {
+				seen[indicator] = true
+				merged = append(merged, indicator)
+			}
+		}
+	}
+	return merged
+}
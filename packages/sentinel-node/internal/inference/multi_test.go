@@ -0,0 +1,205 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// verdictClient answers every request with a fixed verdict, or fails
+type verdictClient struct {
+	pb.SentinelInferenceClient
+	suspicious bool
+	score      float64
+	indicator  string
+	fail       bool
+	calls      atomic.Int32
+}
+
+func (v *verdictClient) Analyze(ctx context.Context, in *pb.AnalyzeRequest, opts ...grpc.CallOption) (*pb.AnalyzeResponse, error) {
+	v.calls.Add(1)
+	if v.fail {
+		return nil, errors.New("server unavailable")
+	}
+	return &pb.AnalyzeResponse{
+		TxHash:         in.TxHash,
+		IsSuspicious:   v.suspicious,
+		AnomalyScore:   v.score,
+		RiskIndicators: []string{v.indicator},
+	}, nil
+}
+
+func (v *verdictClient) Health(ctx context.Context, in *pb.HealthRequest, opts ...grpc.CallOption) (*pb.HealthResponse, error) {
+	if v.fail {
+		return nil, errors.New("server unavailable")
+	}
+	return &pb.HealthResponse{Healthy: true}, nil
+}
+
+func flags(score float64, indicator string) *verdictClient {
+	return &verdictClient{suspicious: true, score: score, indicator: indicator}
+}
+
+func clears(score float64) *verdictClient {
+	return &verdictClient{score: score, indicator: "server_clean"}
+}
+
+func newMockMultiBridge(t *testing.T, quorum int, clients ...*verdictClient) *MultiBridge {
+	t.Helper()
+
+	addresses := make([]string, len(clients))
+	multi, err := NewMultiBridge(MultiBridgeConfig{
+		Bridge:    BridgeConfig{Timeout: 300 * time.Millisecond, MaxRetries: 1, Logger: zerolog.Nop()},
+		Addresses: addresses,
+		Quorum:    quorum,
+	})
+	if err != nil {
+		t.Fatalf("NewMultiBridge failed: %v", err)
+	}
+
+	for i, client := range clients {
+		multi.endpoints[i].client = client
+		multi.endpoints[i].connected = true
+	}
+	return multi
+}
+
+func multiTestTx() *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   100000,
+		Input: []byte{0x12, 0x34, 0x56, 0x78},
+	}
+}
+
+func TestMultiBridge_Quorum(t *testing.T) {
+	tests := []struct {
+		name           string
+		quorum         int
+		clients        []*verdictClient
+		wantSuspicious bool
+		wantScore      float64
+		wantIndicator  string
+	}{
+		{
+			name:           "unanimous",
+			clients:        []*verdictClient{flags(0.9, "a"), flags(0.8, "b"), flags(0.7, "c")},
+			wantSuspicious: true,
+			wantScore:      0.8,
+			wantIndicator:  "c",
+		},
+		{
+			name:           "majority flags",
+			clients:        []*verdictClient{flags(0.9, "a"), clears(0.2), flags(0.7, "b")},
+			wantSuspicious: true,
+			wantScore:      0.7,
+			wantIndicator:  "a",
+		},
+		{
+			name:          "minority flags",
+			clients:       []*verdictClient{flags(0.95, "a"), clears(0.3), clears(0.2)},
+			wantScore:     0.3,
+			wantIndicator: "inference_quorum_disagreement",
+		},
+		{
+			name:          "explicit quorum not met",
+			quorum:        3,
+			clients:       []*verdictClient{flags(0.9, "a"), flags(0.8, "b"), clears(0.1)},
+			wantScore:     0.1,
+			wantIndicator: "inference_quorum_disagreement",
+		},
+		{
+			name:           "failed server doesn't count",
+			clients:        []*verdictClient{flags(0.9, "a"), flags(0.85, "b"), {fail: true}},
+			wantSuspicious: true,
+			wantScore:      0.85,
+		},
+		{
+			name:          "too few servers answer",
+			clients:       []*verdictClient{flags(0.9, "a"), {fail: true}, {fail: true}},
+			wantIndicator: "inference_quorum_unavailable",
+			wantScore:     -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multi := newMockMultiBridge(t, tt.quorum, tt.clients...)
+			defer multi.Close()
+
+			result, err := multi.Analyze(context.Background(), multiTestTx())
+			if err != nil {
+				t.Fatalf("Analyze failed: %v", err)
+			}
+
+			if result.IsSuspicious != tt.wantSuspicious {
+				t.Errorf("IsSuspicious = %v, want %v", result.IsSuspicious, tt.wantSuspicious)
+			}
+			if tt.wantScore >= 0 && result.AnomalyScore != tt.wantScore {
+				t.Errorf("AnomalyScore = %v, want %v", result.AnomalyScore, tt.wantScore)
+			}
+			if tt.wantIndicator != "" && !hasIndicator(result, tt.wantIndicator) {
+				t.Errorf("Expected indicator %q, got %v", tt.wantIndicator, result.RiskIndicators)
+			}
+		})
+	}
+}
+
+func TestMultiBridge_EndpointCircuitBreaker(t *testing.T) {
+	failing := &verdictClient{fail: true}
+	multi := newMockMultiBridge(t, 2, flags(0.9, "a"), flags(0.8, "b"), failing)
+	defer multi.Close()
+
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		multi.Analyze(context.Background(), multiTestTx())
+	}
+	calls := failing.calls.Load()
+
+	result, _ := multi.Analyze(context.Background(), multiTestTx())
+	if failing.calls.Load() != calls {
+		t.Error("Expected the failing endpoint's breaker to skip further calls")
+	}
+	if !result.IsSuspicious {
+		t.Error("Healthy endpoints should still reach quorum")
+	}
+
+	if state, _, _ := multi.endpoints[0].GetCircuitBreakerStatus(); state != CircuitClosed {
+		t.Error("Healthy endpoint's breaker should stay closed")
+	}
+}
+
+func TestMultiBridge_HealthNeedsQuorum(t *testing.T) {
+	healthy := newMockMultiBridge(t, 2, clears(0.1), clears(0.1), &verdictClient{fail: true})
+	defer healthy.Close()
+	if resp, err := healthy.Health(context.Background()); err != nil || !resp.Healthy {
+		t.Errorf("Expected healthy with a quorum of servers up, got %v, %v", resp, err)
+	}
+
+	degraded := newMockMultiBridge(t, 2, clears(0.1), &verdictClient{fail: true}, &verdictClient{fail: true})
+	defer degraded.Close()
+	if _, err := degraded.Health(context.Background()); err == nil {
+		t.Error("Expected an error with fewer than a quorum of servers up")
+	}
+}
+
+func TestNewMultiBridge_InvalidQuorum(t *testing.T) {
+	if _, err := NewMultiBridge(MultiBridgeConfig{Addresses: []string{"", ""}, Quorum: 3}); err == nil {
+		t.Error("Expected error for quorum above server count")
+	}
+	if _, err := NewMultiBridge(MultiBridgeConfig{}); err == nil {
+		t.Error("Expected error without addresses")
+	}
+}
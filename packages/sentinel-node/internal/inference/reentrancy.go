@@ -0,0 +1,176 @@
+package inference
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultReentrancyRepeats         = 3
+	defaultReentrancyWithdrawRepeats = 2
+	// maxMulticallDepth bounds how far multicalls nested in multicalls are
+	// unpacked
+	maxMulticallDepth = 4
+)
+
+// ReentrancyConfig configures the reentrancy heuristic. Mempool calldata
+// can't be traced, so it looks for a batch that calls the same function on
+// the same contract over and over, the shape a reentrant drain usually
+// takes when it is driven from a multicall.
+type ReentrancyConfig struct {
+	// Repeats is how many calls with the same selector to the same target a
+	// batch needs to be flagged (0 = 3)
+	Repeats int
+	// WithdrawRepeats is the same for withdraw, redeem and borrow
+	// entrypoints, which are rarely repeated legitimately (0 = 2)
+	WithdrawRepeats int
+}
+
+// withdrawSelectors are the entrypoints reentrancy exploits drain through
+var withdrawSelectors = map[[4]byte]bool{
+	{0x2e, 0x1a, 0x7d, 0x4d}: true, // withdraw(uint256) (WETH, vaults)
+	{0xb4, 0x60, 0xaf, 0x94}: true, // withdraw(uint256,address,address) (ERC-4626)
+	{0xba, 0x08, 0x76, 0x52}: true, // redeem(uint256,address,address) (ERC-4626)
+	{0x69, 0x32, 0x8d, 0xec}: true, // withdraw(address,uint256,address) (Aave)
+	{0xa4, 0x15, 0xbc, 0xad}: true, // borrow(address,uint256,uint256,uint16,address) (Aave)
+	{0xdb, 0x00, 0x6a, 0x75}: true, // redeem(uint256) (Compound)
+	{0x85, 0x2a, 0x12, 0xe3}: true, // redeemUnderlying(uint256) (Compound)
+	{0xc5, 0xeb, 0xea, 0xec}: true, // borrow(uint256) (Compound)
+	{0x53, 0x12, 0xea, 0x8e}: true, // emergencyWithdraw(uint256)
+}
+
+// multicallABI covers the batching entrypoints of routers (calls to
+// themselves) and of Multicall2/3 (calls to arbitrary targets)
+const multicallABI = `[
+	{"name":"multicall","type":"function","inputs":[{"name":"data","type":"bytes[]"}]},
+	{"name":"multicall","type":"function","inputs":[
+		{"name":"deadline","type":"uint256"},{"name":"data","type":"bytes[]"}]},
+	{"name":"aggregate","type":"function","inputs":[
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}]},
+	{"name":"tryAggregate","type":"function","inputs":[
+		{"name":"requireSuccess","type":"bool"},
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}]},
+	{"name":"aggregate3","type":"function","inputs":[
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},
+			{"name":"callData","type":"bytes"}]}]},
+	{"name":"aggregate3Value","type":"function","stateMutability":"payable","inputs":[
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},
+			{"name":"value","type":"uint256"},{"name":"callData","type":"bytes"}]}]}
+]`
+
+var parsedMulticallABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// batchedCall is one call made from inside a multicall
+type batchedCall struct {
+	target   common.Address
+	selector [4]byte
+}
+
+type reentrancyDetector struct {
+	repeats         int
+	withdrawRepeats int
+}
+
+func newReentrancyDetector(cfg ReentrancyConfig) *reentrancyDetector {
+	d := &reentrancyDetector{repeats: cfg.Repeats, withdrawRepeats: cfg.WithdrawRepeats}
+	if d.repeats <= 0 {
+		d.repeats = defaultReentrancyRepeats
+	}
+	if d.withdrawRepeats <= 0 {
+		d.withdrawRepeats = defaultReentrancyWithdrawRepeats
+	}
+	return d
+}
+
+// suspected reports whether tx batches enough calls to one function of one
+// contract to look like a reentrant drain
+func (d *reentrancyDetector) suspected(tx *types.PendingTransaction) bool {
+	if tx.To == nil {
+		return false
+	}
+	calls, ok := unpackMulticall(*tx.To, tx.Input, 0)
+	if !ok {
+		return false
+	}
+
+	counts := make(map[batchedCall]int, len(calls))
+	for _, call := range calls {
+		counts[call]++
+		limit := d.repeats
+		if withdrawSelectors[call.selector] {
+			limit = d.withdrawRepeats
+		}
+		if counts[call] >= limit {
+			return true
+		}
+	}
+	return false
+}
+
+// unpackMulticall returns the calls a multicall to target makes, with
+// nested multicalls flattened. It returns false if input isn't a multicall.
+func unpackMulticall(target common.Address, input []byte, depth int) ([]batchedCall, bool) {
+	if len(input) < 4 || depth >= maxMulticallDepth {
+		return nil, false
+	}
+	method, err := parsedMulticallABI.MethodById(input[:4])
+	if err != nil {
+		return nil, false
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, false
+	}
+
+	var calls []batchedCall
+	add := func(to common.Address, data []byte) {
+		if nested, ok := unpackMulticall(to, data, depth+1); ok {
+			calls = append(calls, nested...)
+			return
+		}
+		if len(data) >= 4 {
+			call := batchedCall{target: to}
+			copy(call.selector[:], data[:4])
+			calls = append(calls, call)
+		}
+	}
+
+	// The batch is always the last argument
+	switch batch := args[len(args)-1].(type) {
+	case [][]byte:
+		// Router multicalls delegate to the router itself
+		for _, data := range batch {
+			add(target, data)
+		}
+	default:
+		v := reflect.ValueOf(batch)
+		if v.Kind() != reflect.Slice {
+			return nil, false
+		}
+		for i := 0; i < v.Len(); i++ {
+			call := v.Index(i)
+			to, ok := call.FieldByName("Target").Interface().(common.Address)
+			if !ok {
+				return nil, false
+			}
+			data, _ := call.FieldByName("CallData").Interface().([]byte)
+			add(to, data)
+		}
+	}
+	return calls, true
+}
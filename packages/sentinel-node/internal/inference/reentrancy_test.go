@@ -0,0 +1,120 @@
+package inference
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var testVault = common.HexToAddress("0x7a017")
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+func withdrawCall(amount int64) []byte {
+	return append([]byte{0x2e, 0x1a, 0x7d, 0x4d}, common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)...)
+}
+
+func packMulticall(t *testing.T, method string, args ...interface{}) []byte {
+	t.Helper()
+	input, err := parsedMulticallABI.Pack(method, args...)
+	if err != nil {
+		t.Fatalf("Pack %s failed: %v", method, err)
+	}
+	return input
+}
+
+func multicallTx(to common.Address, input []byte) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:     common.HexToHash("0x3e"),
+		To:       &to,
+		Value:    big.NewInt(0),
+		Gas:      500000,
+		GasPrice: big.NewInt(1),
+		Input:    input,
+	}
+}
+
+func TestBridge_HeuristicFlagsRepeatedWithdraw(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+
+	// A vault multicall that withdraws from itself repeatedly
+	input := packMulticall(t, "multicall", [][]byte{withdrawCall(1), withdrawCall(1), withdrawCall(1)})
+	result := bridge.heuristicAnalysis(multicallTx(testVault, input))
+	if !hasIndicator(result, "reentrancy_suspected") {
+		t.Fatalf("Expected reentrancy_suspected, got %v", result.RiskIndicators)
+	}
+	if result.AnomalyScore < DefaultHeuristicWeights.Reentrancy {
+		t.Errorf("Expected the reentrancy weight in the score, got %v", result.AnomalyScore)
+	}
+
+	// One withdrawal among other calls is routine
+	input = packMulticall(t, "multicall", [][]byte{withdrawCall(1), {0xa9, 0x05, 0x9c, 0xbb}})
+	result = bridge.heuristicAnalysis(multicallTx(testVault, input))
+	if hasIndicator(result, "reentrancy_suspected") {
+		t.Errorf("Expected a single withdrawal not flagged, got %v", result.RiskIndicators)
+	}
+}
+
+func TestReentrancyDetector_Multicall3(t *testing.T) {
+	detector := newReentrancyDetector(ReentrancyConfig{})
+	multicall3 := common.HexToAddress("0xca11bde05977b3631167028862be2a173976ca11")
+	other := common.HexToAddress("0x0be")
+
+	tests := []struct {
+		name  string
+		calls []multicall3Call
+		want  bool
+	}{
+		{
+			name: "withdraws repeated against one target",
+			calls: []multicall3Call{
+				{Target: testVault, CallData: withdrawCall(1)},
+				{Target: testVault, CallData: withdrawCall(2)},
+			},
+			want: true,
+		},
+		{
+			name: "withdraws spread over targets",
+			calls: []multicall3Call{
+				{Target: testVault, CallData: withdrawCall(1)},
+				{Target: other, CallData: withdrawCall(1)},
+			},
+			want: false,
+		},
+		{
+			name: "other calls repeated below the limit",
+			calls: []multicall3Call{
+				{Target: testVault, CallData: []byte{0x70, 0xa0, 0x82, 0x31}},
+				{Target: testVault, CallData: []byte{0x70, 0xa0, 0x82, 0x31}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := multicallTx(multicall3, packMulticall(t, "aggregate3", tt.calls))
+			if got := detector.suspected(tx); got != tt.want {
+				t.Errorf("Expected suspected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Withdrawals split across nested multicalls still add up
+	inner := packMulticall(t, "multicall", [][]byte{withdrawCall(1)})
+	tx := multicallTx(multicall3, packMulticall(t, "aggregate3", []multicall3Call{
+		{Target: testVault, CallData: inner},
+		{Target: testVault, CallData: withdrawCall(1)},
+	}))
+	if !detector.suspected(tx) {
+		t.Error("Expected withdrawals in a nested multicall counted")
+	}
+}
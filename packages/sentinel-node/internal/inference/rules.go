@@ -0,0 +1,204 @@
+package inference
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+// flashLoanSelectors are the flash-loan entrypoints, keyed by hex selector.
+// They are the built-in selector rules and are always recognised.
+var flashLoanSelectors = map[string]bool{
+	"5cffe9de": true, // flashLoan
+	"ab9c4b5d": true, // flashLoan (Aave v3)
+	"c1a8a1f5": true, // flash
+	"490e6cbc": true, // flash (Uniswap v3)
+}
+
+// SelectorRule adds Score to the heuristic score of any call to Selector
+// and reports it as Indicator
+type SelectorRule struct {
+	Selector  string  `yaml:"selector"`
+	Indicator string  `yaml:"indicator"`
+	Score     float64 `yaml:"score"`
+
+	// builtin rules score HeuristicWeights.FlashLoan instead of Score
+	builtin bool
+}
+
+// selectorRulesFile is the layout of a rules file. YAML and JSON are both
+// accepted, e.g.
+//
+//	selectors:
+//	  - selector: "0x5cffe9de"
+//	    indicator: flash_loan_detected
+//	    score: 0.4
+type selectorRulesFile struct {
+	Selectors []SelectorRule `yaml:"selectors"`
+}
+
+// SelectorRules maps 4-byte selectors to the indicator and score the
+// heuristic assigns their calls. Rules from the file are layered over the
+// built-in flash-loan rules, so a file entry with score 0 switches one off.
+// The file is re-read by Reload and, while Watch runs, whenever it changes;
+// a file that fails to load leaves the previous rules in place.
+type SelectorRules struct {
+	path         string
+	pollInterval time.Duration
+	logger       zerolog.Logger
+
+	mu      sync.RWMutex
+	rules   map[[4]byte]SelectorRule
+	modTime time.Time
+}
+
+// NewSelectorRules loads the rules at path. A missing file, or an empty
+// path, leaves only the built-in rules. pollInterval is how often Watch
+// checks the file for changes.
+func NewSelectorRules(path string, pollInterval time.Duration, logger zerolog.Logger) (*SelectorRules, error) {
+	r := &SelectorRules{
+		path:         path,
+		pollInterval: pollInterval,
+		logger:       logger,
+		rules:        defaultSelectorRules(),
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func defaultSelectorRules() map[[4]byte]SelectorRule {
+	rules := make(map[[4]byte]SelectorRule, len(flashLoanSelectors))
+	for value := range flashLoanSelectors {
+		var selector [4]byte
+		hex.Decode(selector[:], []byte(value))
+		rules[selector] = SelectorRule{
+			Selector:  "0x" + value,
+			Indicator: "flash_loan_detected",
+			Score:     DefaultHeuristicWeights.FlashLoan,
+			builtin:   true,
+		}
+	}
+	return rules
+}
+
+// Reload re-reads the rules file
+func (r *SelectorRules) Reload() error {
+	if r.path == "" {
+		return nil
+	}
+
+	info, err := os.Stat(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read selector rules: %w", err)
+	}
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read selector rules: %w", err)
+	}
+	rules, err := parseSelectorRules(data)
+	if err != nil {
+		return fmt.Errorf("invalid selector rules in %s: %w", r.path, err)
+	}
+
+	r.mu.Lock()
+	r.rules = rules
+	r.modTime = info.ModTime()
+	r.mu.Unlock()
+
+	r.logger.Info().Str("path", r.path).Int("rules", len(rules)).Msg("Loaded selector rules")
+	return nil
+}
+
+func parseSelectorRules(data []byte) (map[[4]byte]SelectorRule, error) {
+	var file selectorRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	rules := defaultSelectorRules()
+	for i, rule := range file.Selectors {
+		raw, err := hexutil.Decode(rule.Selector)
+		if err != nil || len(raw) != 4 {
+			return nil, fmt.Errorf("rule %d: selector %q is not 4 hex bytes", i, rule.Selector)
+		}
+		if rule.Indicator == "" {
+			return nil, fmt.Errorf("rule %d: indicator is required", i)
+		}
+		if rule.Score < 0 || rule.Score > 1 {
+			return nil, fmt.Errorf("rule %d: score %v outside [0, 1]", i, rule.Score)
+		}
+
+		var selector [4]byte
+		copy(selector[:], raw)
+		if rule.Score == 0 {
+			delete(rules, selector)
+			continue
+		}
+		rules[selector] = rule
+	}
+	return rules, nil
+}
+
+// Match returns the rule for a call's selector, if there is one
+func (r *SelectorRules) Match(selector []byte) (SelectorRule, bool) {
+	if len(selector) < 4 {
+		return SelectorRule{}, false
+	}
+	var key [4]byte
+	copy(key[:], selector)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[key]
+	return rule, ok
+}
+
+// Watch reloads the rules whenever the file's modification time changes,
+// until ctx is done. It returns straight away without a file or poll
+// interval.
+func (r *SelectorRules) Watch(ctx context.Context) {
+	if r.path == "" || r.pollInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(r.path)
+			if err != nil {
+				continue
+			}
+			r.mu.RLock()
+			changed := !info.ModTime().Equal(r.modTime)
+			r.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				r.logger.Warn().Err(err).Msg("Keeping previous selector rules")
+				// Warn once per change rather than on every tick
+				r.mu.Lock()
+				r.modTime = info.ModTime()
+				r.mu.Unlock()
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func writeRules(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func ruleTestTx(input []byte) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.HexToHash("0xabc"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: input,
+	}
+}
+
+func TestSelectorRules_CustomSelectorScoresAsConfigured(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selector_rules.yaml")
+	writeRules(t, path, `
+selectors:
+  - selector: "0xdeadbeef"
+    indicator: new_router_flash
+    score: 0.7
+`)
+
+	rules, err := NewSelectorRules(path, 0, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewSelectorRules failed: %v", err)
+	}
+	bridge, _ := NewBridge(BridgeConfig{SelectorRules: rules, Logger: zerolog.Nop()})
+
+	result := bridge.heuristicAnalysis(ruleTestTx([]byte{0xde, 0xad, 0xbe, 0xef}))
+	if !hasIndicator(result, "new_router_flash") || result.AnomalyScore != 0.7 {
+		t.Errorf("Expected new_router_flash scoring 0.7, got %v scoring %v", result.RiskIndicators, result.AnomalyScore)
+	}
+
+	// The built-in rules still apply alongside the file's
+	result = bridge.heuristicAnalysis(ruleTestTx([]byte{0x5c, 0xff, 0xe9, 0xde}))
+	if !hasIndicator(result, "flash_loan_detected") {
+		t.Errorf("Expected built-in flash-loan rule, got %v", result.RiskIndicators)
+	}
+}
+
+func TestSelectorRules_JSONAndDisablingBuiltins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selector_rules.json")
+	writeRules(t, path, `{"selectors": [{"selector": "0x5cffe9de", "indicator": "flash_loan_detected", "score": 0}]}`)
+
+	rules, err := NewSelectorRules(path, 0, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewSelectorRules failed: %v", err)
+	}
+	if _, ok := rules.Match([]byte{0x5c, 0xff, 0xe9, 0xde}); ok {
+		t.Error("Expected a zero score to switch the built-in rule off")
+	}
+	if _, ok := rules.Match([]byte{0x49, 0x0e, 0x6c, 0xbc}); !ok {
+		t.Error("Expected other built-in rules kept")
+	}
+}
+
+func TestSelectorRules_ReloadKeepsPreviousRulesOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selector_rules.yaml")
+	writeRules(t, path, "selectors:\n  - {selector: \"0xdeadbeef\", indicator: custom, score: 0.3}\n")
+
+	rules, err := NewSelectorRules(path, 0, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewSelectorRules failed: %v", err)
+	}
+
+	writeRules(t, path, "selectors:\n  - {selector: \"0xdead\", indicator: custom, score: 0.3}\n")
+	if err := rules.Reload(); err == nil {
+		t.Fatal("Expected an error for a short selector")
+	}
+	if rule, ok := rules.Match([]byte{0xde, 0xad, 0xbe, 0xef}); !ok || rule.Score != 0.3 {
+		t.Errorf("Expected the previous rules kept, got %+v", rule)
+	}
+
+	writeRules(t, path, "selectors:\n  - {selector: \"0xdeadbeef\", indicator: custom, score: 0.5}\n")
+	if err := rules.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if rule, _ := rules.Match([]byte{0xde, 0xad, 0xbe, 0xef}); rule.Score != 0.5 {
+		t.Errorf("Expected the reloaded score 0.5, got %v", rule.Score)
+	}
+}
+
+func TestSelectorRules_WatchReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selector_rules.yaml")
+
+	// A missing file leaves the built-in rules until one appears
+	rules, err := NewSelectorRules(path, 10*time.Millisecond, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewSelectorRules failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go rules.Watch(ctx)
+
+	writeRules(t, path, "selectors:\n  - {selector: \"0xdeadbeef\", indicator: custom, score: 0.3}\n")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := rules.Match([]byte{0xde, 0xad, 0xbe, 0xef}); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expected the new rules file picked up")
+}
@@ -0,0 +1,153 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	defaultSandwichWindow = 12 * time.Second
+	sandwichScoreBoost    = 0.4
+)
+
+// SandwichConfig configures detection of sandwich attacks against pending
+// swaps
+type SandwichConfig struct {
+	// Window is how far apart the attacker's swaps and the victim's may
+	// arrive in the mempool (0 = 12s, one block)
+	Window time.Duration
+}
+
+// sandwichDetector recognises a transaction that, together with another
+// from the same sender, brackets a victim's swap: the front-run swaps in the
+// victim's direction with at least the victim's gas price, the back-run
+// swaps back with at most it, all through the same router and token pair
+type sandwichDetector struct {
+	window time.Duration
+}
+
+func newSandwichDetector(cfg SandwichConfig) *sandwichDetector {
+	window := cfg.Window
+	if window == 0 {
+		window = defaultSandwichWindow
+	}
+	return &sandwichDetector{window: window}
+}
+
+// swapLeg is a pending router swap reduced to what the detector compares
+type swapLeg struct {
+	tx       *types.PendingTransaction
+	selector [4]byte
+	tokenIn  common.Address
+	tokenOut common.Address
+}
+
+func decodeSwapLeg(tx *types.PendingTransaction) (swapLeg, bool) {
+	if tx.To == nil {
+		return swapLeg{}, false
+	}
+	swap, ok := DecodeSwap(tx.Input, tx.Value)
+	if !ok || len(swap.Path) < 2 {
+		return swapLeg{}, false
+	}
+
+	leg := swapLeg{
+		tx:       tx,
+		tokenIn:  swap.Path[0],
+		tokenOut: swap.Path[len(swap.Path)-1],
+	}
+	copy(leg.selector[:], tx.Input[:4])
+	return leg, true
+}
+
+// samePool reports whether both swaps go through the same router and pair
+func (l swapLeg) samePool(other swapLeg) bool {
+	if *l.tx.To != *other.tx.To {
+		return false
+	}
+	return (l.tokenIn == other.tokenIn && l.tokenOut == other.tokenOut) ||
+		(l.tokenIn == other.tokenOut && l.tokenOut == other.tokenIn)
+}
+
+func (l swapLeg) sameDirection(other swapLeg) bool {
+	return l.tokenIn == other.tokenIn && l.tokenOut == other.tokenOut
+}
+
+// brackets reports whether tx is the front- or back-run of a sandwich
+// around a victim among recent
+func (d *sandwichDetector) brackets(tx *types.PendingTransaction, recent []*types.PendingTransaction) bool {
+	leg, ok := decodeSwapLeg(tx)
+	if !ok {
+		return false
+	}
+
+	legs := make([]swapLeg, 0, len(recent))
+	for _, other := range recent {
+		if other.Hash == tx.Hash || !d.withinWindow(tx, other) {
+			continue
+		}
+		if candidate, ok := decodeSwapLeg(other); ok && candidate.samePool(leg) {
+			legs = append(legs, candidate)
+		}
+	}
+
+	for _, other := range legs {
+		if other.tx.From != tx.From || other.sameDirection(leg) {
+			continue
+		}
+		for _, victim := range legs {
+			if victim.tx.From == tx.From {
+				continue
+			}
+			front, back := leg, other
+			if victim.sameDirection(other) {
+				front, back = other, leg
+			}
+			if victim.selector == front.selector && gasBrackets(front.tx, victim.tx, back.tx) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *sandwichDetector) withinWindow(a, b *types.PendingTransaction) bool {
+	if a.ReceivedAt.IsZero() || b.ReceivedAt.IsZero() {
+		return true
+	}
+	gap := a.ReceivedAt.Sub(b.ReceivedAt)
+	return gap <= d.window && gap >= -d.window
+}
+
+// gasBrackets reports whether the gas prices order front, victim and back
+// in that sequence within a block. Unknown prices don't rule it out.
+func gasBrackets(front, victim, back *types.PendingTransaction) bool {
+	frontPrice, victimPrice, backPrice := front.EffectiveGasPrice(), victim.EffectiveGasPrice(), back.EffectiveGasPrice()
+	if frontPrice == nil || victimPrice == nil || backPrice == nil {
+		return true
+	}
+	return inOrder(frontPrice, victimPrice) && inOrder(victimPrice, backPrice)
+}
+
+func inOrder(higher, lower *big.Int) bool {
+	return higher.Cmp(lower) >= 0
+}
+
+// AnalyzeWithContext analyzes tx like Analyze and additionally weighs it
+// against recent pending transactions, flagging it as sandwich_suspected
+// when it is one half of a front-run/back-run pair around a victim's swap
+func (b *Bridge) AnalyzeWithContext(ctx context.Context, tx *types.PendingTransaction, recent []*types.PendingTransaction) (*types.InferenceResult, error) {
+	result, err := b.Analyze(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if b.sandwiches.brackets(tx, recent) {
+		b.raiseScore(result, "sandwich_suspected", sandwichScoreBoost)
+	}
+	return result, nil
+}
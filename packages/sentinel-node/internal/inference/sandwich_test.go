@@ -0,0 +1,109 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var (
+	testAttacker = common.HexToAddress("0xbad")
+	testVictim   = common.HexToAddress("0x71c7")
+)
+
+func sandwichSwap(t *testing.T, hash string, from common.Address, tokenIn, tokenOut common.Address, gasPrice int64, at time.Time) *types.PendingTransaction {
+	t.Helper()
+	router := testRouter
+	return &types.PendingTransaction{
+		Hash:     common.HexToHash(hash),
+		From:     from,
+		To:       &router,
+		Value:    big.NewInt(0),
+		Gas:      300000,
+		GasPrice: big.NewInt(gasPrice),
+		Input: packSwap(t, "swapExactTokensForTokens",
+			big.NewInt(1000), big.NewInt(1),
+			[]common.Address{tokenIn, tokenOut},
+			from, big.NewInt(1700000000),
+		),
+		ReceivedAt: at,
+	}
+}
+
+func TestBridge_AnalyzeWithContext_SandwichSuspected(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+	now := time.Now()
+
+	victim := sandwichSwap(t, "0x01", testVictim, testWETH, testUSDC, 50, now)
+	frontRun := sandwichSwap(t, "0x02", testAttacker, testWETH, testUSDC, 51, now.Add(time.Second))
+	backRun := sandwichSwap(t, "0x03", testAttacker, testUSDC, testWETH, 49, now.Add(time.Second))
+
+	result, err := bridge.AnalyzeWithContext(context.Background(), backRun, []*types.PendingTransaction{victim, frontRun})
+	if err != nil {
+		t.Fatalf("AnalyzeWithContext failed: %v", err)
+	}
+	if !hasIndicator(result, "sandwich_suspected") {
+		t.Errorf("Expected the back-run flagged, got %v", result.RiskIndicators)
+	}
+
+	// The front-run is caught just the same once the back-run is seen
+	result, _ = bridge.AnalyzeWithContext(context.Background(), frontRun, []*types.PendingTransaction{victim, backRun})
+	if !hasIndicator(result, "sandwich_suspected") {
+		t.Errorf("Expected the front-run flagged, got %v", result.RiskIndicators)
+	}
+
+	// The victim's own swap is not the attack
+	result, _ = bridge.AnalyzeWithContext(context.Background(), victim, []*types.PendingTransaction{frontRun, backRun})
+	if hasIndicator(result, "sandwich_suspected") {
+		t.Errorf("Expected the victim not flagged, got %v", result.RiskIndicators)
+	}
+}
+
+func TestSandwichDetector_RequiresBracketing(t *testing.T) {
+	detector := newSandwichDetector(SandwichConfig{Window: 5 * time.Second})
+	now := time.Now()
+
+	victim := sandwichSwap(t, "0x01", testVictim, testWETH, testUSDC, 50, now)
+	backRun := sandwichSwap(t, "0x03", testAttacker, testUSDC, testWETH, 49, now)
+
+	tests := []struct {
+		name     string
+		frontRun *types.PendingTransaction
+		victim   *types.PendingTransaction
+	}{
+		{
+			name:     "front-run bids below the victim",
+			frontRun: sandwichSwap(t, "0x02", testAttacker, testWETH, testUSDC, 40, now),
+			victim:   victim,
+		},
+		{
+			name:     "attacker swaps outside the window",
+			frontRun: sandwichSwap(t, "0x02", testAttacker, testWETH, testUSDC, 51, now.Add(-10*time.Second)),
+			victim:   victim,
+		},
+		{
+			name:     "victim trades the other way",
+			frontRun: sandwichSwap(t, "0x02", testAttacker, testWETH, testUSDC, 51, now),
+			victim:   sandwichSwap(t, "0x01", testVictim, testUSDC, testWETH, 50, now),
+		},
+		{
+			name:     "front-run is another sender's",
+			frontRun: sandwichSwap(t, "0x02", common.HexToAddress("0xfeed"), testWETH, testUSDC, 51, now),
+			victim:   victim,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if detector.brackets(backRun, []*types.PendingTransaction{tt.victim, tt.frontRun}) {
+				t.Error("Expected no sandwich")
+			}
+		})
+	}
+}
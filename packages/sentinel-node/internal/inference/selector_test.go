@@ -0,0 +1,103 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var trustedRouter = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+
+func newSafeSelectorBridge(t *testing.T, client *mockInferenceClient) *Bridge {
+	t.Helper()
+
+	bridge, err := NewBridge(BridgeConfig{
+		Timeout:    300 * time.Millisecond,
+		MaxRetries: 1,
+		Approvals:  ApprovalConfig{TrustedSpenders: []common.Address{trustedRouter}},
+		SafeSelectors: SafeSelectorConfig{
+			Enabled:     true,
+			Selectors:   []string{"0xd0e30db0", "not-a-selector"}, // WETH deposit()
+			MaxApproval: big.NewInt(1_000_000),
+		},
+		Logger: zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	bridge.client = client
+	bridge.connected = true
+	return bridge
+}
+
+func selectorTestTx(input []byte) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   150000,
+		Input: input,
+	}
+}
+
+func TestBridge_SafeSelectors(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   *types.PendingTransaction
+		safe bool
+	}{
+		{"erc20 transfer", selectorTestTx(packKnown(t, "transfer", common.HexToAddress("0x3"), big.NewInt(100))), true},
+		{"configured selector", selectorTestTx([]byte{0xd0, 0xe3, 0x0d, 0xb0}), true},
+		{"bounded approve to trusted spender", selectorTestTx(packKnown(t, "approve", trustedRouter, big.NewInt(500))), true},
+		{"approve above limit", selectorTestTx(packKnown(t, "approve", trustedRouter, big.NewInt(2_000_000))), false},
+		{"approve to untrusted spender", selectorTestTx(packKnown(t, "approve", common.HexToAddress("0xbad"), big.NewInt(500))), false},
+		{"transferFrom", selectorTestTx(packKnown(t, "transferFrom", common.HexToAddress("0x3"), common.HexToAddress("0x4"), big.NewInt(1))), false},
+		{"unknown selector", selectorTestTx([]byte{0x12, 0x34, 0x56, 0x78}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockInferenceClient{}
+			bridge := newSafeSelectorBridge(t, client)
+
+			result, err := bridge.Analyze(context.Background(), tt.tx)
+			if err != nil {
+				t.Fatalf("Analyze failed: %v", err)
+			}
+
+			called := client.calls.Load() > 0
+			if tt.safe {
+				if called {
+					t.Error("Allowlisted call should skip inference")
+				}
+				if !hasIndicator(result, "known_safe_selector") || result.RiskLevel != "low" {
+					t.Errorf("Expected low-risk known_safe_selector result, got %s %v", result.RiskLevel, result.RiskIndicators)
+				}
+			} else {
+				if !called {
+					t.Error("Non-allowlisted call should reach inference")
+				}
+				if hasIndicator(result, "known_safe_selector") {
+					t.Error("Non-allowlisted call must not be tagged known_safe_selector")
+				}
+			}
+		})
+	}
+}
+
+func TestBridge_SafeSelectorsDisabled(t *testing.T) {
+	client := &mockInferenceClient{}
+	bridge := newMockClientBridge(client, 300*time.Millisecond)
+
+	bridge.Analyze(context.Background(), selectorTestTx(packKnown(t, "transfer", common.HexToAddress("0x3"), big.NewInt(100))))
+	if client.calls.Load() != 1 {
+		t.Error("Without pre-classification every call should reach inference")
+	}
+}
@@ -0,0 +1,173 @@
+package inference
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultSenderAlpha      = 0.4
+	defaultSenderHalfLife   = 30 * time.Minute
+	defaultSenderMaxSenders = 100000
+
+	// Analyses a sender needs before its history contributes
+	senderMinHistory = 3
+	// Smoothed score at which a sender whose scores keep rising is flagged
+	senderEscalationScore = 0.35
+	// Share of the smoothed score added to the current transaction
+	senderScoreWeight = 0.3
+	// Idle senders whose average has decayed below this are forgotten
+	senderForgetScore = 0.01
+)
+
+// SenderHistoryConfig configures smoothing of analysis scores per sender
+type SenderHistoryConfig struct {
+	Enabled bool
+	// Alpha weighs the newest score in the moving average, in (0, 1]
+	Alpha float64
+	// HalfLife decays a sender's average while it is idle
+	HalfLife time.Duration
+	// MaxSenders bounds memory; the least recently seen sender is evicted
+	MaxSenders int
+}
+
+type senderScore struct {
+	ema   float64
+	count int
+	last  time.Time
+}
+
+// SenderHistory keeps an exponential moving average of each sender's
+// analysis scores, so a run of individually borderline transactions from
+// one address scores higher as the pattern escalates
+type SenderHistory struct {
+	mu         sync.Mutex
+	alpha      float64
+	halfLife   time.Duration
+	maxSenders int
+
+	senders   map[common.Address]*senderScore
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+// NewSenderHistory creates a history, applying defaults for unset fields.
+// It returns nil when disabled; a nil history never contributes.
+func NewSenderHistory(cfg SenderHistoryConfig) *SenderHistory {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	alpha := cfg.Alpha
+	if alpha <= 0 || alpha > 1 {
+		alpha = defaultSenderAlpha
+	}
+
+	halfLife := cfg.HalfLife
+	if halfLife == 0 {
+		halfLife = defaultSenderHalfLife
+	}
+
+	maxSenders := cfg.MaxSenders
+	if maxSenders == 0 {
+		maxSenders = defaultSenderMaxSenders
+	}
+
+	return &SenderHistory{
+		alpha:      alpha,
+		halfLife:   halfLife,
+		maxSenders: maxSenders,
+		senders:    make(map[common.Address]*senderScore),
+		now:        time.Now,
+	}
+}
+
+// Record folds score into from's average and returns the boost the current
+// transaction earns. It is nonzero once the sender has enough history, its
+// smoothed score is high and the newest score is not below the trend.
+func (h *SenderHistory) Record(from common.Address, score float64) float64 {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.pruneLocked(now)
+
+	s, ok := h.senders[from]
+	if !ok {
+		if len(h.senders) >= h.maxSenders {
+			h.evictOldestLocked()
+		}
+		s = &senderScore{}
+		h.senders[from] = s
+	}
+
+	prev := h.decayed(s, now)
+	if s.count == 0 {
+		s.ema = score
+	} else {
+		s.ema = h.alpha*score + (1-h.alpha)*prev
+	}
+	s.count++
+	s.last = now
+
+	if s.count < senderMinHistory || s.ema < senderEscalationScore || score < prev {
+		return 0
+	}
+	return senderScoreWeight * s.ema
+}
+
+// decayed returns s's average halved for every HalfLife it has been idle
+func (h *SenderHistory) decayed(s *senderScore, now time.Time) float64 {
+	if s.count == 0 {
+		return 0
+	}
+	idle := now.Sub(s.last)
+	return s.ema * math.Exp2(-float64(idle)/float64(h.halfLife))
+}
+
+// pruneLocked forgets senders whose average has decayed to nothing. Like
+// the cluster tracker it runs a few times per half-life at most.
+func (h *SenderHistory) pruneLocked(now time.Time) {
+	if now.Sub(h.lastPrune) < h.halfLife/4 {
+		return
+	}
+	h.lastPrune = now
+
+	for addr, s := range h.senders {
+		if h.decayed(s, now) < senderForgetScore {
+			delete(h.senders, addr)
+		}
+	}
+}
+
+func (h *SenderHistory) evictOldestLocked() {
+	var (
+		oldest common.Address
+		at     time.Time
+	)
+	for addr, s := range h.senders {
+		if at.IsZero() || s.last.Before(at) {
+			oldest, at = addr, s.last
+		}
+	}
+	delete(h.senders, oldest)
+}
+
+// Size returns the number of tracked senders
+func (h *SenderHistory) Size() int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.senders)
+}
@@ -0,0 +1,106 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+var testSender = common.HexToAddress("0xabc")
+
+func newSenderTestBridge(t *testing.T, client *verdictClient) (*Bridge, *time.Time) {
+	t.Helper()
+	bridge, err := NewBridge(BridgeConfig{
+		Timeout:       300 * time.Millisecond,
+		MaxRetries:    1,
+		SenderHistory: SenderHistoryConfig{Enabled: true, Alpha: 0.5, HalfLife: 10 * time.Minute},
+		Logger:        zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	bridge.client = client
+	bridge.connected = true
+
+	now := time.Unix(1700000000, 0)
+	bridge.senders.now = func() time.Time { return now }
+	return bridge, &now
+}
+
+func TestBridge_EscalatingSenderPattern(t *testing.T) {
+	client := &verdictClient{}
+	bridge, _ := newSenderTestBridge(t, client)
+
+	// Each transaction is borderline on its own but the trend keeps rising
+	scores := []float64{0.3, 0.4, 0.5, 0.55}
+	for i, score := range scores {
+		client.score = score
+		result, err := bridge.Analyze(context.Background(), suspiciousTx(testSender, int64(i)))
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+
+		flagged := hasIndicator(result, "escalating_sender_pattern")
+		if i < senderMinHistory-1 && flagged {
+			t.Errorf("Transaction %d flagged before the sender had enough history", i)
+		}
+		if i >= senderMinHistory-1 {
+			if !flagged {
+				t.Errorf("Expected escalating_sender_pattern on transaction %d, got %v", i, result.RiskIndicators)
+			}
+			if result.AnomalyScore <= score {
+				t.Errorf("Transaction %d score %.2f was not raised above %.2f", i, result.AnomalyScore, score)
+			}
+		}
+	}
+
+	// Another sender with the same single score is not affected
+	client.score = 0.55
+	result, _ := bridge.Analyze(context.Background(), suspiciousTx(common.HexToAddress("0xdef"), 99))
+	if hasIndicator(result, "escalating_sender_pattern") {
+		t.Error("A sender without history should not be flagged")
+	}
+}
+
+func TestSenderHistory_DecaysAndFallingScores(t *testing.T) {
+	history := NewSenderHistory(SenderHistoryConfig{Enabled: true, Alpha: 0.5, HalfLife: 10 * time.Minute})
+	now := time.Unix(1700000000, 0)
+	history.now = func() time.Time { return now }
+
+	for _, score := range []float64{0.5, 0.6, 0.7} {
+		history.Record(testSender, score)
+	}
+
+	// A falling score doesn't extend the pattern
+	if boost := history.Record(testSender, 0.2); boost != 0 {
+		t.Errorf("Falling score should not be boosted, got %.3f", boost)
+	}
+
+	// After a long idle period the average has decayed away
+	now = now.Add(3 * time.Hour)
+	if boost := history.Record(testSender, 0.4); boost != 0 {
+		t.Errorf("Decayed history should not boost, got %.3f", boost)
+	}
+}
+
+func TestSenderHistory_BoundsMemory(t *testing.T) {
+	history := NewSenderHistory(SenderHistoryConfig{Enabled: true, MaxSenders: 2})
+	now := time.Unix(1700000000, 0)
+	history.now = func() time.Time { return now }
+
+	for i := int64(1); i <= 5; i++ {
+		now = now.Add(time.Second)
+		history.Record(common.BigToAddress(big.NewInt(i)), 0.5)
+	}
+
+	if size := history.Size(); size != 2 {
+		t.Errorf("Expected history capped at 2 senders, got %d", size)
+	}
+	if NewSenderHistory(SenderHistoryConfig{}).Record(testSender, 1) != 0 {
+		t.Error("Disabled history should never contribute")
+	}
+}
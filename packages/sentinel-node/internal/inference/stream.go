@@ -0,0 +1,263 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var errStreamClosed = errors.New("inference stream closed")
+
+// inferenceStream multiplexes analysis requests over one AnalyzeStream
+// call. Responses may come back in any order and are handed to whoever is
+// waiting on their transaction hash. Once the stream breaks every waiter is
+// released with the error and the stream is not used again.
+type inferenceStream struct {
+	stream grpc.BidiStreamingClient[pb.AnalyzeRequest, pb.AnalyzeResponse]
+	cancel context.CancelFunc
+
+	// sendMu serializes Send, which the stream doesn't allow concurrently
+	sendMu sync.Mutex
+
+	mu sync.Mutex
+	// waiters holds the callers waiting on each hash, oldest first
+	waiters map[string][]chan *pb.AnalyzeResponse
+	err     error
+	done    chan struct{}
+}
+
+func openInferenceStream(client pb.SentinelInferenceClient) (*inferenceStream, error) {
+	// The stream outlives any single request, so it isn't bound to one's
+	// deadline
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.AnalyzeStream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &inferenceStream{
+		stream:  stream,
+		cancel:  cancel,
+		waiters: make(map[string][]chan *pb.AnalyzeResponse),
+		done:    make(chan struct{}),
+	}
+	go s.recvLoop()
+	return s, nil
+}
+
+func (s *inferenceStream) recvLoop() {
+	for {
+		resp, err := s.stream.Recv()
+		if err != nil {
+			s.fail(err)
+			return
+		}
+
+		s.mu.Lock()
+		if waiting := s.waiters[resp.TxHash]; len(waiting) > 0 {
+			waiting[0] <- resp
+			if len(waiting) == 1 {
+				delete(s.waiters, resp.TxHash)
+			} else {
+				s.waiters[resp.TxHash] = waiting[1:]
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// analyze sends req and waits for the response to its transaction hash
+func (s *inferenceStream) analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	ch := make(chan *pb.AnalyzeResponse, 1)
+
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return nil, s.err
+	}
+	s.waiters[req.TxHash] = append(s.waiters[req.TxHash], ch)
+	s.mu.Unlock()
+
+	s.sendMu.Lock()
+	err := s.stream.Send(req)
+	s.sendMu.Unlock()
+	if err != nil {
+		// io.EOF only says the stream ended; Recv reports why
+		if !errors.Is(err, io.EOF) {
+			s.fail(err)
+		}
+		select {
+		case <-s.done:
+			return nil, s.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-s.done:
+		return nil, s.err
+	case <-ctx.Done():
+		s.forget(req.TxHash, ch)
+		return nil, ctx.Err()
+	}
+}
+
+// forget stops waiting on ch, e.g. when its caller gave up
+func (s *inferenceStream) forget(hash string, ch chan *pb.AnalyzeResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	waiting := s.waiters[hash]
+	for i, w := range waiting {
+		if w == ch {
+			waiting = append(waiting[:i:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(s.waiters, hash)
+	} else {
+		s.waiters[hash] = waiting
+	}
+}
+
+// fail marks the stream broken with err, releasing every waiter. Only the
+// first failure is kept.
+func (s *inferenceStream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	s.waiters = nil
+	close(s.done)
+	s.cancel()
+}
+
+func (s *inferenceStream) broken() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *inferenceStream) close() {
+	s.fail(errStreamClosed)
+}
+
+// AnalyzeStream analyzes tx like Analyze, but sends it to the inference
+// server over a long-lived stream shared by all callers rather than a call
+// of its own, saving the per-call overhead under bursts. Should the stream
+// fail, the transaction is retried with a unary call.
+func (b *Bridge) AnalyzeStream(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
+	return b.analyze(ctx, tx, b.streamAnalysis)
+}
+
+// streamAnalysis is serverAnalysis over the shared stream
+func (b *Bridge) streamAnalysis(ctx context.Context, tx *types.PendingTransaction) (*types.InferenceResult, error) {
+	if !b.allowRequest() {
+		b.logger.Debug().Str("txHash", tx.Hash.Hex()).Msg("circuit breaker open, using fallback")
+		return nil, errCircuitOpen
+	}
+
+	b.mu.RLock()
+	connected := b.connected
+	b.mu.RUnlock()
+	if !connected {
+		b.triggerReconnect()
+		return nil, errNotConnected
+	}
+
+	stream, err := b.inferenceStream()
+	if stream == nil && err == nil {
+		// The server doesn't stream
+		return b.serverAnalysis(ctx, tx)
+	}
+	if err == nil {
+		// Like a unary attempt, leave time for the fallback
+		callCtx, cancel := b.attemptContext(ctx, 1)
+		var resp *pb.AnalyzeResponse
+		resp, err = stream.analyze(callCtx, b.txToRequest(tx))
+		timedOut := callCtx.Err() != nil
+		cancel()
+
+		if err == nil {
+			b.recordSuccess()
+			return b.responseToResult(resp, tx.Hash), nil
+		}
+		if timedOut {
+			b.logger.Warn().Err(err).Str("txHash", tx.Hash.Hex()).Msg("streamed inference timed out, using fallback")
+			b.recordFailure()
+			return nil, err
+		}
+	}
+
+	if status.Code(err) == codes.Unimplemented {
+		b.logger.Info().Msg("inference server does not support streaming, using unary calls")
+		b.streamMu.Lock()
+		b.streamUnsupported = true
+		b.streamMu.Unlock()
+	} else {
+		b.logger.Warn().Err(err).Str("txHash", tx.Hash.Hex()).Msg("inference stream failed, retrying with a unary call")
+		b.recordFailure()
+	}
+	return b.serverAnalysis(ctx, tx)
+}
+
+// inferenceStream returns the shared stream, opening a new one if there is
+// none or the last one broke. It returns nil and no error once the server
+// turned out not to support streaming.
+func (b *Bridge) inferenceStream() (*inferenceStream, error) {
+	b.streamMu.Lock()
+	defer b.streamMu.Unlock()
+
+	if b.streamUnsupported {
+		return nil, nil
+	}
+	if b.stream != nil && !b.stream.broken() {
+		return b.stream, nil
+	}
+
+	b.mu.RLock()
+	client := b.client
+	b.mu.RUnlock()
+	if client == nil {
+		return nil, errNotConnected
+	}
+
+	stream, err := openInferenceStream(client)
+	if err != nil {
+		return nil, err
+	}
+	b.stream = stream
+	return stream, nil
+}
+
+// resetStream closes the shared stream, e.g. because the connection it ran
+// over was replaced. The next streamed call opens a new one.
+func (b *Bridge) resetStream() {
+	b.streamMu.Lock()
+	defer b.streamMu.Unlock()
+
+	if b.stream != nil {
+		b.stream.close()
+		b.stream = nil
+	}
+	b.streamUnsupported = false
+}
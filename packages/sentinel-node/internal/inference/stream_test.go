@@ -0,0 +1,217 @@
+package inference
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/sentinel-protocol/sentinel-node/pkg/proto"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// echoStreamServer answers each streamed request with its own hash after a
+// delay that shrinks with every request, so responses overtake each other
+type echoStreamServer struct {
+	pb.UnimplementedSentinelInferenceServer
+	// streamErr, when set, ends every stream with it straight away
+	streamErr error
+
+	streams    atomic.Int32
+	unaryCalls atomic.Int32
+}
+
+func (s *echoStreamServer) AnalyzeStream(stream pb.SentinelInference_AnalyzeStreamServer) error {
+	s.streams.Add(1)
+	if s.streamErr != nil {
+		return s.streamErr
+	}
+
+	var sendMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		wg.Add(1)
+		go func(req *pb.AnalyzeRequest, delay time.Duration) {
+			defer wg.Done()
+			time.Sleep(delay)
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			stream.Send(&pb.AnalyzeResponse{
+				TxHash:         req.TxHash,
+				AnomalyScore:   0.2,
+				RiskLevel:      pb.RiskLevel_RISK_LOW,
+				RiskIndicators: []string{"streamed"},
+			})
+		}(req, time.Duration(max(0, 20-i))*time.Millisecond)
+	}
+}
+
+func (s *echoStreamServer) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	s.unaryCalls.Add(1)
+	return &pb.AnalyzeResponse{
+		TxHash:         req.TxHash,
+		AnomalyScore:   0.2,
+		RiskLevel:      pb.RiskLevel_RISK_LOW,
+		RiskIndicators: []string{"server_analysis"},
+	}, nil
+}
+
+func newStreamTestBridge(t *testing.T, server *echoStreamServer) *Bridge {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterSentinelInferenceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+
+	bridge, err := NewBridge(BridgeConfig{Timeout: 2 * time.Second, MaxRetries: 1, Logger: zerolog.Nop()})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	bridge.conn = conn
+	bridge.client = pb.NewSentinelInferenceClient(conn)
+	bridge.connected = true
+	t.Cleanup(func() { bridge.Close() })
+	return bridge
+}
+
+func streamTestTx(i int) *types.PendingTransaction {
+	return &types.PendingTransaction{
+		Hash:  common.BigToHash(big.NewInt(int64(i + 1))),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(0),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+}
+
+func TestBridge_AnalyzeStream_CorrelatesResponsesByHash(t *testing.T) {
+	server := &echoStreamServer{}
+	bridge := newStreamTestBridge(t, server)
+
+	const n = 20
+	results := make([]*types.InferenceResult, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = bridge.AnalyzeStream(context.Background(), streamTestTx(i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if result.TxHash != streamTestTx(i).Hash {
+			t.Errorf("Result %d answered %s, expected %s", i, result.TxHash.Hex(), streamTestTx(i).Hash.Hex())
+		}
+		if !hasIndicator(result, "streamed") {
+			t.Errorf("Result %d didn't come from the stream: %v", i, result.RiskIndicators)
+		}
+	}
+	if server.streams.Load() != 1 {
+		t.Errorf("Expected every request on one stream, got %d streams", server.streams.Load())
+	}
+	if server.unaryCalls.Load() != 0 {
+		t.Errorf("Expected no unary calls, got %d", server.unaryCalls.Load())
+	}
+}
+
+func TestBridge_AnalyzeStream_FallsBackToUnary(t *testing.T) {
+	server := &echoStreamServer{streamErr: status.Error(codes.Unavailable, "stream reset")}
+	bridge := newStreamTestBridge(t, server)
+
+	result, err := bridge.AnalyzeStream(context.Background(), streamTestTx(0))
+	if err != nil {
+		t.Fatalf("AnalyzeStream failed: %v", err)
+	}
+	if !hasIndicator(result, "server_analysis") || server.unaryCalls.Load() != 1 {
+		t.Errorf("Expected the unary call to answer after the stream failed, got %v", result.RiskIndicators)
+	}
+
+	// A broken stream is replaced on the next call
+	bridge.AnalyzeStream(context.Background(), streamTestTx(1))
+	if server.streams.Load() != 2 {
+		t.Errorf("Expected a new stream after the failure, got %d", server.streams.Load())
+	}
+}
+
+func TestBridge_AnalyzeStream_FeedsCircuitBreaker(t *testing.T) {
+	server := &echoStreamServer{streamErr: status.Error(codes.Unavailable, "stream reset")}
+	bridge := newStreamTestBridge(t, server)
+
+	var failures []int
+	bridge.client = failingUnaryClient{bridge.client}
+	for i := 0; i < 2; i++ {
+		result, _ := bridge.AnalyzeStream(context.Background(), streamTestTx(i))
+		if !hasIndicator(result, "fallback_analysis") {
+			t.Fatalf("Expected fallback once stream and unary call failed, got %v", result.RiskIndicators)
+		}
+		bridge.mu.RLock()
+		failures = append(failures, bridge.consecutiveFailures)
+		bridge.mu.RUnlock()
+	}
+
+	// Each call records the stream failure and the unary one
+	if failures[0] != 2 || failures[1] != 4 {
+		t.Errorf("Expected failures 2 then 4, got %v", failures)
+	}
+}
+
+func TestBridge_AnalyzeStream_UnsupportedServerUsesUnary(t *testing.T) {
+	server := &echoStreamServer{streamErr: status.Error(codes.Unimplemented, "unknown method")}
+	bridge := newStreamTestBridge(t, server)
+
+	for i := 0; i < 3; i++ {
+		result, _ := bridge.AnalyzeStream(context.Background(), streamTestTx(i))
+		if !hasIndicator(result, "server_analysis") {
+			t.Fatalf("Expected unary analysis, got %v", result.RiskIndicators)
+		}
+	}
+	if server.streams.Load() != 1 {
+		t.Errorf("Expected streaming given up after the first attempt, got %d streams", server.streams.Load())
+	}
+	bridge.mu.RLock()
+	defer bridge.mu.RUnlock()
+	if bridge.consecutiveFailures != 0 {
+		t.Errorf("An unsupported stream isn't a server failure, got %d failures", bridge.consecutiveFailures)
+	}
+}
+
+// failingUnaryClient streams through the wrapped client but fails every
+// unary Analyze
+type failingUnaryClient struct {
+	pb.SentinelInferenceClient
+}
+
+func (failingUnaryClient) Analyze(ctx context.Context, in *pb.AnalyzeRequest, opts ...grpc.CallOption) (*pb.AnalyzeResponse, error) {
+	return nil, status.Error(codes.Unavailable, "server down")
+}
@@ -0,0 +1,155 @@
+package inference
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SwapParams holds the decoded parameters of a DEX router swap call
+type SwapParams struct {
+	Method       string
+	Path         []common.Address
+	AmountIn     *big.Int
+	AmountOutMin *big.Int
+}
+
+// routerABI covers the Uniswap V2/V3 style swap entrypoints whose
+// exact-input amounts reveal slippage tolerance
+const routerABI = `[
+	{"name":"swapExactTokensForTokens","type":"function","inputs":[
+		{"name":"amountIn","type":"uint256"},{"name":"amountOutMin","type":"uint256"},
+		{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}]},
+	{"name":"swapExactTokensForETH","type":"function","inputs":[
+		{"name":"amountIn","type":"uint256"},{"name":"amountOutMin","type":"uint256"},
+		{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}]},
+	{"name":"swapExactETHForTokens","type":"function","stateMutability":"payable","inputs":[
+		{"name":"amountOutMin","type":"uint256"},{"name":"path","type":"address[]"},
+		{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}]},
+	{"name":"exactInputSingle","type":"function","stateMutability":"payable","inputs":[
+		{"name":"params","type":"tuple","components":[
+			{"name":"tokenIn","type":"address"},{"name":"tokenOut","type":"address"},
+			{"name":"fee","type":"uint24"},{"name":"recipient","type":"address"},
+			{"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},
+			{"name":"amountOutMinimum","type":"uint256"},{"name":"sqrtPriceLimitX96","type":"uint160"}]}]},
+	{"name":"exactInput","type":"function","stateMutability":"payable","inputs":[
+		{"name":"params","type":"tuple","components":[
+			{"name":"path","type":"bytes"},{"name":"recipient","type":"address"},
+			{"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},
+			{"name":"amountOutMinimum","type":"uint256"}]}]}
+]`
+
+var parsedRouterABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(routerABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+type exactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	Deadline          *big.Int
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+type exactInputParams struct {
+	Path             []byte
+	Recipient        common.Address
+	Deadline         *big.Int
+	AmountIn         *big.Int
+	AmountOutMinimum *big.Int
+}
+
+// DecodeSwap decodes calldata for known router swap selectors. value is the
+// transaction's ETH value, used as the input amount for ETH-in swaps.
+// It returns false for unknown selectors or calldata that doesn't decode.
+func DecodeSwap(input []byte, value *big.Int) (*SwapParams, bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+
+	method, err := parsedRouterABI.MethodById(input[:4])
+	if err != nil {
+		return nil, false
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, false
+	}
+
+	swap := &SwapParams{Method: method.Name}
+
+	switch method.Name {
+	case "swapExactTokensForTokens", "swapExactTokensForETH":
+		swap.AmountIn, _ = args[0].(*big.Int)
+		swap.AmountOutMin, _ = args[1].(*big.Int)
+		swap.Path, _ = args[2].([]common.Address)
+
+	case "swapExactETHForTokens":
+		swap.AmountIn = value
+		swap.AmountOutMin, _ = args[0].(*big.Int)
+		swap.Path, _ = args[1].([]common.Address)
+
+	case "exactInputSingle":
+		params, ok := abi.ConvertType(args[0], new(exactInputSingleParams)).(*exactInputSingleParams)
+		if !ok {
+			return nil, false
+		}
+		swap.AmountIn = params.AmountIn
+		swap.AmountOutMin = params.AmountOutMinimum
+		swap.Path = []common.Address{params.TokenIn, params.TokenOut}
+
+	case "exactInput":
+		params, ok := abi.ConvertType(args[0], new(exactInputParams)).(*exactInputParams)
+		if !ok {
+			return nil, false
+		}
+		swap.AmountIn = params.AmountIn
+		swap.AmountOutMin = params.AmountOutMinimum
+		swap.Path = decodeV3Path(params.Path)
+	}
+
+	if swap.AmountIn == nil || swap.AmountOutMin == nil {
+		return nil, false
+	}
+
+	return swap, true
+}
+
+// decodeV3Path extracts token addresses from a Uniswap V3 packed path
+// (token(20) | fee(3) | token(20) | ...)
+func decodeV3Path(path []byte) []common.Address {
+	const hop = common.AddressLength + 3
+
+	tokens := make([]common.Address, 0, len(path)/hop+1)
+	for offset := 0; offset+common.AddressLength <= len(path); offset += hop {
+		tokens = append(tokens, common.BytesToAddress(path[offset:offset+common.AddressLength]))
+	}
+	return tokens
+}
+
+// HasExtremeSlippage reports whether the swap accepts (effectively) any output
+// amount, the calldata signature of a victim in a sandwich or of a swap used
+// for price manipulation
+func (s *SwapParams) HasExtremeSlippage() bool {
+	return s.AmountIn.Sign() > 0 && s.AmountOutMin.Cmp(big.NewInt(1)) <= 0
+}
+
+// TouchesAny reports whether any token in the swap path is in the given set
+func (s *SwapParams) TouchesAny(tokens map[common.Address]bool) bool {
+	for _, token := range s.Path {
+		if tokens[token] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package inference
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var (
+	testWETH = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	testUSDC = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+)
+
+func packSwap(t *testing.T, method string, args ...interface{}) []byte {
+	t.Helper()
+	data, err := parsedRouterABI.Pack(method, args...)
+	if err != nil {
+		t.Fatalf("Pack(%s) failed: %v", method, err)
+	}
+	return data
+}
+
+func TestDecodeSwap_ExactTokensForTokens(t *testing.T) {
+	input := packSwap(t, "swapExactTokensForTokens",
+		big.NewInt(1000), big.NewInt(990),
+		[]common.Address{testWETH, testUSDC},
+		common.HexToAddress("0x1"), big.NewInt(1700000000),
+	)
+
+	swap, ok := DecodeSwap(input, nil)
+	if !ok {
+		t.Fatal("Expected swap to decode")
+	}
+
+	if swap.Method != "swapExactTokensForTokens" {
+		t.Errorf("Expected method swapExactTokensForTokens, got %s", swap.Method)
+	}
+	if swap.AmountIn.Int64() != 1000 || swap.AmountOutMin.Int64() != 990 {
+		t.Errorf("Unexpected amounts: in=%s outMin=%s", swap.AmountIn, swap.AmountOutMin)
+	}
+	if len(swap.Path) != 2 || swap.Path[0] != testWETH || swap.Path[1] != testUSDC {
+		t.Errorf("Unexpected path: %v", swap.Path)
+	}
+	if swap.HasExtremeSlippage() {
+		t.Error("Swap with tight amountOutMin should not have extreme slippage")
+	}
+}
+
+func TestDecodeSwap_ExactInputV3Path(t *testing.T) {
+	path := append(append(testWETH.Bytes(), 0x00, 0x0b, 0xb8), testUSDC.Bytes()...)
+	params := exactInputParams{
+		Path:             path,
+		Recipient:        common.HexToAddress("0x1"),
+		Deadline:         big.NewInt(1700000000),
+		AmountIn:         big.NewInt(5000),
+		AmountOutMinimum: big.NewInt(0),
+	}
+
+	swap, ok := DecodeSwap(packSwap(t, "exactInput", params), nil)
+	if !ok {
+		t.Fatal("Expected exactInput to decode")
+	}
+
+	if len(swap.Path) != 2 || swap.Path[0] != testWETH || swap.Path[1] != testUSDC {
+		t.Errorf("Unexpected path: %v", swap.Path)
+	}
+	if !swap.HasExtremeSlippage() {
+		t.Error("Swap with zero amountOutMinimum should have extreme slippage")
+	}
+}
+
+func TestDecodeSwap_UnknownSelector(t *testing.T) {
+	if _, ok := DecodeSwap([]byte{0xde, 0xad, 0xbe, 0xef, 0x01}, nil); ok {
+		t.Error("Unknown selector should not decode")
+	}
+
+	// Known selector with truncated arguments
+	input := packSwap(t, "swapExactTokensForTokens",
+		big.NewInt(1000), big.NewInt(990),
+		[]common.Address{testWETH, testUSDC},
+		common.HexToAddress("0x1"), big.NewInt(1700000000),
+	)
+	if _, ok := DecodeSwap(input[:40], nil); ok {
+		t.Error("Truncated calldata should not decode")
+	}
+}
+
+func TestBridge_HeuristicFlagsHighSlippageSwap(t *testing.T) {
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+
+	tx := &types.PendingTransaction{
+		Hash: common.HexToHash("0x1234"),
+		From: common.HexToAddress("0x1"),
+		To:   ptrAddr(common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")),
+		Gas:  300000,
+		Input: packSwap(t, "swapExactETHForTokens",
+			big.NewInt(0), []common.Address{testWETH, testUSDC},
+			common.HexToAddress("0x1"), big.NewInt(1700000000),
+		),
+		Value: big.NewInt(1e17),
+	}
+
+	result := bridge.heuristicAnalysis(tx)
+	if !hasIndicator(result, "high_slippage_swap") {
+		t.Errorf("Expected high_slippage_swap indicator, got %v", result.RiskIndicators)
+	}
+}
+
+func TestBridge_HeuristicFlagsThinLiquiditySwap(t *testing.T) {
+	thinToken := common.HexToAddress("0xabc")
+	bridge, _ := NewBridge(BridgeConfig{
+		Logger:              zerolog.Nop(),
+		ThinLiquidityTokens: []common.Address{thinToken},
+	})
+
+	input := packSwap(t, "swapExactTokensForTokens",
+		big.NewInt(1000), big.NewInt(990),
+		[]common.Address{testWETH, thinToken},
+		common.HexToAddress("0x1"), big.NewInt(1700000000),
+	)
+	tx := &types.PendingTransaction{
+		Hash:  common.HexToHash("0x1234"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Gas:   300000,
+		Input: input,
+	}
+
+	if !hasIndicator(bridge.heuristicAnalysis(tx), "high_slippage_swap") {
+		t.Error("Swap through a thin-liquidity token should be flagged")
+	}
+
+	// The same swap through liquid tokens is not flagged
+	tx.Input = packSwap(t, "swapExactTokensForTokens",
+		big.NewInt(1000), big.NewInt(990),
+		[]common.Address{testWETH, testUSDC},
+		common.HexToAddress("0x1"), big.NewInt(1700000000),
+	)
+	if hasIndicator(bridge.heuristicAnalysis(tx), "high_slippage_swap") {
+		t.Error("Swap with slippage protection through liquid tokens should not be flagged")
+	}
+}
+
+func hasIndicator(result *types.InferenceResult, indicator string) bool {
+	for _, i := range result.RiskIndicators {
+		if i == indicator {
+			return true
+		}
+	}
+	return false
+}
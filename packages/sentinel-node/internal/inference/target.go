@@ -0,0 +1,32 @@
+package inference
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// TargetProtocol returns the contract a transaction acts on, which is what
+// a pause request against it would pause. A multicall acts on whichever
+// contract its batch calls most, the first of them on a tie, rather than on
+// the router or aggregator it is sent to. Contract creations have no target.
+func TargetProtocol(tx *types.PendingTransaction) (common.Address, bool) {
+	if tx.To == nil {
+		return common.Address{}, false
+	}
+
+	calls, ok := unpackMulticall(*tx.To, tx.Input, 0)
+	if !ok || len(calls) == 0 {
+		return *tx.To, true
+	}
+
+	target, most := *tx.To, 0
+	counts := make(map[common.Address]int, len(calls))
+	for _, call := range calls {
+		counts[call.target]++
+		if counts[call.target] > most {
+			target, most = call.target, counts[call.target]
+		}
+	}
+	return target, true
+}
@@ -0,0 +1,39 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func TestTargetProtocol(t *testing.T) {
+	multicall3 := common.HexToAddress("0xca11")
+	token := common.HexToAddress("0x70c")
+
+	tests := []struct {
+		name   string
+		tx     *types.PendingTransaction
+		want   common.Address
+		wantOK bool
+	}{
+		{"direct call", multicallTx(testVault, withdrawCall(1)), testVault, true},
+		{"contract creation", &types.PendingTransaction{Input: []byte{0x60, 0x80}}, common.Address{}, false},
+		{"router multicall", multicallTx(testVault, packMulticall(t, "multicall", [][]byte{withdrawCall(1), withdrawCall(2)})), testVault, true},
+		{"most called target of an aggregate", multicallTx(multicall3, packMulticall(t, "aggregate3", []multicall3Call{
+			{Target: token, CallData: withdrawCall(1)},
+			{Target: testVault, CallData: withdrawCall(1)},
+			{Target: testVault, CallData: withdrawCall(2)},
+		})), testVault, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TargetProtocol(tt.tx)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Expected %s, %v, got %s, %v", tt.want.Hex(), tt.wantOK, got.Hex(), ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package inference
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var errInsecureNotAllowed = errors.New("inference TLS is not enabled and insecure connections are not allowed")
+
+// TLSConfig secures the connection to the inference server
+type TLSConfig struct {
+	Enabled bool
+	// CAFile verifies the server certificate (empty = system roots)
+	CAFile string
+	// CertFile and KeyFile are presented to the server for mutual TLS;
+	// both or neither must be set
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name checked against the server
+	// certificate, e.g. when dialing by IP
+	ServerName string
+}
+
+// transportCredentials returns the credentials the bridge dials with:
+// TLS when enabled, plaintext only when allowInsecure
+func transportCredentials(cfg TLSConfig, allowInsecure bool) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		if !allowInsecure {
+			return nil, errInsecureNotAllowed
+		}
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func newTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read inference CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in inference CA %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("inference client certificate and key must be set together")
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load inference client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
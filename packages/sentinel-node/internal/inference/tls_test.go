@@ -0,0 +1,125 @@
+package inference
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// writeTestCert writes a self-signed certificate and its key as PEM files
+// under dir, returning their paths
+func writeTestCert(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey failed: %v", err)
+	}
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestTransportCredentials_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeTestCert(t, dir, "inference-ca")
+	certFile, keyFile := writeTestCert(t, dir, "sentinel-node")
+
+	cfg := TLSConfig{
+		Enabled:    true,
+		CAFile:     caFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "inference.internal",
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("newTLSConfig failed: %v", err)
+	}
+	if tlsConfig.RootCAs == nil {
+		t.Error("Expected the configured CA to verify the server")
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Errorf("Expected the client certificate for mTLS, got %d", len(tlsConfig.Certificates))
+	}
+	if tlsConfig.ServerName != "inference.internal" {
+		t.Errorf("Expected server name override, got %q", tlsConfig.ServerName)
+	}
+
+	creds, err := transportCredentials(cfg, false)
+	if err != nil {
+		t.Fatalf("transportCredentials failed: %v", err)
+	}
+	if info := creds.Info(); info.SecurityProtocol != "tls" || info.ServerName != "inference.internal" {
+		t.Errorf("Expected TLS credentials for inference.internal, got %+v", info)
+	}
+}
+
+func TestTransportCredentials_InsecureOnlyWhenAllowed(t *testing.T) {
+	if _, err := transportCredentials(TLSConfig{}, false); !errors.Is(err, errInsecureNotAllowed) {
+		t.Errorf("Expected errInsecureNotAllowed, got %v", err)
+	}
+
+	creds, err := transportCredentials(TLSConfig{}, true)
+	if err != nil {
+		t.Fatalf("transportCredentials failed: %v", err)
+	}
+	if info := creds.Info(); info.SecurityProtocol != "insecure" {
+		t.Errorf("Expected insecure credentials, got %q", info.SecurityProtocol)
+	}
+
+	// A bridge with a server address refuses to start in plaintext unless told to
+	_, err = NewBridge(BridgeConfig{Address: "inference.internal:50051", Logger: zerolog.Nop()})
+	if !errors.Is(err, errInsecureNotAllowed) {
+		t.Errorf("Expected NewBridge to refuse a plaintext connection, got %v", err)
+	}
+}
+
+func TestNewTLSConfig_RejectsIncompleteClientCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _ := writeTestCert(t, dir, "sentinel-node")
+
+	if _, err := newTLSConfig(TLSConfig{Enabled: true, CertFile: certFile}); err == nil {
+		t.Error("Expected an error for a client certificate without a key")
+	}
+	if _, err := newTLSConfig(TLSConfig{Enabled: true, CAFile: filepath.Join(dir, "missing.crt")}); err == nil {
+		t.Error("Expected an error for a missing CA file")
+	}
+}
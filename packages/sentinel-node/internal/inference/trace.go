@@ -0,0 +1,104 @@
+package inference
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const (
+	// Call trees at least this deep are unusual outside of exploit contracts
+	defaultDeepCallDepth = 8
+	// Only results already at medium risk are traced, since each trace is an
+	// extra RPC round trip
+	traceMinScore = 0.4
+
+	deepCallTreeBoost      = 0.1
+	sensitiveInternalBoost = 0.2
+)
+
+// Tracer captures the internal call tree a pending transaction would execute
+type Tracer interface {
+	TraceCall(ctx context.Context, tx *types.PendingTransaction) (*types.CallFrame, error)
+}
+
+// TraceConfig configures internal call analysis of flagged transactions
+type TraceConfig struct {
+	// Tracer runs debug_traceCall; nil disables tracing
+	Tracer Tracer
+	// DeepCallDepth is the nesting depth at which deep_call_tree is raised
+	DeepCallDepth int
+	// SensitiveContracts are flagged when reached through an internal call
+	// rather than called directly (oracles, vaults, governance)
+	SensitiveContracts []common.Address
+}
+
+type traceAnalyzer struct {
+	tracer    Tracer
+	deepDepth int
+	sensitive map[common.Address]bool
+}
+
+func newTraceAnalyzer(cfg TraceConfig) *traceAnalyzer {
+	if cfg.Tracer == nil {
+		return nil
+	}
+
+	depth := cfg.DeepCallDepth
+	if depth == 0 {
+		depth = defaultDeepCallDepth
+	}
+
+	sensitive := make(map[common.Address]bool, len(cfg.SensitiveContracts))
+	for _, addr := range cfg.SensitiveContracts {
+		sensitive[addr] = true
+	}
+
+	return &traceAnalyzer{tracer: cfg.Tracer, deepDepth: depth, sensitive: sensitive}
+}
+
+// traceIndicator is a risk indicator derived from a call trace with the
+// score boost it carries
+type traceIndicator struct {
+	Name  string
+	Boost float64
+}
+
+// indicators derives risk indicators from a call tree. The root frame is
+// the transaction itself; only nested calls count as internal.
+func (a *traceAnalyzer) indicators(root *types.CallFrame) []traceIndicator {
+	var found []traceIndicator
+
+	if callDepth(root) >= a.deepDepth {
+		found = append(found, traceIndicator{Name: "deep_call_tree", Boost: deepCallTreeBoost})
+	}
+
+	if len(a.sensitive) > 0 && a.reachesSensitive(root.Calls) {
+		found = append(found, traceIndicator{Name: "calls_sensitive_contract_internally", Boost: sensitiveInternalBoost})
+	}
+
+	return found
+}
+
+func (a *traceAnalyzer) reachesSensitive(calls []types.CallFrame) bool {
+	for i := range calls {
+		if a.sensitive[calls[i].To] || a.reachesSensitive(calls[i].Calls) {
+			return true
+		}
+	}
+	return false
+}
+
+// callDepth returns the nesting depth of a call tree; a call with no
+// internal calls has depth 1
+func callDepth(frame *types.CallFrame) int {
+	deepest := 0
+	for i := range frame.Calls {
+		if d := callDepth(&frame.Calls[i]); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest + 1
+}
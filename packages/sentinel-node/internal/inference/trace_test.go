@@ -0,0 +1,125 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var testOracle = common.HexToAddress("0x0ac1e00000000000000000000000000000000000")
+
+// exploitTrace is a callTracer response for a contract that takes a flash
+// loan, then reaches the oracle through several nested calls
+const exploitTrace = `{
+	"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x000000000000000000000000000000000000dead", "input": "0x5cffe9de",
+	"calls": [{
+		"type": "CALL", "from": "0x000000000000000000000000000000000000dead", "to": "0x00000000000000000000000000000000000000a1", "input": "0x",
+		"calls": [{
+			"type": "DELEGATECALL", "from": "0x00000000000000000000000000000000000000a1", "to": "0x00000000000000000000000000000000000000a2", "input": "0x",
+			"calls": [{
+				"type": "CALL", "from": "0x00000000000000000000000000000000000000a2", "to": "0x00000000000000000000000000000000000000a3", "input": "0x",
+				"calls": [{
+					"type": "STATICCALL", "from": "0x00000000000000000000000000000000000000a3", "to": "0x0ac1e00000000000000000000000000000000000", "input": "0x50d25bcd"
+				}]
+			}]
+		}]
+	}]
+}`
+
+// shallowTrace is a plain router call with a single internal transfer
+const shallowTrace = `{
+	"type": "CALL", "from": "0x0000000000000000000000000000000000000001", "to": "0x000000000000000000000000000000000000dead", "input": "0x5cffe9de",
+	"calls": [{"type": "CALL", "from": "0x000000000000000000000000000000000000dead", "to": "0x00000000000000000000000000000000000000b1", "input": "0xa9059cbb"}]
+}`
+
+// mockTracer returns a canned debug_traceCall response
+type mockTracer struct {
+	response string
+	err      error
+	calls    int
+}
+
+func (m *mockTracer) TraceCall(ctx context.Context, tx *types.PendingTransaction) (*types.CallFrame, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	var frame types.CallFrame
+	if err := json.Unmarshal([]byte(m.response), &frame); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+func newTraceTestBridge(t *testing.T, tracer *mockTracer) *Bridge {
+	t.Helper()
+	bridge, err := NewBridge(BridgeConfig{
+		Trace: TraceConfig{
+			Tracer:             tracer,
+			DeepCallDepth:      5,
+			SensitiveContracts: []common.Address{testOracle},
+		},
+		Logger: zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	return bridge
+}
+
+func TestBridge_TraceIndicators(t *testing.T) {
+	tracer := &mockTracer{response: exploitTrace}
+	bridge := newTraceTestBridge(t, tracer)
+
+	result, _ := bridge.Analyze(context.Background(), suspiciousTx(common.HexToAddress("0x1"), 1))
+	if tracer.calls != 1 {
+		t.Fatalf("Expected flagged transaction to be traced once, got %d", tracer.calls)
+	}
+	for _, indicator := range []string{"deep_call_tree", "calls_sensitive_contract_internally"} {
+		if !hasIndicator(result, indicator) {
+			t.Errorf("Expected %s, got %v", indicator, result.RiskIndicators)
+		}
+	}
+}
+
+func TestBridge_TraceShallowCallTree(t *testing.T) {
+	bridge := newTraceTestBridge(t, &mockTracer{response: shallowTrace})
+
+	result, _ := bridge.Analyze(context.Background(), suspiciousTx(common.HexToAddress("0x1"), 1))
+	for _, indicator := range []string{"deep_call_tree", "calls_sensitive_contract_internally"} {
+		if hasIndicator(result, indicator) {
+			t.Errorf("Unexpected %s for a shallow trace", indicator)
+		}
+	}
+}
+
+func TestBridge_TraceSkipsLowRiskAndFailures(t *testing.T) {
+	tracer := &mockTracer{response: exploitTrace}
+	bridge := newTraceTestBridge(t, tracer)
+
+	// A plain transfer scores too low to be worth tracing
+	bridge.Analyze(context.Background(), &types.PendingTransaction{
+		Hash:  common.HexToHash("0xb1"),
+		From:  common.HexToAddress("0x1"),
+		To:    ptrAddr(common.HexToAddress("0x2")),
+		Value: big.NewInt(1),
+		Gas:   21000,
+	})
+	if tracer.calls != 0 {
+		t.Errorf("Low-risk transaction should not be traced, got %d calls", tracer.calls)
+	}
+
+	// Providers without debug_traceCall leave the result untouched
+	failing := newTraceTestBridge(t, &mockTracer{err: errors.New("the method debug_traceCall does not exist")})
+	result, _ := failing.Analyze(context.Background(), suspiciousTx(common.HexToAddress("0x1"), 1))
+	if hasIndicator(result, "deep_call_tree") {
+		t.Errorf("Failed trace should not add indicators, got %v", result.RiskIndicators)
+	}
+}
@@ -0,0 +1,119 @@
+package inference
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HeuristicWeights are the score contributions of the fallback heuristics
+// and how their total is capped and classified. Zero fields take the
+// defaults.
+type HeuristicWeights struct {
+	// FlashLoan is added for calls to the built-in flash-loan entrypoints;
+	// selectors from the rules file carry their own score
+	FlashLoan        float64
+	HighSlippageSwap float64
+	HighGasLimit     float64
+	LargeValue       float64
+	ContractCreation float64
+	LargeCalldata    float64
+	// Reentrancy is added for batches that repeat a call to one contract
+	Reentrancy float64
+	// MaxScore caps the total score, at most 1
+	MaxScore float64
+	// Thresholds map the total score to a risk level
+	Thresholds RiskThresholds
+}
+
+// RiskThresholds are the lowest scores classified at each risk level
+type RiskThresholds struct {
+	Critical float64
+	High     float64
+	Medium   float64
+}
+
+// DefaultHeuristicWeights are the weights the heuristics were tuned with
+var DefaultHeuristicWeights = HeuristicWeights{
+	FlashLoan:        0.4,
+	HighSlippageSwap: 0.2,
+	HighGasLimit:     0.1,
+	LargeValue:       0.1,
+	ContractCreation: 0.2,
+	LargeCalldata:    0.1,
+	Reentrancy:       0.3,
+	MaxScore:         1.0,
+	Thresholds: RiskThresholds{
+		Critical: 0.8,
+		High:     0.65,
+		Medium:   0.4,
+	},
+}
+
+// withDefaults fills unset weights from DefaultHeuristicWeights and checks
+// the result is usable
+func (w HeuristicWeights) withDefaults() (HeuristicWeights, error) {
+	d := DefaultHeuristicWeights
+	for _, field := range []struct {
+		value *float64
+		def   float64
+	}{
+		{&w.FlashLoan, d.FlashLoan},
+		{&w.HighSlippageSwap, d.HighSlippageSwap},
+		{&w.HighGasLimit, d.HighGasLimit},
+		{&w.LargeValue, d.LargeValue},
+		{&w.ContractCreation, d.ContractCreation},
+		{&w.LargeCalldata, d.LargeCalldata},
+		{&w.Reentrancy, d.Reentrancy},
+		{&w.MaxScore, d.MaxScore},
+		{&w.Thresholds.Critical, d.Thresholds.Critical},
+		{&w.Thresholds.High, d.Thresholds.High},
+		{&w.Thresholds.Medium, d.Thresholds.Medium},
+	} {
+		if *field.value < 0 {
+			return w, errors.New("heuristic weights and thresholds must not be negative")
+		}
+		if *field.value == 0 {
+			*field.value = field.def
+		}
+	}
+
+	if w.MaxScore > 1 {
+		return w, fmt.Errorf("maximum score %v is above 1", w.MaxScore)
+	}
+	t := w.Thresholds
+	if t.Medium > t.High || t.High > t.Critical {
+		return w, fmt.Errorf("risk thresholds must rise from medium to critical, got %v, %v, %v", t.Medium, t.High, t.Critical)
+	}
+	if t.Critical > w.MaxScore {
+		return w, fmt.Errorf("critical threshold %v is above the maximum score %v", t.Critical, w.MaxScore)
+	}
+	return w, nil
+}
+
+// clamp caps score at MaxScore
+func (w HeuristicWeights) clamp(score float64) float64 {
+	if score > w.MaxScore {
+		return w.MaxScore
+	}
+	return score
+}
+
+// Classify maps an anomaly score to a risk level and recommendation
+func (t RiskThresholds) Classify(anomalyScore float64) (riskLevel, recommendation string) {
+	switch {
+	case anomalyScore >= t.Critical:
+		return "critical", "block"
+	case anomalyScore >= t.High:
+		return "high", "block"
+	case anomalyScore >= t.Medium:
+		return "medium", "flag"
+	default:
+		return "low", "allow"
+	}
+}
+
+// ClassifyScore maps an anomaly score to a risk level and recommendation
+// using the default thresholds
+func ClassifyScore(anomalyScore float64) (riskLevel, recommendation string) {
+	return DefaultHeuristicWeights.Thresholds.Classify(anomalyScore)
+}
@@ -0,0 +1,69 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestBridge_HeuristicWeights_FlashLoanWeight(t *testing.T) {
+	// The flash-loan selector alone, with gas low enough to add nothing else
+	tx := ruleTestTx([]byte{0x5c, 0xff, 0xe9, 0xde})
+
+	bridge, _ := NewBridge(BridgeConfig{Logger: zerolog.Nop()})
+	result := bridge.heuristicAnalysis(tx)
+	if result.AnomalyScore != 0.4 || result.RiskLevel != "medium" {
+		t.Fatalf("Expected default score 0.4 (medium), got %v (%s)", result.AnomalyScore, result.RiskLevel)
+	}
+
+	bridge, err := NewBridge(BridgeConfig{
+		Weights: HeuristicWeights{FlashLoan: 0.85},
+		Logger:  zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	result = bridge.heuristicAnalysis(tx)
+	if result.AnomalyScore != 0.85 || result.RiskLevel != "critical" || !result.IsSuspicious {
+		t.Errorf("Expected score 0.85 (critical, suspicious), got %v (%s, %v)", result.AnomalyScore, result.RiskLevel, result.IsSuspicious)
+	}
+}
+
+func TestBridge_HeuristicWeights_ThresholdsAndCap(t *testing.T) {
+	tx := ruleTestTx([]byte{0x5c, 0xff, 0xe9, 0xde})
+	tx.Gas = 2_000_000
+
+	bridge, err := NewBridge(BridgeConfig{
+		Weights: HeuristicWeights{
+			FlashLoan:    0.6,
+			HighGasLimit: 0.6,
+			MaxScore:     0.9,
+			Thresholds:   RiskThresholds{Critical: 0.9, High: 0.7, Medium: 0.5},
+		},
+		Logger: zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+
+	result := bridge.heuristicAnalysis(tx)
+	if result.AnomalyScore != 0.9 || result.RiskLevel != "critical" {
+		t.Errorf("Expected the score capped at 0.9 (critical), got %v (%s)", result.AnomalyScore, result.RiskLevel)
+	}
+	if level, _ := bridge.weights.Thresholds.Classify(0.6); level != "medium" {
+		t.Errorf("Expected 0.6 classified medium, got %s", level)
+	}
+}
+
+func TestHeuristicWeights_RejectsInvalid(t *testing.T) {
+	for name, weights := range map[string]HeuristicWeights{
+		"negative weight":      {HighGasLimit: -0.1},
+		"unordered thresholds": {Thresholds: RiskThresholds{Critical: 0.5, High: 0.7}},
+		"critical above cap":   {MaxScore: 0.7},
+		"cap above one":        {MaxScore: 1.5},
+	} {
+		if _, err := NewBridge(BridgeConfig{Weights: weights, Logger: zerolog.Nop()}); err == nil {
+			t.Errorf("%s: expected NewBridge to fail", name)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ptypes "github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// Filter selects the pending transactions worth analysing; the rest are
+// dropped before they reach the handlers. The zero Filter passes
+// everything.
+type Filter struct {
+	// Allow, if set, passes only transactions to these addresses, so
+	// contract creations are dropped too
+	Allow []common.Address
+	// Deny drops transactions to these addresses
+	Deny []common.Address
+	// MinValue drops transactions sending less wei (nil = no minimum)
+	MinValue *big.Int
+	// MinGas drops transactions with a lower gas limit
+	MinGas uint64
+}
+
+// txFilter is a Filter with its address lists indexed
+type txFilter struct {
+	allow    map[common.Address]struct{}
+	deny     map[common.Address]struct{}
+	minValue *big.Int
+	minGas   uint64
+}
+
+func newTxFilter(f Filter) txFilter {
+	filter := txFilter{minValue: f.MinValue, minGas: f.MinGas}
+	if len(f.Allow) > 0 {
+		filter.allow = addressSet(f.Allow)
+	}
+	if len(f.Deny) > 0 {
+		filter.deny = addressSet(f.Deny)
+	}
+	return filter
+}
+
+func addressSet(addrs []common.Address) map[common.Address]struct{} {
+	set := make(map[common.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		set[addr] = struct{}{}
+	}
+	return set
+}
+
+// allows reports whether tx passes the filter
+func (f txFilter) allows(tx *ptypes.PendingTransaction) bool {
+	if tx.Gas < f.minGas {
+		return false
+	}
+	if f.minValue != nil && (tx.Value == nil || tx.Value.Cmp(f.minValue) < 0) {
+		return false
+	}
+
+	if tx.To == nil {
+		return f.allow == nil
+	}
+	if _, denied := f.deny[*tx.To]; denied {
+		return false
+	}
+	if f.allow != nil {
+		_, allowed := f.allow[*tx.To]
+		return allowed
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package mempool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ptypes "github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var (
+	watchedProtocol = common.HexToAddress("0xaa")
+	otherProtocol   = common.HexToAddress("0xbb")
+)
+
+func pendingTo(to *common.Address, value int64, gas uint64) *ptypes.PendingTransaction {
+	return &ptypes.PendingTransaction{To: to, Value: big.NewInt(value), Gas: gas}
+}
+
+func TestFilter_Allowlist(t *testing.T) {
+	filter := newTxFilter(Filter{Allow: []common.Address{watchedProtocol}})
+
+	if !filter.allows(pendingTo(&watchedProtocol, 0, 21000)) {
+		t.Error("Expected a transaction to an allowed address passed")
+	}
+	if filter.allows(pendingTo(&otherProtocol, 0, 21000)) {
+		t.Error("Expected a transaction to another address dropped")
+	}
+	if filter.allows(pendingTo(nil, 0, 21000)) {
+		t.Error("Expected a contract creation dropped under an allowlist")
+	}
+}
+
+func TestFilter_Denylist(t *testing.T) {
+	filter := newTxFilter(Filter{Deny: []common.Address{otherProtocol}})
+
+	if filter.allows(pendingTo(&otherProtocol, 0, 21000)) {
+		t.Error("Expected a transaction to a denied address dropped")
+	}
+	if !filter.allows(pendingTo(&watchedProtocol, 0, 21000)) || !filter.allows(pendingTo(nil, 0, 21000)) {
+		t.Error("Expected everything else passed")
+	}
+
+	// Denial wins over an allowlist entry
+	both := newTxFilter(Filter{Allow: []common.Address{otherProtocol}, Deny: []common.Address{otherProtocol}})
+	if both.allows(pendingTo(&otherProtocol, 0, 21000)) {
+		t.Error("Expected a denied address dropped even when allowed")
+	}
+}
+
+func TestFilter_Thresholds(t *testing.T) {
+	filter := newTxFilter(Filter{MinValue: big.NewInt(1e18), MinGas: 100000})
+
+	for _, tc := range []struct {
+		value int64
+		gas   uint64
+		want  bool
+	}{
+		{1e18, 100000, true},
+		{2e18, 500000, true},
+		{1e18 - 1, 500000, false},
+		{2e18, 99999, false},
+	} {
+		if got := filter.allows(pendingTo(&watchedProtocol, tc.value, tc.gas)); got != tc.want {
+			t.Errorf("Value %d, gas %d: expected allowed %v, got %v", tc.value, tc.gas, tc.want, got)
+		}
+	}
+
+	if !newTxFilter(Filter{}).allows(pendingTo(nil, 0, 0)) {
+		t.Error("Expected the zero filter to pass everything")
+	}
+}
+
+func TestListener_FilterDropsBeforeQueueing(t *testing.T) {
+	l := newTestListener(10)
+	l.filter = newTxFilter(Filter{Allow: []common.Address{watchedProtocol}})
+
+	l.deliver(pendingTo(&otherProtocol, 0, 21000))
+	l.deliver(pendingTo(&watchedProtocol, 0, 21000))
+
+	tx, err := l.GetTransaction(context.Background(), time.Second)
+	if err != nil || tx == nil || *tx.To != watchedProtocol {
+		t.Fatalf("Expected only the watched transaction queued, got %+v, %v", tx, err)
+	}
+	if tx, _ := l.GetTransaction(context.Background(), 10*time.Millisecond); tx != nil {
+		t.Errorf("Expected nothing else queued, got %+v", tx)
+	}
+	if filtered := l.Stats().Filtered; filtered != 1 {
+		t.Errorf("Expected 1 transaction filtered, got %d", filtered)
+	}
+}
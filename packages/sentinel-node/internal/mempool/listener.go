@@ -2,76 +2,297 @@ package mempool
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog"
 
 	ptypes "github.com/sentinel-protocol/sentinel-node/pkg/types"
 )
 
+// ErrListenerClosed is returned once the listener has been stopped and its
+// pending transaction channel drained
+var ErrListenerClosed = errors.New("mempool listener closed")
+
+var errSubscriptionClosed = errors.New("pending transaction subscription closed")
+
+// Resubscription backoff after the subscription connection drops
+const (
+	defaultReconnectDelay = time.Second
+	maxReconnectDelay     = 30 * time.Second
+)
+
+// defaultFetchWorkers bounds the transaction lookups in flight at once
+const defaultFetchWorkers = 32
+
+// SubscriptionMethod selects what the pending transaction subscription
+// delivers
+type SubscriptionMethod string
+
+const (
+	// SubscribeHashes receives hashes and fetches each transaction (default)
+	SubscribeHashes SubscriptionMethod = "hashes"
+	// SubscribeGethBodies receives full transactions from Geth's
+	// newPendingTransactions with its full transaction flag set
+	SubscribeGethBodies SubscriptionMethod = "geth"
+	// SubscribeAlchemyBodies receives full transactions from Alchemy's
+	// alchemy_pendingTransactions
+	SubscribeAlchemyBodies SubscriptionMethod = "alchemy"
+)
+
+// ParseSubscriptionMethod converts a config string into a
+// SubscriptionMethod. An empty string yields SubscribeHashes.
+func ParseSubscriptionMethod(s string) (SubscriptionMethod, error) {
+	switch method := SubscriptionMethod(s); method {
+	case "":
+		return SubscribeHashes, nil
+	case SubscribeHashes, SubscribeGethBodies, SubscribeAlchemyBodies:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown subscription method %q (expected %q, %q or %q)",
+			s, SubscribeHashes, SubscribeGethBodies, SubscribeAlchemyBodies)
+	}
+}
+
+// args returns the eth_subscribe parameters for the method
+func (m SubscriptionMethod) args() []interface{} {
+	switch m {
+	case SubscribeGethBodies:
+		return []interface{}{"newPendingTransactions", true}
+	case SubscribeAlchemyBodies:
+		return []interface{}{"alchemy_pendingTransactions"}
+	default:
+		return []interface{}{"newPendingTransactions"}
+	}
+}
+
 type TransactionHandler func(*ptypes.PendingTransaction)
 
 type Listener struct {
-	client     *ethclient.Client
-	wsClient   *ethclient.Client
+	// upstreams serve requests and wsClient the pending transaction
+	// subscription. Without a separate WS URL the subscription shares the
+	// first upstream's client, and a reconnect swaps both under clientMu.
+	upstreams    []*upstream
+	nextUpstream atomic.Uint64
+	wsClient     *ethclient.Client
+	sharedClient bool
+	clientMu     sync.RWMutex
+
+	// subURLs are tried in turn on each reconnect; subIndex is the one
+	// in use, touched only by the listen loop
+	subURLs  []string
+	subIndex int
+
+	dial                func(ctx context.Context, url string) (*ethclient.Client, error)
+	reconnectDelay      time.Duration
+	healthCheckInterval time.Duration
+
 	handlers   []TransactionHandler
 	txChan     chan *ptypes.PendingTransaction
 	bufferSize int
 	running    bool
+	closed     bool
+	done       chan struct{}
+	cancel     context.CancelFunc
 	mu         sync.RWMutex
 	wg         sync.WaitGroup
 	logger     zerolog.Logger
 
+	// hashes queues subscribed hashes for the fetch workers
+	hashes       chan common.Hash
+	fetchWorkers int
+
+	// method is switched to SubscribeHashes, under mu, if the provider
+	// refuses a full-body subscription
+	method SubscriptionMethod
+
+	filter txFilter
+	// seen drops hashes already received, whichever source sent them
+	seen *seenHashes
+
+	maxGasPrice *big.Int
+
 	stats struct {
-		received  uint64
-		processed uint64
-		dropped   uint64
+		received      atomic.Uint64
+		processed     atomic.Uint64
+		dropped       atomic.Uint64
+		droppedHashes atomic.Uint64
+		reconnects    atomic.Uint64
+		filtered      atomic.Uint64
+		duplicates    atomic.Uint64
 	}
 }
 
+// ListenerStats counts a listener's traffic since it was created
+type ListenerStats struct {
+	// Received is pending transaction hashes delivered by the subscription
+	Received uint64
+	// Processed is transactions handed to the handlers
+	Processed uint64
+	// Dropped is fetched transactions discarded because processing fell
+	// behind
+	Dropped uint64
+	// DroppedHashes is hashes discarded unfetched because every worker
+	// was busy and the hash queue full
+	DroppedHashes uint64
+	// Reconnects is how many times the subscription client was redialed
+	// after the subscription failed
+	Reconnects uint64
+	// Filtered is transactions the Filter kept from the handlers
+	Filtered uint64
+	// Duplicates is hashes dropped because they had already been received
+	// within the seen-hash TTL
+	Duplicates uint64
+}
+
 type ListenerConfig struct {
-	RPCURL     string
-	WSURL      string
-	BufferSize int
-	Logger     zerolog.Logger
+	RPCURL string
+	WSURL  string
+	// RPCURLs and WSURLs are further endpoints behind RPCURL and WSURL.
+	// Requests are spread across the healthy RPC endpoints and fail over
+	// between them; the subscription moves to the next WS endpoint each
+	// time it reconnects.
+	RPCURLs []string
+	WSURLs  []string
+	// HealthCheckInterval is how often RPC endpoints are probed when
+	// there are several (0 = 15s)
+	HealthCheckInterval time.Duration
+	BufferSize          int
+	// FetchWorkers is how many transaction lookups run at once (0 = 32)
+	FetchWorkers int
+	// SubscriptionMethod picks hash or full-body notifications; full
+	// bodies skip the per-transaction lookup where the provider serves them
+	SubscriptionMethod SubscriptionMethod
+	// Filter drops transactions before they are queued for the handlers
+	Filter Filter
+	// SeenCacheSize and SeenCacheTTL bound the hashes remembered to drop
+	// repeats (0 = 100000 and 10m)
+	SeenCacheSize int
+	SeenCacheTTL  time.Duration
+	Logger        zerolog.Logger
+	// MaxGasPrice caps GetGasPrice, the price node-initiated transactions
+	// bid (nil = uncapped)
+	MaxGasPrice *big.Int
 }
 
 func NewListener(cfg ListenerConfig) (*Listener, error) {
-	client, err := ethclient.Dial(cfg.RPCURL)
+	method, err := ParseSubscriptionMethod(string(cfg.SubscriptionMethod))
 	if err != nil {
 		return nil, err
 	}
 
-	var wsClient *ethclient.Client
-	if cfg.WSURL != "" {
-		wsClient, err = ethclient.Dial(cfg.WSURL)
+	rpcURLs := cfg.RPCURLs
+	if cfg.RPCURL != "" || len(rpcURLs) == 0 {
+		rpcURLs = append([]string{cfg.RPCURL}, rpcURLs...)
+	}
+	upstreams := make([]*upstream, 0, len(rpcURLs))
+	closeUpstreams := func() {
+		for _, u := range upstreams {
+			u.client.Close()
+		}
+	}
+	for _, rpcURL := range rpcURLs {
+		client, err := ethclient.Dial(rpcURL)
+		if err != nil {
+			closeUpstreams()
+			return nil, err
+		}
+		upstreams = append(upstreams, newUpstream(rpcURL, client))
+	}
+
+	var wsURLs []string
+	if cfg.WSURL != "" && cfg.WSURL != rpcURLs[0] {
+		wsURLs = append(wsURLs, cfg.WSURL)
+	}
+	wsURLs = append(wsURLs, cfg.WSURLs...)
+
+	wsClient, subURLs, shared := upstreams[0].client, rpcURLs[:1], true
+	if len(wsURLs) > 0 {
+		wsClient, err = ethclient.Dial(wsURLs[0])
 		if err != nil {
-			client.Close()
+			closeUpstreams()
 			return nil, err
 		}
+		subURLs, shared = wsURLs, false
+	}
+
+	healthCheckInterval := cfg.HealthCheckInterval
+	if healthCheckInterval <= 0 {
+		healthCheckInterval = defaultHealthCheckInterval
 	}
 
 	bufferSize := cfg.BufferSize
 	if bufferSize == 0 {
 		bufferSize = 10000
 	}
+	fetchWorkers := cfg.FetchWorkers
+	if fetchWorkers <= 0 {
+		fetchWorkers = defaultFetchWorkers
+	}
 
 	return &Listener{
-		client:     client,
-		wsClient:   wsClient,
-		handlers:   make([]TransactionHandler, 0),
-		txChan:     make(chan *ptypes.PendingTransaction, bufferSize),
-		bufferSize: bufferSize,
-		logger:     cfg.Logger,
+		upstreams:           upstreams,
+		wsClient:            wsClient,
+		sharedClient:        shared,
+		subURLs:             subURLs,
+		dial:                ethclient.DialContext,
+		reconnectDelay:      defaultReconnectDelay,
+		healthCheckInterval: healthCheckInterval,
+		handlers:            make([]TransactionHandler, 0),
+		txChan:              make(chan *ptypes.PendingTransaction, bufferSize),
+		bufferSize:          bufferSize,
+		done:                make(chan struct{}),
+		hashes:              make(chan common.Hash, bufferSize),
+		fetchWorkers:        fetchWorkers,
+		method:              method,
+		filter:              newTxFilter(cfg.Filter),
+		seen:                newSeenHashes(cfg.SeenCacheSize, cfg.SeenCacheTTL),
+		logger:              cfg.Logger,
+		maxGasPrice:         cfg.MaxGasPrice,
 	}, nil
 }
 
+func (l *Listener) subscriptionClient() *ethclient.Client {
+	l.clientMu.RLock()
+	defer l.clientMu.RUnlock()
+	return l.wsClient
+}
+
+// reconnect dials a fresh subscription client on the next subscription
+// endpoint and closes the dropped one. When requests share the
+// subscription's connection the request client is swapped in the same
+// critical section, so no fetch keeps using a dead client.
+func (l *Listener) reconnect(ctx context.Context) error {
+	l.subIndex = (l.subIndex + 1) % len(l.subURLs)
+	client, err := l.dial(ctx, l.subURLs[l.subIndex])
+	if err != nil {
+		return err
+	}
+
+	l.clientMu.Lock()
+	old := l.wsClient
+	l.wsClient = client
+	if l.sharedClient {
+		l.upstreams[0].client = client
+	}
+	l.clientMu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
 func (l *Listener) AddHandler(handler TransactionHandler) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -80,95 +301,314 @@ func (l *Listener) AddHandler(handler TransactionHandler) {
 
 func (l *Listener) Start(ctx context.Context) error {
 	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return ErrListenerClosed
+	}
 	if l.running {
 		l.mu.Unlock()
 		return nil
 	}
+	// Canceled by Stop so lookups in flight don't hold it up
+	ctx, l.cancel = context.WithCancel(ctx)
 	l.running = true
 	l.mu.Unlock()
 
-	l.wg.Add(2)
+	l.wg.Add(2 + l.fetchWorkers)
 	go l.listenLoop(ctx)
 	go l.processLoop(ctx)
+	for i := 0; i < l.fetchWorkers; i++ {
+		go l.fetchLoop(ctx)
+	}
+	// With a single upstream there is nothing to fail over to
+	if len(l.upstreams) > 1 {
+		l.wg.Add(1)
+		go l.healthLoop(ctx)
+	}
 
 	l.logger.Info().Msg("Mempool listener started")
 	return nil
 }
 
+// Stop halts the loops and, once they have exited, closes the pending
+// transaction channel so blocked GetTransaction callers return
+// ErrListenerClosed. Stopping twice is a no-op.
 func (l *Listener) Stop() {
 	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
 	l.running = false
+	cancel := l.cancel
 	l.mu.Unlock()
 
+	close(l.done)
+	if cancel != nil {
+		cancel()
+	}
 	l.wg.Wait()
 
-	if l.client != nil {
-		l.client.Close()
+	// Fetches still in flight check closed under the lock before sending
+	l.mu.Lock()
+	l.closed = true
+	close(l.txChan)
+	l.mu.Unlock()
+
+	l.clientMu.Lock()
+	for _, u := range l.upstreams {
+		u.client.Close()
 	}
-	if l.wsClient != nil {
+	if l.wsClient != nil && !l.sharedClient {
 		l.wsClient.Close()
 	}
+	l.clientMu.Unlock()
 
+	stats := l.Stats()
 	l.logger.Info().
-		Uint64("received", l.stats.received).
-		Uint64("processed", l.stats.processed).
-		Uint64("dropped", l.stats.dropped).
+		Uint64("received", stats.Received).
+		Uint64("processed", stats.Processed).
+		Uint64("dropped", stats.Dropped).
+		Uint64("droppedHashes", stats.DroppedHashes).
+		Uint64("reconnects", stats.Reconnects).
+		Uint64("filtered", stats.Filtered).
+		Uint64("duplicates", stats.Duplicates).
 		Msg("Mempool listener stopped")
 }
 
 func (l *Listener) listenLoop(ctx context.Context) {
 	defer l.wg.Done()
 
-	client := l.wsClient
-	if client == nil {
-		client = l.client
+	delay := l.reconnectDelay
+	attempt := 0
+	for {
+		subscribed, err := l.subscribe(ctx)
+		if err == nil {
+			return
+		}
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			l.logger.Error().Err(err).Msg("Provider does not support pending transaction subscriptions")
+			return
+		}
+		if !subscribed && l.fallBackToHashes(err) {
+			continue
+		}
+
+		// Backoff grows until a subscription is established again; one
+		// that was up before failing is a fresh drop and starts over
+		if subscribed {
+			delay, attempt = l.reconnectDelay, 0
+		}
+		attempt++
+
+		l.logger.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Dur("retryIn", delay).
+			Msg("Pending transaction subscription lost, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.done:
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+
+		if err := l.reconnect(ctx); err != nil {
+			l.logger.Warn().Err(err).Int("attempt", attempt).Msg("Failed to reconnect mempool client")
+			continue
+		}
+		l.stats.reconnects.Add(1)
+	}
+}
+
+// fallBackToHashes switches a full-body subscription the provider refused
+// to hashes, reporting whether it did. A JSON-RPC error means the provider
+// answered and doesn't serve the method, as opposed to a dropped
+// connection worth retrying.
+func (l *Listener) fallBackToHashes(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
 	}
 
-	pendingTxChan := make(chan common.Hash, l.bufferSize)
+	l.mu.Lock()
+	method := l.method
+	if method == SubscribeHashes {
+		l.mu.Unlock()
+		return false
+	}
+	l.method = SubscribeHashes
+	l.mu.Unlock()
 
-	sub, err := client.Client().EthSubscribe(ctx, pendingTxChan, "newPendingTransactions")
+	l.logger.Warn().
+		Err(err).
+		Str("method", string(method)).
+		Msg("Provider refused full-body pending transaction subscription, subscribing to hashes")
+	return true
+}
+
+func (l *Listener) subscriptionMethod() SubscriptionMethod {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.method
+}
+
+// subscribe streams pending transactions until the subscription fails,
+// returning the failure, or the listener shuts down, returning nil. It
+// reports whether the subscription was established.
+func (l *Listener) subscribe(ctx context.Context) (bool, error) {
+	method := l.subscriptionMethod()
+	notifications := make(chan json.RawMessage, l.bufferSize)
+
+	sub, err := l.subscriptionClient().Client().EthSubscribe(ctx, notifications, method.args()...)
 	if err != nil {
-		l.logger.Error().Err(err).Msg("Failed to subscribe to pending transactions")
-		return
+		return false, err
 	}
 	defer sub.Unsubscribe()
 
-	l.logger.Info().Msg("Subscribed to pending transactions")
+	l.logger.Info().Str("method", string(method)).Msg("Subscribed to pending transactions")
 
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return true, nil
+		case <-l.done:
+			return true, nil
 		case err := <-sub.Err():
-			l.logger.Error().Err(err).Msg("Subscription error")
-			return
-		case txHash := <-pendingTxChan:
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			return true, err
+		case notification := <-notifications:
 			l.mu.RLock()
 			running := l.running
 			l.mu.RUnlock()
 			if !running {
-				return
+				return true, nil
 			}
 
-			l.stats.received++
+			if method == SubscribeHashes {
+				l.receiveHash(notification)
+			} else {
+				l.receiveBody(notification)
+			}
+		}
+	}
+}
+
+func (l *Listener) receiveHash(notification json.RawMessage) {
+	var txHash common.Hash
+	if err := json.Unmarshal(notification, &txHash); err != nil {
+		l.logger.Debug().Err(err).Msg("Dropped malformed pending transaction hash")
+		return
+	}
+	l.queueHash(txHash)
+}
 
-			go l.fetchAndEnqueue(ctx, txHash)
+// receiveBody enqueues a transaction delivered in full, with no lookup.
+// One that doesn't decode is fetched by its hash instead, if it has one.
+func (l *Listener) receiveBody(notification json.RawMessage) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalJSON(notification); err != nil {
+		var body struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if json.Unmarshal(notification, &body) == nil && body.Hash != (common.Hash{}) {
+			l.queueHash(body.Hash)
+			return
+		}
+		l.logger.Debug().Err(err).Msg("Dropped malformed pending transaction")
+		return
+	}
+
+	l.stats.received.Add(1)
+	if l.seen.checkAndAdd(tx.Hash()) {
+		l.stats.duplicates.Add(1)
+		return
+	}
+	l.deliver(l.convertTransaction(tx, tx.Hash()))
+}
+
+// queueHash hands a hash to the fetch workers without blocking the
+// subscription, dropping it when they are all busy and the queue is full
+func (l *Listener) queueHash(txHash common.Hash) {
+	l.stats.received.Add(1)
+
+	select {
+	case l.hashes <- txHash:
+	default:
+		l.stats.droppedHashes.Add(1)
+	}
+}
+
+// fetchLoop looks up queued hashes one at a time, so a mempool burst costs
+// at most fetchWorkers concurrent RPC calls
+func (l *Listener) fetchLoop(ctx context.Context) {
+	defer l.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.done:
+			return
+		case txHash := <-l.hashes:
+			l.fetchAndEnqueue(ctx, txHash)
 		}
 	}
 }
 
 func (l *Listener) fetchAndEnqueue(ctx context.Context, txHash common.Hash) {
-	tx, isPending, err := l.client.TransactionByHash(ctx, txHash)
-	if err != nil || !isPending {
+	if l.seen.checkAndAdd(txHash) {
+		l.stats.duplicates.Add(1)
+		return
+	}
+
+	var tx *types.Transaction
+	var isPending bool
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		tx, isPending, err = client.TransactionByHash(ctx, txHash)
+		return err
+	})
+	if err != nil {
+		// Not known yet, or no upstream answered: let a re-announcement
+		// fetch it again
+		l.seen.forget(txHash)
+		return
+	}
+	if !isPending {
+		return
+	}
+
+	l.deliver(l.convertTransaction(tx, txHash))
+}
+
+// deliver enqueues tx if it passes the filter
+func (l *Listener) deliver(tx *ptypes.PendingTransaction) {
+	if !l.filter.allows(tx) {
+		l.stats.filtered.Add(1)
 		return
 	}
+	l.enqueue(tx)
+}
 
-	pendingTx := l.convertTransaction(tx, txHash)
+// enqueue hands tx to the process loop without blocking. Fetches can finish
+// after Stop, so the send happens under the lock and only while open.
+func (l *Listener) enqueue(tx *ptypes.PendingTransaction) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.closed {
+		return
+	}
 
 	select {
-	case l.txChan <- pendingTx:
+	case l.txChan <- tx:
 	default:
-		l.stats.dropped++
+		l.stats.dropped.Add(1)
 	}
 }
 
@@ -179,6 +619,8 @@ func (l *Listener) processLoop(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-l.done:
+			return
 		case tx := <-l.txChan:
 			l.mu.RLock()
 			running := l.running
@@ -190,7 +632,7 @@ func (l *Listener) processLoop(ctx context.Context) {
 				return
 			}
 
-			l.stats.processed++
+			l.stats.processed.Add(1)
 
 			for _, handler := range handlers {
 				handler(tx)
@@ -228,11 +670,16 @@ func (l *Listener) convertTransaction(tx *types.Transaction, hash common.Hash) *
 	}
 }
 
+// GetTransaction waits up to timeout for the next pending transaction,
+// returning nil on timeout and ErrListenerClosed after Stop
 func (l *Listener) GetTransaction(ctx context.Context, timeout time.Duration) (*ptypes.PendingTransaction, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
-	case tx := <-l.txChan:
+	case tx, ok := <-l.txChan:
+		if !ok {
+			return nil, ErrListenerClosed
+		}
 		return tx, nil
 	case <-time.After(timeout):
 		return nil, nil
@@ -240,7 +687,20 @@ func (l *Listener) GetTransaction(ctx context.Context, timeout time.Duration) (*
 }
 
 func (l *Listener) GetStats() (received, processed, dropped uint64) {
-	return l.stats.received, l.stats.processed, l.stats.dropped
+	return l.stats.received.Load(), l.stats.processed.Load(), l.stats.dropped.Load()
+}
+
+// Stats returns all of the listener's counters
+func (l *Listener) Stats() ListenerStats {
+	return ListenerStats{
+		Received:      l.stats.received.Load(),
+		Processed:     l.stats.processed.Load(),
+		Dropped:       l.stats.dropped.Load(),
+		DroppedHashes: l.stats.droppedHashes.Load(),
+		Reconnects:    l.stats.reconnects.Load(),
+		Filtered:      l.stats.filtered.Load(),
+		Duplicates:    l.stats.duplicates.Load(),
+	}
 }
 
 func (l *Listener) SimulateTransaction(ctx context.Context, tx *ptypes.PendingTransaction) ([]byte, error) {
@@ -260,7 +720,11 @@ func (l *Listener) SimulateTransaction(ctx context.Context, tx *ptypes.PendingTr
 		Data:       tx.Input,
 	}
 
-	result, err := l.client.CallContract(ctx, msg, nil)
+	var result []byte
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		result, err = client.CallContract(ctx, msg, nil)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -268,10 +732,80 @@ func (l *Listener) SimulateTransaction(ctx context.Context, tx *ptypes.PendingTr
 	return result, nil
 }
 
+// GetGasPrice returns the suggested gas price, capped at MaxGasPrice so a
+// fee spike can't make the node overpay
 func (l *Listener) GetGasPrice(ctx context.Context) (*big.Int, error) {
-	return l.client.SuggestGasPrice(ctx)
+	var price *big.Int
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if l.maxGasPrice != nil && price.Cmp(l.maxGasPrice) > 0 {
+		return new(big.Int).Set(l.maxGasPrice), nil
+	}
+	return price, nil
 }
 
 func (l *Listener) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
-	return l.client.PendingNonceAt(ctx, address)
+	var nonce uint64
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, address)
+		return err
+	})
+	return nonce, err
+}
+
+func (l *Listener) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (l *Listener) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (l *Listener) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (l *Listener) BlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := l.failover(ctx, func(client *ethclient.Client) (err error) {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+// CheckSubscription opens a pending transaction subscription and drops it
+// straight away, reporting whether the provider will serve one. A refused
+// full-body subscription falls back to hashes, as the listener would.
+func (l *Listener) CheckSubscription(ctx context.Context) error {
+	notifications := make(chan json.RawMessage, 1)
+	sub, err := l.subscriptionClient().Client().EthSubscribe(ctx, notifications, l.subscriptionMethod().args()...)
+	if err != nil && l.fallBackToHashes(err) {
+		sub, err = l.subscriptionClient().Client().EthSubscribe(ctx, notifications, SubscribeHashes.args()...)
+	}
+	if err != nil {
+		return err
+	}
+	sub.Unsubscribe()
+	return nil
 }
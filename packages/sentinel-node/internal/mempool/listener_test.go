@@ -0,0 +1,568 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+
+	ptypes "github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// newTestListener builds a listener without RPC clients, so only the
+// channel handling is exercised
+func newTestListener(bufferSize int) *Listener {
+	return &Listener{
+		handlers:   make([]TransactionHandler, 0),
+		txChan:     make(chan *ptypes.PendingTransaction, bufferSize),
+		bufferSize: bufferSize,
+		done:       make(chan struct{}),
+		logger:     zerolog.Nop(),
+
+		hashes:       make(chan common.Hash, bufferSize),
+		fetchWorkers: 1,
+		method:       SubscribeHashes,
+	}
+}
+
+// useClient serves both requests and the subscription from client
+func useClient(l *Listener, client *ethclient.Client) {
+	l.upstreams = []*upstream{newUpstream("inproc", client)}
+	l.wsClient, l.sharedClient, l.subURLs = client, true, []string{"inproc"}
+}
+
+func TestListener_GetTransactionUnblocksOnStop(t *testing.T) {
+	l := newTestListener(10)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := l.GetTransaction(context.Background(), time.Minute)
+		errCh <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	l.Stop()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrListenerClosed) {
+			t.Errorf("Expected ErrListenerClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetTransaction still blocked after Stop")
+	}
+}
+
+func TestListener_DrainsBufferedBeforeClosed(t *testing.T) {
+	l := newTestListener(10)
+	l.enqueue(&ptypes.PendingTransaction{Hash: common.HexToHash("0x1")})
+	l.Stop()
+
+	tx, err := l.GetTransaction(context.Background(), time.Second)
+	if err != nil || tx == nil || tx.Hash != common.HexToHash("0x1") {
+		t.Fatalf("Expected the buffered transaction, got %v %v", tx, err)
+	}
+
+	if _, err := l.GetTransaction(context.Background(), time.Second); !errors.Is(err, ErrListenerClosed) {
+		t.Errorf("Expected ErrListenerClosed once drained, got %v", err)
+	}
+}
+
+func TestListener_LateEnqueueAfterStop(t *testing.T) {
+	l := newTestListener(1000)
+
+	// Fetches racing Stop must never send on the closed channel
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.enqueue(&ptypes.PendingTransaction{Nonce: uint64(i)})
+		}(i)
+	}
+
+	l.Stop()
+	wg.Wait()
+
+	l.enqueue(&ptypes.PendingTransaction{})
+	l.Stop()
+
+	if err := l.Start(context.Background()); !errors.Is(err, ErrListenerClosed) {
+		t.Errorf("Expected Start after Stop to fail with ErrListenerClosed, got %v", err)
+	}
+}
+
+// fakeEthService serves the pending transaction subscription and counts
+// the transaction lookups it receives
+type fakeEthService struct {
+	lookups atomic.Int32
+
+	// lookupDelay holds each lookup, tracking how many overlap
+	lookupDelay time.Duration
+	// pendingBody answers lookups with this pending transaction instead of
+	// none, after failing failLookups of them
+	pendingBody json.RawMessage
+	failLookups atomic.Int32
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	// failSubscribes refuses that many subscriptions before serving one
+	failSubscribes atomic.Int32
+	// hashesOnly refuses full transaction subscriptions
+	hashesOnly bool
+
+	// rateLimited refuses block number requests as over quota
+	rateLimited atomic.Bool
+	// reverts has every eth_call revert, counting them in calls
+	reverts bool
+	calls   atomic.Int32
+
+	mu       sync.Mutex
+	notifier *rpc.Notifier
+	sub      *rpc.Subscription
+}
+
+func (s *fakeEthService) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	if fullTx != nil && *fullTx && s.hashesOnly {
+		return nil, errors.New("full transactions not supported")
+	}
+	if s.failSubscribes.Add(-1) >= 0 {
+		return nil, errors.New("subscription limit reached")
+	}
+
+	sub := notifier.CreateSubscription()
+	s.mu.Lock()
+	s.notifier, s.sub = notifier, sub
+	s.mu.Unlock()
+	return sub, nil
+}
+
+func (s *fakeEthService) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	s.calls.Add(1)
+	if s.reverts {
+		return nil, revertError{}
+	}
+	return hexutil.Bytes{0x01}, nil
+}
+
+func (s *fakeEthService) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1e9))
+}
+
+func (s *fakeEthService) BlockNumber() (hexutil.Uint64, error) {
+	if s.rateLimited.Load() {
+		return 0, rateLimitError{}
+	}
+	return 100, nil
+}
+
+// rateLimitError is how providers refuse calls over the plan's quota
+type rateLimitError struct{}
+
+func (rateLimitError) Error() string  { return "daily request count exceeded, request rate limited" }
+func (rateLimitError) ErrorCode() int { return -32005 }
+
+// revertError is an eth_call reverting with its revert data
+type revertError struct{}
+
+func (revertError) Error() string          { return "execution reverted" }
+func (revertError) ErrorCode() int         { return 3 }
+func (revertError) ErrorData() interface{} { return "0x08c379a0" }
+
+// notify sends a hash or transaction on the latest subscription,
+// reporting whether there was one
+func (s *fakeEthService) notify(notification interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sub == nil {
+		return false
+	}
+	return s.notifier.Notify(s.sub.ID, notification) == nil
+}
+
+func (s *fakeEthService) GetTransactionByHash(hash common.Hash) (json.RawMessage, error) {
+	s.lookups.Add(1)
+	if s.failLookups.Add(-1) >= 0 {
+		return nil, errors.New("upstream unavailable")
+	}
+
+	inFlight := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.maxInFlight.Load()
+		if inFlight <= peak || s.maxInFlight.CompareAndSwap(peak, inFlight) {
+			break
+		}
+	}
+	time.Sleep(s.lookupDelay)
+	return s.pendingBody, nil
+}
+
+func newFakeEthServer(t *testing.T) (*rpc.Server, *fakeEthService) {
+	t.Helper()
+	service := &fakeEthService{}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatalf("RegisterName failed: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	return server, service
+}
+
+func TestListener_ReconnectSwapsRequestClient(t *testing.T) {
+	server1, service1 := newFakeEthServer(t)
+	server2, service2 := newFakeEthServer(t)
+
+	first := ethclient.NewClient(rpc.DialInProc(server1))
+	l := newTestListener(10)
+	useClient(l, first)
+	l.reconnectDelay = 10 * time.Millisecond
+	l.dial = func(ctx context.Context, url string) (*ethclient.Client, error) {
+		return ethclient.NewClient(rpc.DialInProc(server2)), nil
+	}
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer l.Stop()
+
+	// Drop the connection the subscription and requests share
+	time.Sleep(20 * time.Millisecond)
+	first.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for l.requestClient() == first {
+		if time.Now().After(deadline) {
+			t.Fatal("Listener did not reconnect after the connection dropped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if l.subscriptionClient() != l.requestClient() {
+		t.Error("Shared subscription and request clients diverged after reconnect")
+	}
+
+	before := service1.lookups.Load()
+	if _, _, err := l.requestClient().TransactionByHash(context.Background(), common.HexToHash("0x1")); err == nil {
+		t.Error("Expected not found from the fake service")
+	}
+
+	if service2.lookups.Load() != 1 {
+		t.Errorf("Expected the lookup on the new connection, got %d", service2.lookups.Load())
+	}
+	if service1.lookups.Load() != before {
+		t.Error("Lookup went to the dropped connection")
+	}
+}
+
+func TestListener_BoundsConcurrentFetches(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	service.lookupDelay = 5 * time.Millisecond
+
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	l := newTestListener(50)
+	useClient(l, client)
+	l.fetchWorkers = 4
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer l.Stop()
+
+	// A burst far beyond what the workers and their queue can take
+	for i := 0; i < 1000; i++ {
+		l.queueHash(common.BigToHash(big.NewInt(int64(i))))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(l.hashes) > 0 || service.inFlight.Load() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Fetch workers did not drain the queue")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if peak := service.maxInFlight.Load(); peak > 4 {
+		t.Errorf("Expected at most 4 lookups in flight, got %d", peak)
+	}
+	stats := l.Stats()
+	if stats.Received != 1000 || stats.DroppedHashes == 0 {
+		t.Errorf("Expected all 1000 hashes received and the overflow dropped, got %+v", stats)
+	}
+	if fetched := uint64(service.lookups.Load()); fetched+stats.DroppedHashes != 1000 {
+		t.Errorf("Expected every hash either fetched or dropped, got %d fetched, %d dropped", fetched, stats.DroppedHashes)
+	}
+}
+
+func TestListener_ResubscribesAfterFailure(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	service.failSubscribes.Store(1)
+
+	l := newTestListener(10)
+	useClient(l, ethclient.NewClient(rpc.DialInProc(server)))
+	l.reconnectDelay = 10 * time.Millisecond
+	l.dial = func(ctx context.Context, url string) (*ethclient.Client, error) {
+		return ethclient.NewClient(rpc.DialInProc(server)), nil
+	}
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer l.Stop()
+
+	// The first subscription is refused; the retry is served
+	deadline := time.Now().Add(2 * time.Second)
+	for !service.notify(common.HexToHash("0x1")) {
+		if time.Now().After(deadline) {
+			t.Fatal("Listener did not resubscribe after the subscription failed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	for l.Stats().Received == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("No hashes received on the new subscription")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if reconnects := l.Stats().Reconnects; reconnects != 1 {
+		t.Errorf("Expected 1 reconnect, got %d", reconnects)
+	}
+}
+
+// startSubscribedListener starts a listener with the given subscription
+// method against service, returning once a subscription is being served
+func startSubscribedListener(t *testing.T, server *rpc.Server, service *fakeEthService, method SubscriptionMethod) *Listener {
+	t.Helper()
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	l := newTestListener(10)
+	useClient(l, client)
+	l.method = method
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(l.Stop)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.mu.Lock()
+		subscribed := service.sub != nil
+		service.mu.Unlock()
+		if subscribed {
+			return l
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Listener did not subscribe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestListener_FullBodySubscription(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	l := startSubscribedListener(t, server, service, SubscribeGethBodies)
+	delivered := make(chan *ptypes.PendingTransaction, 1)
+	l.AddHandler(func(tx *ptypes.PendingTransaction) { delivered <- tx })
+
+	key, _ := crypto.GenerateKey()
+	to := common.HexToAddress("0xdead")
+	signed, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		Data:      []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+	if err != nil {
+		t.Fatalf("SignNewTx failed: %v", err)
+	}
+	body, _ := signed.MarshalJSON()
+	service.notify(json.RawMessage(body))
+
+	var tx *ptypes.PendingTransaction
+	select {
+	case tx = <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the transaction delivered to handlers")
+	}
+	if tx.Hash != signed.Hash() || tx.From != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("Unexpected hash %s or sender %s", tx.Hash.Hex(), tx.From.Hex())
+	}
+	if *tx.To != to || tx.Value.Cmp(big.NewInt(1e18)) != 0 || tx.Nonce != 7 || len(tx.Input) != 4 {
+		t.Errorf("Unexpected transaction %+v", tx)
+	}
+	if tx.MaxFeePerGas.Cmp(big.NewInt(30e9)) != 0 || tx.MaxPriorityFeePerGas.Cmp(big.NewInt(2e9)) != 0 {
+		t.Errorf("Unexpected fees %s / %s", tx.MaxFeePerGas, tx.MaxPriorityFeePerGas)
+	}
+	if lookups := service.lookups.Load(); lookups != 0 {
+		t.Errorf("Expected no lookup for a full-body notification, got %d", lookups)
+	}
+
+	// A body that doesn't decode is fetched by its hash instead
+	service.notify(map[string]interface{}{"hash": common.HexToHash("0x2")})
+	deadline := time.Now().Add(2 * time.Second)
+	for service.lookups.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the undecodable transaction fetched by hash")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestListener_FullBodyFallsBackToHashes(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	service.hashesOnly = true
+	l := startSubscribedListener(t, server, service, SubscribeGethBodies)
+
+	if method := l.subscriptionMethod(); method != SubscribeHashes {
+		t.Fatalf("Expected a fallback to hashes, got %s", method)
+	}
+
+	service.notify(common.HexToHash("0x1"))
+	deadline := time.Now().Add(2 * time.Second)
+	for service.lookups.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the hash fetched after falling back")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if reconnects := l.Stats().Reconnects; reconnects != 0 {
+		t.Errorf("Expected the fallback without a reconnect, got %d", reconnects)
+	}
+}
+
+func TestParseSubscriptionMethod(t *testing.T) {
+	for input, want := range map[string]SubscriptionMethod{
+		"":        SubscribeHashes,
+		"hashes":  SubscribeHashes,
+		"geth":    SubscribeGethBodies,
+		"alchemy": SubscribeAlchemyBodies,
+	} {
+		if got, err := ParseSubscriptionMethod(input); err != nil || got != want {
+			t.Errorf("ParseSubscriptionMethod(%q) = %s, %v; want %s", input, got, err, want)
+		}
+	}
+	if _, err := ParseSubscriptionMethod("bodies"); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+}
+
+func TestListener_FailsOverToHealthyUpstream(t *testing.T) {
+	primaryServer, primary := newFakeEthServer(t)
+	secondaryServer, secondary := newFakeEthServer(t)
+
+	l := newTestListener(10)
+	l.upstreams = []*upstream{
+		newUpstream("http://primary.example/v2/key", ethclient.NewClient(rpc.DialInProc(primaryServer))),
+		newUpstream("http://secondary.example/v2/key", ethclient.NewClient(rpc.DialInProc(secondaryServer))),
+	}
+	// The primary's connection drops, and round-robin picks it next
+	l.upstreams[0].client.Close()
+	l.nextUpstream.Store(1)
+
+	ctx := context.Background()
+	price, err := l.GetGasPrice(ctx)
+	if err != nil || price.Cmp(big.NewInt(1e9)) != 0 {
+		t.Fatalf("Expected the gas price from the secondary, got %v, %v", price, err)
+	}
+	if l.upstreams[0].healthy.Load() {
+		t.Fatal("Expected the failed primary marked unhealthy")
+	}
+
+	to := common.HexToAddress("0xdead")
+	if result, err := l.SimulateTransaction(ctx, &ptypes.PendingTransaction{To: &to}); err != nil || len(result) != 1 {
+		t.Errorf("Expected the call served by the secondary, got %x, %v", result, err)
+	}
+
+	// Lookups skip the unhealthy primary entirely
+	for i := 0; i < 4; i++ {
+		l.fetchAndEnqueue(ctx, common.HexToHash("0x1"))
+	}
+	if primary.lookups.Load() != 0 || secondary.lookups.Load() != 4 {
+		t.Errorf("Expected every lookup on the secondary, got %d primary, %d secondary",
+			primary.lookups.Load(), secondary.lookups.Load())
+	}
+
+	// A JSON-RPC error is tried on the next upstream, without marking
+	// either unhealthy
+	l.upstreams[0].healthy.Store(true)
+	l.upstreams[0].client = ethclient.NewClient(rpc.DialInProc(primaryServer))
+	if err := l.failover(ctx, func(client *ethclient.Client) error {
+		return client.Client().Call(nil, "eth_unknownMethod")
+	}); err == nil || !l.upstreams[0].healthy.Load() || !l.upstreams[1].healthy.Load() {
+		t.Errorf("Expected a JSON-RPC error returned without marking upstreams unhealthy, got %v", err)
+	}
+}
+
+func TestListener_FailsOverOnJSONRPCError(t *testing.T) {
+	primaryServer, primary := newFakeEthServer(t)
+	secondaryServer, secondary := newFakeEthServer(t)
+
+	l := newTestListener(10)
+	l.upstreams = []*upstream{
+		newUpstream("http://primary.example", ethclient.NewClient(rpc.DialInProc(primaryServer))),
+		newUpstream("http://secondary.example", ethclient.NewClient(rpc.DialInProc(secondaryServer))),
+	}
+	ctx := context.Background()
+
+	// The primary is over quota, but still up
+	primary.rateLimited.Store(true)
+	l.nextUpstream.Store(1)
+	if number, err := l.BlockNumber(ctx); err != nil || number != 100 {
+		t.Fatalf("Expected the block number from the secondary, got %d, %v", number, err)
+	}
+	if !l.upstreams[0].healthy.Load() {
+		t.Error("Expected a rate-limited upstream kept in rotation")
+	}
+
+	// A revert is the answer on every upstream, so it isn't retried
+	primary.reverts, secondary.reverts = true, true
+	l.nextUpstream.Store(1)
+	to := common.HexToAddress("0xdead")
+	if _, err := l.SimulateTransaction(ctx, &ptypes.PendingTransaction{To: &to}); err == nil {
+		t.Fatal("Expected the revert returned")
+	}
+	if primary.calls.Load() != 1 || secondary.calls.Load() != 0 {
+		t.Errorf("Expected the revert from the first upstream only, got %d primary, %d secondary calls",
+			primary.calls.Load(), secondary.calls.Load())
+	}
+}
+
+func TestListener_HealthCheckRestoresUpstream(t *testing.T) {
+	server, _ := newFakeEthServer(t)
+	l := newTestListener(10)
+	l.upstreams = []*upstream{
+		newUpstream("http://primary.example", ethclient.NewClient(rpc.DialInProc(server))),
+		newUpstream("http://secondary.example", ethclient.NewClient(rpc.DialInProc(server))),
+	}
+	l.upstreams[0].healthy.Store(false)
+	l.upstreams[1].client.Close()
+
+	l.checkUpstreams(context.Background())
+	if !l.upstreams[0].healthy.Load() || l.upstreams[1].healthy.Load() {
+		t.Errorf("Expected health to follow the probes, got primary %v, secondary %v",
+			l.upstreams[0].healthy.Load(), l.upstreams[1].healthy.Load())
+	}
+	if host := l.upstreams[0].host; host != "primary.example" {
+		t.Errorf("Expected the upstream named by host only, got %q", host)
+	}
+}
@@ -0,0 +1,96 @@
+package mempool
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultSeenCacheSize = 100000
+	// Long enough to cover the rebroadcasts a provider sends while a
+	// transaction sits in the mempool
+	defaultSeenCacheTTL = 10 * time.Minute
+)
+
+type seenHash struct {
+	hash   common.Hash
+	expiry time.Time
+}
+
+// seenHashes remembers recently received transaction hashes so repeats are
+// dropped before they are fetched. It is an LRU bounded by size whose
+// entries also expire after ttl.
+type seenHashes struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[common.Hash]*list.Element
+
+	now func() time.Time
+}
+
+// newSeenHashes creates a set, applying defaults for unset limits
+func newSeenHashes(size int, ttl time.Duration) *seenHashes {
+	if size <= 0 {
+		size = defaultSeenCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultSeenCacheTTL
+	}
+	return &seenHashes{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[common.Hash]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// checkAndAdd records hash and reports whether it had already been seen
+// within the TTL. A nil set never reports a duplicate.
+func (s *seenHashes) checkAndAdd(hash common.Hash) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elem, ok := s.entries[hash]; ok {
+		entry := elem.Value.(*seenHash)
+		s.order.MoveToFront(elem)
+		if now.Before(entry.expiry) {
+			return true
+		}
+		entry.expiry = now.Add(s.ttl)
+		return false
+	}
+
+	s.entries[hash] = s.order.PushFront(&seenHash{hash: hash, expiry: now.Add(s.ttl)})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*seenHash).hash)
+	}
+	return false
+}
+
+// forget drops hash, so its next sighting isn't reported as a duplicate
+func (s *seenHashes) forget(hash common.Hash) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[hash]; ok {
+		s.order.Remove(elem)
+		delete(s.entries, hash)
+	}
+}
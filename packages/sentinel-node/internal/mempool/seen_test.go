@@ -0,0 +1,106 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestSeenHashes_EvictsAndExpires(t *testing.T) {
+	seen := newSeenHashes(2, time.Minute)
+	now := time.Unix(1700000000, 0)
+	seen.now = func() time.Time { return now }
+
+	hashes := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")}
+	for i, hash := range hashes {
+		if seen.checkAndAdd(hash) {
+			t.Fatalf("Hash %d reported as seen on first sight", i)
+		}
+	}
+
+	if seen.checkAndAdd(hashes[0]) {
+		t.Error("Least recently seen hash should have been evicted")
+	}
+	if !seen.checkAndAdd(hashes[0]) {
+		t.Error("Expected a repeat within the TTL to be caught")
+	}
+	now = now.Add(2 * time.Minute)
+	if seen.checkAndAdd(hashes[0]) {
+		t.Error("Expired hash should not count as a repeat")
+	}
+}
+
+// pendingTestBody is a signed transaction as eth_getTransactionByHash
+// returns one still in the mempool
+func pendingTestBody(t *testing.T) json.RawMessage {
+	t.Helper()
+	key, _ := crypto.GenerateKey()
+	to := common.HexToAddress("0xdead")
+	signed, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       21000,
+		To:        &to,
+	})
+	if err != nil {
+		t.Fatalf("SignNewTx failed: %v", err)
+	}
+	body, _ := signed.MarshalJSON()
+	return body
+}
+
+func TestListener_SkipsDuplicateHashes(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	service.pendingBody = pendingTestBody(t)
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	l := newTestListener(10)
+	useClient(l, client)
+	l.seen = newSeenHashes(10, time.Minute)
+
+	txHash := common.BigToHash(big.NewInt(1))
+	l.fetchAndEnqueue(context.Background(), txHash)
+	l.fetchAndEnqueue(context.Background(), txHash)
+
+	if lookups := service.lookups.Load(); lookups != 1 {
+		t.Errorf("Expected the repeated hash fetched once, got %d lookups", lookups)
+	}
+	if duplicates := l.Stats().Duplicates; duplicates != 1 {
+		t.Errorf("Expected 1 duplicate, got %d", duplicates)
+	}
+}
+
+func TestListener_RefetchesHashAfterFailedLookup(t *testing.T) {
+	server, service := newFakeEthServer(t)
+	service.pendingBody = pendingTestBody(t)
+	service.failLookups.Store(1)
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	l := newTestListener(10)
+	useClient(l, client)
+	l.seen = newSeenHashes(10, time.Minute)
+
+	// The first announcement's lookup fails, so the re-announcement is
+	// fetched rather than dropped as a duplicate
+	txHash := common.BigToHash(big.NewInt(1))
+	l.fetchAndEnqueue(context.Background(), txHash)
+	if len(l.txChan) != 0 {
+		t.Fatal("Expected nothing delivered from the failed lookup")
+	}
+	l.fetchAndEnqueue(context.Background(), txHash)
+
+	if lookups := service.lookups.Load(); lookups != 2 {
+		t.Errorf("Expected the hash fetched again, got %d lookups", lookups)
+	}
+	if len(l.txChan) != 1 || l.Stats().Duplicates != 0 {
+		t.Errorf("Expected the second delivery to get through, got %d queued, %d duplicates",
+			len(l.txChan), l.Stats().Duplicates)
+	}
+}
@@ -0,0 +1,49 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	ptypes "github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// TraceCall runs the pending transaction through debug_traceCall with the
+// callTracer against the pending block and returns its internal call tree.
+// Providers without the debug namespace return an error.
+func (l *Listener) TraceCall(ctx context.Context, tx *ptypes.PendingTransaction) (*ptypes.CallFrame, error) {
+	var frame ptypes.CallFrame
+	err := l.failover(ctx, func(client *ethclient.Client) error {
+		return client.Client().CallContext(ctx, &frame, "debug_traceCall",
+			toCallArg(tx), "pending", map[string]interface{}{"tracer": "callTracer"})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// toCallArg encodes a pending transaction as eth_call style arguments
+func toCallArg(tx *ptypes.PendingTransaction) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":  tx.From,
+		"input": hexutil.Bytes(tx.Input),
+	}
+	if tx.To != nil {
+		arg["to"] = tx.To
+	}
+	if tx.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(tx.Gas)
+	}
+	if tx.Value != nil {
+		arg["value"] = (*hexutil.Big)(tx.Value)
+	}
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(tx.MaxFeePerGas)
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.MaxPriorityFeePerGas)
+	} else if tx.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(tx.GasPrice)
+	}
+	return arg
+}
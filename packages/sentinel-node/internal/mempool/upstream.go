@@ -0,0 +1,161 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultHealthCheckInterval is how often upstreams are probed when
+	// there is more than one to choose from
+	defaultHealthCheckInterval = 15 * time.Second
+	// upstreamCheckTimeout bounds a single upstream probe
+	upstreamCheckTimeout = 5 * time.Second
+)
+
+// upstream is one RPC endpoint requests can be served by. Its client is
+// guarded by the listener's clientMu, since a reconnect replaces the one
+// shared with the subscription.
+type upstream struct {
+	// host names the endpoint in logs without the API key most providers
+	// put in the URL path
+	host    string
+	client  *ethclient.Client
+	healthy atomic.Bool
+}
+
+func newUpstream(rawURL string, client *ethclient.Client) *upstream {
+	u := &upstream{host: rawURL, client: client}
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		u.host = parsed.Host
+	}
+	u.healthy.Store(true)
+	return u
+}
+
+// candidates returns the upstreams to try in order: the healthy ones from
+// the next round-robin position, then the unhealthy ones as a last resort
+func (l *Listener) candidates() []*upstream {
+	n := len(l.upstreams)
+	if n == 1 {
+		return l.upstreams
+	}
+
+	start := int(l.nextUpstream.Add(1) % uint64(n))
+	ordered := make([]*upstream, 0, n)
+	var unhealthy []*upstream
+	for i := 0; i < n; i++ {
+		u := l.upstreams[(start+i)%n]
+		if u.healthy.Load() {
+			ordered = append(ordered, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+	return append(ordered, unhealthy...)
+}
+
+func (l *Listener) clientOf(u *upstream) *ethclient.Client {
+	l.clientMu.RLock()
+	defer l.clientMu.RUnlock()
+	return u.client
+}
+
+// requestClient returns the client for the next RPC request, spreading
+// reads across the healthy upstreams
+func (l *Listener) requestClient() *ethclient.Client {
+	return l.clientOf(l.candidates()[0])
+}
+
+// failover runs call against each upstream in turn until one answers. An
+// upstream that can't be reached is marked unhealthy until a health check
+// finds it working again; one refusing the call with a JSON-RPC error, e.g.
+// a rate limit, is only skipped for this call.
+func (l *Listener) failover(ctx context.Context, call func(*ethclient.Client) error) error {
+	var err error
+	for _, u := range l.candidates() {
+		err = call(l.clientOf(u))
+		if !upstreamFailed(ctx, err) {
+			return err
+		}
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			l.logger.Debug().Err(err).Str("upstream", u.host).Msg("RPC upstream refused call, trying the next")
+			continue
+		}
+		if u.healthy.Swap(false) && len(l.upstreams) > 1 {
+			l.logger.Warn().Err(err).Str("upstream", u.host).Msg("RPC upstream failed, failing over")
+		}
+	}
+	return err
+}
+
+// upstreamFailed reports whether another upstream might answer a call that
+// failed with err: the upstream was unreachable, or refused the call with a
+// JSON-RPC error such as a rate limit or a -32000 server error. Not found
+// and execution reverts are answers any upstream would repeat.
+func upstreamFailed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	return !isRevert(err)
+}
+
+// isRevert reports whether err is a call reverting, which nodes report with
+// the revert data attached or, without it, by message
+func isRevert(err error) bool {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		return true
+	}
+	return strings.Contains(err.Error(), "execution reverted")
+}
+
+// healthLoop probes the upstreams until the listener stops, so failed ones
+// rejoin the rotation once they recover
+func (l *Listener) healthLoop(ctx context.Context) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.done:
+			return
+		case <-ticker.C:
+			l.checkUpstreams(ctx)
+		}
+	}
+}
+
+func (l *Listener) checkUpstreams(ctx context.Context) {
+	for _, u := range l.upstreams {
+		checkCtx, cancel := context.WithTimeout(ctx, upstreamCheckTimeout)
+		_, err := l.clientOf(u).BlockNumber(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		healthy := err == nil
+		if u.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			l.logger.Info().Str("upstream", u.host).Msg("RPC upstream recovered")
+		} else {
+			l.logger.Warn().Err(err).Str("upstream", u.host).Msg("RPC upstream failed health check")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// defaultBundleBlocks is how many upcoming blocks a pause bundle targets;
+// builders drop a bundle once its block has passed
+const defaultBundleBlocks = 5
+
+// ErrBundleRejected is returned when the relay refuses a bundle
+var ErrBundleRejected = errors.New("relay rejected bundle")
+
+// BlockNumberer reports the chain head bundles are targeted from
+type BlockNumberer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type FlashbotsConfig struct {
+	// URL is the relay's JSON-RPC endpoint
+	URL string
+	// Key signs the X-Flashbots-Signature header that identifies the
+	// searcher to the relay
+	Key   *ecdsa.PrivateKey
+	Chain BlockNumberer
+	// Blocks is how many upcoming blocks each bundle targets (0 = 5)
+	Blocks int
+	// Client sends the relay requests (nil = http.DefaultClient)
+	Client *http.Client
+}
+
+// FlashbotsRelay sends transactions as single-transaction Flashbots
+// bundles, so they reach block builders without passing through the
+// public mempool where an attacker could see and front-run them
+type FlashbotsRelay struct {
+	url    string
+	key    *ecdsa.PrivateKey
+	chain  BlockNumberer
+	blocks int
+	client *http.Client
+}
+
+func NewFlashbotsRelay(cfg FlashbotsConfig) (*FlashbotsRelay, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("relay URL is required")
+	}
+	if cfg.Key == nil {
+		return nil, errors.New("relay signing key is required")
+	}
+	if cfg.Chain == nil {
+		return nil, errors.New("chain head source is required")
+	}
+
+	relay := &FlashbotsRelay{
+		url:    cfg.URL,
+		key:    cfg.Key,
+		chain:  cfg.Chain,
+		blocks: cfg.Blocks,
+		client: cfg.Client,
+	}
+	if relay.blocks <= 0 {
+		relay.blocks = defaultBundleBlocks
+	}
+	if relay.client == nil {
+		relay.client = http.DefaultClient
+	}
+	return relay, nil
+}
+
+type bundleParams struct {
+	Txs         []hexutil.Bytes `json:"txs"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SendTransaction submits tx as a bundle for each of the next blocks. It
+// succeeds if the relay accepted the bundle for at least one of them.
+func (r *FlashbotsRelay) SendTransaction(ctx context.Context, tx *gethtypes.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	head, err := r.chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block number: %w", err)
+	}
+
+	var accepted int
+	var lastErr error
+	for block := head + 1; block <= head+uint64(r.blocks); block++ {
+		err := r.call(ctx, "eth_sendBundle", bundleParams{
+			Txs:         []hexutil.Bytes{raw},
+			BlockNumber: hexutil.Uint64(block),
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		accepted++
+	}
+	if accepted == 0 {
+		return lastErr
+	}
+	return nil
+}
+
+func (r *FlashbotsRelay) call(ctx context.Context, method string, params ...interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	signature, err := r.sign(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Flashbots-Signature", signature)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	var result rpcResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("%w: HTTP %d: %s", ErrBundleRejected, resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%w: %s (%d)", ErrBundleRejected, result.Error.Message, result.Error.Code)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: HTTP %d", ErrBundleRejected, resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the X-Flashbots-Signature header for body: the signer's
+// address and its EIP-191 signature of the hex keccak256 of the body
+func (r *FlashbotsRelay) sign(body []byte) (string, error) {
+	digest := crypto.Keccak256Hash(body).Hex()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(digest)), r.key)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(r.key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
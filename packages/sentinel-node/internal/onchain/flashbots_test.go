@@ -0,0 +1,145 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type fixedHead uint64
+
+func (h fixedHead) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(h), nil
+}
+
+// relayCall is a request received by the mock relay
+type relayCall struct {
+	body      []byte
+	signature string
+}
+
+// mockRelay answers eth_sendBundle, rejecting every bundle while reject is set
+func mockRelay(t *testing.T, reject bool) (*httptest.Server, *[]relayCall) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := []relayCall{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, relayCall{body: body, signature: r.Header.Get("X-Flashbots-Signature")})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if reject {
+			io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"bundle simulation failed"}}`)
+			return
+		}
+		io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func testPauseTx(t *testing.T) *gethtypes.Transaction {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	tx, err := gethtypes.SignNewTx(key, gethtypes.LatestSignerForChainID(big.NewInt(1)), &gethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(2e9),
+		Gas:       100000,
+		To:        &testRouter,
+	})
+	if err != nil {
+		t.Fatalf("SignNewTx failed: %v", err)
+	}
+	return tx
+}
+
+func TestFlashbotsRelay_SendsSignedBundles(t *testing.T) {
+	server, calls := mockRelay(t, false)
+	key, _ := crypto.GenerateKey()
+	relay, err := NewFlashbotsRelay(FlashbotsConfig{URL: server.URL, Key: key, Chain: fixedHead(100), Blocks: 3})
+	if err != nil {
+		t.Fatalf("NewFlashbotsRelay failed: %v", err)
+	}
+	tx := testPauseTx(t)
+	raw, _ := tx.MarshalBinary()
+
+	if err := relay.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("SendTransaction failed: %v", err)
+	}
+	if len(*calls) != 3 {
+		t.Fatalf("Expected a bundle for each of 3 blocks, got %d", len(*calls))
+	}
+
+	for i, call := range *calls {
+		var req struct {
+			Method string         `json:"method"`
+			Params []bundleParams `json:"params"`
+		}
+		if err := json.Unmarshal(call.body, &req); err != nil {
+			t.Fatalf("Invalid request body %s: %v", call.body, err)
+		}
+		if req.Method != "eth_sendBundle" || len(req.Params) != 1 {
+			t.Fatalf("Expected an eth_sendBundle call, got %s", call.body)
+		}
+		bundle := req.Params[0]
+		if len(bundle.Txs) != 1 || hexutil.Encode(bundle.Txs[0]) != hexutil.Encode(raw) {
+			t.Errorf("Expected the raw pause transaction bundled, got %v", bundle.Txs)
+		}
+		if want := uint64(101 + i); uint64(bundle.BlockNumber) != want {
+			t.Errorf("Expected bundle %d to target block %d, got %d", i, want, bundle.BlockNumber)
+		}
+
+		// The header is address:signature over the hex keccak of the body
+		address, signature, ok := strings.Cut(call.signature, ":")
+		if !ok {
+			t.Fatalf("Malformed X-Flashbots-Signature %q", call.signature)
+		}
+		sig, err := hexutil.Decode(signature)
+		if err != nil {
+			t.Fatalf("Invalid signature encoding: %v", err)
+		}
+		digest := accounts.TextHash([]byte(crypto.Keccak256Hash(call.body).Hex()))
+		pub, err := crypto.SigToPub(digest, sig)
+		if err != nil {
+			t.Fatalf("SigToPub failed: %v", err)
+		}
+		signer := crypto.PubkeyToAddress(key.PublicKey)
+		if crypto.PubkeyToAddress(*pub) != signer || common.HexToAddress(address) != signer {
+			t.Errorf("Expected the body signed by %s, got header %q", signer.Hex(), call.signature)
+		}
+	}
+}
+
+func TestFlashbotsRelay_Rejected(t *testing.T) {
+	server, calls := mockRelay(t, true)
+	key, _ := crypto.GenerateKey()
+	relay, _ := NewFlashbotsRelay(FlashbotsConfig{URL: server.URL, Key: key, Chain: fixedHead(100), Blocks: 2})
+
+	err := relay.SendTransaction(context.Background(), testPauseTx(t))
+	if !errors.Is(err, ErrBundleRejected) {
+		t.Errorf("Expected ErrBundleRejected, got %v", err)
+	}
+	if len(*calls) != 2 {
+		t.Errorf("Expected every target block tried, got %d", len(*calls))
+	}
+}
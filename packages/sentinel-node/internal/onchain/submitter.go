@@ -0,0 +1,247 @@
+package onchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+// routerABI covers the SentinelRouter entrypoint that executes a pause on
+// an aggregated BLS signature
+const routerABI = `[
+	{"name":"executePauseWithAggregatedSignature","type":"function","inputs":[
+		{"name":"targetProtocol","type":"address"},{"name":"evidenceHash","type":"bytes32"},
+		{"name":"aggregatedSignature","type":"bytes"},{"name":"signers","type":"address[]"}]}
+]`
+
+var parsedRouterABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(routerABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// gasHeadroom is added to the estimate, in percent, since the router's
+// signature checks depend on registry state that can change before mining
+const gasHeadroom = 20
+
+var errNoRouter = errors.New("router address is required")
+
+// TxSender publishes signed transactions
+type TxSender interface {
+	SendTransaction(ctx context.Context, tx *gethtypes.Transaction) error
+}
+
+// Backend is the part of an ethclient pause transactions are built and,
+// without a private sender, published through
+type Backend interface {
+	TxSender
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+}
+
+type SubmitterConfig struct {
+	// Router is the SentinelRouter the pause is executed through
+	Router common.Address
+	// Key signs the transactions; its address pays for them
+	Key     *ecdsa.PrivateKey
+	ChainID *big.Int
+	Backend Backend
+	// Private, if set, publishes the signed transactions instead of the
+	// backend, keeping them out of the public mempool
+	Private TxSender
+	// PublicFallback sends through the backend when Private fails. Off,
+	// a pause that can't go out privately isn't sent at all.
+	PublicFallback bool
+	// MaxGasPrice caps the fee bid per gas in wei (nil = no cap)
+	MaxGasPrice *big.Int
+	Logger      zerolog.Logger
+}
+
+// Submitter executes aggregated pause requests on chain. Submissions are
+// serialized and nonces assigned locally, so pauses sent in quick
+// succession (or privately, where the public pending nonce doesn't see
+// them) don't collide.
+type Submitter struct {
+	router      common.Address
+	key         *ecdsa.PrivateKey
+	from        common.Address
+	signer      gethtypes.Signer
+	backend     Backend
+	private     TxSender
+	fallback    bool
+	maxGasPrice *big.Int
+	logger      zerolog.Logger
+
+	mu sync.Mutex
+	// nonce is the next nonce to use; nil until fetched, and after a
+	// failed send so the next one starts from the chain's view
+	nonce *uint64
+}
+
+func NewSubmitter(cfg SubmitterConfig) (*Submitter, error) {
+	if cfg.Router == (common.Address{}) {
+		return nil, errNoRouter
+	}
+	if cfg.Key == nil {
+		return nil, errors.New("signing key is required")
+	}
+	if cfg.ChainID == nil || cfg.ChainID.Sign() <= 0 {
+		return nil, errors.New("chain ID is required")
+	}
+	if cfg.Backend == nil {
+		return nil, errors.New("backend is required")
+	}
+
+	return &Submitter{
+		router:      cfg.Router,
+		key:         cfg.Key,
+		from:        crypto.PubkeyToAddress(cfg.Key.PublicKey),
+		signer:      gethtypes.LatestSignerForChainID(cfg.ChainID),
+		backend:     cfg.Backend,
+		private:     cfg.Private,
+		fallback:    cfg.PublicFallback,
+		maxGasPrice: cfg.MaxGasPrice,
+		logger:      cfg.Logger,
+	}, nil
+}
+
+// From returns the address transactions are sent from
+func (s *Submitter) From() common.Address {
+	return s.from
+}
+
+// PauseMessage is the message the router checks an aggregated pause
+// signature against: abi.encodePacked(targetProtocol, evidenceHash,
+// block.chainid). Shares must be made with BLSSigner.SignForRouter over it.
+func PauseMessage(request types.PauseRequest, chainID uint64) []byte {
+	message := make([]byte, 0, common.AddressLength+common.HashLength+32)
+	message = append(message, request.TargetProtocol.Bytes()...)
+	message = append(message, request.EvidenceHash.Bytes()...)
+	return append(message, common.LeftPadBytes(new(big.Int).SetUint64(chainID).Bytes(), 32)...)
+}
+
+// EncodePause returns the router calldata executing agg
+func EncodePause(agg *types.AggregatedPauseRequest) ([]byte, error) {
+	return parsedRouterABI.Pack("executePauseWithAggregatedSignature",
+		agg.Request.TargetProtocol,
+		agg.Request.EvidenceHash,
+		agg.AggregatedSignature,
+		agg.Signers,
+	)
+}
+
+// Submit signs and sends the transaction executing agg, returning its hash
+// once it is published. It doesn't wait for it to be mined.
+func (s *Submitter) Submit(ctx context.Context, agg *types.AggregatedPauseRequest) (common.Hash, error) {
+	input, err := EncodePause(agg)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode pause: %w", err)
+	}
+
+	gas, err := s.backend.EstimateGas(ctx, ethereum.CallMsg{From: s.from, To: &s.router, Data: input})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	gas += gas * gasHeadroom / 100
+
+	feeCap, tipCap, err := s.fees(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nonce == nil {
+		nonce, err := s.backend.PendingNonceAt(ctx, s.from)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch nonce: %w", err)
+		}
+		s.nonce = &nonce
+	}
+
+	tx, err := gethtypes.SignNewTx(s.key, s.signer, &gethtypes.DynamicFeeTx{
+		ChainID:   s.signer.ChainID(),
+		Nonce:     *s.nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &s.router,
+		Data:      input,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign pause transaction: %w", err)
+	}
+
+	private, err := s.send(ctx, tx)
+	if err != nil {
+		s.nonce = nil
+		return common.Hash{}, fmt.Errorf("failed to send pause transaction: %w", err)
+	}
+	*s.nonce++
+
+	s.logger.Info().
+		Str("tx", tx.Hash().Hex()).
+		Str("protocol", agg.Request.TargetProtocol.Hex()).
+		Int("signers", len(agg.Signers)).
+		Uint64("nonce", tx.Nonce()).
+		Bool("private", private).
+		Msg("Pause transaction sent")
+
+	return tx.Hash(), nil
+}
+
+// send publishes tx privately when a private sender is configured,
+// reporting whether it did
+func (s *Submitter) send(ctx context.Context, tx *gethtypes.Transaction) (bool, error) {
+	if s.private != nil {
+		err := s.private.SendTransaction(ctx, tx)
+		if err == nil {
+			return true, nil
+		}
+		if !s.fallback {
+			return false, err
+		}
+		s.logger.Warn().Err(err).Str("tx", tx.Hash().Hex()).Msg("Private submission failed, sending pause through the public mempool")
+	}
+	return false, s.backend.SendTransaction(ctx, tx)
+}
+
+// fees bids twice the suggested gas price so the transaction survives a
+// few blocks of rising base fees, capped at maxGasPrice
+func (s *Submitter) fees(ctx context.Context) (feeCap, tipCap *big.Int, err error) {
+	price, err := s.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas price: %w", err)
+	}
+	tipCap, err = s.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas tip: %w", err)
+	}
+
+	feeCap = new(big.Int).Mul(price, big.NewInt(2))
+	if s.maxGasPrice != nil && s.maxGasPrice.Sign() > 0 && feeCap.Cmp(s.maxGasPrice) > 0 {
+		feeCap = new(big.Int).Set(s.maxGasPrice)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = new(big.Int).Set(feeCap)
+	}
+	return feeCap, tipCap, nil
+}
@@ -0,0 +1,334 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/internal/consensus"
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+var testRouter = common.HexToAddress("0x70a7e5")
+
+// mockBackend records the transactions it is asked to send
+type mockBackend struct {
+	nonce    uint64
+	price    *big.Int
+	tip      *big.Int
+	sendErr  error
+	sent     []*gethtypes.Transaction
+	estimate ethereum.CallMsg
+}
+
+func (m *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return m.nonce, nil
+}
+
+func (m *mockBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return m.price, nil
+}
+
+func (m *mockBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return m.tip, nil
+}
+
+func (m *mockBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	m.estimate = call
+	return 100000, nil
+}
+
+func (m *mockBackend) SendTransaction(ctx context.Context, tx *gethtypes.Transaction) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.sent = append(m.sent, tx)
+	return nil
+}
+
+func testAggregate() *types.AggregatedPauseRequest {
+	return &types.AggregatedPauseRequest{
+		Request: types.PauseRequest{
+			TargetProtocol: common.HexToAddress("0xdead"),
+			EvidenceHash:   common.HexToHash("0xbeef"),
+		},
+		AggregatedSignature: []byte{0x01, 0x02, 0x03},
+		Signers:             []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xb0b")},
+	}
+}
+
+func newTestSubmitter(t *testing.T, backend *mockBackend, private TxSender, maxGasPrice *big.Int) *Submitter {
+	t.Helper()
+	return newTestSubmitterWithConfig(t, SubmitterConfig{Backend: backend, Private: private, MaxGasPrice: maxGasPrice})
+}
+
+func newTestSubmitterWithConfig(t *testing.T, cfg SubmitterConfig) *Submitter {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	cfg.Router = testRouter
+	cfg.Key = key
+	cfg.ChainID = big.NewInt(1)
+	cfg.Logger = zerolog.Nop()
+	submitter, err := NewSubmitter(cfg)
+	if err != nil {
+		t.Fatalf("NewSubmitter failed: %v", err)
+	}
+	return submitter
+}
+
+func TestSubmitter_EncodesAndSignsPause(t *testing.T) {
+	backend := &mockBackend{nonce: 7, price: big.NewInt(30e9), tip: big.NewInt(2e9)}
+	submitter := newTestSubmitter(t, backend, nil, nil)
+	agg := testAggregate()
+
+	hash, err := submitter.Submit(context.Background(), agg)
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(backend.sent) != 1 {
+		t.Fatalf("Expected 1 transaction sent, got %d", len(backend.sent))
+	}
+	tx := backend.sent[0]
+	if tx.Hash() != hash {
+		t.Errorf("Expected the sent transaction's hash reported, got %s", hash.Hex())
+	}
+
+	if *tx.To() != testRouter || tx.Nonce() != 7 || tx.Gas() != 120000 {
+		t.Errorf("Unexpected transaction: to %s, nonce %d, gas %d", tx.To().Hex(), tx.Nonce(), tx.Gas())
+	}
+	if tx.GasFeeCap().Cmp(big.NewInt(60e9)) != 0 || tx.GasTipCap().Cmp(big.NewInt(2e9)) != 0 {
+		t.Errorf("Unexpected fees: cap %s, tip %s", tx.GasFeeCap(), tx.GasTipCap())
+	}
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(big.NewInt(1)), tx)
+	if err != nil || from != submitter.From() {
+		t.Errorf("Expected the transaction signed by %s, got %s, %v", submitter.From().Hex(), from.Hex(), err)
+	}
+
+	method, err := parsedRouterABI.MethodById(tx.Data()[:4])
+	if err != nil || method.Name != "executePauseWithAggregatedSignature" {
+		t.Fatalf("Expected executePauseWithAggregatedSignature, got %v, %v", method, err)
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		t.Fatalf("Unpack failed: %v", err)
+	}
+	if args[0].(common.Address) != agg.Request.TargetProtocol ||
+		common.Hash(args[1].([32]byte)) != agg.Request.EvidenceHash ||
+		!bytes.Equal(args[2].([]byte), agg.AggregatedSignature) {
+		t.Errorf("Unexpected pause arguments %v", args[:3])
+	}
+	if signers := args[3].([]common.Address); len(signers) != 2 || signers[1] != agg.Signers[1] {
+		t.Errorf("Expected the aggregate's signers, got %v", signers)
+	}
+	if !bytes.Equal(backend.estimate.Data, tx.Data()) || backend.estimate.From != submitter.From() {
+		t.Error("Expected gas estimated for the same call")
+	}
+}
+
+// routerFieldModulus is BLSVerifier.P, the bn254 base field modulus
+var routerFieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+// routerG2Generator is BLSVerifier.getG2Generator, coordinates in the order
+// the contract passes them to the pairing precompile
+var routerG2Generator = []string{
+	"11559732032986387107991004021392285783925812861821192530917403151452391805634",
+	"10857046999023057135944570762232829481370756359578518086990519993285655852781",
+	"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+	"8495653923123431417604973247489272438418190587263600148770280649306958101930",
+}
+
+// routerHashToG1 transliterates BLSVerifier.hashToG1
+func routerHashToG1(message []byte) (x, y *big.Int) {
+	p := routerFieldModulus
+	h := new(big.Int).Mod(new(big.Int).SetBytes(crypto.Keccak256(message)), p)
+	exponent := new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2)
+	for i := int64(0); i < 256; i++ {
+		x := new(big.Int).Mod(new(big.Int).Add(h, big.NewInt(i)), p)
+		y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+		y2.Add(y2, big.NewInt(3)).Mod(y2, p)
+		y := new(big.Int).Exp(y2, exponent, p)
+		if new(big.Int).Exp(y, big.NewInt(2), p).Cmp(y2) == 0 {
+			return x, y
+		}
+	}
+	return nil, nil
+}
+
+// routerAccepts replays the signature check executePauseWithAggregatedSignature
+// makes on calldata: the message is packed and hashed as the contract does,
+// the pairing input laid out as BLSVerifier.verifyMultiPairing lays it out,
+// and the pairs checked by the same code as the EVM's pairing precompile
+func routerAccepts(t *testing.T, calldata []byte, keys map[common.Address][]byte, chainID uint64) bool {
+	t.Helper()
+	args, err := parsedRouterABI.Methods["executePauseWithAggregatedSignature"].Inputs.Unpack(calldata[4:])
+	if err != nil {
+		t.Fatalf("Unpack failed: %v", err)
+	}
+	target, evidence := args[0].(common.Address), args[1].([32]byte)
+	signature, signers := args[2].([]byte), args[3].([]common.Address)
+
+	message := append(append(target.Bytes(), evidence[:]...), math.U256Bytes(new(big.Int).SetUint64(chainID))...)
+	hx, hy := routerHashToG1(message)
+	negY := new(big.Int).Sub(routerFieldModulus, hy)
+
+	input := append([]byte{}, signature[:64]...)
+	for _, coordinate := range routerG2Generator {
+		value, _ := new(big.Int).SetString(coordinate, 10)
+		input = append(input, math.U256Bytes(value)...)
+	}
+	for _, signer := range signers {
+		input = append(input, math.U256Bytes(new(big.Int).Set(hx))...)
+		input = append(input, math.U256Bytes(new(big.Int).Set(negY))...)
+		input = append(input, keys[signer][:128]...)
+	}
+	// verifyMultiPairing passes one more, all-zero pair
+	input = append(input, make([]byte, 192)...)
+
+	var g1s []*bn256.G1
+	var g2s []*bn256.G2
+	for i := 0; i < len(input); i += 192 {
+		g1, g2 := new(bn256.G1), new(bn256.G2)
+		if _, err := g1.Unmarshal(input[i : i+64]); err != nil {
+			return false
+		}
+		if _, err := g2.Unmarshal(input[i+64 : i+192]); err != nil {
+			return false
+		}
+		g1s, g2s = append(g1s, g1), append(g2s, g2)
+	}
+	return bn256.PairingCheck(g1s, g2s)
+}
+
+func TestEncodePause_VerifiesUnderRouterScheme(t *testing.T) {
+	const chainID = 31337
+	request := types.PauseRequest{
+		TargetProtocol: common.HexToAddress("0xdead"),
+		EvidenceHash:   common.HexToHash("0xbeef"),
+	}
+
+	keys := make(map[common.Address][]byte)
+	var signers []common.Address
+	var shares, chainShares [][]byte
+	for i := 1; i <= 5; i++ {
+		signer, err := consensus.NewBLSSigner("")
+		if err != nil {
+			t.Fatalf("NewBLSSigner failed: %v", err)
+		}
+		share, err := signer.SignForRouter(PauseMessage(request, chainID))
+		if err != nil {
+			t.Fatalf("SignForRouter failed: %v", err)
+		}
+		// Signed under the gossip domain instead, which the router can't check
+		chainShare, _ := signer.SignForChain(PauseMessage(request, chainID), chainID)
+
+		address := common.BigToAddress(big.NewInt(int64(i)))
+		keys[address] = signer.PublicKey()
+		signers = append(signers, address)
+		shares = append(shares, share)
+		chainShares = append(chainShares, chainShare)
+	}
+
+	encode := func(shares [][]byte) []byte {
+		aggSig, err := consensus.AggregateSignatures(shares)
+		if err != nil {
+			t.Fatalf("AggregateSignatures failed: %v", err)
+		}
+		input, err := EncodePause(&types.AggregatedPauseRequest{Request: request, AggregatedSignature: aggSig, Signers: signers})
+		if err != nil {
+			t.Fatalf("EncodePause failed: %v", err)
+		}
+		return input
+	}
+
+	calldata := encode(shares)
+	if !routerAccepts(t, calldata, keys, chainID) {
+		t.Fatal("Expected the router to accept the aggregated pause")
+	}
+	if routerAccepts(t, calldata, keys, 1) {
+		t.Error("Expected the router on another chain to reject the pause")
+	}
+	if routerAccepts(t, encode(chainShares), keys, chainID) {
+		t.Error("Expected shares hashed to G1 the gossip way to be rejected")
+	}
+}
+
+func TestSubmitter_Nonces(t *testing.T) {
+	backend := &mockBackend{nonce: 3, price: big.NewInt(1e9), tip: big.NewInt(1e9)}
+	submitter := newTestSubmitter(t, backend, nil, nil)
+
+	submitter.Submit(context.Background(), testAggregate())
+	// The chain hasn't seen the first one yet; the local nonce moves on
+	submitter.Submit(context.Background(), testAggregate())
+	if backend.sent[0].Nonce() != 3 || backend.sent[1].Nonce() != 4 {
+		t.Fatalf("Expected nonces 3 and 4, got %d and %d", backend.sent[0].Nonce(), backend.sent[1].Nonce())
+	}
+
+	// A failed send starts over from the chain's pending nonce
+	backend.sendErr = errors.New("nonce too low")
+	if _, err := submitter.Submit(context.Background(), testAggregate()); err == nil {
+		t.Fatal("Expected the send error returned")
+	}
+	backend.sendErr = nil
+	backend.nonce = 9
+	submitter.Submit(context.Background(), testAggregate())
+	if got := backend.sent[2].Nonce(); got != 9 {
+		t.Errorf("Expected the nonce refetched after a failure, got %d", got)
+	}
+}
+
+func TestSubmitter_CapsGasPrice(t *testing.T) {
+	backend := &mockBackend{price: big.NewInt(400e9), tip: big.NewInt(900e9)}
+	submitter := newTestSubmitter(t, backend, nil, big.NewInt(500e9))
+
+	submitter.Submit(context.Background(), testAggregate())
+	tx := backend.sent[0]
+	if tx.GasFeeCap().Cmp(big.NewInt(500e9)) != 0 || tx.GasTipCap().Cmp(big.NewInt(500e9)) != 0 {
+		t.Errorf("Expected fees capped at 500 gwei, got cap %s, tip %s", tx.GasFeeCap(), tx.GasTipCap())
+	}
+}
+
+func TestSubmitter_PrivateSender(t *testing.T) {
+	backend := &mockBackend{price: big.NewInt(1e9), tip: big.NewInt(1e9)}
+	private := &mockBackend{}
+	submitter := newTestSubmitter(t, backend, private, nil)
+
+	submitter.Submit(context.Background(), testAggregate())
+	if len(private.sent) != 1 || len(backend.sent) != 0 {
+		t.Errorf("Expected the transaction sent privately only, got %d private, %d public", len(private.sent), len(backend.sent))
+	}
+}
+
+func TestSubmitter_PublicFallback(t *testing.T) {
+	rejecting := &mockBackend{sendErr: ErrBundleRejected}
+
+	// With MEV protection a pause that can't go out privately isn't sent
+	backend := &mockBackend{price: big.NewInt(1e9), tip: big.NewInt(1e9)}
+	strict := newTestSubmitterWithConfig(t, SubmitterConfig{Backend: backend, Private: rejecting})
+	if _, err := strict.Submit(context.Background(), testAggregate()); !errors.Is(err, ErrBundleRejected) {
+		t.Errorf("Expected the rejection returned, got %v", err)
+	}
+	if len(backend.sent) != 0 {
+		t.Errorf("Expected nothing sent publicly, got %d", len(backend.sent))
+	}
+
+	lenient := newTestSubmitterWithConfig(t, SubmitterConfig{Backend: backend, Private: rejecting, PublicFallback: true})
+	if _, err := lenient.Submit(context.Background(), testAggregate()); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(backend.sent) != 1 {
+		t.Errorf("Expected the pause sent publicly after the rejection, got %d", len(backend.sent))
+	}
+}
@@ -0,0 +1,188 @@
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+const defaultBufferSize = 1024
+
+// Record is one analyzed transaction together with its outcome, in the
+// shape consumed by offline model training
+type Record struct {
+	Transaction *types.PendingTransaction `json:"transaction"`
+	Result      *types.InferenceResult    `json:"result"`
+	RecordedAt  time.Time                 `json:"recordedAt"`
+}
+
+type Config struct {
+	// Type selects the backend. Only "file" (JSONL) is built in; other
+	// backends can be plugged in through NewWithWriter.
+	Type string
+	// Path is the JSONL file records are appended to
+	Path string
+	// SampleRate is the fraction of analyses recorded, in (0, 1]
+	SampleRate float64
+	// BufferSize bounds the records queued for writing; excess records are dropped
+	BufferSize int
+	Logger     zerolog.Logger
+}
+
+// ResultSink exports analysis results off the hot path. Record never
+// blocks: when the writer falls behind, records are dropped and counted.
+type ResultSink struct {
+	writer     io.WriteCloser
+	records    chan Record
+	sampleRate float64
+	sample     func() float64
+	logger     zerolog.Logger
+
+	closed    bool
+	closeMu   sync.RWMutex
+	done      chan struct{}
+	closeErr  error
+	closeOnce sync.Once
+
+	written atomic.Uint64
+	dropped atomic.Uint64
+	errors  atomic.Uint64
+}
+
+// New creates a sink for the configured backend
+func New(cfg Config) (*ResultSink, error) {
+	switch cfg.Type {
+	case "", "file":
+	default:
+		return nil, fmt.Errorf("unsupported result sink type %q", cfg.Type)
+	}
+
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("result sink path is required")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithWriter(file, cfg), nil
+}
+
+// NewWithWriter creates a sink that writes JSONL records to w
+func NewWithWriter(w io.WriteCloser, cfg Config) *ResultSink {
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	sampleRate := cfg.SampleRate
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+
+	s := &ResultSink{
+		writer:     w,
+		records:    make(chan Record, bufferSize),
+		sampleRate: sampleRate,
+		sample:     rand.Float64,
+		logger:     cfg.Logger,
+		done:       make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Record queues an analysis for export, subject to sampling. It drops the
+// record instead of blocking when the buffer is full.
+func (s *ResultSink) Record(tx *types.PendingTransaction, result *types.InferenceResult) {
+	if tx == nil || result == nil {
+		return
+	}
+
+	if s.sampleRate < 1 && s.sample() >= s.sampleRate {
+		return
+	}
+
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.records <- Record{Transaction: tx, Result: result, RecordedAt: time.Now()}:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+func (s *ResultSink) run() {
+	defer close(s.done)
+
+	buf := bufio.NewWriter(s.writer)
+	encoder := json.NewEncoder(buf)
+
+	for record := range s.records {
+		if err := encoder.Encode(record); err != nil {
+			s.errors.Add(1)
+			s.logger.Debug().Err(err).Msg("Failed to write result record")
+			continue
+		}
+		s.written.Add(1)
+
+		// Flush whenever we catch up so records are visible promptly
+		if len(s.records) == 0 {
+			if err := buf.Flush(); err != nil {
+				s.errors.Add(1)
+				s.logger.Debug().Err(err).Msg("Failed to flush result records")
+			}
+		}
+	}
+
+	if err := buf.Flush(); err != nil {
+		s.errors.Add(1)
+	}
+}
+
+// Close stops accepting records, drains the buffer and closes the writer
+func (s *ResultSink) Close() error {
+	s.closeOnce.Do(func() {
+		s.closeMu.Lock()
+		s.closed = true
+		close(s.records)
+		s.closeMu.Unlock()
+
+		<-s.done
+		s.closeErr = s.writer.Close()
+
+		s.logger.Info().
+			Uint64("written", s.written.Load()).
+			Uint64("dropped", s.dropped.Load()).
+			Msg("Result sink closed")
+	})
+	return s.closeErr
+}
+
+// Stats returns the number of records written, dropped on backpressure,
+// and failed to encode or flush
+func (s *ResultSink) Stats() (written, dropped, errors uint64) {
+	return s.written.Load(), s.dropped.Load(), s.errors.Load()
+}
@@ -0,0 +1,148 @@
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/sentinel-protocol/sentinel-node/pkg/types"
+)
+
+func testRecord(i int64) (*types.PendingTransaction, *types.InferenceResult) {
+	hash := common.BigToHash(big.NewInt(i))
+	tx := &types.PendingTransaction{
+		Hash:  hash,
+		From:  common.HexToAddress("0x1"),
+		Value: big.NewInt(i),
+		Gas:   500000,
+		Input: []byte{0x5c, 0xff, 0xe9, 0xde},
+	}
+	result := &types.InferenceResult{
+		TxHash:       hash,
+		AnomalyScore: 0.5,
+		RiskLevel:    "medium",
+	}
+	return tx, result
+}
+
+// blockingWriter blocks every write until released
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+func (w *blockingWriter) Close() error { return nil }
+
+func TestResultSink_WritesRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results", "analysis.jsonl")
+
+	sink, err := New(Config{Path: path, Logger: zerolog.Nop()})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for i := int64(1); i <= 3; i++ {
+		sink.Record(testRecord(i))
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid JSONL line: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	if records[2].Transaction.Value.Int64() != 3 || records[2].Result.RiskLevel != "medium" {
+		t.Errorf("Unexpected record contents: %+v", records[2])
+	}
+
+	written, dropped, _ := sink.Stats()
+	if written != 3 || dropped != 0 {
+		t.Errorf("Expected written=3 dropped=0, got written=%d dropped=%d", written, dropped)
+	}
+}
+
+func TestResultSink_DropsOnBackpressure(t *testing.T) {
+	writer := &blockingWriter{release: make(chan struct{})}
+	sink := NewWithWriter(writer, Config{BufferSize: 2, Logger: zerolog.Nop()})
+
+	done := make(chan struct{})
+	go func() {
+		for i := int64(0); i < 100; i++ {
+			sink.Record(testRecord(i))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Record blocked on a stalled writer")
+	}
+
+	_, dropped, _ := sink.Stats()
+	if dropped == 0 {
+		t.Error("Expected records to be dropped when the buffer is full")
+	}
+
+	close(writer.release)
+	sink.Close()
+}
+
+func TestResultSink_Sampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analysis.jsonl")
+	sink, err := New(Config{Path: path, SampleRate: 0.5, Logger: zerolog.Nop()})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	// Alternate samples above and below the rate
+	next := 0.0
+	sink.sample = func() float64 {
+		next = 0.75 - next
+		return next
+	}
+
+	for i := int64(0); i < 10; i++ {
+		sink.Record(testRecord(i))
+	}
+	sink.Close()
+
+	written, _, _ := sink.Stats()
+	if written != 5 {
+		t.Errorf("Expected 5 sampled records, got %d", written)
+	}
+}
+
+func TestNew_UnsupportedType(t *testing.T) {
+	if _, err := New(Config{Type: "carrier-pigeon", Path: "x"}); err == nil {
+		t.Error("Expected error for unsupported sink type")
+	}
+}
@@ -149,7 +149,10 @@ type AnalyzeRequest struct {
 	Nonce       uint64                 `protobuf:"varint,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	ChainId     uint64                 `protobuf:"varint,9,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	// Optional: simulation results if already simulated
-	Simulation    *SimulationResult `protobuf:"bytes,10,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	Simulation *SimulationResult `protobuf:"bytes,10,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	// Optional: calldata decoded by the node; unset for unknown selectors,
+	// in which case input_data is the only source
+	DecodedCall   *DecodedCall `protobuf:"bytes,11,opt,name=decoded_call,json=decodedCall,proto3" json:"decoded_call,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -254,6 +257,134 @@ func (x *AnalyzeRequest) GetSimulation() *SimulationResult {
 	return nil
 }
 
+func (x *AnalyzeRequest) GetDecodedCall() *DecodedCall {
+	if x != nil {
+		return x.DecodedCall
+	}
+	return nil
+}
+
+// Calldata decoded against a known ABI
+type DecodedCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selector      string                 `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"` // 0x-prefixed 4-byte selector
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`     // e.g., "transfer", "swapExactTokensForTokens"
+	Arguments     []*DecodedArgument     `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecodedCall) Reset() {
+	*x = DecodedCall{}
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecodedCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedCall) ProtoMessage() {}
+
+func (x *DecodedCall) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedCall.ProtoReflect.Descriptor instead.
+func (*DecodedCall) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DecodedCall) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *DecodedCall) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *DecodedCall) GetArguments() []*DecodedArgument {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type DecodedArgument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`   // Solidity type, e.g., "address", "uint256[]"
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // Decimal for integers, 0x-hex for addresses and bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecodedArgument) Reset() {
+	*x = DecodedArgument{}
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecodedArgument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedArgument) ProtoMessage() {}
+
+func (x *DecodedArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedArgument.ProtoReflect.Descriptor instead.
+func (*DecodedArgument) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DecodedArgument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DecodedArgument) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DecodedArgument) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // Pre-computed simulation result
 type SimulationResult struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -268,7 +399,7 @@ type SimulationResult struct {
 
 func (x *SimulationResult) Reset() {
 	*x = SimulationResult{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[1]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +411,7 @@ func (x *SimulationResult) String() string {
 func (*SimulationResult) ProtoMessage() {}
 
 func (x *SimulationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[1]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +424,7 @@ func (x *SimulationResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimulationResult.ProtoReflect.Descriptor instead.
 func (*SimulationResult) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{1}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SimulationResult) GetSuccess() bool {
@@ -344,7 +475,7 @@ type StorageChange struct {
 
 func (x *StorageChange) Reset() {
 	*x = StorageChange{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[2]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +487,7 @@ func (x *StorageChange) String() string {
 func (*StorageChange) ProtoMessage() {}
 
 func (x *StorageChange) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[2]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +500,7 @@ func (x *StorageChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageChange.ProtoReflect.Descriptor instead.
 func (*StorageChange) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{2}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StorageChange) GetContract() string {
@@ -420,7 +551,7 @@ type CallTrace struct {
 
 func (x *CallTrace) Reset() {
 	*x = CallTrace{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[3]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -432,7 +563,7 @@ func (x *CallTrace) String() string {
 func (*CallTrace) ProtoMessage() {}
 
 func (x *CallTrace) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[3]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +576,7 @@ func (x *CallTrace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallTrace.ProtoReflect.Descriptor instead.
 func (*CallTrace) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{3}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CallTrace) GetCallType() string {
@@ -546,7 +677,7 @@ type AnalyzeResponse struct {
 
 func (x *AnalyzeResponse) Reset() {
 	*x = AnalyzeResponse{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[4]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -558,7 +689,7 @@ func (x *AnalyzeResponse) String() string {
 func (*AnalyzeResponse) ProtoMessage() {}
 
 func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[4]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,7 +702,7 @@ func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
 func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{4}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AnalyzeResponse) GetTxHash() string {
@@ -667,7 +798,7 @@ type ProtocolContext struct {
 
 func (x *ProtocolContext) Reset() {
 	*x = ProtocolContext{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[5]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +810,7 @@ func (x *ProtocolContext) String() string {
 func (*ProtocolContext) ProtoMessage() {}
 
 func (x *ProtocolContext) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[5]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +823,7 @@ func (x *ProtocolContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProtocolContext.ProtoReflect.Descriptor instead.
 func (*ProtocolContext) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{5}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ProtocolContext) GetProtocol() string {
@@ -757,7 +888,7 @@ type FeatureBreakdown struct {
 
 func (x *FeatureBreakdown) Reset() {
 	*x = FeatureBreakdown{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[6]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -769,7 +900,7 @@ func (x *FeatureBreakdown) String() string {
 func (*FeatureBreakdown) ProtoMessage() {}
 
 func (x *FeatureBreakdown) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[6]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,7 +913,7 @@ func (x *FeatureBreakdown) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeatureBreakdown.ProtoReflect.Descriptor instead.
 func (*FeatureBreakdown) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{6}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FeatureBreakdown) GetFlashLoan() *FlashLoanFeatures {
@@ -828,7 +959,7 @@ type FlashLoanFeatures struct {
 
 func (x *FlashLoanFeatures) Reset() {
 	*x = FlashLoanFeatures{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[7]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -840,7 +971,7 @@ func (x *FlashLoanFeatures) String() string {
 func (*FlashLoanFeatures) ProtoMessage() {}
 
 func (x *FlashLoanFeatures) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[7]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,7 +984,7 @@ func (x *FlashLoanFeatures) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlashLoanFeatures.ProtoReflect.Descriptor instead.
 func (*FlashLoanFeatures) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{7}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FlashLoanFeatures) GetHasFlashLoan() bool {
@@ -920,7 +1051,7 @@ type StateVarianceFeatures struct {
 
 func (x *StateVarianceFeatures) Reset() {
 	*x = StateVarianceFeatures{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[8]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -932,7 +1063,7 @@ func (x *StateVarianceFeatures) String() string {
 func (*StateVarianceFeatures) ProtoMessage() {}
 
 func (x *StateVarianceFeatures) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[8]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,7 +1076,7 @@ func (x *StateVarianceFeatures) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateVarianceFeatures.ProtoReflect.Descriptor instead.
 func (*StateVarianceFeatures) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{8}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StateVarianceFeatures) GetTotalStorageChanges() int32 {
@@ -1018,7 +1149,7 @@ type BytecodeFeatures struct {
 
 func (x *BytecodeFeatures) Reset() {
 	*x = BytecodeFeatures{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[9]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1030,7 +1161,7 @@ func (x *BytecodeFeatures) String() string {
 func (*BytecodeFeatures) ProtoMessage() {}
 
 func (x *BytecodeFeatures) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[9]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,7 +1174,7 @@ func (x *BytecodeFeatures) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BytecodeFeatures.ProtoReflect.Descriptor instead.
 func (*BytecodeFeatures) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{9}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BytecodeFeatures) GetBytecodeLength() int32 {
@@ -1156,7 +1287,7 @@ type OpcodeFeatures struct {
 
 func (x *OpcodeFeatures) Reset() {
 	*x = OpcodeFeatures{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[10]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1168,7 +1299,7 @@ func (x *OpcodeFeatures) String() string {
 func (*OpcodeFeatures) ProtoMessage() {}
 
 func (x *OpcodeFeatures) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[10]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,7 +1312,7 @@ func (x *OpcodeFeatures) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpcodeFeatures.ProtoReflect.Descriptor instead.
 func (*OpcodeFeatures) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{10}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *OpcodeFeatures) GetTotalCalls() int32 {
@@ -1271,7 +1402,7 @@ type AnalyzeBatchRequest struct {
 
 func (x *AnalyzeBatchRequest) Reset() {
 	*x = AnalyzeBatchRequest{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[11]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1283,7 +1414,7 @@ func (x *AnalyzeBatchRequest) String() string {
 func (*AnalyzeBatchRequest) ProtoMessage() {}
 
 func (x *AnalyzeBatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[11]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,7 +1427,7 @@ func (x *AnalyzeBatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeBatchRequest.ProtoReflect.Descriptor instead.
 func (*AnalyzeBatchRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{11}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AnalyzeBatchRequest) GetTransactions() []*AnalyzeRequest {
@@ -1317,7 +1448,7 @@ type AnalyzeBatchResponse struct {
 
 func (x *AnalyzeBatchResponse) Reset() {
 	*x = AnalyzeBatchResponse{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[12]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1329,7 +1460,7 @@ func (x *AnalyzeBatchResponse) String() string {
 func (*AnalyzeBatchResponse) ProtoMessage() {}
 
 func (x *AnalyzeBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[12]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1342,7 +1473,7 @@ func (x *AnalyzeBatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeBatchResponse.ProtoReflect.Descriptor instead.
 func (*AnalyzeBatchResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{12}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AnalyzeBatchResponse) GetResults() []*AnalyzeResponse {
@@ -1368,7 +1499,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[13]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1380,7 +1511,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[13]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1393,7 +1524,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{13}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{15}
 }
 
 type HealthResponse struct {
@@ -1409,7 +1540,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[14]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1421,7 +1552,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[14]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1434,7 +1565,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{14}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *HealthResponse) GetHealthy() bool {
@@ -1481,7 +1612,7 @@ type StatsRequest struct {
 
 func (x *StatsRequest) Reset() {
 	*x = StatsRequest{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[15]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1493,7 +1624,7 @@ func (x *StatsRequest) String() string {
 func (*StatsRequest) ProtoMessage() {}
 
 func (x *StatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[15]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1506,7 +1637,7 @@ func (x *StatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{15}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{17}
 }
 
 // Statistics response
@@ -1530,7 +1661,7 @@ type StatsResponse struct {
 
 func (x *StatsResponse) Reset() {
 	*x = StatsResponse{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[16]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1542,7 +1673,7 @@ func (x *StatsResponse) String() string {
 func (*StatsResponse) ProtoMessage() {}
 
 func (x *StatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[16]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1555,7 +1686,7 @@ func (x *StatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{16}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *StatsResponse) GetTransactionsAnalyzed() uint64 {
@@ -1634,7 +1765,7 @@ type RecentAlert struct {
 
 func (x *RecentAlert) Reset() {
 	*x = RecentAlert{}
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[17]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1646,7 +1777,7 @@ func (x *RecentAlert) String() string {
 func (*RecentAlert) ProtoMessage() {}
 
 func (x *RecentAlert) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_sentinel_proto_msgTypes[17]
+	mi := &file_pkg_proto_sentinel_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,7 +1790,7 @@ func (x *RecentAlert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecentAlert.ProtoReflect.Descriptor instead.
 func (*RecentAlert) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{17}
+	return file_pkg_proto_sentinel_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RecentAlert) GetTxHash() string {
@@ -1701,7 +1832,7 @@ var File_pkg_proto_sentinel_proto protoreflect.FileDescriptor
 
 const file_pkg_proto_sentinel_proto_rawDesc = "" +
 	"\n" +
-	"\x18pkg/proto/sentinel.proto\x12\bsentinel\"\xbc\x02\n" +
+	"\x18pkg/proto/sentinel.proto\x12\bsentinel\"\xf6\x02\n" +
 	"\x0eAnalyzeRequest\x12\x17\n" +
 	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12!\n" +
 	"\ffrom_address\x18\x02 \x01(\tR\vfromAddress\x12\x1d\n" +
@@ -1717,7 +1848,16 @@ const file_pkg_proto_sentinel_proto_rawDesc = "" +
 	"\n" +
 	"simulation\x18\n" +
 	" \x01(\v2\x1a.sentinel.SimulationResultR\n" +
-	"simulation\"\xd3\x01\n" +
+	"simulation\x128\n" +
+	"\fdecoded_call\x18\v \x01(\v2\x15.sentinel.DecodedCallR\vdecodedCall\"z\n" +
+	"\vDecodedCall\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x127\n" +
+	"\targuments\x18\x03 \x03(\v2\x19.sentinel.DecodedArgumentR\targuments\"O\n" +
+	"\x0fDecodedArgument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\xd3\x01\n" +
 	"\x10SimulationResult\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
 	"\bgas_used\x18\x02 \x01(\x04R\agasUsed\x12@\n" +
@@ -1873,12 +2013,13 @@ const file_pkg_proto_sentinel_proto_rawDesc = "" +
 	"\x14RECOMMENDATION_ALLOW\x10\x01\x12\x17\n" +
 	"\x13RECOMMENDATION_FLAG\x10\x02\x12\x19\n" +
 	"\x15RECOMMENDATION_REVIEW\x10\x03\x12\x18\n" +
-	"\x14RECOMMENDATION_BLOCK\x10\x042\x9c\x02\n" +
+	"\x14RECOMMENDATION_BLOCK\x10\x042\xe6\x02\n" +
 	"\x11SentinelInference\x12>\n" +
 	"\aAnalyze\x12\x18.sentinel.AnalyzeRequest\x1a\x19.sentinel.AnalyzeResponse\x12M\n" +
 	"\fAnalyzeBatch\x12\x1d.sentinel.AnalyzeBatchRequest\x1a\x1e.sentinel.AnalyzeBatchResponse\x12;\n" +
 	"\x06Health\x12\x17.sentinel.HealthRequest\x1a\x18.sentinel.HealthResponse\x12;\n" +
-	"\bGetStats\x12\x16.sentinel.StatsRequest\x1a\x17.sentinel.StatsResponseB6Z4github.com/sentinel-protocol/sentinel-node/pkg/protob\x06proto3"
+	"\bGetStats\x12\x16.sentinel.StatsRequest\x1a\x17.sentinel.StatsResponse\x12H\n" +
+	"\rAnalyzeStream\x12\x18.sentinel.AnalyzeRequest\x1a\x19.sentinel.AnalyzeResponse(\x010\x01B6Z4github.com/sentinel-protocol/sentinel-node/pkg/protob\x06proto3"
 
 var (
 	file_pkg_proto_sentinel_proto_rawDescOnce sync.Once
@@ -1893,63 +2034,69 @@ func file_pkg_proto_sentinel_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_proto_sentinel_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pkg_proto_sentinel_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_pkg_proto_sentinel_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_pkg_proto_sentinel_proto_goTypes = []any{
 	(RiskLevel)(0),                // 0: sentinel.RiskLevel
 	(Recommendation)(0),           // 1: sentinel.Recommendation
 	(*AnalyzeRequest)(nil),        // 2: sentinel.AnalyzeRequest
-	(*SimulationResult)(nil),      // 3: sentinel.SimulationResult
-	(*StorageChange)(nil),         // 4: sentinel.StorageChange
-	(*CallTrace)(nil),             // 5: sentinel.CallTrace
-	(*AnalyzeResponse)(nil),       // 6: sentinel.AnalyzeResponse
-	(*ProtocolContext)(nil),       // 7: sentinel.ProtocolContext
-	(*FeatureBreakdown)(nil),      // 8: sentinel.FeatureBreakdown
-	(*FlashLoanFeatures)(nil),     // 9: sentinel.FlashLoanFeatures
-	(*StateVarianceFeatures)(nil), // 10: sentinel.StateVarianceFeatures
-	(*BytecodeFeatures)(nil),      // 11: sentinel.BytecodeFeatures
-	(*OpcodeFeatures)(nil),        // 12: sentinel.OpcodeFeatures
-	(*AnalyzeBatchRequest)(nil),   // 13: sentinel.AnalyzeBatchRequest
-	(*AnalyzeBatchResponse)(nil),  // 14: sentinel.AnalyzeBatchResponse
-	(*HealthRequest)(nil),         // 15: sentinel.HealthRequest
-	(*HealthResponse)(nil),        // 16: sentinel.HealthResponse
-	(*StatsRequest)(nil),          // 17: sentinel.StatsRequest
-	(*StatsResponse)(nil),         // 18: sentinel.StatsResponse
-	(*RecentAlert)(nil),           // 19: sentinel.RecentAlert
-	nil,                           // 20: sentinel.StatsResponse.ByRiskLevelEntry
-	nil,                           // 21: sentinel.StatsResponse.ByProtocolEntry
+	(*DecodedCall)(nil),           // 3: sentinel.DecodedCall
+	(*DecodedArgument)(nil),       // 4: sentinel.DecodedArgument
+	(*SimulationResult)(nil),      // 5: sentinel.SimulationResult
+	(*StorageChange)(nil),         // 6: sentinel.StorageChange
+	(*CallTrace)(nil),             // 7: sentinel.CallTrace
+	(*AnalyzeResponse)(nil),       // 8: sentinel.AnalyzeResponse
+	(*ProtocolContext)(nil),       // 9: sentinel.ProtocolContext
+	(*FeatureBreakdown)(nil),      // 10: sentinel.FeatureBreakdown
+	(*FlashLoanFeatures)(nil),     // 11: sentinel.FlashLoanFeatures
+	(*StateVarianceFeatures)(nil), // 12: sentinel.StateVarianceFeatures
+	(*BytecodeFeatures)(nil),      // 13: sentinel.BytecodeFeatures
+	(*OpcodeFeatures)(nil),        // 14: sentinel.OpcodeFeatures
+	(*AnalyzeBatchRequest)(nil),   // 15: sentinel.AnalyzeBatchRequest
+	(*AnalyzeBatchResponse)(nil),  // 16: sentinel.AnalyzeBatchResponse
+	(*HealthRequest)(nil),         // 17: sentinel.HealthRequest
+	(*HealthResponse)(nil),        // 18: sentinel.HealthResponse
+	(*StatsRequest)(nil),          // 19: sentinel.StatsRequest
+	(*StatsResponse)(nil),         // 20: sentinel.StatsResponse
+	(*RecentAlert)(nil),           // 21: sentinel.RecentAlert
+	nil,                           // 22: sentinel.StatsResponse.ByRiskLevelEntry
+	nil,                           // 23: sentinel.StatsResponse.ByProtocolEntry
 }
 var file_pkg_proto_sentinel_proto_depIdxs = []int32{
-	3,  // 0: sentinel.AnalyzeRequest.simulation:type_name -> sentinel.SimulationResult
-	4,  // 1: sentinel.SimulationResult.storage_changes:type_name -> sentinel.StorageChange
-	5,  // 2: sentinel.SimulationResult.call_traces:type_name -> sentinel.CallTrace
-	5,  // 3: sentinel.CallTrace.calls:type_name -> sentinel.CallTrace
-	0,  // 4: sentinel.AnalyzeResponse.risk_level:type_name -> sentinel.RiskLevel
-	1,  // 5: sentinel.AnalyzeResponse.recommendation:type_name -> sentinel.Recommendation
-	7,  // 6: sentinel.AnalyzeResponse.protocol_context:type_name -> sentinel.ProtocolContext
-	8,  // 7: sentinel.AnalyzeResponse.features:type_name -> sentinel.FeatureBreakdown
-	9,  // 8: sentinel.FeatureBreakdown.flash_loan:type_name -> sentinel.FlashLoanFeatures
-	10, // 9: sentinel.FeatureBreakdown.state_variance:type_name -> sentinel.StateVarianceFeatures
-	11, // 10: sentinel.FeatureBreakdown.bytecode:type_name -> sentinel.BytecodeFeatures
-	12, // 11: sentinel.FeatureBreakdown.opcode:type_name -> sentinel.OpcodeFeatures
-	2,  // 12: sentinel.AnalyzeBatchRequest.transactions:type_name -> sentinel.AnalyzeRequest
-	6,  // 13: sentinel.AnalyzeBatchResponse.results:type_name -> sentinel.AnalyzeResponse
-	20, // 14: sentinel.StatsResponse.by_risk_level:type_name -> sentinel.StatsResponse.ByRiskLevelEntry
-	21, // 15: sentinel.StatsResponse.by_protocol:type_name -> sentinel.StatsResponse.ByProtocolEntry
-	19, // 16: sentinel.StatsResponse.recent_alerts:type_name -> sentinel.RecentAlert
-	0,  // 17: sentinel.RecentAlert.risk_level:type_name -> sentinel.RiskLevel
-	2,  // 18: sentinel.SentinelInference.Analyze:input_type -> sentinel.AnalyzeRequest
-	13, // 19: sentinel.SentinelInference.AnalyzeBatch:input_type -> sentinel.AnalyzeBatchRequest
-	15, // 20: sentinel.SentinelInference.Health:input_type -> sentinel.HealthRequest
-	17, // 21: sentinel.SentinelInference.GetStats:input_type -> sentinel.StatsRequest
-	6,  // 22: sentinel.SentinelInference.Analyze:output_type -> sentinel.AnalyzeResponse
-	14, // 23: sentinel.SentinelInference.AnalyzeBatch:output_type -> sentinel.AnalyzeBatchResponse
-	16, // 24: sentinel.SentinelInference.Health:output_type -> sentinel.HealthResponse
-	18, // 25: sentinel.SentinelInference.GetStats:output_type -> sentinel.StatsResponse
-	22, // [22:26] is the sub-list for method output_type
-	18, // [18:22] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	5,  // 0: sentinel.AnalyzeRequest.simulation:type_name -> sentinel.SimulationResult
+	3,  // 1: sentinel.AnalyzeRequest.decoded_call:type_name -> sentinel.DecodedCall
+	4,  // 2: sentinel.DecodedCall.arguments:type_name -> sentinel.DecodedArgument
+	6,  // 3: sentinel.SimulationResult.storage_changes:type_name -> sentinel.StorageChange
+	7,  // 4: sentinel.SimulationResult.call_traces:type_name -> sentinel.CallTrace
+	7,  // 5: sentinel.CallTrace.calls:type_name -> sentinel.CallTrace
+	0,  // 6: sentinel.AnalyzeResponse.risk_level:type_name -> sentinel.RiskLevel
+	1,  // 7: sentinel.AnalyzeResponse.recommendation:type_name -> sentinel.Recommendation
+	9,  // 8: sentinel.AnalyzeResponse.protocol_context:type_name -> sentinel.ProtocolContext
+	10, // 9: sentinel.AnalyzeResponse.features:type_name -> sentinel.FeatureBreakdown
+	11, // 10: sentinel.FeatureBreakdown.flash_loan:type_name -> sentinel.FlashLoanFeatures
+	12, // 11: sentinel.FeatureBreakdown.state_variance:type_name -> sentinel.StateVarianceFeatures
+	13, // 12: sentinel.FeatureBreakdown.bytecode:type_name -> sentinel.BytecodeFeatures
+	14, // 13: sentinel.FeatureBreakdown.opcode:type_name -> sentinel.OpcodeFeatures
+	2,  // 14: sentinel.AnalyzeBatchRequest.transactions:type_name -> sentinel.AnalyzeRequest
+	8,  // 15: sentinel.AnalyzeBatchResponse.results:type_name -> sentinel.AnalyzeResponse
+	22, // 16: sentinel.StatsResponse.by_risk_level:type_name -> sentinel.StatsResponse.ByRiskLevelEntry
+	23, // 17: sentinel.StatsResponse.by_protocol:type_name -> sentinel.StatsResponse.ByProtocolEntry
+	21, // 18: sentinel.StatsResponse.recent_alerts:type_name -> sentinel.RecentAlert
+	0,  // 19: sentinel.RecentAlert.risk_level:type_name -> sentinel.RiskLevel
+	2,  // 20: sentinel.SentinelInference.Analyze:input_type -> sentinel.AnalyzeRequest
+	15, // 21: sentinel.SentinelInference.AnalyzeBatch:input_type -> sentinel.AnalyzeBatchRequest
+	17, // 22: sentinel.SentinelInference.Health:input_type -> sentinel.HealthRequest
+	19, // 23: sentinel.SentinelInference.GetStats:input_type -> sentinel.StatsRequest
+	2,  // 24: sentinel.SentinelInference.AnalyzeStream:input_type -> sentinel.AnalyzeRequest
+	8,  // 25: sentinel.SentinelInference.Analyze:output_type -> sentinel.AnalyzeResponse
+	16, // 26: sentinel.SentinelInference.AnalyzeBatch:output_type -> sentinel.AnalyzeBatchResponse
+	18, // 27: sentinel.SentinelInference.Health:output_type -> sentinel.HealthResponse
+	20, // 28: sentinel.SentinelInference.GetStats:output_type -> sentinel.StatsResponse
+	8,  // 29: sentinel.SentinelInference.AnalyzeStream:output_type -> sentinel.AnalyzeResponse
+	25, // [25:30] is the sub-list for method output_type
+	20, // [20:25] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_sentinel_proto_init() }
@@ -1963,7 +2110,7 @@ func file_pkg_proto_sentinel_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_sentinel_proto_rawDesc), len(file_pkg_proto_sentinel_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   20,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SentinelInference_Analyze_FullMethodName      = "/sentinel.SentinelInference/Analyze"
-	SentinelInference_AnalyzeBatch_FullMethodName = "/sentinel.SentinelInference/AnalyzeBatch"
-	SentinelInference_Health_FullMethodName       = "/sentinel.SentinelInference/Health"
-	SentinelInference_GetStats_FullMethodName     = "/sentinel.SentinelInference/GetStats"
+	SentinelInference_Analyze_FullMethodName       = "/sentinel.SentinelInference/Analyze"
+	SentinelInference_AnalyzeBatch_FullMethodName  = "/sentinel.SentinelInference/AnalyzeBatch"
+	SentinelInference_Health_FullMethodName        = "/sentinel.SentinelInference/Health"
+	SentinelInference_GetStats_FullMethodName      = "/sentinel.SentinelInference/GetStats"
+	SentinelInference_AnalyzeStream_FullMethodName = "/sentinel.SentinelInference/AnalyzeStream"
 )
 
 // SentinelInferenceClient is the client API for SentinelInference service.
@@ -39,6 +40,9 @@ type SentinelInferenceClient interface {
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 	// Get model info and statistics
 	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// Analyze transactions over one long-lived stream; each response carries
+	// the tx_hash of the request it answers and may arrive out of order
+	AnalyzeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AnalyzeRequest, AnalyzeResponse], error)
 }
 
 type sentinelInferenceClient struct {
@@ -89,6 +93,19 @@ func (c *sentinelInferenceClient) GetStats(ctx context.Context, in *StatsRequest
 	return out, nil
 }
 
+func (c *sentinelInferenceClient) AnalyzeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AnalyzeRequest, AnalyzeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SentinelInference_ServiceDesc.Streams[0], SentinelInference_AnalyzeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AnalyzeRequest, AnalyzeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SentinelInference_AnalyzeStreamClient = grpc.BidiStreamingClient[AnalyzeRequest, AnalyzeResponse]
+
 // SentinelInferenceServer is the server API for SentinelInference service.
 // All implementations must embed UnimplementedSentinelInferenceServer
 // for forward compatibility.
@@ -103,6 +120,9 @@ type SentinelInferenceServer interface {
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	// Get model info and statistics
 	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// Analyze transactions over one long-lived stream; each response carries
+	// the tx_hash of the request it answers and may arrive out of order
+	AnalyzeStream(grpc.BidiStreamingServer[AnalyzeRequest, AnalyzeResponse]) error
 	mustEmbedUnimplementedSentinelInferenceServer()
 }
 
@@ -125,6 +145,9 @@ func (UnimplementedSentinelInferenceServer) Health(context.Context, *HealthReque
 func (UnimplementedSentinelInferenceServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedSentinelInferenceServer) AnalyzeStream(grpc.BidiStreamingServer[AnalyzeRequest, AnalyzeResponse]) error {
+	return status.Error(codes.Unimplemented, "method AnalyzeStream not implemented")
+}
 func (UnimplementedSentinelInferenceServer) mustEmbedUnimplementedSentinelInferenceServer() {}
 func (UnimplementedSentinelInferenceServer) testEmbeddedByValue()                           {}
 
@@ -218,6 +241,13 @@ func _SentinelInference_GetStats_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SentinelInference_AnalyzeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SentinelInferenceServer).AnalyzeStream(&grpc.GenericServerStream[AnalyzeRequest, AnalyzeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SentinelInference_AnalyzeStreamServer = grpc.BidiStreamingServer[AnalyzeRequest, AnalyzeResponse]
+
 // SentinelInference_ServiceDesc is the grpc.ServiceDesc for SentinelInference service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -242,6 +272,13 @@ var SentinelInference_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _SentinelInference_GetStats_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AnalyzeStream",
+			Handler:       _SentinelInference_AnalyzeStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "pkg/proto/sentinel.proto",
 }
@@ -1,10 +1,13 @@
 package types
 
 import (
+	"encoding/binary"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type PendingTransaction struct {
@@ -34,6 +37,16 @@ func (tx *PendingTransaction) IsSimpleTransfer() bool {
 	return len(tx.Input) == 0 || (len(tx.Input) == 1 && tx.Input[0] == 0)
 }
 
+// EffectiveGasPrice is the most the transaction can pay per gas: the fee cap
+// for EIP-1559 transactions, since the base fee isn't known from the
+// mempool, and the gas price otherwise
+func (tx *PendingTransaction) EffectiveGasPrice() *big.Int {
+	if tx.MaxFeePerGas != nil {
+		return tx.MaxFeePerGas
+	}
+	return tx.GasPrice
+}
+
 func (tx *PendingTransaction) Selector() []byte {
 	if len(tx.Input) >= 4 {
 		return tx.Input[:4]
@@ -50,6 +63,19 @@ type InferenceResult struct {
 	RiskIndicators []string    `json:"riskIndicators"`
 	Recommendation string      `json:"recommendation"`
 	LatencyMs      float64     `json:"latencyMs"`
+	// Metadata is external context attached by enrichers, e.g. address labels
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// CallFrame is a node of a transaction's call tree as reported by the
+// callTracer of debug_traceCall
+type CallFrame struct {
+	Type  string         `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+	Error string         `json:"error,omitempty"`
+	Calls []CallFrame    `json:"calls,omitempty"`
 }
 
 type PauseRequest struct {
@@ -59,6 +85,12 @@ type PauseRequest struct {
 	Signers        []common.Address `json:"signers"`
 }
 
+// ID identifies a pause request by what it pauses and why, so the
+// originator and every co-signer refer to its signature shares alike
+func (r PauseRequest) ID() string {
+	return crypto.Keccak256Hash(r.TargetProtocol.Bytes(), r.EvidenceHash.Bytes()).Hex()
+}
+
 type SignedPauseRequest struct {
 	Request   PauseRequest `json:"request"`
 	Signature []byte       `json:"signature"`
@@ -74,6 +106,8 @@ type AggregatedPauseRequest struct {
 type NodeInfo struct {
 	Address      common.Address `json:"address"`
 	PeerID       string         `json:"peerId"`
+	// BLSPublicKey is preferably the 64-byte compressed G2 point; the
+	// 128-byte uncompressed form is also accepted by verification
 	BLSPublicKey []byte         `json:"blsPublicKey"`
 	Stake        *big.Int       `json:"stake"`
 	IsActive     bool           `json:"isActive"`
@@ -94,6 +128,14 @@ type NodeStats struct {
 	PauseRequestsSigned  uint64        `json:"pauseRequestsSigned"`
 	AverageLatencyMs     float64       `json:"averageLatencyMs"`
 	Uptime               time.Duration `json:"uptime"`
+	GossipBytesSent      uint64        `json:"gossipBytesSent"`
+	GossipMessagesShed   uint64        `json:"gossipMessagesShed"`
+	GossipConnections    int           `json:"gossipConnections"`
+	// Detection lead time, averaged over locally raised alerts; lead time
+	// only counts alerts whose transaction has since been mined
+	AverageDetectionLatencyMs float64 `json:"averageDetectionLatencyMs"`
+	AlertsMined               uint64  `json:"alertsMined"`
+	AverageLeadTimeMs         float64 `json:"averageLeadTimeMs"`
 }
 
 type AlertLevel string
@@ -113,4 +155,62 @@ type Alert struct {
 	Message        string         `json:"message"`
 	Timestamp      time.Time      `json:"timestamp"`
 	Result         *InferenceResult `json:"result,omitempty"`
+	Timing         *DetectionTiming `json:"timing,omitempty"`
+}
+
+// DetectionTiming records when an alerted transaction was first seen in the
+// mempool, when analysis flagged it, and when it was mined
+type DetectionTiming struct {
+	ReceivedAt time.Time  `json:"receivedAt"`
+	DetectedAt time.Time  `json:"detectedAt"`
+	MinedAt    *time.Time `json:"minedAt,omitempty"`
+}
+
+// DetectionLatency is how long the transaction sat in the mempool before it
+// was flagged
+func (t *DetectionTiming) DetectionLatency() time.Duration {
+	return t.DetectedAt.Sub(t.ReceivedAt)
+}
+
+// LeadTime is how long before being mined the transaction was flagged. It
+// is negative if the alert came too late, and false until the transaction
+// is mined.
+func (t *DetectionTiming) LeadTime() (time.Duration, bool) {
+	if t.MinedAt == nil {
+		return 0, false
+	}
+	return t.MinedAt.Sub(t.DetectedAt), true
+}
+
+// AlertEpoch is the detection window folded into alert IDs, so every node
+// that flags the same transaction at the same risk level within an epoch
+// derives the same ID
+const AlertEpoch = time.Hour
+
+// ComputeAlertID returns a stable alert ID derived from the transaction hash,
+// the epoch in which it was received and the detected risk level
+func ComputeAlertID(tx *PendingTransaction, result *InferenceResult) string {
+	var epoch [8]byte
+	if !tx.ReceivedAt.IsZero() {
+		binary.BigEndian.PutUint64(epoch[:], uint64(tx.ReceivedAt.Unix()/int64(AlertEpoch.Seconds())))
+	}
+
+	var riskLevel string
+	if result != nil {
+		riskLevel = result.RiskLevel
+	}
+
+	return crypto.Keccak256Hash(tx.Hash.Bytes(), epoch[:], []byte(riskLevel)).Hex()
+}
+
+// AlertAck announces that a node has acted on an alert, e.g. initiated a
+// pause, so peers holding the same alert ID don't repeat the action
+type AlertAck struct {
+	AlertID string `json:"alertId"`
+	// Action names what the node did, e.g. "pause"
+	Action string `json:"action"`
+	// HandledBy is the acting node's operator address, set from the
+	// gossip envelope it was verified against
+	HandledBy string    `json:"handledBy"`
+	Timestamp time.Time `json:"timestamp"`
 }
@@ -225,6 +225,28 @@ func TestPauseRequest(t *testing.T) {
 	}
 }
 
+func TestPauseRequest_ID(t *testing.T) {
+	request := PauseRequest{
+		TargetProtocol: common.HexToAddress("0x1"),
+		EvidenceHash:   common.HexToHash("0x2"),
+		Timestamp:      time.Now(),
+	}
+
+	// Timestamp and signers don't change which request it is
+	same := request
+	same.Timestamp = request.Timestamp.Add(time.Minute)
+	same.Signers = []common.Address{common.HexToAddress("0x3")}
+	if request.ID() != same.ID() {
+		t.Errorf("Expected the same ID, got %s and %s", request.ID(), same.ID())
+	}
+
+	other := request
+	other.EvidenceHash = common.HexToHash("0x4")
+	if request.ID() == other.ID() {
+		t.Error("Expected different evidence to give a different ID")
+	}
+}
+
 func TestSignedPauseRequest(t *testing.T) {
 	request := SignedPauseRequest{
 		Request: PauseRequest{
@@ -358,6 +380,66 @@ func TestAlert(t *testing.T) {
 	}
 }
 
+func TestComputeAlertID(t *testing.T) {
+	received := time.Unix(1700000000, 0)
+	tx := &PendingTransaction{Hash: common.HexToHash("0x1234"), ReceivedAt: received}
+	result := &InferenceResult{RiskLevel: "high", AnomalyScore: 0.7}
+
+	id := ComputeAlertID(tx, result)
+	if id == "" {
+		t.Fatal("Expected a non-empty alert ID")
+	}
+
+	// Same transaction and result, including a copy received moments later
+	// by another node within the epoch, yields the same ID
+	if again := ComputeAlertID(tx, &InferenceResult{RiskLevel: "high", AnomalyScore: 0.75}); again != id {
+		t.Errorf("Expected stable ID, got %s and %s", id, again)
+	}
+	later := &PendingTransaction{Hash: tx.Hash, ReceivedAt: received.Add(time.Second)}
+	if again := ComputeAlertID(later, result); again != id {
+		t.Errorf("Expected same ID within the epoch, got %s and %s", id, again)
+	}
+
+	others := map[string]string{
+		"different tx":    ComputeAlertID(&PendingTransaction{Hash: common.HexToHash("0x5678"), ReceivedAt: received}, result),
+		"different level": ComputeAlertID(tx, &InferenceResult{RiskLevel: "critical"}),
+		"different epoch": ComputeAlertID(&PendingTransaction{Hash: tx.Hash, ReceivedAt: received.Add(AlertEpoch)}, result),
+	}
+	for name, other := range others {
+		if other == id {
+			t.Errorf("Expected %s to produce a different ID", name)
+		}
+	}
+}
+
+func TestDetectionTiming_LeadTime(t *testing.T) {
+	received := time.Unix(1700000000, 0)
+	timing := &DetectionTiming{
+		ReceivedAt: received,
+		DetectedAt: received.Add(250 * time.Millisecond),
+	}
+
+	if latency := timing.DetectionLatency(); latency != 250*time.Millisecond {
+		t.Errorf("Expected 250ms detection latency, got %v", latency)
+	}
+	if _, ok := timing.LeadTime(); ok {
+		t.Error("Expected no lead time before the transaction is mined")
+	}
+
+	mined := received.Add(12 * time.Second)
+	timing.MinedAt = &mined
+	if lead, ok := timing.LeadTime(); !ok || lead != 11750*time.Millisecond {
+		t.Errorf("Expected 11.75s lead time, got %v (%v)", lead, ok)
+	}
+
+	// Flagged after the block landed
+	late := received.Add(100 * time.Millisecond)
+	timing.MinedAt = &late
+	if lead, _ := timing.LeadTime(); lead >= 0 {
+		t.Errorf("Expected negative lead time for a late alert, got %v", lead)
+	}
+}
+
 // Helper to create pointer to address
 func ptrAddr(addr common.Address) *common.Address {
 	return &addr